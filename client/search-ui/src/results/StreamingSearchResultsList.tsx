@@ -29,7 +29,7 @@ import { SettingsCascadeProps } from '@sourcegraph/shared/src/settings/settings'
 import { TelemetryProps } from '@sourcegraph/shared/src/telemetry/telemetryService'
 import { ThemeProps } from '@sourcegraph/shared/src/theme'
 
-import { luckySearchClickedEvent } from '../util/events'
+import { luckySearchClickedEvent, luckySearchProposedQueryRules } from '../util/events'
 
 import { NoResultsPage } from './NoResultsPage'
 import { StreamingSearchResultFooter } from './StreamingSearchResultsFooter'
@@ -121,6 +121,14 @@ export const StreamingSearchResultsList: React.FunctionComponent<
 
                 telemetryService.log(event)
             }
+
+            // Record the rules of the query generated by lucky search that found the clicked result, to see which rules help.
+            if (results?.alert?.kind === 'lucky-search-queries' && results.alert.proposedQueries) {
+                const rules = luckySearchProposedQueryRules(results.alert.proposedQueries, index)
+                if (rules) {
+                    telemetryService.log('SearchResultClickedLucky', { index, rules })
+                }
+            }
         },
         [telemetryService, results, luckySearchEnabled]
     )
@@ -33,3 +33,23 @@ export const luckySearchClickedEvent = (alertTitle: string, descriptions: string
 
     return `${prefix}${rule}`
 }
+
+/**
+ * Returns the names of the rules of the lucky search proposed query that added
+ * the result at the given index to the results, or undefined if no proposed
+ * query added it.
+ */
+export const luckySearchProposedQueryRules = (
+    proposedQueries: { annotations?: { name: string; value: string }[] }[],
+    index: number
+): string[] | undefined => {
+    for (const proposedQuery of proposedQueries) {
+        const annotation = (name: string): string | undefined =>
+            proposedQuery.annotations?.find(entry => entry.name === name)?.value
+        const [start, end] = (annotation('ResultRange') ?? '').split(':').map(Number)
+        if (index >= start && index < end) {
+            return annotation('Rules')?.split(',') ?? []
+        }
+    }
+    return undefined
+}
@@ -233,7 +233,7 @@ interface Alert {
 }
 
 // Same key values from internal/search/alert.go
-export type AnnotationName = 'ResultCount'
+export type AnnotationName = 'ResultCount' | 'ResultRange' | 'Rules'
 
 interface ProposedQuery {
     description?: string | null
@@ -380,7 +380,7 @@ const ChangesetSpecNode: React.FunctionComponent<React.PropsWithChildren<Changes
     }
 
     // This should not happen.
-    if (node.description.__typename === 'ExistingChangesetReference') {
+    if (node.description.__typename !== 'GitBranchChangesetDescription') {
         return null
     }
 
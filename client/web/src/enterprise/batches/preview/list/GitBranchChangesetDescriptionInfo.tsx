@@ -19,7 +19,7 @@ export const GitBranchChangesetDescriptionInfo: React.FunctionComponent<React.Pr
         return <></>
     }
 
-    if (node.targets.changesetSpec.description.__typename === 'ExistingChangesetReference') {
+    if (node.targets.changesetSpec.description.__typename !== 'GitBranchChangesetDescription') {
         return <></>
     }
 
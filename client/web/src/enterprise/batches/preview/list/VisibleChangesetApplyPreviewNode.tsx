@@ -288,6 +288,15 @@ const ExpandedSection: React.FunctionComponent<
         )
     }
 
+    if (node.targets.changesetSpec.description.__typename === 'IssueChangesetDescription') {
+        return (
+            <>
+                <H3>{node.targets.changesetSpec.description.title}</H3>
+                <Description description={node.targets.changesetSpec.description.body} />
+            </>
+        )
+    }
+
     return (
         <Tabs size="large">
             <TabList>
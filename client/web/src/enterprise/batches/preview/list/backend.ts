@@ -39,6 +39,7 @@ const changesetSpecFieldsFragment = gql`
             __typename
             ...ExistingChangesetReferenceFields
             ...GitBranchChangesetDescriptionFields
+            ...IssueChangesetDescriptionFields
         }
         forkTarget {
             pushUser
@@ -54,6 +55,16 @@ const changesetSpecFieldsFragment = gql`
         externalID
     }
 
+    fragment IssueChangesetDescriptionFields on IssueChangesetDescription {
+        baseRepository {
+            name
+            url
+        }
+        title
+        published
+        body
+    }
+
     fragment GitBranchChangesetDescriptionFields on GitBranchChangesetDescription {
         baseRepository {
             name
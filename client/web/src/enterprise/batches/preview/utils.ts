@@ -54,7 +54,7 @@ export const checkPublishability = (
         return { publishable: false, reason: 'You do not have permission to publish to this repository.' }
     }
     // The changeset is an existing, imported reference
-    if (node.targets.changesetSpec.description.__typename !== 'GitBranchChangesetDescription') {
+    if (node.targets.changesetSpec.description.__typename === 'ExistingChangesetReference') {
         return {
             publishable: false,
             reason: 'You cannot modify the publication state for an imported changeset.',
@@ -115,6 +115,51 @@ export const AggregationModeControls: FC<AggregationModeControlsProps> = props =
                     </Button>
                 </Tooltip>
             </div>
+
+            <div onMouseEnter={() => handleModeHover(SearchAggregationMode.LANGUAGE)}>
+                <Tooltip content={availabilityGroups[SearchAggregationMode.LANGUAGE]?.reasonUnavailable}>
+                    <Button
+                        variant="secondary"
+                        size={size}
+                        outline={mode !== SearchAggregationMode.LANGUAGE}
+                        disabled={!isModeAvailable(SearchAggregationMode.LANGUAGE)}
+                        data-testid="language-aggregation-mode"
+                        onClick={() => onModeChange(SearchAggregationMode.LANGUAGE)}
+                    >
+                        Language
+                    </Button>
+                </Tooltip>
+            </div>
+
+            <div onMouseEnter={() => handleModeHover(SearchAggregationMode.DIRECTORY)}>
+                <Tooltip content={availabilityGroups[SearchAggregationMode.DIRECTORY]?.reasonUnavailable}>
+                    <Button
+                        variant="secondary"
+                        size={size}
+                        outline={mode !== SearchAggregationMode.DIRECTORY}
+                        disabled={!isModeAvailable(SearchAggregationMode.DIRECTORY)}
+                        data-testid="directory-aggregation-mode"
+                        onClick={() => onModeChange(SearchAggregationMode.DIRECTORY)}
+                    >
+                        Directory
+                    </Button>
+                </Tooltip>
+            </div>
+
+            <div onMouseEnter={() => handleModeHover(SearchAggregationMode.DATE)}>
+                <Tooltip content={availabilityGroups[SearchAggregationMode.DATE]?.reasonUnavailable}>
+                    <Button
+                        variant="secondary"
+                        size={size}
+                        outline={mode !== SearchAggregationMode.DATE}
+                        disabled={!isModeAvailable(SearchAggregationMode.DATE)}
+                        data-testid="date-aggregation-mode"
+                        onClick={() => onModeChange(SearchAggregationMode.DATE)}
+                    >
+                        Date
+                    </Button>
+                </Tooltip>
+            </div>
         </div>
     )
 }
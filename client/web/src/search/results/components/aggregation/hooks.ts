@@ -54,7 +54,7 @@ function useSyncedWithURLState<State, SerializedState>(
     return [queryParameter, setNextState]
 }
 
-type SerializedAggregationMode = 'repo' | 'path' | 'author' | 'group' | ''
+type SerializedAggregationMode = 'repo' | 'path' | 'author' | 'group' | 'language' | 'directory' | 'date' | ''
 
 const aggregationModeSerializer = (mode: SearchAggregationMode | null): SerializedAggregationMode => {
     switch (mode) {
@@ -66,6 +66,12 @@ const aggregationModeSerializer = (mode: SearchAggregationMode | null): Serializ
             return 'author'
         case SearchAggregationMode.CAPTURE_GROUP:
             return 'group'
+        case SearchAggregationMode.LANGUAGE:
+            return 'language'
+        case SearchAggregationMode.DIRECTORY:
+            return 'directory'
+        case SearchAggregationMode.DATE:
+            return 'date'
 
         default:
             return ''
@@ -84,6 +90,12 @@ const aggregationModeDeserializer = (
             return SearchAggregationMode.AUTHOR
         case 'group':
             return SearchAggregationMode.CAPTURE_GROUP
+        case 'language':
+            return SearchAggregationMode.LANGUAGE
+        case 'directory':
+            return SearchAggregationMode.DIRECTORY
+        case 'date':
+            return SearchAggregationMode.DATE
 
         default:
             return null
@@ -24,6 +24,9 @@ type Services struct {
 	NewExecutorProxyHandler     NewExecutorProxyHandler
 	NewGitHubAppSetupHandler    NewGitHubAppSetupHandler
 	NewComputeStreamHandler     NewComputeStreamHandler
+	InsightsDashboardsExport    http.Handler
+	InsightsDashboardsImport    http.Handler
+	NotebooksExport             http.Handler
 	AuthzResolver               graphqlbackend.AuthzResolver
 	BatchChangesResolver        graphqlbackend.BatchChangesResolver
 	CodeIntelResolver           graphqlbackend.CodeIntelResolver
@@ -64,6 +67,9 @@ func DefaultServices() Services {
 		NewExecutorProxyHandler:   func() http.Handler { return makeNotFoundHandler("executor proxy") },
 		NewGitHubAppSetupHandler:  func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
 		NewComputeStreamHandler:   func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
+		InsightsDashboardsExport:  makeNotFoundHandler("insights dashboards export"),
+		InsightsDashboardsImport:  makeNotFoundHandler("insights dashboards import"),
+		NotebooksExport:           makeNotFoundHandler("notebooks export"),
 	}
 }
 
@@ -54,6 +54,24 @@ type DeleteBatchChangeArgs struct {
 	BatchChange graphql.ID
 }
 
+type SetBatchChangeMergePolicyArgs struct {
+	BatchChange       graphql.ID
+	MergeMethod       string
+	RequiredApprovals int32
+	Windows           *[]BatchChangeMergeWindowInput
+	MaxMergesPerHour  int32
+}
+
+type BatchChangeMergeWindowInput struct {
+	Days  *[]string
+	Start *string
+	End   *string
+}
+
+type DeleteBatchChangeMergePolicyArgs struct {
+	BatchChange graphql.ID
+}
+
 type SyncChangesetArgs struct {
 	Changeset graphql.ID
 }
@@ -267,6 +285,8 @@ type BatchChangesResolver interface {
 	CloseBatchChange(ctx context.Context, args *CloseBatchChangeArgs) (BatchChangeResolver, error)
 	MoveBatchChange(ctx context.Context, args *MoveBatchChangeArgs) (BatchChangeResolver, error)
 	DeleteBatchChange(ctx context.Context, args *DeleteBatchChangeArgs) (*EmptyResponse, error)
+	SetBatchChangeMergePolicy(ctx context.Context, args *SetBatchChangeMergePolicyArgs) (BatchChangeMergePolicyResolver, error)
+	DeleteBatchChangeMergePolicy(ctx context.Context, args *DeleteBatchChangeMergePolicyArgs) (*EmptyResponse, error)
 	CreateBatchChangesCredential(ctx context.Context, args *CreateBatchChangesCredentialArgs) (BatchChangesCredentialResolver, error)
 	DeleteBatchChangesCredential(ctx context.Context, args *DeleteBatchChangesCredentialArgs) (*EmptyResponse, error)
 
@@ -495,6 +515,7 @@ type ChangesetSpecDeltaResolver interface {
 type ChangesetDescription interface {
 	ToExistingChangesetReference() (ExistingChangesetReferenceResolver, bool)
 	ToGitBranchChangesetDescription() (GitBranchChangesetDescriptionResolver, bool)
+	ToIssueChangesetDescription() (IssueChangesetDescriptionResolver, bool)
 }
 
 type ExistingChangesetReferenceResolver interface {
@@ -521,6 +542,15 @@ type GitBranchChangesetDescriptionResolver interface {
 	Published() *batches.PublishedValue
 }
 
+type IssueChangesetDescriptionResolver interface {
+	BaseRepository() *RepositoryResolver
+
+	Title() string
+	Body() string
+
+	Published() *batches.PublishedValue
+}
+
 type GitCommitDescriptionResolver interface {
 	Message() string
 	Subject() string
@@ -649,6 +679,37 @@ type BatchChangeResolver interface {
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
 	BatchSpecs(ctx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
+	MergePolicy(ctx context.Context) (BatchChangeMergePolicyResolver, error)
+	Rollout(ctx context.Context) (BatchChangeRolloutResolver, error)
+}
+
+type BatchChangeMergePolicyResolver interface {
+	MergeMethod() string
+	RequiredApprovals() int32
+	Windows() []BatchChangeMergeWindowResolver
+	MaxMergesPerHour() int32
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+}
+
+type BatchChangeMergeWindowResolver interface {
+	Days() []string
+	Start() *string
+	End() *string
+}
+
+type BatchChangeRolloutResolver interface {
+	WaitFor() string
+	Stages() []BatchChangeRolloutStageResolver
+}
+
+type BatchChangeRolloutStageResolver interface {
+	Stage() int32
+	State() string
+	TotalCount() int32
+	PublishedCount() int32
+	CompletedCount() int32
 }
 
 type BatchChangesConnectionResolver interface {
@@ -751,6 +812,10 @@ type ExternalChangesetResolver interface {
 	Diff(ctx context.Context) (RepositoryComparisonInterface, error)
 	DiffStat(ctx context.Context) (*DiffStat, error)
 	Labels(ctx context.Context) ([]ChangesetLabelResolver, error)
+	// Kind returns a value of type btypes.ChangesetKind in its GraphQL
+	// representation.
+	Kind() string
+	Assignees() []string
 
 	Error() *string
 	SyncerError() *string
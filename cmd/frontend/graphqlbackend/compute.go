@@ -8,8 +8,17 @@ type ComputeArgs struct {
 	Query string
 }
 
+type ComputeChangesetSpecsArgs struct {
+	Query         string
+	Title         string
+	Body          *string
+	Branch        string
+	CommitMessage string
+}
+
 type ComputeResolver interface {
 	Compute(ctx context.Context, args *ComputeArgs) ([]ComputeResultResolver, error)
+	ComputeChangesetSpecs(ctx context.Context, args *ComputeChangesetSpecsArgs) ([]string, error)
 }
 
 type ComputeResultResolver interface {
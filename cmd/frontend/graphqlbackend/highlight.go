@@ -105,3 +105,20 @@ func highlightContent(ctx context.Context, args *HighlightArgs, content, path st
 
 	return result, nil
 }
+
+// HighlightLineRange syntax highlights a file and returns the HTML table rows of its lines in the
+// 0-based range [startLine, endLine), like the lineRanges field of highlighted files. It lets code
+// outside of the GraphQL API render code the way file blobs are rendered.
+func HighlightLineRange(ctx context.Context, repoName, revision, path, content string, startLine, endLine int32) ([]string, error) {
+	highlighted, err := highlightContent(ctx, &HighlightArgs{}, content, path, highlight.Metadata{RepoName: repoName, Revision: revision})
+	if err != nil {
+		return nil, err
+	}
+	lineRanges, err := highlighted.LineRanges(&struct{ Ranges []highlight.LineRange }{
+		Ranges: []highlight.LineRange{{StartLine: startLine, EndLine: endLine}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lineRanges[0], nil
+}
@@ -18,6 +18,7 @@ type InsightsResolver interface {
 	Insights(ctx context.Context, args *InsightsArgs) (InsightConnectionResolver, error)
 	InsightsDashboards(ctx context.Context, args *InsightsDashboardsArgs) (InsightsDashboardConnectionResolver, error)
 	InsightViews(ctx context.Context, args *InsightViewQueryArgs) (InsightViewConnectionResolver, error)
+	ExportInsightsDashboards(ctx context.Context, args *ExportInsightsDashboardsArgs) (string, error)
 
 	SearchInsightLivePreview(ctx context.Context, args SearchInsightLivePreviewArgs) ([]SearchInsightLivePreviewSeriesResolver, error)
 	SearchInsightPreview(ctx context.Context, args SearchInsightPreviewArgs) ([]SearchInsightLivePreviewSeriesResolver, error)
@@ -28,6 +29,7 @@ type InsightsResolver interface {
 	DeleteInsightsDashboard(ctx context.Context, args *DeleteInsightsDashboardArgs) (*EmptyResponse, error)
 	RemoveInsightViewFromDashboard(ctx context.Context, args *RemoveInsightViewFromDashboardArgs) (InsightsDashboardPayloadResolver, error)
 	AddInsightViewToDashboard(ctx context.Context, args *AddInsightViewToDashboardArgs) (InsightsDashboardPayloadResolver, error)
+	ImportInsightsDashboards(ctx context.Context, args *ImportInsightsDashboardsArgs) (ImportInsightsDashboardsPayloadResolver, error)
 
 	CreateLineChartSearchInsight(ctx context.Context, args *CreateLineChartSearchInsightArgs) (InsightViewPayloadResolver, error)
 	UpdateLineChartSearchInsight(ctx context.Context, args *UpdateLineChartSearchInsightArgs) (InsightViewPayloadResolver, error)
@@ -38,7 +40,11 @@ type InsightsResolver interface {
 
 	// Admin Management
 	UpdateInsightSeries(ctx context.Context, args *UpdateInsightSeriesArgs) (InsightSeriesMetadataPayloadResolver, error)
+	RetryInsightSeriesBackfill(ctx context.Context, args *RetryInsightSeriesBackfillArgs) (InsightSeriesMetadataPayloadResolver, error)
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
+	InsightSeriesAlerts(ctx context.Context, args *InsightSeriesAlertsArgs) ([]InsightSeriesAlertResolver, error)
+	CreateInsightSeriesAlert(ctx context.Context, args *CreateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	DeleteInsightSeriesAlert(ctx context.Context, args *DeleteInsightSeriesAlertArgs) (*EmptyResponse, error)
 }
 
 type SearchInsightLivePreviewArgs struct {
@@ -252,6 +258,26 @@ type InsightsDashboardPayloadResolver interface {
 	Dashboard(ctx context.Context) (InsightsDashboardResolver, error)
 }
 
+type ExportInsightsDashboardsArgs struct {
+	Ids    []graphql.ID
+	Format string
+}
+
+type ImportInsightsDashboardsArgs struct {
+	Input ImportInsightsDashboardsInput
+}
+
+type ImportInsightsDashboardsInput struct {
+	Document   string
+	OnConflict *string
+}
+
+type ImportInsightsDashboardsPayloadResolver interface {
+	Dashboards(ctx context.Context) ([]InsightsDashboardResolver, error)
+	SkippedDashboards() []string
+	SkippedInsights() []string
+}
+
 type AddInsightViewToDashboardArgs struct {
 	Input AddInsightViewToDashboardInput
 }
@@ -279,6 +305,52 @@ type UpdateInsightSeriesInput struct {
 	Enabled  *bool
 }
 
+type RetryInsightSeriesBackfillArgs struct {
+	Input RetryInsightSeriesBackfillInput
+}
+
+type RetryInsightSeriesBackfillInput struct {
+	SeriesId     string
+	Frames       []DateTime
+	Repositories *[]graphql.ID
+}
+
+type InsightSeriesAlertsArgs struct {
+	SeriesId string
+}
+
+type CreateInsightSeriesAlertArgs struct {
+	Input CreateInsightSeriesAlertInput
+}
+
+type CreateInsightSeriesAlertInput struct {
+	SeriesId   string
+	Kind       string
+	Direction  string
+	Value      float64
+	WindowDays *int32
+	ActionType string
+	ActionURL  *string
+}
+
+type DeleteInsightSeriesAlertArgs struct {
+	Id graphql.ID
+}
+
+type InsightSeriesAlertResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Kind() string
+	Direction() string
+	Value() float64
+	WindowDays() int32
+	ActionType() string
+	ActionURL() *string
+	Triggered() bool
+	LastTriggeredAt() *DateTime
+	LastValue() *float64
+}
+
 type InsightSeriesMetadataResolver interface {
 	SeriesId(ctx context.Context) (string, error)
 	Query(ctx context.Context) (string, error)
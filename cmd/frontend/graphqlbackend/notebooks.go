@@ -26,6 +26,8 @@ type NotebooksResolver interface {
 	CreateNotebookStar(ctx context.Context, args CreateNotebookStarInputArgs) (NotebookStarResolver, error)
 	DeleteNotebookStar(ctx context.Context, args DeleteNotebookStarInputArgs) (*EmptyResponse, error)
 
+	RestoreNotebookRevision(ctx context.Context, args RestoreNotebookRevisionArgs) (NotebookResolver, error)
+
 	NodeResolvers() map[string]NodeByIDFunc
 }
 
@@ -59,6 +61,29 @@ type NotebookResolver interface {
 	ViewerCanManage(ctx context.Context) (bool, error)
 	ViewerHasStarred(ctx context.Context) (bool, error)
 	Stars(ctx context.Context, args ListNotebookStarsArgs) (NotebookStarConnectionResolver, error)
+	Revisions(ctx context.Context, args ListNotebookRevisionsArgs) (NotebookRevisionConnectionResolver, error)
+	RevisionDiff(ctx context.Context, args NotebookRevisionDiffArgs) ([]NotebookBlockDiffResolver, error)
+}
+
+type NotebookRevisionResolver interface {
+	ID() graphql.ID
+	Title() string
+	Blocks() []NotebookBlockResolver
+	Author(context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+}
+
+type NotebookRevisionConnectionResolver interface {
+	Nodes() []NotebookRevisionResolver
+	TotalCount() int32
+	PageInfo() *graphqlutil.PageInfo
+}
+
+type NotebookBlockDiffResolver interface {
+	Type() string
+	BlockID() string
+	OldBlock() NotebookBlockResolver
+	NewBlock() NotebookBlockResolver
 }
 
 type NotebookBlockResolver interface {
@@ -82,6 +107,7 @@ type QueryBlockResolver interface {
 type FileBlockResolver interface {
 	ID() string
 	FileInput() FileBlockInputResolver
+	PinStatus(ctx context.Context) (NotebookBlockPinStatusResolver, error)
 }
 
 type FileBlockInputResolver interface {
@@ -89,11 +115,13 @@ type FileBlockInputResolver interface {
 	FilePath() string
 	Revision() *string
 	LineRange() FileBlockLineRangeResolver
+	PinnedCommit() *string
 }
 
 type SymbolBlockResolver interface {
 	ID() string
 	SymbolInput() SymbolBlockInputResolver
+	PinStatus(ctx context.Context) (NotebookBlockPinStatusResolver, error)
 }
 
 type SymbolBlockInputResolver interface {
@@ -104,6 +132,14 @@ type SymbolBlockInputResolver interface {
 	SymbolName() string
 	SymbolContainerName() string
 	SymbolKind() string
+	PinnedCommit() *string
+}
+
+type NotebookBlockPinStatusResolver interface {
+	State() string
+	HeadCommit() string
+	SuggestedLineRange() FileBlockLineRangeResolver
+	CheckedAt() DateTime
 }
 
 type ComputeBlockResolver interface {
@@ -161,6 +197,7 @@ type CreateFileBlockInput struct {
 	FilePath       string                         `json:"filePath"`
 	Revision       *string                        `json:"revision"`
 	LineRange      *CreateFileBlockLineRangeInput `json:"lineRange"`
+	PinnedCommit   *string                        `json:"pinnedCommit"`
 }
 
 type CreateSymbolBlockInput struct {
@@ -171,6 +208,7 @@ type CreateSymbolBlockInput struct {
 	SymbolName          string  `json:"symbolName"`
 	SymbolContainerName string  `json:"symbolContainerName"`
 	SymbolKind          string  `json:"symbolKind"`
+	PinnedCommit        *string `json:"pinnedCommit"`
 }
 
 type CreateFileBlockLineRangeInput struct {
@@ -201,3 +239,18 @@ type CreateNotebookStarInputArgs struct {
 type DeleteNotebookStarInputArgs struct {
 	NotebookID graphql.ID
 }
+
+type ListNotebookRevisionsArgs struct {
+	First int32   `json:"first"`
+	After *string `json:"after"`
+}
+
+type NotebookRevisionDiffArgs struct {
+	From graphql.ID `json:"from"`
+	To   graphql.ID `json:"to"`
+}
+
+type RestoreNotebookRevisionArgs struct {
+	NotebookID graphql.ID
+	RevisionID graphql.ID
+}
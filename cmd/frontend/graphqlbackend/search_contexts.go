@@ -44,6 +44,8 @@ type SearchContextResolver interface {
 	ViewerCanManage(ctx context.Context) bool
 	Repositories(ctx context.Context) ([]SearchContextRepositoryRevisionsResolver, error)
 	Query() string
+	Dynamic() bool
+	MembershipRefreshedAt() *DateTime
 }
 
 type SearchContextConnectionResolver interface {
@@ -63,6 +65,7 @@ type SearchContextInputArgs struct {
 	Public      bool
 	Namespace   *graphql.ID
 	Query       string
+	Dynamic     bool
 }
 
 type SearchContextEditInputArgs struct {
@@ -70,6 +73,7 @@ type SearchContextEditInputArgs struct {
 	Description string
 	Public      bool
 	Query       string
+	Dynamic     *bool
 }
 
 type SearchContextRepositoryRevisionsInputArgs struct {
@@ -381,6 +381,8 @@ func LogSearchLatency(ctx context.Context, db database.DB, si *search.Inputs, du
 				types = append(types, "regexp")
 			case si.PatternType == query.SearchTypeLucky:
 				types = append(types, "lucky")
+			case si.PatternType == query.SearchTypeFuzzy:
+				types = append(types, "fuzzy")
 			}
 		}
 	}
@@ -411,6 +413,8 @@ func LogSearchLatency(ctx context.Context, db database.DB, si *search.Inputs, du
 			types = append(types, "regexp")
 		} else if q.IsStructural() {
 			types = append(types, "structural")
+		} else if q.IsFuzzy() {
+			types = append(types, "fuzzy")
 		} else if si.Query.Exists(query.FieldFile) {
 			// No search pattern specified and file: is specified.
 			types = append(types, "file")
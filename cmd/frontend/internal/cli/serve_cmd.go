@@ -338,12 +338,15 @@ func makeExternalAPI(db database.DB, schema *graphql.Schema, enterprise enterpri
 		schema,
 		rateLimiter,
 		&httpapi.Handlers{
-			GitHubWebhook:             enterprise.GitHubWebhook,
-			GitLabWebhook:             enterprise.GitLabWebhook,
-			BitbucketServerWebhook:    enterprise.BitbucketServerWebhook,
-			BitbucketCloudWebhook:     enterprise.BitbucketCloudWebhook,
-			NewCodeIntelUploadHandler: enterprise.NewCodeIntelUploadHandler,
-			NewComputeStreamHandler:   enterprise.NewComputeStreamHandler,
+			GitHubWebhook:                   enterprise.GitHubWebhook,
+			GitLabWebhook:                   enterprise.GitLabWebhook,
+			BitbucketServerWebhook:          enterprise.BitbucketServerWebhook,
+			BitbucketCloudWebhook:           enterprise.BitbucketCloudWebhook,
+			NewCodeIntelUploadHandler:       enterprise.NewCodeIntelUploadHandler,
+			NewComputeStreamHandler:         enterprise.NewComputeStreamHandler,
+			InsightsDashboardsExportHandler: enterprise.InsightsDashboardsExport,
+			InsightsDashboardsImportHandler: enterprise.InsightsDashboardsImport,
+			NotebooksExportHandler:          enterprise.NotebooksExport,
 		},
 		enterprise.NewExecutorProxyHandler,
 		enterprise.NewGitHubAppSetupHandler,
@@ -31,12 +31,15 @@ func newTest(t *testing.T) *httptestutil.Client {
 		nil,
 		rateLimiter,
 		&Handlers{
-			GitHubWebhook:             enterpriseServices.GitHubWebhook,
-			GitLabWebhook:             enterpriseServices.GitLabWebhook,
-			BitbucketServerWebhook:    enterpriseServices.BitbucketServerWebhook,
-			BitbucketCloudWebhook:     enterpriseServices.BitbucketCloudWebhook,
-			NewCodeIntelUploadHandler: enterpriseServices.NewCodeIntelUploadHandler,
-			NewComputeStreamHandler:   enterpriseServices.NewComputeStreamHandler,
+			GitHubWebhook:                   enterpriseServices.GitHubWebhook,
+			GitLabWebhook:                   enterpriseServices.GitLabWebhook,
+			BitbucketServerWebhook:          enterpriseServices.BitbucketServerWebhook,
+			BitbucketCloudWebhook:           enterpriseServices.BitbucketCloudWebhook,
+			NewCodeIntelUploadHandler:       enterpriseServices.NewCodeIntelUploadHandler,
+			NewComputeStreamHandler:         enterpriseServices.NewComputeStreamHandler,
+			InsightsDashboardsExportHandler: enterpriseServices.InsightsDashboardsExport,
+			InsightsDashboardsImportHandler: enterpriseServices.InsightsDashboardsImport,
+			NotebooksExportHandler:          enterpriseServices.NotebooksExport,
 		},
 	))
 }
@@ -42,12 +42,15 @@ import (
 )
 
 type Handlers struct {
-	GitHubWebhook             webhooks.Registerer
-	GitLabWebhook             http.Handler
-	BitbucketServerWebhook    http.Handler
-	BitbucketCloudWebhook     http.Handler
-	NewCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler
-	NewComputeStreamHandler   enterprise.NewComputeStreamHandler
+	GitHubWebhook                   webhooks.Registerer
+	GitLabWebhook                   http.Handler
+	BitbucketServerWebhook          http.Handler
+	BitbucketCloudWebhook           http.Handler
+	NewCodeIntelUploadHandler       enterprise.NewCodeIntelUploadHandler
+	NewComputeStreamHandler         enterprise.NewComputeStreamHandler
+	InsightsDashboardsExportHandler http.Handler
+	InsightsDashboardsImportHandler http.Handler
+	NotebooksExportHandler          http.Handler
 }
 
 // NewHandler returns a new API handler that uses the provided API
@@ -99,6 +102,9 @@ func NewHandler(
 	m.Get(apirouter.BitbucketCloudWebhooks).Handler(trace.Route(webhookMiddleware.Logger(handlers.BitbucketCloudWebhook)))
 	m.Get(apirouter.LSIFUpload).Handler(trace.Route(handlers.NewCodeIntelUploadHandler(false)))
 	m.Get(apirouter.ComputeStream).Handler(trace.Route(handlers.NewComputeStreamHandler()))
+	m.Get(apirouter.InsightsDashboardsExport).Handler(trace.Route(handlers.InsightsDashboardsExportHandler))
+	m.Get(apirouter.InsightsDashboardsImport).Handler(trace.Route(handlers.InsightsDashboardsImportHandler))
+	m.Get(apirouter.NotebooksExport).Handler(trace.Route(handlers.NotebooksExportHandler))
 
 	ghSync := repos.GitHubWebhookHandler{}
 	ghSync.Register(&gh)
@@ -110,6 +116,12 @@ func NewHandler(
 	m.Get(apirouter.GraphQL).Handler(trace.Route(handler(serveGraphQL(schema, rateLimiter, false))))
 
 	m.Get(apirouter.SearchStream).Handler(trace.Route(frontendsearch.StreamHandler(db)))
+	m.Get(apirouter.SearchExplain).Handler(trace.Route(frontendsearch.ExplainHandler(db)))
+
+	exportHandler := frontendsearch.NewExportHandler(db)
+	m.Get(apirouter.SearchExports).Handler(trace.Route(http.HandlerFunc(exportHandler.ServeCreate)))
+	m.Get(apirouter.SearchExport).Handler(trace.Route(http.HandlerFunc(exportHandler.ServeStatus)))
+	m.Get(apirouter.SearchExportDownload).Handler(trace.Route(http.HandlerFunc(exportHandler.ServeDownload)))
 
 	// Return the minimum src-cli version that's compatible with this instance
 	m.Get(apirouter.SrcCli).Handler(trace.Route(newSrcCliVersionHandler(logger)))
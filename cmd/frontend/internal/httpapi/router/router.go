@@ -11,8 +11,17 @@ const (
 	LSIFUpload = "lsif.upload"
 	GraphQL    = "graphql"
 
-	SearchStream  = "search.stream"
-	ComputeStream = "compute.stream"
+	SearchStream         = "search.stream"
+	SearchExplain        = "search.explain"
+	SearchExports        = "search.exports"
+	SearchExport         = "search.export"
+	SearchExportDownload = "search.export.download"
+	ComputeStream        = "compute.stream"
+
+	InsightsDashboardsExport = "insights.dashboards.export"
+	InsightsDashboardsImport = "insights.dashboards.import"
+
+	NotebooksExport = "notebooks.export"
 
 	SrcCli             = "src-cli"
 	SrcCliVersionCache = "src-cli.version-cache"
@@ -57,7 +66,14 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/bitbucket-cloud-webhooks").Methods("POST").Name(BitbucketCloudWebhooks)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
+	base.Path("/search/explain").Methods("GET").Name(SearchExplain)
+	base.Path("/search/exports").Methods("POST").Name(SearchExports)
+	base.Path("/search/exports/{id:[0-9]+}").Methods("GET").Name(SearchExport)
+	base.Path("/search/exports/{id:[0-9]+}/download").Methods("GET").Name(SearchExportDownload)
 	base.Path("/compute/stream").Methods("GET", "POST").Name(ComputeStream)
+	base.Path("/insights/dashboards/export").Methods("GET").Name(InsightsDashboardsExport)
+	base.Path("/insights/dashboards/import").Methods("POST").Name(InsightsDashboardsImport)
+	base.Path("/notebooks/{id}/export").Methods("GET").Name(NotebooksExport)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 
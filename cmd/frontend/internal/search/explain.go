@@ -0,0 +1,225 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/log"
+	"go.uber.org/atomic"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/printer"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ExplainHandler is an http handler which describes how a search query runs.
+//
+// By default, it builds the job tree of the query and resolves the
+// repositories it searches, without running the search. With analyze=true, it
+// runs the search, discards the results, and reports the wall time and result
+// count of every job.
+func ExplainHandler(db database.DB) http.Handler {
+	logger := log.Scoped("searchExplainHandler", "")
+	return &explainHandler{
+		logger:       logger,
+		db:           db,
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+	}
+}
+
+type explainHandler struct {
+	logger       log.Logger
+	db           database.DB
+	searchClient client.SearchClient
+}
+
+// explainNode is the JSON representation of a job in an explain or analyze
+// response.
+type explainNode struct {
+	Name     string                 `json:"name"`
+	Fields   map[string]interface{} `json:"fields,omitempty"`
+	Estimate *explainEstimate       `json:"estimate,omitempty"`
+
+	// Only set by analyze.
+	DurationMs *int64 `json:"durationMs,omitempty"`
+	Results    *int64 `json:"results,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	Children []explainNode `json:"children,omitempty"`
+}
+
+type explainEstimate struct {
+	ResolvedRepos  int `json:"resolvedRepos"`
+	IndexedRepos   int `json:"indexedRepos"`
+	UnindexedRepos int `json:"unindexedRepos"`
+	Pages          int `json:"pages"`
+	ZoektCalls     int `json:"zoektCalls"`
+	SearcherCalls  int `json:"searcherCalls"`
+}
+
+type explainResponse struct {
+	Query   string `json:"query"`
+	Analyze bool   `json:"analyze"`
+
+	// Only set by analyze.
+	DurationMs *int64 `json:"durationMs,omitempty"`
+	Results    *int64 `json:"results,omitempty"`
+	Alert      string `json:"alert,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	Plan []explainNode `json:"plan"`
+}
+
+func (h *explainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "search.ServeExplain", "")
+	defer tr.Finish()
+
+	q := r.URL.Query()
+	query := q.Get("q")
+	if query == "" {
+		http.Error(w, "no query found", http.StatusBadRequest)
+		return
+	}
+	version := q.Get("v")
+	if version == "" {
+		version = "V3"
+	}
+	var patternType *string
+	if t := q.Get("t"); t != "" {
+		patternType = &t
+	}
+	analyze := false
+	if a := q.Get("analyze"); a != "" {
+		var err error
+		if analyze, err = strconv.ParseBool(a); err != nil {
+			http.Error(w, errors.Errorf("analyze must be parseable as a boolean, got %q: %w", a, err).Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	tr.TagFields(
+		otlog.String("query", query),
+		otlog.Bool("analyze", analyze),
+	)
+
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, h.db)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	inputs, err := h.searchClient.Plan(ctx, version, patternType, query, search.Streaming, settings, envvar.SourcegraphDotComMode())
+	if err != nil {
+		var queryErr *client.QueryError
+		if errors.As(err, &queryErr) {
+			http.Error(w, queryErr.Error(), http.StatusBadRequest)
+			return
+		}
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := explainResponse{Query: query, Analyze: analyze}
+	if analyze {
+		ctx, analysis := job.WithAnalysis(ctx)
+
+		var results atomic.Int64
+		countingStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+			results.Add(int64(len(event.Results)))
+		})
+
+		start := time.Now()
+		alert, err := planJob.Run(ctx, h.searchClient.JobClients(), countingStream)
+		durationMs := time.Since(start).Milliseconds()
+		total := results.Load()
+
+		resp.DurationMs = &durationMs
+		resp.Results = &total
+		if alert != nil {
+			resp.Alert = alert.Title
+		}
+		if err != nil {
+			resp.Error = err.Error()
+		}
+		for _, child := range analysis.Children() {
+			resp.Plan = append(resp.Plan, fromAnalysis(child))
+		}
+	} else {
+		node, err := jobutil.Explain(ctx, h.searchClient.JobClients(), planJob)
+		if err != nil {
+			tr.SetError(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp.Plan = []explainNode{fromExplainNode(node)}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Warn("failed to write explain response", log.Error(err))
+	}
+}
+
+func fromExplainNode(n *jobutil.ExplainNode) explainNode {
+	node := explainNode{
+		Name:   n.Name,
+		Fields: fieldsMap(n.Fields),
+	}
+	if e := n.Estimate; e != nil {
+		node.Estimate = &explainEstimate{
+			ResolvedRepos:  e.ResolvedRepos,
+			IndexedRepos:   e.IndexedRepos,
+			UnindexedRepos: e.UnindexedRepos,
+			Pages:          e.Pages,
+			ZoektCalls:     e.ZoektCalls,
+			SearcherCalls:  e.SearcherCalls,
+		}
+	}
+	for _, child := range n.Children {
+		node.Children = append(node.Children, fromExplainNode(child))
+	}
+	return node
+}
+
+func fromAnalysis(a *job.Analysis) explainNode {
+	durationMs := a.Duration.Milliseconds()
+	results := a.Results
+	node := explainNode{
+		Name:       a.Name,
+		Fields:     fieldsMap(a.Fields),
+		DurationMs: &durationMs,
+		Results:    &results,
+	}
+	if a.Err != nil {
+		node.Error = a.Err.Error()
+	}
+	for _, child := range a.Children() {
+		node.Children = append(node.Children, fromAnalysis(child))
+	}
+	return node
+}
+
+func fieldsMap(fields []otlog.Field) map[string]interface{} {
+	if len(fields) == 0 {
+		return nil
+	}
+	return printer.FieldsMap(fields)
+}
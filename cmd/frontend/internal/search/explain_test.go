@@ -0,0 +1,70 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestServeExplain(t *testing.T) {
+	graphqlbackend.MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { graphqlbackend.MockDecodedViewerFinalSettings = nil })
+
+	mock := client.NewMockSearchClient()
+	mock.PlanFunc.SetDefaultReturn(&search.Inputs{Features: &search.Features{}}, nil)
+
+	ts := httptest.NewServer(&explainHandler{
+		logger:       logtest.Scoped(t),
+		searchClient: mock,
+	})
+	defer ts.Close()
+
+	get := func(t *testing.T, query string) (int, explainResponse) {
+		t.Helper()
+		res, err := http.Get(ts.URL + query)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var resp explainResponse
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+		}
+		return res.StatusCode, resp
+	}
+
+	t.Run("explain", func(t *testing.T) {
+		status, resp := get(t, "?q=test")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, explainResponse{
+			Query: "test",
+			Plan:  []explainNode{{Name: "NoopJob"}},
+		}, resp)
+	})
+
+	t.Run("analyze", func(t *testing.T) {
+		status, resp := get(t, "?q=test&analyze=true")
+		require.Equal(t, http.StatusOK, status)
+		require.True(t, resp.Analyze)
+		require.NotNil(t, resp.DurationMs)
+		require.Equal(t, int64(0), *resp.Results)
+	})
+
+	t.Run("no query", func(t *testing.T) {
+		status, _ := get(t, "?analyze=true")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("invalid analyze", func(t *testing.T) {
+		status, _ := get(t, "?q=test&analyze=maybe")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
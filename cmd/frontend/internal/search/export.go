@@ -0,0 +1,265 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/exports"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// exportsConfig is read at startup, since the environment is locked before
+// the first export is downloaded.
+var exportsConfig = func() *exports.Config {
+	c := &exports.Config{}
+	c.Load()
+	return c
+}()
+
+// ExportHandler serves the endpoints to create search result exports, check
+// their progress and download them. Exports are run by the search-exports
+// worker job.
+type ExportHandler struct {
+	logger       log.Logger
+	db           database.DB
+	store        exportStore
+	searchClient client.SearchClient
+
+	uploadStoreOnce sync.Once
+	uploadStore     uploadstore.Store
+	uploadStoreErr  error
+}
+
+type exportStore interface {
+	Create(ctx context.Context, userID int32, query string, patternType *string, format exports.Format, settings *schema.Settings) (*exports.Export, error)
+	GetByID(ctx context.Context, id int) (*exports.Export, error)
+}
+
+func NewExportHandler(db database.DB) *ExportHandler {
+	logger := log.Scoped("searchExportHandler", "")
+	return &ExportHandler{
+		logger:       logger,
+		db:           db,
+		store:        exports.NewStore(db.Handle()),
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+	}
+}
+
+type exportResponse struct {
+	ID             int                 `json:"id"`
+	Query          string              `json:"query"`
+	Format         exports.Format      `json:"format"`
+	State          string              `json:"state"`
+	FailureMessage string              `json:"failureMessage,omitempty"`
+	Progress       *streamapi.Progress `json:"progress,omitempty"`
+	CreatedAt      time.Time           `json:"createdAt"`
+	FinishedAt     *time.Time          `json:"finishedAt,omitempty"`
+
+	// Size and DownloadURL are set once the export completed.
+	Size        *int64 `json:"size,omitempty"`
+	DownloadURL string `json:"downloadUrl,omitempty"`
+}
+
+func newExportResponse(e *exports.Export) exportResponse {
+	resp := exportResponse{
+		ID:         e.ID,
+		Query:      e.Query,
+		Format:     e.Format,
+		State:      e.State,
+		Progress:   e.Progress,
+		CreatedAt:  e.CreatedAt,
+		FinishedAt: e.FinishedAt,
+	}
+	if e.FailureMessage != nil {
+		resp.FailureMessage = *e.FailureMessage
+	}
+	if e.State == "completed" && e.UploadKey != nil {
+		resp.Size = e.UploadSize
+		resp.DownloadURL = fmt.Sprintf("/.api/search/exports/%d/download", e.ID)
+	}
+	return resp
+}
+
+// ServeCreate enqueues an export of the results of the query q, with pattern
+// type t, in the given format (csv or jsonl).
+func (h *ExportHandler) ServeCreate(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "search.ServeExportCreate", "")
+	defer tr.Finish()
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		http.Error(w, "must be signed in to export search results", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.FormValue("q")
+	if query == "" {
+		http.Error(w, "no query found", http.StatusBadRequest)
+		return
+	}
+	var patternType *string
+	if t := r.FormValue("t"); t != "" {
+		patternType = &t
+	}
+	format, err := exports.ParseFormat(r.FormValue("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, h.db)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Reject invalid queries now rather than failing the export later.
+	if _, err := h.searchClient.Plan(ctx, "V3", patternType, query, search.Streaming, settings, envvar.SourcegraphDotComMode()); err != nil {
+		var queryErr *client.QueryError
+		if errors.As(err, &queryErr) {
+			http.Error(w, queryErr.Error(), http.StatusBadRequest)
+			return
+		}
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	e, err := h.store.Create(ctx, a.UID, query, patternType, format, settings)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, newExportResponse(e))
+}
+
+// ServeStatus returns the state and progress of an export.
+func (h *ExportHandler) ServeStatus(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "search.ServeExportStatus", "")
+	defer tr.Finish()
+
+	e, ok := h.getExport(ctx, w, r)
+	if !ok {
+		return
+	}
+	h.writeJSON(w, http.StatusOK, newExportResponse(e))
+}
+
+// ServeDownload streams the file of a completed export.
+func (h *ExportHandler) ServeDownload(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "search.ServeExportDownload", "")
+	defer tr.Finish()
+
+	e, ok := h.getExport(ctx, w, r)
+	if !ok {
+		return
+	}
+	if e.State != "completed" || e.UploadKey == nil {
+		http.Error(w, fmt.Sprintf("export is %s", e.State), http.StatusConflict)
+		return
+	}
+
+	store, err := h.getUploadStore()
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rc, err := store.Get(ctx, *e.UploadKey)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", e.Format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=search-export-%d.%s", e.ID, e.Format))
+	if e.UploadSize != nil {
+		w.Header().Set("Content-Length", strconv.FormatInt(*e.UploadSize, 10))
+	}
+	if _, err := io.Copy(w, rc); err != nil {
+		h.logger.Warn("failed to write search export", log.Int("id", e.ID), log.Error(err))
+	}
+}
+
+// getExport returns the export named by the request path. Exports can only be
+// read by the user who created them and by site admins. Other users get a
+// 404, so that they can't tell which exports exist.
+func (h *ExportHandler) getExport(ctx context.Context, w http.ResponseWriter, r *http.Request) (*exports.Export, bool) {
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		http.Error(w, "must be signed in to read search exports", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid export id", http.StatusBadRequest)
+		return nil, false
+	}
+
+	e, err := h.store.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, exports.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return nil, false
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	if err := backend.CheckSiteAdminOrSameUser(ctx, h.db, e.UserID); err != nil {
+		http.Error(w, exports.ErrNotFound.Error(), http.StatusNotFound)
+		return nil, false
+	}
+	return e, true
+}
+
+func (h *ExportHandler) getUploadStore() (uploadstore.Store, error) {
+	h.uploadStoreOnce.Do(func() {
+		if err := exportsConfig.Validate(); err != nil {
+			h.uploadStoreErr = errors.Wrap(err, "invalid search exports configuration")
+			return
+		}
+		observationContext := &observation.Context{
+			Logger:     h.logger,
+			Tracer:     &trace.Tracer{TracerProvider: otel.GetTracerProvider()},
+			Registerer: prometheus.DefaultRegisterer,
+		}
+		h.uploadStore, h.uploadStoreErr = exports.NewUploadStore(context.Background(), exportsConfig, observationContext)
+	})
+	return h.uploadStore, h.uploadStoreErr
+}
+
+func (h *ExportHandler) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Warn("failed to write search export response", log.Error(err))
+	}
+}
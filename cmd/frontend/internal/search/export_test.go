@@ -0,0 +1,153 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type fakeExportStore struct {
+	exports map[int]*exports.Export
+}
+
+func (s *fakeExportStore) Create(_ context.Context, userID int32, query string, patternType *string, format exports.Format, settings *schema.Settings) (*exports.Export, error) {
+	e := &exports.Export{
+		ID:          len(s.exports) + 1,
+		UserID:      userID,
+		Query:       query,
+		PatternType: patternType,
+		Format:      format,
+		Settings:    settings,
+		State:       "queued",
+	}
+	s.exports[e.ID] = e
+	return e, nil
+}
+
+func (s *fakeExportStore) GetByID(_ context.Context, id int) (*exports.Export, error) {
+	e, ok := s.exports[id]
+	if !ok {
+		return nil, exports.ErrNotFound
+	}
+	return e, nil
+}
+
+func TestExportHandler(t *testing.T) {
+	graphqlbackend.MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { graphqlbackend.MockDecodedViewerFinalSettings = nil })
+
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 2}, nil)
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	searchClient := client.NewMockSearchClient()
+	searchClient.PlanFunc.SetDefaultReturn(&search.Inputs{}, nil)
+
+	uploadKey := "search-exports/2.csv"
+	uploadSize := int64(42)
+	store := &fakeExportStore{exports: map[int]*exports.Export{}}
+	h := &ExportHandler{
+		logger:       logtest.Scoped(t),
+		db:           db,
+		store:        store,
+		searchClient: searchClient,
+	}
+
+	do := func(t *testing.T, userID int32, r *http.Request, serve http.HandlerFunc) (int, exportResponse) {
+		t.Helper()
+		if userID != 0 {
+			r = r.WithContext(actor.WithActor(r.Context(), actor.FromUser(userID)))
+		}
+		w := httptest.NewRecorder()
+		serve(w, r)
+
+		var resp exportResponse
+		if w.Code == http.StatusOK || w.Code == http.StatusCreated {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		}
+		return w.Code, resp
+	}
+
+	create := func(t *testing.T, userID int32, form url.Values) (int, exportResponse) {
+		t.Helper()
+		r := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return do(t, userID, r, h.ServeCreate)
+	}
+
+	status := func(t *testing.T, userID int32, id string) (int, exportResponse) {
+		t.Helper()
+		r := mux.SetURLVars(httptest.NewRequest("GET", "/", nil), map[string]string{"id": id})
+		return do(t, userID, r, h.ServeStatus)
+	}
+
+	t.Run("create", func(t *testing.T) {
+		code, resp := create(t, 1, url.Values{"q": {"foo"}, "format": {"csv"}})
+		require.Equal(t, http.StatusCreated, code)
+		require.Equal(t, exportResponse{ID: 1, Query: "foo", Format: exports.FormatCSV, State: "queued"}, resp)
+	})
+
+	t.Run("create unauthenticated", func(t *testing.T) {
+		code, _ := create(t, 0, url.Values{"q": {"foo"}, "format": {"csv"}})
+		require.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("create invalid format", func(t *testing.T) {
+		code, _ := create(t, 1, url.Values{"q": {"foo"}, "format": {"xlsx"}})
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("create no query", func(t *testing.T) {
+		code, _ := create(t, 1, url.Values{"format": {"jsonl"}})
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		code, resp := status(t, 1, "1")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "queued", resp.State)
+		require.Empty(t, resp.DownloadURL)
+	})
+
+	t.Run("completed status has download URL", func(t *testing.T) {
+		store.exports[2] = &exports.Export{ID: 2, UserID: 1, Format: exports.FormatCSV, State: "completed", UploadKey: &uploadKey, UploadSize: &uploadSize}
+		code, resp := status(t, 1, "2")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "/.api/search/exports/2/download", resp.DownloadURL)
+		require.Equal(t, &uploadSize, resp.Size)
+	})
+
+	t.Run("other users can't read exports", func(t *testing.T) {
+		code, _ := status(t, 2, "1")
+		require.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("missing export", func(t *testing.T) {
+		code, _ := status(t, 1, "100")
+		require.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("download before completion", func(t *testing.T) {
+		r := mux.SetURLVars(httptest.NewRequest("GET", "/", nil), map[string]string{"id": "1"})
+		code, _ := do(t, 1, r, h.ServeDownload)
+		require.Equal(t, http.StatusConflict, code)
+	})
+}
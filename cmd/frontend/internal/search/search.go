@@ -294,6 +294,8 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -513,6 +515,14 @@ func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.Sear
 	return commitEvent
 }
 
+func fromOwner(owner *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	return &streamhttp.EventOwnerMatch{
+		Type:   streamhttp.OwnerMatchType,
+		Handle: owner.Handle,
+		Email:  owner.Email,
+	}
+}
+
 // eventStreamOTHook returns a StatHook which logs to log.
 func eventStreamOTHook(log func(...otlog.Field)) func(streamhttp.WriterStat) {
 	return func(stat streamhttp.WriterStat) {
@@ -563,8 +573,11 @@ func GuessSource(r *http.Request) trace.SourceType {
 
 func repoIDs(results []result.Match) []api.RepoID {
 	ids := make(map[api.RepoID]struct{}, 5)
-	for _, result := range results {
-		ids[result.RepoName().ID] = struct{}{}
+	for _, match := range results {
+		if _, ok := match.(*result.OwnerMatch); ok {
+			continue
+		}
+		ids[match.RepoName().ID] = struct{}{}
 	}
 
 	res := make([]api.RepoID, 0, len(ids))
@@ -667,13 +680,17 @@ func (h *eventHandler) Send(event streaming.SearchEvent) {
 	}
 
 	for _, match := range event.Results {
-		repo := match.RepoName()
-
-		// Don't send matches which we cannot map to a repo the actor has access to. This
-		// check is expected to always pass. Missing metadata is a sign that we have
-		// searched repos that user shouldn't have access to.
-		if md, ok := repoMetadata[repo.ID]; !ok || md.Name != repo.Name {
-			continue
+		// Owner matches are not associated with a single repo. They are
+		// resolved from file matches in repos the actor has access to.
+		if _, ok := match.(*result.OwnerMatch); !ok {
+			repo := match.RepoName()
+
+			// Don't send matches which we cannot map to a repo the actor has access to. This
+			// check is expected to always pass. Missing metadata is a sign that we have
+			// searched repos that user shouldn't have access to.
+			if md, ok := repoMetadata[repo.ID]; !ok || md.Name != repo.Name {
+				continue
+			}
 		}
 
 		eventMatch := fromMatch(match, repoMetadata, h.enableChunkMatches)
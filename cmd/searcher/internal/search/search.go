@@ -182,6 +182,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 			log.String("pattern", p.Pattern),
 			log.Bool("isRegExp", p.IsRegExp),
 			log.Bool("isStructuralPat", p.IsStructuralPat),
+			log.Bool("isFuzzy", p.IsFuzzy),
 			log.Strings("languages", p.Languages),
 			log.Bool("isWordMatch", p.IsWordMatch),
 			log.Bool("isCaseSensitive", p.IsCaseSensitive),
@@ -194,12 +195,6 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 			log.Error(err))
 	}(time.Now())
 
-	if p.IsStructuralPat && p.Indexed {
-		// Execute the new structural search path that directly calls Zoekt.
-		// TODO use limit in indexed structural search
-		return structuralSearchWithZoekt(ctx, p, sender)
-	}
-
 	// Compile pattern before fetching from store incase it is bad.
 	var rg *readerGrep
 	if !p.IsStructuralPat {
@@ -209,6 +204,12 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 		}
 	}
 
+	if p.IsFuzzy && p.Indexed {
+		// Zoekt finds the candidate files for fuzzy patterns, which we then
+		// verify.
+		return fuzzySearchWithZoekt(ctx, rg, p, sender)
+	}
+
 	if p.FetchTimeout == "" {
 		p.FetchTimeout = "500ms"
 	}
@@ -228,7 +229,34 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 		return path, zf, err
 	}
 
-	hybrid := !p.IsStructuralPat && p.FeatHybrid
+	if p.IsStructuralPat && p.Indexed {
+		// Zoekt narrows the search down to the files which contain the
+		// literals of the pattern. We only fetch those from gitserver.
+		paths, ok, err := zoektCandidatePaths(ctx, p)
+		if err != nil {
+			return errors.Wrap(err, "indexed structural search failed")
+		}
+		if !ok {
+			s.Log.Debug("indexed structural search is falling back to searching the whole archive",
+				log.String("repo", string(p.Repo)),
+				log.String("commit", string(p.Commit)))
+		} else {
+			if len(paths) == 0 {
+				return nil
+			}
+
+			getZf = func() (string, *zipFile, error) {
+				path, err := s.Store.PrepareZipPaths(prepareCtx, p.Repo, p.Commit, paths)
+				if err != nil {
+					return "", nil, err
+				}
+				zf, err := s.Store.zipCache.Get(path)
+				return path, zf, err
+			}
+		}
+	}
+
+	hybrid := !p.IsStructuralPat && !p.IsFuzzy && p.FeatHybrid
 	if hybrid {
 		unsearched, ok, err := s.hybrid(ctx, p, sender)
 		if err != nil {
@@ -295,6 +323,9 @@ func validateParams(p *protocol.Request) error {
 	if p.IsNegated && p.IsStructuralPat {
 		return errors.New("Negated patterns are not supported for structural searches")
 	}
+	if p.IsNegated && p.IsFuzzy {
+		return errors.New("Negated patterns are not supported for fuzzy searches")
+	}
 	return nil
 }
 
@@ -0,0 +1,278 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/sourcegraph/zoekt"
+	zoektquery "github.com/sourcegraph/zoekt/query"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/backend"
+	zoektutil "github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+)
+
+// fuzzyMatcher finds approximate matches of a pattern: substrings of a line
+// which can be turned into the pattern with at most maxEdits single byte
+// insertions, deletions or substitutions.
+//
+// Matching is done with the dynamic programming algorithm of Sellers ("The
+// theory and computation of evolutionary distances: pattern recognition",
+// 1980), which is O(len(pattern)) per byte of input. Since that is too slow
+// to run over every file, we first prune inputs with pieces of the pattern:
+// if the pattern is split into maxEdits+1 pieces, every match must contain at
+// least one of the pieces unchanged, as each edit can alter at most one piece.
+type fuzzyMatcher struct {
+	pattern  []byte
+	maxEdits int
+
+	// pieces are maxEdits+1 non-overlapping substrings of pattern, at least
+	// one of which appears in any match.
+	pieces [][]byte
+
+	// cost and start are the current and previous columns of the dynamic
+	// programming matrix. They are reused between calls, so fuzzyMatcher is
+	// not safe for concurrent use.
+	cost, prevCost   []int
+	start, prevStart []int
+}
+
+func newFuzzyMatcher(pattern []byte, maxEdits int) *fuzzyMatcher {
+	n := maxEdits + 1
+	if n > len(pattern) {
+		n = len(pattern)
+	}
+	pieces := make([][]byte, 0, n)
+	for i := 0; i < n; i++ {
+		pieces = append(pieces, pattern[i*len(pattern)/n:(i+1)*len(pattern)/n])
+	}
+
+	m := len(pattern) + 1
+	return &fuzzyMatcher{
+		pattern:   pattern,
+		maxEdits:  maxEdits,
+		pieces:    pieces,
+		cost:      make([]int, m),
+		prevCost:  make([]int, m),
+		start:     make([]int, m),
+		prevStart: make([]int, m),
+	}
+}
+
+// Copy returns a copy of fm that is safe to use from another goroutine.
+func (fm *fuzzyMatcher) Copy() *fuzzyMatcher {
+	return newFuzzyMatcher(fm.pattern, fm.maxEdits)
+}
+
+// mayMatch returns false if buf can't contain a match.
+func (fm *fuzzyMatcher) mayMatch(buf []byte) bool {
+	for _, piece := range fm.pieces {
+		if bytes.Contains(buf, piece) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match reports whether buf contains a match.
+func (fm *fuzzyMatcher) Match(buf []byte) bool {
+	return len(fm.FindAllIndex(buf, 1)) > 0
+}
+
+// FindAllIndex returns the locations of at most n non-overlapping matches in
+// buf, in the same format as regexp.FindAllIndex. Matches don't span lines.
+// Of overlapping candidate matches, the one with the fewest edits is
+// reported.
+func (fm *fuzzyMatcher) FindAllIndex(buf []byte, n int) (locs [][]int) {
+	if !fm.mayMatch(buf) {
+		return nil
+	}
+
+	for lineStart := 0; lineStart < len(buf) && len(locs) < n; {
+		lineEnd := bytes.IndexByte(buf[lineStart:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(buf)
+		} else {
+			lineEnd += lineStart
+		}
+		if line := buf[lineStart:lineEnd]; fm.mayMatch(line) {
+			locs = fm.findLine(line, lineStart, locs, n)
+		}
+		lineStart = lineEnd + 1
+	}
+	return locs
+}
+
+// findLine appends the matches in line to locs, until locs has n entries.
+// offset is the position of line in the buffer being searched.
+func (fm *fuzzyMatcher) findLine(line []byte, offset int, locs [][]int, n int) [][]int {
+	m := len(fm.pattern)
+
+	// best is the best of the candidate matches which overlap the first
+	// candidate found: the one with the fewest edits, and of those the
+	// longest.
+	var (
+		found              bool
+		bestStart, bestEnd int
+		bestCost           int
+	)
+
+	fm.reset(0)
+	for j := 0; ; j++ {
+		if j < len(line) {
+			fm.step(line[j], j)
+			c, start, end := fm.cost[m], fm.start[m], j+1
+			if !found {
+				if c <= fm.maxEdits {
+					found, bestStart, bestEnd, bestCost = true, start, end, c
+				}
+				continue
+			}
+
+			// Matches are at most len(pattern)+maxEdits long, so there are
+			// no more overlapping candidates past that.
+			if end <= bestStart+m+fm.maxEdits {
+				better := c < bestCost || (c == bestCost && end-start > bestEnd-bestStart)
+				if c <= fm.maxEdits && start < bestEnd && better {
+					bestStart, bestEnd, bestCost = start, end, c
+				}
+				continue
+			}
+		}
+
+		if found {
+			locs = append(locs, []int{offset + bestStart, offset + bestEnd})
+			if len(locs) >= n {
+				return locs
+			}
+			found = false
+
+			// Restart the search after the match so that matches don't
+			// overlap.
+			fm.reset(bestEnd)
+			j = bestEnd - 1
+			continue
+		}
+
+		if j >= len(line) {
+			return locs
+		}
+	}
+}
+
+// reset initializes the matrix for matches starting at pos or later.
+func (fm *fuzzyMatcher) reset(pos int) {
+	for i := range fm.cost {
+		fm.cost[i] = i
+		fm.start[i] = pos
+	}
+}
+
+// step advances the matrix by the byte c at position pos. Afterwards cost[i]
+// is the least number of edits between pattern[:i] and a substring ending
+// after c, and start[i] is where that substring starts.
+func (fm *fuzzyMatcher) step(c byte, pos int) {
+	fm.cost, fm.prevCost = fm.prevCost, fm.cost
+	fm.start, fm.prevStart = fm.prevStart, fm.start
+
+	// A match may start anywhere, so matching the empty prefix is free.
+	fm.cost[0] = 0
+	fm.start[0] = pos + 1
+
+	for i := 1; i <= len(fm.pattern); i++ {
+		// Substitution, or a match if the bytes are equal.
+		cost, start := fm.prevCost[i-1], fm.prevStart[i-1]
+		if fm.pattern[i-1] != c {
+			cost++
+		}
+		// Deletion of a pattern byte.
+		if fm.cost[i-1]+1 < cost {
+			cost, start = fm.cost[i-1]+1, fm.start[i-1]
+		}
+		// Insertion of c.
+		if fm.prevCost[i]+1 < cost {
+			cost, start = fm.prevCost[i]+1, fm.prevStart[i]
+		}
+		fm.cost[i], fm.start[i] = cost, start
+	}
+}
+
+// fuzzySearchWithZoekt searches an indexed revision for fuzzy matches. Zoekt
+// narrows the search down to the files which contain a piece of the pattern,
+// which are then verified with rg.
+func fuzzySearchWithZoekt(ctx context.Context, rg *readerGrep, p *protocol.Request, sender matchSender) error {
+	if p.Branch == "" {
+		p.Branch = "HEAD"
+	}
+	branchRepos := []zoektquery.BranchRepos{{Branch: p.Branch, Repos: roaring.BitmapOf(uint32(p.RepoID))}}
+
+	filePathPatterns, err := handleFilePathPatterns(&search.TextPatternInfo{
+		IncludePatterns: p.IncludePatterns,
+		ExcludePattern:  p.ExcludePattern,
+		IsCaseSensitive: p.IsCaseSensitive,
+	})
+	if err != nil {
+		return err
+	}
+
+	q := zoektquery.NewAnd(
+		&zoektquery.BranchesRepos{List: branchRepos},
+		filePathPatterns,
+		fuzzyCandidateQuery(rg.fuzzy, p.IsCaseSensitive),
+	)
+
+	k := zoektutil.ResultCountFactor(1, int32(p.Limit), false)
+	searchOpts := zoektutil.SearchOpts(ctx, k, int32(p.Limit), nil)
+	searchOpts.Whole = true
+
+	// Zoekt may call us concurrently, but rg is not safe for concurrent use.
+	var mu sync.Mutex
+	t0 := time.Now()
+	client := getZoektClient(p.IndexerEndpoints)
+	err = client.StreamSearch(ctx, q, &searchOpts, backend.ZoektStreamFunc(func(event *zoekt.SearchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, file := range event.Files {
+			if sender.Remaining() <= 0 {
+				return
+			}
+			if !rg.matchPath.MatchPath(file.FileName) {
+				continue
+			}
+			cms := rg.find(file.Content, sender.Remaining())
+			if len(cms) == 0 {
+				continue
+			}
+			sender.Send(protocol.FileMatch{
+				Path:         file.FileName,
+				ChunkMatches: cms,
+			})
+		}
+	}))
+	if err != nil {
+		return err
+	}
+	if time.Since(t0) >= searchOpts.MaxWallTime {
+		return errNoResultsInTimeout
+	}
+	return nil
+}
+
+// fuzzyCandidateQuery returns a zoekt query for the files that contain one of
+// the pieces of the fuzzy pattern. Zoekt evaluates it with its trigram index.
+func fuzzyCandidateQuery(fm *fuzzyMatcher, caseSensitive bool) zoektquery.Q {
+	or := make([]zoektquery.Q, 0, len(fm.pieces))
+	for _, piece := range fm.pieces {
+		or = append(or, &zoektquery.Substring{
+			Pattern:       string(piece),
+			CaseSensitive: caseSensitive,
+			Content:       true,
+		})
+	}
+	return zoektquery.NewOr(or...)
+}
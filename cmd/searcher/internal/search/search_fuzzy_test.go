@@ -0,0 +1,114 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+)
+
+func TestFuzzyMatcher(t *testing.T) {
+	cases := []struct {
+		name     string
+		pattern  string
+		maxEdits int
+		input    string
+		want     []string
+	}{{
+		name:     "exact",
+		pattern:  "handler",
+		maxEdits: 1,
+		input:    "func handler() {}",
+		want:     []string{"handler"},
+	}, {
+		name:     "substitution",
+		pattern:  "handler",
+		maxEdits: 1,
+		input:    "func hendler() {}",
+		want:     []string{"hendler"},
+	}, {
+		name:     "deletion",
+		pattern:  "handler",
+		maxEdits: 1,
+		input:    "func hander() {}",
+		want:     []string{"hander"},
+	}, {
+		name:     "insertion",
+		pattern:  "handler",
+		maxEdits: 1,
+		input:    "func handlerr() {}",
+		want:     []string{"handler"},
+	}, {
+		name:     "too many edits",
+		pattern:  "handler",
+		maxEdits: 1,
+		input:    "func hnadler() {}",
+	}, {
+		name:     "two edits",
+		pattern:  "handler",
+		maxEdits: 2,
+		input:    "func hnadler() {}",
+		want:     []string{"hnadler"},
+	}, {
+		name:     "multiple matches",
+		pattern:  "receive",
+		maxEdits: 2,
+		input:    "recieve()\nreceive()\nrecive()\nreceiver()",
+		want:     []string{"recieve", "receive", "recive", "receive"},
+	}, {
+		name:     "matches don't span lines",
+		pattern:  "foobar",
+		maxEdits: 1,
+		input:    "foo\nbar",
+	}, {
+		name:     "no overlapping matches",
+		pattern:  "aaaa",
+		maxEdits: 1,
+		input:    "aaaaaaa",
+		want:     []string{"aaaa", "aaa"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fm := newFuzzyMatcher([]byte(tc.pattern), tc.maxEdits)
+			var got []string
+			for _, loc := range fm.FindAllIndex([]byte(tc.input), 100) {
+				got = append(got, tc.input[loc[0]:loc[1]])
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		fm := newFuzzyMatcher([]byte("foo"), 1)
+		if got := fm.FindAllIndex([]byte("foo fo foo"), 2); len(got) != 2 {
+			t.Errorf("got %d matches, want 2", len(got))
+		}
+	})
+}
+
+func TestFuzzyFind(t *testing.T) {
+	rg, err := compile(&protocol.PatternInfo{
+		Pattern:  "NewHandler",
+		IsFuzzy:  true,
+		MaxEdits: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := rg.find([]byte("package foo\n\nh := newHandlr(db)\n"), 10)
+	want := []protocol.ChunkMatch{{
+		Content:      "h := newHandlr(db)",
+		ContentStart: protocol.Location{Offset: 13, Line: 2},
+		Ranges: []protocol.Range{{
+			Start: protocol.Location{Offset: 18, Line: 2, Column: 5},
+			End:   protocol.Location{Offset: 27, Line: 2, Column: 14},
+		}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -43,6 +43,9 @@ type readerGrep struct {
 	// re is the regexp to match, or nil if empty ("match all files' content").
 	re *regexp.Regexp
 
+	// fuzzy is used instead of re for fuzzy patterns.
+	fuzzy *fuzzyMatcher
+
 	// ignoreCase if true means we need to do case insensitive matching.
 	ignoreCase bool
 
@@ -67,9 +70,16 @@ type readerGrep struct {
 func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 	var (
 		re               *regexp.Regexp
+		fuzzy            *fuzzyMatcher
 		literalSubstring []byte
 	)
-	if p.Pattern != "" {
+	if p.Pattern != "" && p.IsFuzzy {
+		pattern := []byte(p.Pattern)
+		if !p.IsCaseSensitive {
+			pattern = bytes.ToLower(pattern)
+		}
+		fuzzy = newFuzzyMatcher(pattern, p.MaxEdits)
+	} else if p.Pattern != "" {
 		expr := p.Pattern
 		if !p.IsRegExp {
 			expr = regexp.QuoteMeta(expr)
@@ -133,6 +143,7 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 
 	return &readerGrep{
 		re:               re,
+		fuzzy:            fuzzy,
 		ignoreCase:       !p.IsCaseSensitive,
 		matchPath:        matchPath,
 		literalSubstring: literalSubstring,
@@ -142,8 +153,13 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 // Copy returns a copied version of rg that is safe to use from another
 // goroutine.
 func (rg *readerGrep) Copy() *readerGrep {
+	var fuzzy *fuzzyMatcher
+	if rg.fuzzy != nil {
+		fuzzy = rg.fuzzy.Copy()
+	}
 	return &readerGrep{
 		re:               rg.re,
+		fuzzy:            fuzzy,
 		ignoreCase:       rg.ignoreCase,
 		matchPath:        rg.matchPath,
 		literalSubstring: rg.literalSubstring,
@@ -153,12 +169,15 @@ func (rg *readerGrep) Copy() *readerGrep {
 // matchString returns whether rg's regexp pattern matches s. It is intended to be
 // used to match file paths.
 func (rg *readerGrep) matchString(s string) bool {
-	if rg.re == nil {
+	if rg.re == nil && rg.fuzzy == nil {
 		return true
 	}
 	if rg.ignoreCase {
 		s = strings.ToLower(s)
 	}
+	if rg.fuzzy != nil {
+		return rg.fuzzy.Match([]byte(s))
+	}
 	return rg.re.MatchString(s)
 }
 
@@ -166,9 +185,17 @@ func (rg *readerGrep) matchString(s string) bool {
 // LimitHit is true if some matches may not have been included in the result.
 // NOTE: This is not safe to use concurrently.
 func (rg *readerGrep) Find(zf *zipFile, f *srcFile, limit int) (matches []protocol.ChunkMatch, err error) {
+	if rg.ignoreCase && rg.transformBuf == nil {
+		rg.transformBuf = make([]byte, zf.MaxLen)
+	}
+	return rg.find(zf.DataFor(f), limit), nil
+}
+
+// find returns the matches of rg in fileBuf. It is Find for file contents
+// which are not read from a zip archive.
+func (rg *readerGrep) find(fileBuf []byte, limit int) []protocol.ChunkMatch {
 	// fileMatchBuf is what we run match on, fileBuf is the original
 	// data (for Preview).
-	fileBuf := zf.DataFor(f)
 	fileMatchBuf := fileBuf
 
 	// If we are ignoring case, we transform the input instead of
@@ -177,8 +204,8 @@ func (rg *readerGrep) Find(zf *zipFile, f *srcFile, limit int) (matches []protoc
 	// trade some correctness for perf by using a non-utf8 aware
 	// lowercase function.
 	if rg.ignoreCase {
-		if rg.transformBuf == nil {
-			rg.transformBuf = make([]byte, zf.MaxLen)
+		if len(rg.transformBuf) < len(fileBuf) {
+			rg.transformBuf = make([]byte, len(fileBuf))
 		}
 		fileMatchBuf = rg.transformBuf[:len(fileBuf)]
 		casetransform.BytesToLowerASCII(fileMatchBuf, fileBuf)
@@ -191,18 +218,24 @@ func (rg *readerGrep) Find(zf *zipFile, f *srcFile, limit int) (matches []protoc
 	// searching for results. We use the same approach when we search
 	// per-line. Additionally if we have a non-empty literalSubstring, we use
 	// that to prune out files since doing bytes.Index is very fast.
-	if !bytes.Contains(fileMatchBuf, rg.literalSubstring) {
-		return nil, nil
-	}
+	var locs [][]int
+	if rg.fuzzy != nil {
+		// The fuzzy matcher does its own pruning.
+		locs = rg.fuzzy.FindAllIndex(fileMatchBuf, limit+1)
+	} else {
+		if !bytes.Contains(fileMatchBuf, rg.literalSubstring) {
+			return nil
+		}
 
-	// find limit+1 matches so we know whether we hit the limit
-	locs := rg.re.FindAllIndex(fileMatchBuf, limit+1)
+		// find limit+1 matches so we know whether we hit the limit
+		locs = rg.re.FindAllIndex(fileMatchBuf, limit+1)
+	}
 	if len(locs) == 0 {
-		return nil, nil // short-circuit if we have no matches
+		return nil // short-circuit if we have no matches
 	}
 	ranges := locsToRanges(fileBuf, locs)
 	chunks := chunkRanges(ranges, 0)
-	return chunksToMatches(fileBuf, chunks), nil
+	return chunksToMatches(fileBuf, chunks)
 }
 
 // locs must be sorted, non-overlapping, and must be valid slices of buf.
@@ -302,7 +335,7 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *zipFile, patternMatche
 		files = zf.Files
 	)
 
-	if rg.re == nil || (patternMatchesPaths && !patternMatchesContent) {
+	if (rg.re == nil && rg.fuzzy == nil) || (patternMatchesPaths && !patternMatchesContent) {
 		// Fast path for only matching file paths (or with a nil pattern, which matches all files,
 		// so is effectively matching only on file paths).
 		for _, f := range files {
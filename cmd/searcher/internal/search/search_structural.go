@@ -12,9 +12,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
-	"time"
 
-	"github.com/RoaringBitmap/roaring"
 	"github.com/opentracing/opentracing-go/ext"
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/prometheus/client_golang/prometheus"
@@ -26,7 +24,6 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/comby"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
-	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -293,36 +290,6 @@ func lookupMatcher(language string) string {
 	return ".generic"
 }
 
-func structuralSearchWithZoekt(ctx context.Context, p *protocol.Request, sender matchSender) (err error) {
-	patternInfo := &search.TextPatternInfo{
-		Pattern:                      p.Pattern,
-		IsNegated:                    p.IsNegated,
-		IsRegExp:                     p.IsRegExp,
-		IsStructuralPat:              p.IsStructuralPat,
-		CombyRule:                    p.CombyRule,
-		IsWordMatch:                  p.IsWordMatch,
-		IsCaseSensitive:              p.IsCaseSensitive,
-		FileMatchLimit:               int32(p.Limit),
-		IncludePatterns:              p.IncludePatterns,
-		ExcludePattern:               p.ExcludePattern,
-		PathPatternsAreCaseSensitive: p.PathPatternsAreCaseSensitive,
-		PatternMatchesContent:        p.PatternMatchesContent,
-		PatternMatchesPath:           p.PatternMatchesPath,
-		Languages:                    p.Languages,
-	}
-
-	if p.Branch == "" {
-		p.Branch = "HEAD"
-	}
-	branchRepos := []zoektquery.BranchRepos{{Branch: p.Branch, Repos: roaring.BitmapOf(uint32(p.RepoID))}}
-	err = zoektSearch(ctx, patternInfo, branchRepos, time.Since, p.IndexerEndpoints, p.Repo, sender)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 // filteredStructuralSearch filters the list of files with a regex search before passing the zip to comby
 func filteredStructuralSearch(ctx context.Context, zipPath string, zf *zipFile, p *protocol.PatternInfo, repo api.RepoName, sender matchSender) error {
 	// Make a copy of the pattern info to modify it to work for a regex search
@@ -550,7 +550,7 @@ func TestBuildQuery(t *testing.T) {
 	pattern := ":[x~*]"
 	want := "error parsing regexp: missing argument to repetition operator: `*`"
 	t.Run("build query", func(t *testing.T) {
-		_, err := buildQuery(&search.TextPatternInfo{Pattern: pattern}, nil, nil, false)
+		_, err := buildQuery(&search.TextPatternInfo{Pattern: pattern}, nil, nil)
 		if diff := cmp.Diff(err.Error(), want); diff != "" {
 			t.Error(diff)
 		}
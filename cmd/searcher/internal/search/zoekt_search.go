@@ -1,20 +1,16 @@
 package search
 
 import (
-	"archive/tar"
 	"context"
-	"path/filepath"
 	"regexp/syntax" //nolint:depguard // zoekt requires this pkg
-	"strings"
 	"sync"
 	"sync/atomic"
-	"time"
 
+	"github.com/RoaringBitmap/roaring"
 	"github.com/sourcegraph/zoekt"
 	zoektquery "github.com/sourcegraph/zoekt/query"
 
-	"github.com/sourcegraph/log"
-	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/comby"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/backend"
@@ -65,102 +61,118 @@ func handleFilePathPatterns(query *search.TextPatternInfo) (zoektquery.Q, error)
 	return zoektquery.NewAnd(and...), nil
 }
 
-func buildQuery(args *search.TextPatternInfo, branchRepos []zoektquery.BranchRepos, filePathPatterns zoektquery.Q, shortcircuit bool) (zoektquery.Q, error) {
-	regexString := comby.StructuralPatToRegexpQuery(args.Pattern, shortcircuit)
-	if len(regexString) == 0 {
-		return &zoektquery.Const{Value: true}, nil
-	}
-	re, err := syntax.Parse(regexString, syntax.ClassNL|syntax.PerlX|syntax.UnicodeGroups)
-	if err != nil {
-		return nil, err
+// minLiteralLen is the length below which literals don't narrow down a zoekt
+// search, as zoekt can't use its trigram index for them.
+const minLiteralLen = 3
+
+// buildQuery returns a zoekt query for the files which may contain a match for
+// the structural pattern in args: those containing its literals and the
+// regular expressions of its regexp holes. If no part of the pattern can
+// narrow down the files, it returns a query that matches everything.
+func buildQuery(args *search.TextPatternInfo, branchRepos []zoektquery.BranchRepos, filePathPatterns zoektquery.Q) (zoektquery.Q, error) {
+	literals, holeRegexps := comby.StructuralPatToLiterals(args.Pattern)
+
+	var and []zoektquery.Q
+	for _, literal := range literals {
+		if len(literal) < minLiteralLen {
+			continue
+		}
+		and = append(and, &zoektquery.Substring{
+			Pattern:       literal,
+			CaseSensitive: true,
+			Content:       true,
+		})
 	}
-	return zoektquery.NewAnd(
-		&zoektquery.BranchesRepos{List: branchRepos},
-		filePathPatterns,
-		&zoektquery.Regexp{
+	for _, holeRegexp := range holeRegexps {
+		re, err := syntax.Parse(holeRegexp, syntax.ClassNL|syntax.PerlX|syntax.UnicodeGroups)
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, &zoektquery.Regexp{
 			Regexp:        re,
 			CaseSensitive: true,
 			Content:       true,
-		},
-	), nil
+		})
+	}
+	if len(and) == 0 {
+		return &zoektquery.Const{Value: true}, nil
+	}
+
+	return zoektquery.NewAnd(append([]zoektquery.Q{
+		&zoektquery.BranchesRepos{List: branchRepos},
+		filePathPatterns,
+	}, and...)...), nil
 }
 
-// zoektSearch searches repositories using zoekt, returning file contents for
-// files that match the given pattern.
+// zoektCandidatePaths returns the paths of the files at p.Commit which may
+// match the structural pattern of p, according to zoekt. Only these files need
+// to be fetched from gitserver and passed to comby.
 //
-// Timeouts are reported through the context, and as a special case errNoResultsInTimeout
-// is returned if no results are found in the given timeout (instead of the more common
-// case of finding partial or full results in the given timeout).
-func zoektSearch(ctx context.Context, args *search.TextPatternInfo, branchRepos []zoektquery.BranchRepos, since func(t time.Time) time.Duration, endpoints []string, repo api.RepoName, sender matchSender) (err error) {
-	if len(branchRepos) == 0 {
-		return nil
+// If ok is false, zoekt can't narrow down the files, either because the
+// pattern has no literals or because p.Commit isn't the indexed commit. The
+// caller should search the whole archive instead.
+func zoektCandidatePaths(ctx context.Context, p *protocol.Request) (paths []string, ok bool, err error) {
+	if p.Branch == "" {
+		p.Branch = "HEAD"
 	}
+	branchRepos := []zoektquery.BranchRepos{{Branch: p.Branch, Repos: roaring.BitmapOf(uint32(p.RepoID))}}
 
-	numRepos := 0
-	for _, br := range branchRepos {
-		numRepos += int(br.Repos.GetCardinality())
+	args := &search.TextPatternInfo{
+		Pattern:         p.Pattern,
+		IncludePatterns: p.IncludePatterns,
+		ExcludePattern:  p.ExcludePattern,
+		IsCaseSensitive: p.IsCaseSensitive,
 	}
-
-	// Choose sensible values for k when we generalize this.
-	k := zoektutil.ResultCountFactor(numRepos, args.FileMatchLimit, false)
-	searchOpts := zoektutil.SearchOpts(ctx, k, args.FileMatchLimit, nil)
-	searchOpts.Whole = true
-
 	filePathPatterns, err := handleFilePathPatterns(args)
 	if err != nil {
-		return err
+		return nil, false, err
+	}
+	q, err := buildQuery(args, branchRepos, filePathPatterns)
+	if err != nil {
+		return nil, false, err
 	}
+	if c, isConst := q.(*zoektquery.Const); isConst && c.Value {
+		return nil, false, nil
+	}
+
+	k := zoektutil.ResultCountFactor(1, int32(p.Limit), false)
+	opts := zoektutil.SearchOpts(ctx, k, int32(p.Limit), nil)
+	// Every file with a match is a candidate, so don't cap the number of
+	// files zoekt returns.
+	opts.MaxDocDisplayCount = 0
 
-	t0 := time.Now()
-	q, err := buildQuery(args, branchRepos, filePathPatterns, false)
+	client := getZoektClient(p.IndexerEndpoints)
+	res, err := client.Search(ctx, q, &opts)
 	if err != nil {
-		return err
+		return nil, false, err
 	}
 
-	tarInputEventC := make(chan comby.TarInputEvent)
-	wg := sync.WaitGroup{}
-	defer wg.Wait()
+	// If zoekt stopped early or dropped files we don't have all the
+	// candidates.
+	if res.Stats.FilesSkipped > 0 || res.Stats.ShardsSkipped > 0 || len(res.Files) < res.Stats.FileCount {
+		return nil, false, nil
+	}
 
-	var extensionHint string
-	if len(args.IncludePatterns) > 0 {
-		// Remove anchor that's added by autocomplete
-		extensionHint = strings.TrimSuffix(filepath.Ext(args.IncludePatterns[0]), "$")
+	for _, fm := range res.Files {
+		// The paths are only valid for the commit we are searching.
+		if fm.Version != string(p.Commit) {
+			return nil, false, nil
+		}
+		paths = append(paths, fm.FileName)
 	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		err := structuralSearch(ctx, comby.Tar{TarInputEventC: tarInputEventC}, all, extensionHint, args.Pattern, args.CombyRule, args.Languages, repo, sender)
+	// Without matches we don't know which commit zoekt searched.
+	if len(paths) == 0 {
+		indexed, found, err := zoektIndexedCommit(ctx, client, p.Repo)
 		if err != nil {
-			log.NamedError("structural search error", err)
+			return nil, false, err
 		}
-	}()
-
-	client := getZoektClient(endpoints)
-	err = client.StreamSearch(ctx, q, &searchOpts, backend.ZoektStreamFunc(func(event *zoekt.SearchResult) {
-		for _, file := range event.Files {
-			hdr := tar.Header{
-				Name: file.FileName,
-				Mode: 0600,
-				Size: int64(len(file.Content)),
-			}
-			tarInput := comby.TarInputEvent{
-				Header:  hdr,
-				Content: file.Content,
-			}
-			tarInputEventC <- tarInput
+		if !found || indexed != p.Commit {
+			return nil, false, nil
 		}
-	}))
-	close(tarInputEventC)
-
-	if err != nil {
-		return err
-	}
-	if since(t0) >= searchOpts.MaxWallTime {
-		return errNoResultsInTimeout
 	}
 
-	return nil
+	return paths, true, nil
 }
 
 var errNoResultsInTimeout = errors.New("no results found in specified timeout")
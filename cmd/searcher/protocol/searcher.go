@@ -50,7 +50,8 @@ type Request struct {
 	IndexerEndpoints []string
 
 	// Whether the revision to be searched is indexed or unindexed. This matters for
-	// structural search because it will query Zoekt for indexed structural search.
+	// structural and fuzzy search because they query Zoekt for candidate files
+	// of indexed revisions.
 	Indexed bool
 
 	// FeatHybrid is a feature flag which enables hybrid search. Hybrid search
@@ -76,6 +77,13 @@ type PatternInfo struct {
 	// IsStructuralPat if true will treat the pattern as a Comby structural search pattern.
 	IsStructuralPat bool
 
+	// IsFuzzy if true will match any substring of a line that is within
+	// MaxEdits insertions, deletions or substitutions of the pattern.
+	IsFuzzy bool
+
+	// MaxEdits is the edit distance tolerated by fuzzy patterns.
+	MaxEdits int
+
 	// IsWordMatch if true will only match the pattern at word boundaries.
 	IsWordMatch bool
 
@@ -145,6 +153,9 @@ func (p *PatternInfo) String() string {
 			args = append(args, "comby")
 		}
 	}
+	if p.IsFuzzy {
+		args = append(args, fmt.Sprintf("fuzzy:%d", p.MaxEdits))
+	}
 	if p.IsWordMatch {
 		args = append(args, "word")
 	}
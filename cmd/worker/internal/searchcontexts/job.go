@@ -0,0 +1,66 @@
+package searchcontexts
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+)
+
+type config struct {
+	env.BaseConfig
+
+	RefreshInterval time.Duration
+}
+
+func (c *config) Load() {
+	c.RefreshInterval = c.GetInterval("SEARCH_CONTEXTS_DYNAMIC_REFRESH_INTERVAL", "1h", "How often the repositories of a dynamic search context are resolved from its query.")
+}
+
+// dynamicContextsJob resolves the repositories of dynamic search contexts
+// from their queries.
+type dynamicContextsJob struct {
+	config *config
+}
+
+var _ job.Job = &dynamicContextsJob{}
+
+func NewDynamicContextsJob() job.Job {
+	return &dynamicContextsJob{config: &config{}}
+}
+
+func (j *dynamicContextsJob) Description() string {
+	return "Resolves the repositories of dynamic search contexts from their queries."
+}
+
+func (j *dynamicContextsJob) Config() []env.Config {
+	return []env.Config{j.config}
+}
+
+func (j *dynamicContextsJob) Routines(ctx context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	mainAppDB, err := workerdb.Init()
+	if err != nil {
+		return nil, err
+	}
+	db := database.NewDB(logger, mainAppDB)
+
+	r := &refresher{
+		logger:       logger.Scoped("searchcontexts.Refresher", "resolves the repositories of dynamic search contexts"),
+		store:        db.SearchContexts(),
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+		interval:     j.config.RefreshInterval,
+	}
+	return []goroutine.BackgroundRoutine{
+		// We check for stale contexts more often than the refresh interval,
+		// so that new and updated contexts are resolved soon.
+		goroutine.NewPeriodicGoroutine(context.Background(), 1*time.Minute, r),
+	}, nil
+}
@@ -0,0 +1,139 @@
+package searchcontexts
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// refreshBatchSize is the maximum number of search contexts resolved per run
+// of the refresher.
+const refreshBatchSize = 10
+
+// refresher resolves the repositories of the dynamic search contexts which
+// weren't resolved within the last interval, and stores them along with the
+// membership changes.
+type refresher struct {
+	logger       log.Logger
+	store        database.SearchContextsStore
+	searchClient client.SearchClient
+	interval     time.Duration
+}
+
+var _ goroutine.Handler = &refresher{}
+var _ goroutine.ErrorHandler = &refresher{}
+
+func (r *refresher) Handle(ctx context.Context) error {
+	// Membership doesn't depend on who searches, permissions are applied when
+	// the repositories of a context are read.
+	ctx = actor.WithInternalActor(ctx)
+
+	searchContexts, err := r.store.ListDynamicSearchContextsToRefresh(ctx, time.Now().Add(-r.interval), refreshBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, sc := range searchContexts {
+		if err := r.refresh(ctx, sc); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "search context %d", sc.ID))
+		}
+	}
+	return errs
+}
+
+func (r *refresher) HandleError(err error) {
+	r.logger.Error("error resolving the repositories of dynamic search contexts", log.Error(err))
+}
+
+func (r *refresher) refresh(ctx context.Context, sc *types.SearchContext) error {
+	repositoryRevisions, err := r.resolve(ctx, sc.Query)
+	if err != nil {
+		// Move the context to the back of the queue, so that contexts whose
+		// query keeps failing or timing out don't block the other ones.
+		if markErr := r.store.MarkDynamicSearchContextRefreshAttempted(ctx, sc.ID); markErr != nil {
+			err = errors.Append(err, markErr)
+		}
+		return err
+	}
+
+	events, err := r.store.SetDynamicSearchContextMembership(ctx, sc.ID, repositoryRevisions)
+	if err != nil {
+		return err
+	}
+	r.logger.Debug("resolved repositories of dynamic search context",
+		log.Int64("searchContextID", sc.ID),
+		log.Int("repositories", len(repositoryRevisions)),
+		log.Int("changes", len(events)))
+	return nil
+}
+
+// resolve returns the repositories and revisions matched by the search
+// context query q.
+func (r *refresher) resolve(ctx context.Context, q string) ([]*types.SearchContextRepositoryRevisions, error) {
+	// The query is grouped so that the added parameters apply to all of its
+	// operands, and not only to the last one of a top-level "or".
+	inputs, err := r.searchClient.Plan(ctx, "V3", nil, "("+q+") select:repo count:all", search.Streaming, &schema.Settings{}, envvar.SourcegraphDotComMode())
+	if err != nil {
+		return nil, err
+	}
+
+	agg := streaming.NewAggregatingStream()
+	if _, err := r.searchClient.Execute(ctx, agg, inputs); err != nil {
+		return nil, err
+	}
+
+	// An incomplete result would remove repositories from the context, so we
+	// keep the current membership until we get a complete one.
+	if agg.Stats.IsLimitHit || agg.Stats.Status.Any(search.RepoStatusTimedout) {
+		return nil, errors.New("search did not complete")
+	}
+
+	return repositoryRevisions(agg.Results), nil
+}
+
+// repositoryRevisions groups the revisions of the repositories of matches.
+// Matches without a revision are for the default branch.
+func repositoryRevisions(matches result.Matches) []*types.SearchContextRepositoryRevisions {
+	byID := make(map[api.RepoID]*types.SearchContextRepositoryRevisions)
+	revs := make(map[api.RepoID]map[string]struct{})
+	for _, m := range matches {
+		repo := m.RepoName()
+		rev := "HEAD"
+		if rm, ok := m.(*result.RepoMatch); ok && rm.Rev != "" {
+			rev = rm.Rev
+		}
+
+		if _, ok := byID[repo.ID]; !ok {
+			byID[repo.ID] = &types.SearchContextRepositoryRevisions{Repo: repo}
+			revs[repo.ID] = make(map[string]struct{})
+		}
+		if _, ok := revs[repo.ID][rev]; !ok {
+			revs[repo.ID][rev] = struct{}{}
+			byID[repo.ID].Revisions = append(byID[repo.ID].Revisions, rev)
+		}
+	}
+
+	out := make([]*types.SearchContextRepositoryRevisions, 0, len(byID))
+	for _, repoRevs := range byID {
+		sort.Strings(repoRevs.Revisions)
+		out = append(out, repoRevs)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Repo.ID < out[j].Repo.ID })
+	return out
+}
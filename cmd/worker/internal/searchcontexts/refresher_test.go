@@ -0,0 +1,73 @@
+package searchcontexts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRefresher(t *testing.T) {
+	store := database.NewMockSearchContextsStore()
+	store.ListDynamicSearchContextsToRefreshFunc.SetDefaultReturn([]*types.SearchContext{
+		{ID: 1, Name: "gomod", Query: "repo:has.file(go.mod)", Dynamic: true},
+	}, nil)
+
+	searchClient := client.NewMockSearchClient()
+	searchClient.PlanFunc.SetDefaultReturn(&search.Inputs{}, nil)
+
+	r := &refresher{
+		logger:       logtest.Scoped(t),
+		store:        store,
+		searchClient: searchClient,
+		interval:     time.Hour,
+	}
+
+	t.Run("stores resolved repositories", func(t *testing.T) {
+		searchClient.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: result.Matches{
+				&result.RepoMatch{ID: 2, Name: "b"},
+				&result.RepoMatch{ID: 1, Name: "a", Rev: "main"},
+				&result.RepoMatch{ID: 1, Name: "a"},
+			}})
+			return nil, nil
+		})
+
+		require.NoError(t, r.Handle(context.Background()))
+		mockassert.CalledOnce(t, store.SetDynamicSearchContextMembershipFunc)
+
+		call := store.SetDynamicSearchContextMembershipFunc.History()[0]
+		assert.Equal(t, int64(1), call.Arg1)
+		assert.Equal(t, []*types.SearchContextRepositoryRevisions{
+			{Repo: types.MinimalRepo{ID: 1, Name: "a"}, Revisions: []string{"HEAD", "main"}},
+			{Repo: types.MinimalRepo{ID: 2, Name: "b"}, Revisions: []string{"HEAD"}},
+		}, call.Arg2)
+		assert.Equal(t, "(repo:has.file(go.mod)) select:repo count:all", searchClient.PlanFunc.History()[0].Arg3)
+	})
+
+	t.Run("keeps membership of incomplete searches", func(t *testing.T) {
+		searchClient.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{
+				Results: result.Matches{&result.RepoMatch{ID: 1, Name: "a"}},
+				Stats:   streaming.Stats{IsLimitHit: true},
+			})
+			return nil, nil
+		})
+
+		assert.Error(t, r.Handle(context.Background()))
+		mockassert.CalledOnce(t, store.SetDynamicSearchContextMembershipFunc)
+		mockassert.CalledOnce(t, store.MarkDynamicSearchContextRefreshAttemptedFunc)
+		assert.Equal(t, int64(1), store.MarkDynamicSearchContextRefreshAttemptedFunc.History()[0].Arg1)
+	})
+}
@@ -0,0 +1,68 @@
+package searchexports
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+)
+
+// exportsJob runs queued search result exports and deletes expired ones.
+type exportsJob struct {
+	config *exports.Config
+}
+
+var _ job.Job = &exportsJob{}
+
+func NewExportsJob() job.Job {
+	return &exportsJob{config: &exports.Config{}}
+}
+
+func (j *exportsJob) Description() string {
+	return "Runs search result exports and deletes expired exports."
+}
+
+func (j *exportsJob) Config() []env.Config {
+	return []env.Config{j.config}
+}
+
+func (j *exportsJob) Routines(ctx context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	observationContext := &observation.Context{
+		Logger:     logger.Scoped("routines", "search exports job routines"),
+		Tracer:     &trace.Tracer{TracerProvider: otel.GetTracerProvider()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+
+	mainAppDB, err := workerdb.Init()
+	if err != nil {
+		return nil, err
+	}
+	db := database.NewDB(logger, mainAppDB)
+
+	uploadStore, err := exports.NewUploadStore(ctx, j.config, observationContext)
+	if err != nil {
+		return nil, err
+	}
+
+	workerStore := exports.CreateWorkerStore(logger.Scoped("exports.WorkerStore", ""), db.Handle())
+	workerMetrics := workerutil.NewMetrics(observationContext, "search_exports_processor")
+	resetterMetrics := dbworker.NewMetrics(observationContext, "search_exports")
+
+	return []goroutine.BackgroundRoutine{
+		exports.NewWorker(ctx, logger.Scoped("exports.Worker", ""), db, uploadStore, workerStore, workerMetrics),
+		exports.NewResetter(ctx, logger.Scoped("exports.Resetter", ""), workerStore, *resetterMetrics),
+		exports.NewJanitor(ctx, logger.Scoped("exports.Janitor", ""), exports.NewStore(db.Handle()), uploadStore, j.config.Retention, observationContext),
+	}, nil
+}
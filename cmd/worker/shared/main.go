@@ -17,6 +17,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/gitserver"
 	workermigrations "github.com/sourcegraph/sourcegraph/cmd/worker/internal/migrations"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/repostatistics"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/searchexports"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/webhooks"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -49,6 +51,8 @@ func Start(logger log.Logger, additionalJobs map[string]job.Job, registerEnterpr
 		"gitserver-metrics":                     gitserver.NewMetricsJob(),
 		"record-encrypter":                      encryption.NewRecordEncrypterJob(),
 		"repo-statistics-compactor":             repostatistics.NewCompactor(),
+		"search-exports":                        searchexports.NewExportsJob(),
+		"search-contexts-dynamic-refresher":     searchcontexts.NewDynamicContextsJob(),
 	}
 
 	jobs := map[string]job.Job{}
@@ -92,6 +92,20 @@ type BatchChange struct {
 	DiffStat                DiffStat
 	BulkOperations          BulkOperationConnection
 	BatchSpecs              BatchSpecConnection
+	Rollout                 *BatchChangeRollout
+}
+
+type BatchChangeRollout struct {
+	WaitFor string
+	Stages  []BatchChangeRolloutStage
+}
+
+type BatchChangeRolloutStage struct {
+	Stage          int32
+	State          string
+	TotalCount     int32
+	PublishedCount int32
+	CompletedCount int32
 }
 
 type BatchChangeConnection struct {
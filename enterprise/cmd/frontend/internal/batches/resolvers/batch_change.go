@@ -188,6 +188,35 @@ func (r *batchChangeResolver) ClosedAt() *graphqlbackend.DateTime {
 	return &graphqlbackend.DateTime{Time: r.batchChange.ClosedAt}
 }
 
+func (r *batchChangeResolver) MergePolicy(ctx context.Context) (graphqlbackend.BatchChangeMergePolicyResolver, error) {
+	policy, err := r.store.GetBatchChangeMergePolicy(ctx, r.batchChange.ID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batchChangeMergePolicyResolver{store: r.store, policy: policy}, nil
+}
+
+func (r *batchChangeResolver) Rollout(ctx context.Context) (graphqlbackend.BatchChangeRolloutResolver, error) {
+	batchSpec, err := r.computeBatchSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rollout := batchSpec.Rollout()
+	if rollout == nil {
+		return nil, nil
+	}
+
+	stages, err := r.store.ListRolloutStages(ctx, batchSpec.ID, rollout.WaitForOrDefault())
+	if err != nil {
+		return nil, err
+	}
+	return &batchChangeRolloutResolver{waitFor: rollout.WaitForOrDefault(), stages: stages}, nil
+}
+
 func (r *batchChangeResolver) ChangesetsStats(ctx context.Context) (graphqlbackend.ChangesetsStatsResolver, error) {
 	stats, err := r.store.GetChangesetsStats(ctx, r.batchChange.ID)
 	if err != nil {
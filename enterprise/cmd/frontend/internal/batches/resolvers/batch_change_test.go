@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 func TestBatchChangeResolver(t *testing.T) {
@@ -218,6 +219,91 @@ func TestBatchChangeResolver_BatchSpecs(t *testing.T) {
 	assertBatchSpecsInResponse(t, otherUserCtx, s, batchChange.ID, batchSpec1, batchSpec2)
 }
 
+func TestBatchChangeResolver_Rollout(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	userID := bt.CreateTestUser(t, db, true).ID
+	userCtx := actor.WithActor(ctx, actor.FromUser(userID))
+	repos, _ := bt.CreateTestRepos(t, ctx, db, 2)
+
+	bstore := store.New(db, &observation.TestContext, nil)
+
+	s, err := newSchema(db, &Resolver{store: bstore})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, bstore, "test-rollout", userID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, bstore, "test-rollout", userID, batchSpec.ID)
+	batchChangeAPIID := string(marshalBatchChangeID(batchChange.ID))
+
+	// Without a rollout, all changesets are published at once.
+	{
+		var response struct{ Node apitest.BatchChange }
+		apitest.MustExec(userCtx, t, s, map[string]any{"batchChange": batchChangeAPIID}, &response, queryBatchChangeRollout)
+
+		if response.Node.Rollout != nil {
+			t.Fatalf("unexpected rollout: %+v", response.Node.Rollout)
+		}
+	}
+
+	batchSpec.Spec.ChangesetTemplate.Rollout = &batcheslib.Rollout{
+		WaitFor: batcheslib.RolloutWaitForApproved,
+		Stages: []batcheslib.RolloutStage{
+			{Repositories: []string{string(repos[0].Name)}},
+		},
+	}
+	if err := bstore.UpdateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, repo := range repos {
+		spec := bt.CreateChangesetSpec(t, ctx, bstore, bt.TestSpecOpts{
+			User:         userID,
+			Repo:         repo.ID,
+			BatchSpec:    batchSpec.ID,
+			HeadRef:      "refs/heads/test-rollout",
+			Published:    true,
+			Typ:          btypes.ChangesetSpecTypeBranch,
+			RolloutStage: int32(i + 1),
+		})
+		opts := bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			CurrentSpec:        spec.ID,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+		}
+		if i == 0 {
+			opts.PublicationState = btypes.ChangesetPublicationStatePublished
+			opts.ExternalState = btypes.ChangesetExternalStateOpen
+		}
+		bt.CreateChangeset(t, ctx, bstore, opts)
+	}
+
+	want := &apitest.BatchChangeRollout{
+		WaitFor: "APPROVED",
+		Stages: []apitest.BatchChangeRolloutStage{
+			{Stage: 1, State: "ACTIVE", TotalCount: 1, PublishedCount: 1},
+			{Stage: 2, State: "PENDING", TotalCount: 1},
+		},
+	}
+
+	var response struct{ Node apitest.BatchChange }
+	apitest.MustExec(userCtx, t, s, map[string]any{"batchChange": batchChangeAPIID}, &response, queryBatchChangeRollout)
+
+	if diff := cmp.Diff(want, response.Node.Rollout); diff != "" {
+		t.Fatalf("wrong rollout (-want +got):\n%s", diff)
+	}
+}
+
 func assertBatchSpecsInResponse(t *testing.T, ctx context.Context, s *graphql.Schema, batchChangeID int64, wantBatchSpecs ...*btypes.BatchSpec) {
 	t.Helper()
 
@@ -307,3 +393,16 @@ query($batchChange: ID!, $includeLocallyExecutedSpecs: Boolean){
   }
 }
 `
+
+const queryBatchChangeRollout = `
+query($batchChange: ID!) {
+  node(id: $batchChange) {
+    ... on BatchChange {
+      rollout {
+        waitFor
+        stages { stage, state, totalCount, publishedCount, completedCount }
+      }
+    }
+  }
+}
+`
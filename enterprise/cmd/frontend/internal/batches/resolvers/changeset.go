@@ -427,6 +427,22 @@ func (r *changesetResolver) Labels(ctx context.Context) ([]graphqlbackend.Change
 	return resolvers, nil
 }
 
+func (r *changesetResolver) Kind() string {
+	// Changesets that haven't been stored yet, such as the ones in a preview,
+	// may not have a kind set.
+	if r.changeset.IsIssue() {
+		return btypes.ChangesetKindIssue.ToGraphQL()
+	}
+	return btypes.ChangesetKindPullRequest.ToGraphQL()
+}
+
+func (r *changesetResolver) Assignees() []string {
+	if !r.changeset.Published() {
+		return []string{}
+	}
+	return r.changeset.Assignees()
+}
+
 func (r *changesetResolver) Events(ctx context.Context, args *graphqlbackend.ChangesetEventsConnectionArgs) (graphqlbackend.ChangesetEventsConnectionResolver, error) {
 	if err := validateFirstParamDefaults(args.First); err != nil {
 		return nil, err
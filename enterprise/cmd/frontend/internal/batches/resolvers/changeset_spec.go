@@ -127,9 +127,9 @@ func (r *changesetSpecResolver) ToVisibleChangesetSpec() (graphqlbackend.Visible
 
 var _ graphqlbackend.ChangesetDescription = &changesetDescriptionResolver{}
 
-// changesetDescriptionResolver implements both ChangesetDescription
-// interfaces: ExistingChangesetReferenceResolver and
-// GitBranchChangesetDescriptionResolver.
+// changesetDescriptionResolver implements all ChangesetDescription
+// interfaces: ExistingChangesetReferenceResolver,
+// GitBranchChangesetDescriptionResolver and IssueChangesetDescriptionResolver.
 type changesetDescriptionResolver struct {
 	store        *store.Store
 	repoResolver *graphqlbackend.RepositoryResolver
@@ -149,6 +149,12 @@ func (r *changesetDescriptionResolver) ToGitBranchChangesetDescription() (graphq
 	}
 	return nil, false
 }
+func (r *changesetDescriptionResolver) ToIssueChangesetDescription() (graphqlbackend.IssueChangesetDescriptionResolver, bool) {
+	if r.spec.Type == btypes.ChangesetSpecTypeIssue {
+		return r, true
+	}
+	return nil, false
+}
 
 func (r *changesetDescriptionResolver) BaseRepository() *graphqlbackend.RepositoryResolver {
 	return r.repoResolver
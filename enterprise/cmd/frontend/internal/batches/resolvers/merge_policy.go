@@ -0,0 +1,110 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type batchChangeMergePolicyResolver struct {
+	store  *store.Store
+	policy *btypes.BatchChangeMergePolicy
+}
+
+var _ graphqlbackend.BatchChangeMergePolicyResolver = &batchChangeMergePolicyResolver{}
+
+func (r *batchChangeMergePolicyResolver) MergeMethod() string {
+	return string(r.policy.MergeMethod)
+}
+
+func (r *batchChangeMergePolicyResolver) RequiredApprovals() int32 {
+	return r.policy.RequiredApprovals
+}
+
+func (r *batchChangeMergePolicyResolver) Windows() []graphqlbackend.BatchChangeMergeWindowResolver {
+	resolvers := make([]graphqlbackend.BatchChangeMergeWindowResolver, 0, len(r.policy.Windows))
+	for _, w := range r.policy.Windows {
+		resolvers = append(resolvers, &batchChangeMergeWindowResolver{window: w})
+	}
+	return resolvers
+}
+
+func (r *batchChangeMergePolicyResolver) MaxMergesPerHour() int32 {
+	return r.policy.MaxMergesPerHour
+}
+
+func (r *batchChangeMergePolicyResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.policy.CreatorID == 0 {
+		return nil, nil
+	}
+
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.policy.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchChangeMergePolicyResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.policy.CreatedAt}
+}
+
+func (r *batchChangeMergePolicyResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.policy.UpdatedAt}
+}
+
+type batchChangeMergeWindowResolver struct {
+	window *schema.BatchChangeRolloutWindow
+}
+
+var _ graphqlbackend.BatchChangeMergeWindowResolver = &batchChangeMergeWindowResolver{}
+
+func (r *batchChangeMergeWindowResolver) Days() []string {
+	if r.window.Days == nil {
+		return []string{}
+	}
+	return r.window.Days
+}
+
+func (r *batchChangeMergeWindowResolver) Start() *string {
+	if r.window.Start == "" {
+		return nil
+	}
+	return &r.window.Start
+}
+
+func (r *batchChangeMergeWindowResolver) End() *string {
+	if r.window.End == "" {
+		return nil
+	}
+	return &r.window.End
+}
+
+// mergeWindowsFromInput converts the given merge windows to rollout windows.
+// Merge windows have no rate, since the merge policy limits the number of
+// merges itself.
+func mergeWindowsFromInput(input *[]graphqlbackend.BatchChangeMergeWindowInput) []*schema.BatchChangeRolloutWindow {
+	if input == nil {
+		return nil
+	}
+
+	windows := make([]*schema.BatchChangeRolloutWindow, 0, len(*input))
+	for _, in := range *input {
+		w := &schema.BatchChangeRolloutWindow{Rate: "unlimited"}
+		if in.Days != nil {
+			w.Days = *in.Days
+		}
+		if in.Start != nil {
+			w.Start = *in.Start
+		}
+		if in.End != nil {
+			w.End = *in.End
+		}
+		windows = append(windows, w)
+	}
+	return windows
+}
@@ -716,6 +716,69 @@ func (r *Resolver) DeleteBatchChange(ctx context.Context, args *graphqlbackend.D
 	return &graphqlbackend.EmptyResponse{}, err
 }
 
+func (r *Resolver) SetBatchChangeMergePolicy(ctx context.Context, args *graphqlbackend.SetBatchChangeMergePolicyArgs) (_ graphqlbackend.BatchChangeMergePolicyResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeMergePolicy", fmt.Sprintf("BatchChange: %q", args.BatchChange))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: SetBatchChangeMergePolicy checks whether current user is authorized.
+	policy, err := svc.SetBatchChangeMergePolicy(ctx, service.SetBatchChangeMergePolicyOpts{
+		BatchChangeID:     batchChangeID,
+		MergeMethod:       btypes.MergePolicyMethod(args.MergeMethod),
+		RequiredApprovals: args.RequiredApprovals,
+		Windows:           mergeWindowsFromInput(args.Windows),
+		MaxMergesPerHour:  args.MaxMergesPerHour,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangeMergePolicyResolver{store: r.store, policy: policy}, nil
+}
+
+func (r *Resolver) DeleteBatchChangeMergePolicy(ctx context.Context, args *graphqlbackend.DeleteBatchChangeMergePolicyArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchChangeMergePolicy", fmt.Sprintf("BatchChange: %q", args.BatchChange))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: DeleteBatchChangeMergePolicy checks whether current user is authorized.
+	if err := svc.DeleteBatchChangeMergePolicy(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func (r *Resolver) BatchChanges(ctx context.Context, args *graphqlbackend.ListBatchChangesArgs) (graphqlbackend.BatchChangesConnectionResolver, error) {
 	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
@@ -1163,7 +1226,7 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 			PublicKey:  keypair.PublicKey,
 			Passphrase: keypair.Passphrase,
 		}
-	} else if externalServiceType == extsvc.TypeBitbucketCloud {
+	} else if externalServiceType == extsvc.TypeBitbucketCloud || externalServiceType == extsvc.TypeGerrit {
 		a = &auth.BasicAuthWithSSH{
 			BasicAuth:  auth.BasicAuth{Username: *username, Password: credential},
 			PrivateKey: keypair.PrivateKey,
@@ -0,0 +1,55 @@
+package resolvers
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+type batchChangeRolloutResolver struct {
+	waitFor batcheslib.RolloutWaitFor
+	stages  btypes.RolloutStages
+}
+
+var _ graphqlbackend.BatchChangeRolloutResolver = &batchChangeRolloutResolver{}
+
+func (r *batchChangeRolloutResolver) WaitFor() string {
+	return strings.ToUpper(string(r.waitFor))
+}
+
+func (r *batchChangeRolloutResolver) Stages() []graphqlbackend.BatchChangeRolloutStageResolver {
+	resolvers := make([]graphqlbackend.BatchChangeRolloutStageResolver, 0, len(r.stages))
+	for _, s := range r.stages {
+		resolvers = append(resolvers, &batchChangeRolloutStageResolver{stage: s, state: r.stages.State(s)})
+	}
+	return resolvers
+}
+
+type batchChangeRolloutStageResolver struct {
+	stage *btypes.RolloutStage
+	state btypes.RolloutStageState
+}
+
+var _ graphqlbackend.BatchChangeRolloutStageResolver = &batchChangeRolloutStageResolver{}
+
+func (r *batchChangeRolloutStageResolver) Stage() int32 {
+	return r.stage.Stage
+}
+
+func (r *batchChangeRolloutStageResolver) State() string {
+	return string(r.state)
+}
+
+func (r *batchChangeRolloutStageResolver) TotalCount() int32 {
+	return r.stage.Total
+}
+
+func (r *batchChangeRolloutStageResolver) PublishedCount() int32 {
+	return r.stage.Published
+}
+
+func (r *batchChangeRolloutStageResolver) CompletedCount() int32 {
+	return r.stage.Done
+}
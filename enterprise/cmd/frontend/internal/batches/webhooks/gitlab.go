@@ -261,6 +261,9 @@ func (h *GitLabWebhook) getChangesetForPR(ctx context.Context, tx *store.Store,
 		RepoID:              repo.ID,
 		ExternalID:          strconv.FormatInt(pr.ID, 10),
 		ExternalServiceType: h.ServiceType,
+		// Webhooks are only received for pull and merge requests, whose
+		// numbers can coincide with the ones of tracked issues.
+		Kind: btypes.ChangesetKindPullRequest,
 	})
 }
 
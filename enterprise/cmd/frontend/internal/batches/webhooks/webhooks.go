@@ -120,6 +120,9 @@ func (h Webhook) upsertChangesetEvent(
 		RepoID:              r.ID,
 		ExternalID:          strconv.FormatInt(pr.ID, 10),
 		ExternalServiceType: h.ServiceType,
+		// Webhooks are only received for pull and merge requests, whose
+		// numbers can coincide with the ones of tracked issues.
+		Kind: btypes.ChangesetKindPullRequest,
 	})
 	if err != nil {
 		if err == store.ErrNoResults {
@@ -983,6 +983,9 @@ func (m *monitorTriggerEvent) ResultCount() int32 {
 	for _, cm := range m.TriggerJob.SearchResults {
 		count += cm.ResultCount()
 	}
+	for _, cm := range m.TriggerJob.ContentResults {
+		count += cm.ResultCount()
+	}
 	return int32(count)
 }
 
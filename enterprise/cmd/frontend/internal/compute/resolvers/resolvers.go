@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/inconshreveable/log15"
@@ -14,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func NewResolver(logger log.Logger, db database.DB) gql.ComputeResolver {
@@ -166,6 +168,8 @@ func toComputeResultResolver(result compute.Result, repoResolver *gql.Repository
 		return &computeResultResolver{result: toComputeMatchContextResolver(r, repoResolver, path, commit)}
 	case *compute.Text:
 		return &computeResultResolver{result: toComputeTextResolver(r, repoResolver, path, commit)}
+	case *compute.FileDiff:
+		return &computeResultResolver{result: toComputeTextResolver(&compute.Text{Value: r.Value, Kind: r.Kind}, repoResolver, path, commit)}
 	default:
 		panic(fmt.Sprintf("unsupported compute result %T", r))
 	}
@@ -219,6 +223,28 @@ func toResultResolverList(ctx context.Context, cmd compute.Command, matches []re
 	return results, nil
 }
 
+// batchSearch returns the matches of the search query that underlies computeQuery,
+// and whether the search hit a limit, in which case not all matches are returned.
+func batchSearch(ctx context.Context, logger log.Logger, db database.DB, computeQuery *compute.Query) (_ []result.Match, limitHit bool, err error) {
+	searchQuery, err := computeQuery.ToSearchQuery()
+	if err != nil {
+		return nil, false, err
+	}
+	log15.Debug("compute", "search", searchQuery)
+
+	patternType := "regexp"
+	job, err := gql.NewBatchSearchImplementer(ctx, logger, db, &gql.SearchArgs{Query: searchQuery, PatternType: &patternType})
+	if err != nil {
+		return nil, false, err
+	}
+
+	results, err := job.Results(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return results.Matches, results.LimitHit(), nil
+}
+
 // NewBatchComputeImplementer is a function that abstracts away the need to have a
 // handle on (*schemaResolver) Compute.
 func NewBatchComputeImplementer(ctx context.Context, logger log.Logger, db database.DB, args *gql.ComputeArgs) ([]gql.ComputeResultResolver, error) {
@@ -226,26 +252,74 @@ func NewBatchComputeImplementer(ctx context.Context, logger log.Logger, db datab
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := computeQuery.Command.(compute.AggregatingCommand); ok {
+		return nil, errors.New("aggregating compute commands like count.by are only supported by the streaming compute API")
+	}
 
-	searchQuery, err := computeQuery.ToSearchQuery()
+	matches, _, err := batchSearch(ctx, logger, db, computeQuery)
 	if err != nil {
 		return nil, err
 	}
-	log15.Debug("compute", "search", searchQuery)
+	return toResultResolverList(ctx, computeQuery.Command, matches, db)
+}
 
-	patternType := "regexp"
-	job, err := gql.NewBatchSearchImplementer(ctx, logger, db, &gql.SearchArgs{Query: searchQuery, PatternType: &patternType})
+func (r *Resolver) Compute(ctx context.Context, args *gql.ComputeArgs) ([]gql.ComputeResultResolver, error) {
+	return NewBatchComputeImplementer(ctx, r.logger, r.db, args)
+}
+
+func (r *Resolver) ComputeChangesetSpecs(ctx context.Context, args *gql.ComputeChangesetSpecsArgs) ([]string, error) {
+	computeQuery, err := compute.Parse(args.Query)
 	if err != nil {
 		return nil, err
 	}
+	replace, ok := computeQuery.Command.(*compute.Replace)
+	if !ok {
+		return nil, errors.New("changeset specs can only be computed for replace commands")
+	}
+	replace.Diff = true
 
-	results, err := job.Results(ctx)
+	// The changeset specs must cover every match, so search for all of them
+	// rather than the default number of results.
+	allMatchesQuery := computeQuery.WithCountAll()
+	matches, limitHit, err := batchSearch(ctx, r.logger, r.db, &allMatchesQuery)
 	if err != nil {
 		return nil, err
 	}
-	return toResultResolverList(ctx, computeQuery.Command, results.Matches, db)
-}
+	if limitHit {
+		return nil, errors.New("the search hit a result limit, so the changeset specs wouldn't cover all matches: narrow down the query, for example with repo: filters")
+	}
 
-func (r *Resolver) Compute(ctx context.Context, args *gql.ComputeArgs) ([]gql.ComputeResultResolver, error) {
-	return NewBatchComputeImplementer(ctx, r.logger, r.db, args)
+	var diffs []*compute.FileDiff
+	for _, m := range matches {
+		res, err := replace.Run(ctx, r.db, m)
+		if err != nil {
+			return nil, err
+		}
+		if diff, ok := res.(*compute.FileDiff); ok {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	tmpl := compute.ChangesetTemplate{
+		Title:         args.Title,
+		Branch:        args.Branch,
+		CommitMessage: args.CommitMessage,
+	}
+	if args.Body != nil {
+		tmpl.Body = *args.Body
+	}
+	specs, err := compute.ChangesetSpecs(ctx, r.db, diffs, tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	rawSpecs := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		raw, err := json.Marshal(spec)
+		if err != nil {
+			return nil, err
+		}
+		rawSpecs = append(rawSpecs, string(raw))
+	}
+	return rawSpecs, nil
 }
@@ -23,14 +23,18 @@ func toComputeResult(ctx context.Context, db database.DB, cmd compute.Command, m
 			if err != nil {
 				return nil, err
 			}
-			out = append(out, result)
+			if result != nil {
+				out = append(out, result)
+			}
 		}
 	} else {
 		result, err := cmd.Run(ctx, db, match)
 		if err != nil {
 			return nil, err
 		}
-		out = append(out, result)
+		if result != nil {
+			out = append(out, result)
+		}
 	}
 	return out, nil
 }
@@ -49,6 +53,14 @@ func NewComputeStream(ctx context.Context, logger log.Logger, db database.DB, se
 			eventsC <- ev
 		}
 	}
+
+	// Aggregating commands reduce their results as they are computed, and
+	// only send the aggregate once the search is done.
+	var aggregator compute.Aggregator
+	if c, ok := computeCommand.(compute.AggregatingCommand); ok {
+		aggregator = c.NewAggregator()
+	}
+
 	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
 		if !event.Stats.Zero() {
 			g.Go(func() (Event, error) {
@@ -59,6 +71,12 @@ func NewComputeStream(ctx context.Context, logger log.Logger, db database.DB, se
 			match := match
 			g.Go(func() (Event, error) {
 				results, err := toComputeResult(ctx, db, computeCommand, match)
+				if aggregator != nil {
+					for _, result := range results {
+						aggregator.Add(result)
+					}
+					return Event{nil, streaming.Stats{}}, err
+				}
 				return Event{results, streaming.Stats{}}, err
 			}, cb)
 		}
@@ -90,9 +108,12 @@ func NewComputeStream(ctx context.Context, logger log.Logger, db database.DB, se
 		defer close(final)
 		defer close(eventsC)
 		defer close(errorC)
-		defer g.Wait()
 
 		alert, err := searchClient.Execute(ctx, stream, inputs)
+		g.Wait()
+		if aggregator != nil {
+			eventsC <- Event{Results: []compute.Result{aggregator.Result()}}
+		}
 		final <- finalResult{alert: alert, err: err}
 	}()
 
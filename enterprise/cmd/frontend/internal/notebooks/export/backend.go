@@ -0,0 +1,272 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	computestreaming "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/compute/streaming"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks/render"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// computeTableLimit is the number of rows of the tables of compute blocks.
+	computeTableLimit = 50
+	// symbolSearchLimit bounds the search for the definition of the symbol of a symbol block.
+	symbolSearchLimit = 50
+)
+
+// backend fetches the content of notebook blocks on behalf of the user in the context, so that they
+// only see the repositories they have access to.
+type backend struct {
+	logger          log.Logger
+	db              database.DB
+	gitserverClient gitserver.Client
+}
+
+var _ render.Backend = &backend{}
+
+func (b *backend) Search(ctx context.Context, query string, limit int) (*render.SearchResults, error) {
+	matches, limitHit, err := b.search(ctx, nil, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &render.SearchResults{LimitHit: limitHit}
+	for _, match := range matches {
+		results.Results = append(results.Results, toSearchResults(match)...)
+	}
+	return results, nil
+}
+
+// search runs a query and stops once limit results were found.
+func (b *backend) search(ctx context.Context, patternType *string, query string, limit int) (result.Matches, bool, error) {
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, b.db)
+	if err != nil {
+		return nil, false, err
+	}
+
+	searchClient := client.NewSearchClient(b.logger, b.db, search.Indexed(), search.SearcherURLs())
+	inputs, err := searchClient.Plan(ctx, "V3", patternType, query, search.Streaming, settings, envvar.SourcegraphDotComMode())
+	if err != nil {
+		return nil, false, err
+	}
+	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan)
+	if err != nil {
+		return nil, false, err
+	}
+
+	agg := streaming.NewAggregatingStream()
+	_, err = jobutil.NewLimitJob(limit, planJob).Run(ctx, searchClient.JobClients(), agg)
+	if err != nil {
+		return nil, false, err
+	}
+	return agg.Results, agg.Stats.IsLimitHit, nil
+}
+
+func toSearchResults(match result.Match) []render.SearchResult {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		fileResult := render.SearchResult{
+			Label:    string(m.Repo.Name) + " › " + m.Path,
+			URL:      m.File.URL().String(),
+			FilePath: m.Path,
+		}
+		for _, chunk := range m.ChunkMatches {
+			fileResult.Chunks = append(fileResult.Chunks, render.Chunk{
+				StartLine: int32(chunk.ContentStart.Line),
+				Lines:     strings.Split(strings.TrimSuffix(chunk.Content, "\n"), "\n"),
+			})
+		}
+		if len(m.Symbols) == 0 {
+			return []render.SearchResult{fileResult}
+		}
+
+		symbolResults := make([]render.SearchResult, 0, len(m.Symbols))
+		for _, symbol := range m.Symbols {
+			symbolResults = append(symbolResults, render.SearchResult{
+				Label: fmt.Sprintf("%s (%s) in %s", symbol.Symbol.Name, symbol.Symbol.Kind, fileResult.Label),
+				URL:   symbol.URL().String(),
+			})
+		}
+		return symbolResults
+
+	case *result.RepoMatch:
+		return []render.SearchResult{{Label: string(m.Name), URL: m.URL().String()}}
+
+	case *result.CommitMatch:
+		commitResult := render.SearchResult{
+			Label: fmt.Sprintf("%s › %s: %s", m.Repo.Name, m.Commit.Author.Name, m.Commit.Message.Subject()),
+			URL:   m.URL().String(),
+		}
+		if m.DiffPreview != nil {
+			commitResult.Chunks = []render.Chunk{{Lines: strings.Split(m.DiffPreview.Content, "\n")}}
+		}
+		return []render.SearchResult{commitResult}
+	}
+	return nil
+}
+
+func (b *backend) Compute(ctx context.Context, query string) (*render.Table, error) {
+	computeQuery, err := compute.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	searchQuery, err := computeQuery.ToSearchQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	// Count the values of the results like the compute block of the web app does, unless the
+	// command already aggregates them in a table.
+	counts := make(map[string]int)
+	otherCount := 0
+	events, getErr := computestreaming.NewComputeStream(ctx, b.logger, b.db, searchQuery, computeQuery.Command)
+	for event := range events {
+		for _, computeResult := range event.Results {
+			switch v := computeResult.(type) {
+			case *compute.Table:
+				otherCount += v.OtherCount
+				for _, row := range v.Rows {
+					counts[row.Key] += row.Count
+				}
+			case *compute.Text:
+				counts[v.Value]++
+			case *compute.TextExtra:
+				counts[v.Value]++
+			case *compute.MatchContext:
+				for _, match := range v.Matches {
+					counts[match.Value]++
+				}
+			}
+		}
+	}
+	if _, err := getErr(); err != nil {
+		return nil, err
+	}
+
+	table := &render.Table{OtherCount: otherCount}
+	for value, count := range counts {
+		table.Rows = append(table.Rows, render.TableRow{Value: value, Count: count})
+	}
+	sort.Slice(table.Rows, func(i, j int) bool {
+		if table.Rows[i].Count != table.Rows[j].Count {
+			return table.Rows[i].Count > table.Rows[j].Count
+		}
+		return table.Rows[i].Value < table.Rows[j].Value
+	})
+	if len(table.Rows) > computeTableLimit {
+		for _, row := range table.Rows[computeTableLimit:] {
+			table.OtherCount += row.Count
+		}
+		table.Rows = table.Rows[:computeTableLimit]
+	}
+	return table, nil
+}
+
+func (b *backend) File(ctx context.Context, repositoryName, revision, filePath string, lineRange *notebooks.LineRange) (*render.Snippet, error) {
+	// Ensure the user has access to the repository.
+	repo, err := b.db.Repos().GetByName(ctx, api.RepoName(repositoryName))
+	if err != nil {
+		return nil, err
+	}
+	commitID, err := b.gitserverClient.ResolveRevision(ctx, repo.Name, revision, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil, err
+	}
+	content, err := b.gitserverClient.ReadFile(ctx, repo.Name, commitID, filePath, authz.DefaultSubRepoPermsChecker)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(content) {
+		return nil, errors.Errorf("%s is a binary file", filePath)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	startLine, endLine := int32(0), int32(len(lines))
+	if lineRange != nil {
+		startLine, endLine = clamp(lineRange.StartLine, 0, endLine), clamp(lineRange.EndLine, 0, endLine)
+		if startLine > endLine {
+			startLine = endLine
+		}
+	}
+
+	snippet := &render.Snippet{
+		RepositoryName: repositoryName,
+		Revision:       revision,
+		FilePath:       filePath,
+		StartLine:      startLine,
+		Lines:          lines[startLine:endLine],
+	}
+	highlightedLines, err := graphqlbackend.HighlightLineRange(ctx, string(repo.Name), string(commitID), filePath, string(content), startLine, endLine)
+	if err != nil {
+		// Show the plain lines when the file can't be highlighted.
+		b.logger.Warn("failed to highlight notebook block", log.String("repo", repositoryName), log.String("path", filePath), log.Error(err))
+	} else {
+		snippet.HighlightedLines = highlightedLines
+	}
+	return snippet, nil
+}
+
+func (b *backend) Symbol(ctx context.Context, input *notebooks.NotebookSymbolBlockInput, revision string) (*render.Snippet, error) {
+	repoFilter := "^" + regexp.QuoteMeta(input.RepositoryName) + "$"
+	if revision != "" {
+		repoFilter += "@" + revision
+	}
+	query := fmt.Sprintf("repo:%s file:^%s$ type:symbol ^%s$", repoFilter, regexp.QuoteMeta(input.FilePath), regexp.QuoteMeta(input.SymbolName))
+	patternType := "regexp"
+	matches, _, err := b.search(ctx, &patternType, query, symbolSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, match := range matches {
+		fileMatch, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		for _, symbol := range fileMatch.Symbols {
+			if symbol.Symbol.Name != input.SymbolName || symbol.Symbol.Parent != input.SymbolContainerName {
+				continue
+			}
+			// Symbol lines are 1-based.
+			line := int32(symbol.Symbol.Line) - 1
+			lineRange := &notebooks.LineRange{StartLine: line - input.LineContext, EndLine: line + input.LineContext + 1}
+			return b.File(ctx, input.RepositoryName, revision, input.FilePath, lineRange)
+		}
+	}
+	return nil, errors.Errorf("symbol %s not found in %s", input.SymbolName, input.FilePath)
+}
+
+func (b *backend) MarkdownHTML(text string) string {
+	return graphqlbackend.Markdown(text).HTML()
+}
+
+func clamp(value, min, max int32) int32 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
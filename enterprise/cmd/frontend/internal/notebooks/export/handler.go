@@ -0,0 +1,98 @@
+// Package export serves notebooks rendered as standalone Markdown or HTML documents.
+package export
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks/render"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxRequestDuration bounds the time spent running the queries of the blocks of a notebook.
+const maxRequestDuration = 2 * time.Minute
+
+// NewHandler returns the handler of the notebook export endpoint. The notebook is given by its
+// GraphQL ID, and the format parameter is either markdown (the default) or html.
+func NewHandler(logger log.Logger, db database.DB) http.Handler {
+	return &handler{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type handler struct {
+	logger log.Logger
+	db     database.DB
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), maxRequestDuration)
+	defer cancel()
+
+	tr, ctx := trace.New(ctx, "notebooks.ServeExport", "")
+	defer tr.Finish()
+
+	notebookID, err := unmarshalNotebookID(graphql.ID(mux.Vars(r)["id"]))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	format, err := render.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The store only returns notebooks the user can read.
+	notebook, err := notebooks.Notebooks(h.db).GetNotebook(ctx, notebookID)
+	if errors.Is(err, notebooks.ErrNotebookNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	backend := &backend{logger: h.logger, db: h.db, gitserverClient: gitserver.NewClient(h.db)}
+	renderer := render.NewRenderer(backend, conf.ExternalURL(), render.DefaultResultLimit)
+
+	// Render the whole document before writing it, so that errors are reported with a status code.
+	var buf bytes.Buffer
+	if err := renderer.Render(ctx, &buf, notebook, format); err != nil {
+		tr.SetError(err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, context.DeadlineExceeded) {
+			status = http.StatusGatewayTimeout
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	_, _ = buf.WriteTo(w)
+}
+
+const notebookIDKind = "Notebook"
+
+func unmarshalNotebookID(id graphql.ID) (notebookID int64, err error) {
+	if kind := relay.UnmarshalKind(id); kind != notebookIDKind {
+		err = errors.Errorf("expected notebook ID to have kind %q; got %q", notebookIDKind, kind)
+		return
+	}
+	err = relay.UnmarshalSpec(id, &notebookID)
+	return
+}
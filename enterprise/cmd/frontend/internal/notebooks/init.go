@@ -3,7 +3,10 @@ package notebooks
 import (
 	"context"
 
+	"github.com/sourcegraph/log"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/notebooks/export"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/notebooks/resolvers"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -13,5 +16,6 @@ import (
 
 func Init(ctx context.Context, db database.DB, _ conftypes.UnifiedWatchable, enterpriseServices *enterprise.Services, observationContext *observation.Context) error {
 	enterpriseServices.NotebooksResolver = resolvers.NewResolver(db)
+	enterpriseServices.NotebooksExport = export.NewHandler(log.Scoped("notebooks.export", "renders notebooks as Markdown or HTML"), db)
 	return nil
 }
@@ -56,3 +56,17 @@ type NotebookStar struct {
 type NotebookStarUser struct {
 	Username string
 }
+
+type NotebookRevision struct {
+	ID     string
+	Title  string
+	Author NotebookUser
+	Blocks []NotebookBlock
+}
+
+type NotebookBlockDiff struct {
+	Type     string
+	BlockID  string
+	OldBlock *NotebookBlock
+	NewBlock *NotebookBlock
+}
@@ -0,0 +1,109 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// resolvePinnedCommits replaces the pinned commits of the blocks that are not full commit SHAs with
+// the SHA of the commit they resolve to. Blocks that were already pinned keep their commit, so that
+// saving a notebook doesn't move its pins.
+func resolvePinnedCommits(ctx context.Context, db database.DB, blocks notebooks.NotebookBlocks) error {
+	var client gitserver.Client
+	for _, block := range blocks {
+		var repositoryName string
+		var pinnedCommit *string
+		switch {
+		case block.FileInput != nil:
+			repositoryName, pinnedCommit = block.FileInput.RepositoryName, block.FileInput.PinnedCommit
+		case block.SymbolInput != nil:
+			repositoryName, pinnedCommit = block.SymbolInput.RepositoryName, block.SymbolInput.PinnedCommit
+		}
+		if pinnedCommit == nil || gitdomain.IsAbsoluteRevision(*pinnedCommit) {
+			continue
+		}
+
+		// Ensure the user has access to the repository.
+		repo, err := db.Repos().GetByName(ctx, api.RepoName(repositoryName))
+		if err != nil {
+			return err
+		}
+		if client == nil {
+			client = gitserver.NewClient(db)
+		}
+		commitID, err := client.ResolveRevision(ctx, repo.Name, *pinnedCommit, gitserver.ResolveRevisionOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "resolving the pinned commit of block %s", block.ID)
+		}
+		*pinnedCommit = string(commitID)
+	}
+	return nil
+}
+
+// pinChecksLoader loads the pin checks of the blocks of a notebook once, when the pin status of one
+// of its blocks is first requested.
+type pinChecksLoader struct {
+	db         database.DB
+	notebookID int64
+
+	once   sync.Once
+	checks map[string]*notebooks.NotebookBlockPinCheck
+	err    error
+}
+
+func (l *pinChecksLoader) pinStatus(ctx context.Context, block notebooks.NotebookBlock) (graphqlbackend.NotebookBlockPinStatusResolver, error) {
+	if l == nil || block.PinnedCommit() == "" {
+		return nil, nil
+	}
+
+	l.once.Do(func() {
+		var checks []*notebooks.NotebookBlockPinCheck
+		checks, l.err = notebooks.Notebooks(l.db).ListNotebookBlockPinChecks(ctx, l.notebookID)
+		l.checks = make(map[string]*notebooks.NotebookBlockPinCheck, len(checks))
+		for _, check := range checks {
+			l.checks[check.BlockID] = check
+		}
+	})
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	check, ok := l.checks[block.ID]
+	// The check is outdated if the block was pinned to another commit since.
+	if !ok || check.PinnedCommit != block.PinnedCommit() {
+		return nil, nil
+	}
+	return &notebookBlockPinStatusResolver{check}, nil
+}
+
+type notebookBlockPinStatusResolver struct {
+	check *notebooks.NotebookBlockPinCheck
+}
+
+func (r *notebookBlockPinStatusResolver) State() string {
+	return strings.ToUpper(string(r.check.State))
+}
+
+func (r *notebookBlockPinStatusResolver) HeadCommit() string {
+	return r.check.HeadCommit
+}
+
+func (r *notebookBlockPinStatusResolver) SuggestedLineRange() graphqlbackend.FileBlockLineRangeResolver {
+	if r.check.SuggestedLineRange == nil {
+		return nil
+	}
+	return &fileBlockLineRangeResolver{*r.check.SuggestedLineRange}
+}
+
+func (r *notebookBlockPinStatusResolver) CheckedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.check.CheckedAt}
+}
@@ -92,6 +92,7 @@ func convertNotebookBlockInput(inputBlock graphqlbackend.CreateNotebookBlockInpu
 			FilePath:       inputBlock.FileInput.FilePath,
 			Revision:       inputBlock.FileInput.Revision,
 			LineRange:      convertLineRangeInput(inputBlock.FileInput.LineRange),
+			PinnedCommit:   inputBlock.FileInput.PinnedCommit,
 		}
 	case graphqlbackend.NotebookSymbolBlockType:
 		if inputBlock.SymbolInput == nil {
@@ -106,6 +107,7 @@ func convertNotebookBlockInput(inputBlock graphqlbackend.CreateNotebookBlockInpu
 			SymbolName:          inputBlock.SymbolInput.SymbolName,
 			SymbolContainerName: inputBlock.SymbolInput.SymbolContainerName,
 			SymbolKind:          inputBlock.SymbolInput.SymbolKind,
+			PinnedCommit:        inputBlock.SymbolInput.PinnedCommit,
 		}
 	case graphqlbackend.NotebookComputeBlockType:
 		if inputBlock.ComputeInput == nil {
@@ -134,6 +136,9 @@ func (r *Resolver) CreateNotebook(ctx context.Context, args graphqlbackend.Creat
 		}
 		blocks = append(blocks, *block)
 	}
+	if err := resolvePinnedCommits(ctx, r.db, blocks); err != nil {
+		return nil, err
+	}
 
 	notebook := &notebooks.Notebook{
 		Title:         notebookInput.Title,
@@ -189,6 +194,9 @@ func (r *Resolver) UpdateNotebook(ctx context.Context, args graphqlbackend.Updat
 		}
 		blocks = append(blocks, *block)
 	}
+	if err := resolvePinnedCommits(ctx, r.db, blocks); err != nil {
+		return nil, err
+	}
 
 	notebook.Title = notebookInput.Title
 	notebook.Public = notebookInput.Public
@@ -382,8 +390,9 @@ func (r *notebookResolver) Title(ctx context.Context) string {
 
 func (r *notebookResolver) Blocks(ctx context.Context) []graphqlbackend.NotebookBlockResolver {
 	blockResolvers := make([]graphqlbackend.NotebookBlockResolver, 0, len(r.notebook.Blocks))
+	pinChecks := &pinChecksLoader{db: r.db, notebookID: r.notebook.ID}
 	for _, block := range r.notebook.Blocks {
-		blockResolvers = append(blockResolvers, &notebookBlockResolver{block})
+		blockResolvers = append(blockResolvers, &notebookBlockResolver{block, pinChecks})
 	}
 	return blockResolvers
 }
@@ -488,6 +497,8 @@ func (r *notebookResolver) ViewerHasStarred(ctx context.Context) (bool, error) {
 
 type notebookBlockResolver struct {
 	block notebooks.NotebookBlock
+	// pinChecks is nil for the blocks of revisions, which have no pin status.
+	pinChecks *pinChecksLoader
 }
 
 func (r *notebookBlockResolver) ToMarkdownBlock() (graphqlbackend.MarkdownBlockResolver, bool) {
@@ -506,14 +517,14 @@ func (r *notebookBlockResolver) ToQueryBlock() (graphqlbackend.QueryBlockResolve
 
 func (r *notebookBlockResolver) ToFileBlock() (graphqlbackend.FileBlockResolver, bool) {
 	if r.block.Type == notebooks.NotebookFileBlockType {
-		return &fileBlockResolver{r.block}, true
+		return &fileBlockResolver{r.block, r.pinChecks}, true
 	}
 	return nil, false
 }
 
 func (r *notebookBlockResolver) ToSymbolBlock() (graphqlbackend.SymbolBlockResolver, bool) {
 	if r.block.Type == notebooks.NotebookSymbolBlockType {
-		return &symbolBlockResolver{r.block}, true
+		return &symbolBlockResolver{r.block, r.pinChecks}, true
 	}
 	return nil, false
 }
@@ -553,7 +564,8 @@ func (r *queryBlockResolver) QueryInput() string {
 
 type fileBlockResolver struct {
 	// block.type == NotebookFileBlockType
-	block notebooks.NotebookBlock
+	block     notebooks.NotebookBlock
+	pinChecks *pinChecksLoader
 }
 
 func (r *fileBlockResolver) ID() string {
@@ -564,6 +576,10 @@ func (r *fileBlockResolver) FileInput() graphqlbackend.FileBlockInputResolver {
 	return &fileBlockInputResolver{*r.block.FileInput}
 }
 
+func (r *fileBlockResolver) PinStatus(ctx context.Context) (graphqlbackend.NotebookBlockPinStatusResolver, error) {
+	return r.pinChecks.pinStatus(ctx, r.block)
+}
+
 type fileBlockInputResolver struct {
 	input notebooks.NotebookFileBlockInput
 }
@@ -587,6 +603,10 @@ func (r *fileBlockInputResolver) LineRange() graphqlbackend.FileBlockLineRangeRe
 	return &fileBlockLineRangeResolver{*r.input.LineRange}
 }
 
+func (r *fileBlockInputResolver) PinnedCommit() *string {
+	return r.input.PinnedCommit
+}
+
 type fileBlockLineRangeResolver struct {
 	lineRange notebooks.LineRange
 }
@@ -601,7 +621,8 @@ func (r *fileBlockLineRangeResolver) EndLine() int32 {
 
 type symbolBlockResolver struct {
 	// block.type == NotebookSymbolBlockType
-	block notebooks.NotebookBlock
+	block     notebooks.NotebookBlock
+	pinChecks *pinChecksLoader
 }
 
 func (r *symbolBlockResolver) ID() string {
@@ -612,6 +633,10 @@ func (r *symbolBlockResolver) SymbolInput() graphqlbackend.SymbolBlockInputResol
 	return &symbolBlockInputResolver{*r.block.SymbolInput}
 }
 
+func (r *symbolBlockResolver) PinStatus(ctx context.Context) (graphqlbackend.NotebookBlockPinStatusResolver, error) {
+	return r.pinChecks.pinStatus(ctx, r.block)
+}
+
 type symbolBlockInputResolver struct {
 	input notebooks.NotebookSymbolBlockInput
 }
@@ -644,6 +669,10 @@ func (r *symbolBlockInputResolver) SymbolKind() string {
 	return r.input.SymbolKind
 }
 
+func (r *symbolBlockInputResolver) PinnedCommit() *string {
+	return r.input.PinnedCommit
+}
+
 type computeBlockResolver struct {
 	// block.type == NotebookComputeBlockType
 	block notebooks.NotebookBlock
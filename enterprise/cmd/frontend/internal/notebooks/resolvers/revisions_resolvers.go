@@ -0,0 +1,228 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const notebookRevisionIDKind = "NotebookRevision"
+
+func marshalNotebookRevisionID(revisionID int64) graphql.ID {
+	return relay.MarshalID(notebookRevisionIDKind, revisionID)
+}
+
+func unmarshalNotebookRevisionID(id graphql.ID) (revisionID int64, err error) {
+	if kind := relay.UnmarshalKind(id); kind != notebookRevisionIDKind {
+		err = errors.Errorf("expected graphql ID to have kind %q; got %q", notebookRevisionIDKind, kind)
+		return
+	}
+	err = relay.UnmarshalSpec(id, &revisionID)
+	return
+}
+
+func marshalNotebookRevisionCursor(cursor int64) string {
+	return string(relay.MarshalID("NotebookRevisionCursor", cursor))
+}
+
+func unmarshalNotebookRevisionCursor(cursor *string) (int64, error) {
+	if cursor == nil {
+		return 0, nil
+	}
+	var after int64
+	err := relay.UnmarshalSpec(graphql.ID(*cursor), &after)
+	if err != nil {
+		return -1, err
+	}
+	return after, nil
+}
+
+type notebookRevisionConnectionResolver struct {
+	afterCursor int64
+	revisions   []graphqlbackend.NotebookRevisionResolver
+	totalCount  int32
+	hasNextPage bool
+}
+
+func (n *notebookRevisionConnectionResolver) Nodes() []graphqlbackend.NotebookRevisionResolver {
+	return n.revisions
+}
+
+func (n *notebookRevisionConnectionResolver) TotalCount() int32 {
+	return n.totalCount
+}
+
+func (n *notebookRevisionConnectionResolver) PageInfo() *graphqlutil.PageInfo {
+	if len(n.revisions) == 0 || !n.hasNextPage {
+		return graphqlutil.HasNextPage(false)
+	}
+	// The after value (offset) for the next page is computed from the current after value + the number of retrieved notebook revisions
+	return graphqlutil.NextPageCursor(marshalNotebookRevisionCursor(n.afterCursor + int64(len(n.revisions))))
+}
+
+type notebookRevisionResolver struct {
+	revision *notebooks.NotebookRevision
+	db       database.DB
+}
+
+func (r *notebookRevisionResolver) ID() graphql.ID {
+	return marshalNotebookRevisionID(r.revision.ID)
+}
+
+func (r *notebookRevisionResolver) Title() string {
+	return r.revision.Title
+}
+
+func (r *notebookRevisionResolver) Blocks() []graphqlbackend.NotebookBlockResolver {
+	blockResolvers := make([]graphqlbackend.NotebookBlockResolver, 0, len(r.revision.Blocks))
+	for _, block := range r.revision.Blocks {
+		blockResolvers = append(blockResolvers, &notebookBlockResolver{block, nil})
+	}
+	return blockResolvers
+}
+
+func (r *notebookRevisionResolver) Author(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.revision.AuthorUserID == 0 {
+		return nil, nil
+	}
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.revision.AuthorUserID)
+	if err != nil {
+		// Handle soft-deleted users
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *notebookRevisionResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.revision.CreatedAt}
+}
+
+type notebookBlockDiffResolver struct {
+	diff notebooks.NotebookBlockDiff
+}
+
+func (r *notebookBlockDiffResolver) Type() string {
+	return strings.ToUpper(string(r.diff.Type))
+}
+
+func (r *notebookBlockDiffResolver) BlockID() string {
+	return r.diff.BlockID
+}
+
+func (r *notebookBlockDiffResolver) OldBlock() graphqlbackend.NotebookBlockResolver {
+	if r.diff.Old == nil {
+		return nil
+	}
+	return &notebookBlockResolver{*r.diff.Old, nil}
+}
+
+func (r *notebookBlockDiffResolver) NewBlock() graphqlbackend.NotebookBlockResolver {
+	if r.diff.New == nil {
+		return nil
+	}
+	return &notebookBlockResolver{*r.diff.New, nil}
+}
+
+func (r *notebookResolver) Revisions(ctx context.Context, args graphqlbackend.ListNotebookRevisionsArgs) (graphqlbackend.NotebookRevisionConnectionResolver, error) {
+	afterCursor, err := unmarshalNotebookRevisionCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	// Request one extra to determine if there are more pages
+	pageOpts := notebooks.ListNotebookRevisionsPageOptions{First: args.First + 1, After: afterCursor}
+	store := notebooks.Notebooks(r.db)
+	revisions, err := store.ListNotebookRevisions(ctx, pageOpts, r.notebook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := store.CountNotebookRevisions(ctx, r.notebook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNextPage := false
+	if len(revisions) == int(args.First)+1 {
+		hasNextPage = true
+		revisions = revisions[:len(revisions)-1]
+	}
+
+	revisionResolvers := make([]graphqlbackend.NotebookRevisionResolver, len(revisions))
+	for idx, revision := range revisions {
+		revisionResolvers[idx] = &notebookRevisionResolver{revision, r.db}
+	}
+	return &notebookRevisionConnectionResolver{
+		afterCursor: afterCursor,
+		revisions:   revisionResolvers,
+		totalCount:  int32(count),
+		hasNextPage: hasNextPage,
+	}, nil
+}
+
+func (r *notebookResolver) RevisionDiff(ctx context.Context, args graphqlbackend.NotebookRevisionDiffArgs) ([]graphqlbackend.NotebookBlockDiffResolver, error) {
+	fromRevisionID, err := unmarshalNotebookRevisionID(args.From)
+	if err != nil {
+		return nil, err
+	}
+	toRevisionID, err := unmarshalNotebookRevisionID(args.To)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := notebooks.Notebooks(r.db).DiffNotebookRevisions(ctx, r.notebook.ID, fromRevisionID, toRevisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	diffResolvers := make([]graphqlbackend.NotebookBlockDiffResolver, len(diff))
+	for idx, blockDiff := range diff {
+		diffResolvers[idx] = &notebookBlockDiffResolver{blockDiff}
+	}
+	return diffResolvers, nil
+}
+
+func (r *Resolver) RestoreNotebookRevision(ctx context.Context, args graphqlbackend.RestoreNotebookRevisionArgs) (graphqlbackend.NotebookResolver, error) {
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	notebookID, err := unmarshalNotebookID(args.NotebookID)
+	if err != nil {
+		return nil, err
+	}
+	revisionID, err := unmarshalNotebookRevisionID(args.RevisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	store := notebooks.Notebooks(r.db)
+	notebook, err := store.GetNotebook(ctx, notebookID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateNotebookWritePermissionsForUser(ctx, r.db, notebook, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	restoredNotebook, err := store.RestoreNotebookRevision(ctx, notebook.ID, revisionID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &notebookResolver{restoredNotebook, r.db}, nil
+}
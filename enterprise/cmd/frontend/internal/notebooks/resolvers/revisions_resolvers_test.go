@@ -0,0 +1,151 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers/apitest"
+	notebooksapitest "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/notebooks/resolvers/apitest"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+const listNotebookRevisionsQuery = `
+query NotebookRevisions($id: ID!, $first: Int!, $after: String) {
+	node(id: $id) {
+		... on Notebook {
+			revisions(first: $first, after: $after) {
+				nodes {
+					id
+					title
+					author {
+						username
+					}
+				}
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+				totalCount
+			}
+		}
+	}
+}
+`
+
+const notebookRevisionDiffQuery = `
+query NotebookRevisionDiff($id: ID!, $from: ID!, $to: ID!) {
+	node(id: $id) {
+		... on Notebook {
+			revisionDiff(from: $from, to: $to) {
+				type
+				blockID
+			}
+		}
+	}
+}
+`
+
+const restoreNotebookRevisionMutation = `
+mutation RestoreNotebookRevision($notebookID: ID!, $revisionID: ID!) {
+	restoreNotebookRevision(notebookID: $notebookID, revisionID: $revisionID) {
+		title
+	}
+}
+`
+
+func TestNotebookRevisions(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	internalCtx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+
+	user1, err := u.Create(internalCtx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	user2, err := u.Create(internalCtx, database.NewUser{Username: "u2", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	createdNotebooks := createNotebooks(t, db, []*notebooks.Notebook{userNotebookFixture(user1.ID, true)})
+	notebook := createdNotebooks[0]
+	notebook.Title = "Updated Title"
+	notebook.Blocks = notebook.Blocks[1:]
+	if _, err := notebooks.Notebooks(db).UpdateNotebook(internalCtx, notebook); err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := graphqlbackend.NewSchema(db, nil, nil, nil, nil, nil, nil, nil, nil, NewResolver(db), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user1Ctx := actor.WithActor(context.Background(), actor.FromUser(user1.ID))
+
+	input := map[string]any{"id": marshalNotebookID(notebook.ID), "first": 1}
+	var listResponse struct {
+		Node struct {
+			Revisions struct {
+				Nodes      []notebooksapitest.NotebookRevision
+				TotalCount int32
+				PageInfo   apitest.PageInfo
+			}
+		}
+	}
+	apitest.MustExec(user1Ctx, t, schema, input, &listResponse, listNotebookRevisionsQuery)
+	revisions := listResponse.Node.Revisions
+	if revisions.TotalCount != 2 {
+		t.Fatalf("expected 2 notebook revisions, got %d", revisions.TotalCount)
+	}
+	if len(revisions.Nodes) != 1 || revisions.Nodes[0].Title != "Updated Title" || !revisions.PageInfo.HasNextPage {
+		t.Fatalf("unexpected first page of revisions %+v", revisions)
+	}
+	latestRevisionID := revisions.Nodes[0].ID
+
+	input["after"] = *revisions.PageInfo.EndCursor
+	apitest.MustExec(user1Ctx, t, schema, input, &listResponse, listNotebookRevisionsQuery)
+	revisions = listResponse.Node.Revisions
+	if len(revisions.Nodes) != 1 || revisions.Nodes[0].Title != "Notebook Title" || revisions.PageInfo.HasNextPage {
+		t.Fatalf("unexpected second page of revisions %+v", revisions)
+	}
+	firstRevisionID := revisions.Nodes[0].ID
+
+	input = map[string]any{"id": marshalNotebookID(notebook.ID), "from": firstRevisionID, "to": latestRevisionID}
+	var diffResponse struct {
+		Node struct {
+			RevisionDiff []notebooksapitest.NotebookBlockDiff
+		}
+	}
+	apitest.MustExec(user1Ctx, t, schema, input, &diffResponse, notebookRevisionDiffQuery)
+	wantDiff := []notebooksapitest.NotebookBlockDiff{
+		{Type: "UNCHANGED", BlockID: "2"},
+		{Type: "UNCHANGED", BlockID: "3"},
+		{Type: "UNCHANGED", BlockID: "4"},
+		{Type: "UNCHANGED", BlockID: "5"},
+		{Type: "REMOVED", BlockID: "1"},
+	}
+	if diff := cmp.Diff(wantDiff, diffResponse.Node.RevisionDiff); diff != "" {
+		t.Fatalf("wrong revision diff: %s", diff)
+	}
+
+	input = map[string]any{"notebookID": marshalNotebookID(notebook.ID), "revisionID": firstRevisionID}
+	var restoreResponse struct{ RestoreNotebookRevision notebooksapitest.Notebook }
+	// user2 cannot restore a revision of user1's notebook
+	apiError := apitest.Exec(actor.WithActor(context.Background(), actor.FromUser(user2.ID)), t, schema, input, &restoreResponse, restoreNotebookRevisionMutation)
+	if apiError == nil {
+		t.Fatalf("expected error when restoring a revision of a notebook without write access, got nil")
+	}
+
+	apitest.MustExec(user1Ctx, t, schema, input, &restoreResponse, restoreNotebookRevisionMutation)
+	if restoreResponse.RestoreNotebookRevision.Title != "Notebook Title" {
+		t.Fatalf("expected the notebook title to be restored, got %q", restoreResponse.RestoreNotebookRevision.Title)
+	}
+}
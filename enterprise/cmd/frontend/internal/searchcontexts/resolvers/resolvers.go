@@ -96,6 +96,7 @@ func (r *Resolver) CreateSearchContext(ctx context.Context, args graphqlbackend.
 			NamespaceUserID: namespaceUserID,
 			NamespaceOrgID:  namespaceOrgID,
 			Query:           args.SearchContext.Query,
+			Dynamic:         args.SearchContext.Dynamic,
 		},
 		repositoryRevisions,
 	)
@@ -129,6 +130,9 @@ func (r *Resolver) UpdateSearchContext(ctx context.Context, args graphqlbackend.
 	updated.Description = args.SearchContext.Description
 	updated.Public = args.SearchContext.Public
 	updated.Query = args.SearchContext.Query
+	if args.SearchContext.Dynamic != nil {
+		updated.Dynamic = *args.SearchContext.Dynamic
+	}
 
 	searchContext, err := searchcontexts.UpdateSearchContextWithRepositoryRevisions(
 		ctx,
@@ -411,6 +415,17 @@ func (r *searchContextResolver) Query() string {
 	return r.sc.Query
 }
 
+func (r *searchContextResolver) Dynamic() bool {
+	return r.sc.Dynamic
+}
+
+func (r *searchContextResolver) MembershipRefreshedAt() *graphqlbackend.DateTime {
+	if r.sc.MembershipRefreshedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.sc.MembershipRefreshedAt}
+}
+
 type searchContextConnectionResolver struct {
 	afterCursor    int32
 	searchContexts []graphqlbackend.SearchContextResolver
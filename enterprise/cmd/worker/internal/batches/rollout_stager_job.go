@@ -0,0 +1,46 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/rollout"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type rolloutStagerJob struct{}
+
+func NewRolloutStagerJob() job.Job {
+	return &rolloutStagerJob{}
+}
+
+func (j *rolloutStagerJob) Description() string {
+	return ""
+}
+
+func (j *rolloutStagerJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *rolloutStagerJob) Routines(_ context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	workCtx := actor.WithInternalActor(context.Background())
+
+	bstore, err := InitStore()
+	if err != nil {
+		return nil, err
+	}
+
+	routines := []goroutine.BackgroundRoutine{
+		rollout.NewStager(
+			workCtx,
+			logger.Scoped("RolloutStager", "releases the changesets of later rollout stages of batch changes"),
+			bstore,
+		),
+	}
+
+	return routines, nil
+}
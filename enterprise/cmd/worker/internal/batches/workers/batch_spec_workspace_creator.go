@@ -263,6 +263,14 @@ func (r *batchSpecWorkspaceCreator) process(
 	}
 	cs = append(cs, im...)
 
+	// The same goes for "createIssues" statements, for which we create
+	// ChangesetSpecs of issues.
+	issues, err := changesetSpecsForIssues(ctx, r.store, evaluatableSpec, spec.ID, spec.UserID)
+	if err != nil {
+		return err
+	}
+	cs = append(cs, issues...)
+
 	tx, err := r.store.Transact(ctx)
 	if err != nil {
 		return err
@@ -289,11 +297,31 @@ func (r *batchSpecWorkspaceCreator) process(
 }
 
 func changesetSpecsForImports(ctx context.Context, s *store.Store, importChangesets []batcheslib.ImportChangeset, batchSpecID int64, userID int32) ([]*btypes.ChangesetSpec, error) {
-	cs := []*btypes.ChangesetSpec{}
+	specs, err := batcheslib.BuildImportChangesetSpecs(ctx, importChangesets, repoFetcher(s))
+	if err != nil {
+		return nil, err
+	}
+	return toChangesetSpecs(specs, batchSpecID, userID)
+}
+
+func changesetSpecsForIssues(ctx context.Context, s *store.Store, spec *batcheslib.BatchSpec, batchSpecID int64, userID int32) ([]*btypes.ChangesetSpec, error) {
+	attrs := &template.BatchChangeAttributes{
+		Name:        spec.Name,
+		Description: spec.Description,
+	}
+	specs, err := batcheslib.BuildIssueChangesetSpecs(ctx, attrs, spec.CreateIssues, repoFetcher(s))
+	if err != nil {
+		return nil, err
+	}
+	return toChangesetSpecs(specs, batchSpecID, userID)
+}
 
+// repoFetcher returns a batcheslib.RepoFetcher that resolves the given
+// repository names to their GraphQL IDs.
+func repoFetcher(s *store.Store) batcheslib.RepoFetcher {
 	reposStore := s.Repos()
 
-	specs, err := batcheslib.BuildImportChangesetSpecs(ctx, importChangesets, func(ctx context.Context, repoNames []string) (map[string]string, error) {
+	return func(ctx context.Context, repoNames []string) (map[string]string, error) {
 		if len(repoNames) == 0 {
 			return map[string]string{}, nil
 		}
@@ -310,10 +338,11 @@ func changesetSpecsForImports(ctx context.Context, s *store.Store, importChanges
 			repoNameIDs[string(r.Name)] = string(graphqlbackend.MarshalRepositoryID(r.ID))
 		}
 		return repoNameIDs, nil
-	})
-	if err != nil {
-		return nil, err
 	}
+}
+
+func toChangesetSpecs(specs []*batcheslib.ChangesetSpec, batchSpecID int64, userID int32) ([]*btypes.ChangesetSpec, error) {
+	cs := []*btypes.ChangesetSpec{}
 	for _, c := range specs {
 		repoID, err := graphqlbackend.UnmarshalRepositoryID(graphql.ID(c.BaseRepository))
 		if err != nil {
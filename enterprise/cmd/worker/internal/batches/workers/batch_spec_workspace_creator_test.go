@@ -713,6 +713,10 @@ func (d *dummyWorkspaceResolver) ResolveWorkspacesForBatchSpec(context.Context,
 	return d.workspaces, d.err
 }
 
+func (d *dummyWorkspaceResolver) ResolveRepositoriesMatchingQuery(context.Context, string) ([]*service.RepoRevision, error) {
+	return nil, d.err
+}
+
 const testDiff = `diff README.md README.md
 index 671e50a..851b23a 100644
 --- README.md
@@ -0,0 +1,109 @@
+package notebooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// checkBatchSize is the maximum number of pinned blocks checked per run of
+// the checker.
+const checkBatchSize = 100
+
+// pinChecker compares the notebook blocks pinned to a commit which weren't
+// checked within the last interval with the default branch of their
+// repository, using the diff of the file of the block.
+type pinChecker struct {
+	logger   log.Logger
+	store    notebooks.NotebooksStore
+	client   gitserver.Client
+	interval time.Duration
+}
+
+var _ goroutine.Handler = &pinChecker{}
+var _ goroutine.ErrorHandler = &pinChecker{}
+
+func (c *pinChecker) Handle(ctx context.Context) error {
+	// The checks are only shown to users that can access the notebook.
+	ctx = actor.WithInternalActor(ctx)
+
+	blocks, err := c.store.ListPinnedNotebookBlocksToCheck(ctx, time.Now().Add(-c.interval), checkBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, pinned := range blocks {
+		check, err := c.check(ctx, pinned)
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "notebook %d block %s", pinned.NotebookID, pinned.Block.ID))
+			// Move the block to the back of the queue, so that blocks whose
+			// check keeps failing don't block the other ones.
+			if err := c.store.RecordNotebookBlockPinCheckFailure(ctx, pinned, err.Error()); err != nil {
+				errs = errors.Append(errs, err)
+			}
+			continue
+		}
+		if err := c.store.UpsertNotebookBlockPinCheck(ctx, check); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (c *pinChecker) HandleError(err error) {
+	c.logger.Error("error checking pinned notebook blocks", log.Error(err))
+}
+
+// check compares the pinned lines of the block with the default branch.
+// Symbol blocks have no line range, so only changes of their file are
+// detected.
+func (c *pinChecker) check(ctx context.Context, pinned *notebooks.PinnedNotebookBlock) (*notebooks.NotebookBlockPinCheck, error) {
+	var (
+		repoName  api.RepoName
+		filePath  string
+		lineRange *notebooks.LineRange
+	)
+	switch {
+	case pinned.Block.FileInput != nil:
+		repoName, filePath, lineRange = api.RepoName(pinned.Block.FileInput.RepositoryName), pinned.Block.FileInput.FilePath, pinned.Block.FileInput.LineRange
+	case pinned.Block.SymbolInput != nil:
+		repoName, filePath = api.RepoName(pinned.Block.SymbolInput.RepositoryName), pinned.Block.SymbolInput.FilePath
+	}
+	check := &notebooks.NotebookBlockPinCheck{
+		NotebookID:   pinned.NotebookID,
+		BlockID:      pinned.Block.ID,
+		PinnedCommit: pinned.Block.PinnedCommit(),
+		State:        notebooks.NotebookBlockPinUnavailable,
+	}
+
+	_, headCommit, err := c.client.GetDefaultBranch(ctx, repoName, true)
+	if errcode.IsNotFound(err) || (err == nil && headCommit == "") {
+		return check, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if _, err := c.client.ResolveRevision(ctx, repoName, check.PinnedCommit, gitserver.ResolveRevisionOptions{NoEnsureRevision: true}); errcode.IsNotFound(err) {
+		return check, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	hunks, err := c.client.DiffPath(ctx, authz.DefaultSubRepoPermsChecker, repoName, check.PinnedCommit, string(headCommit), filePath)
+	if err != nil {
+		return nil, err
+	}
+	check.HeadCommit = string(headCommit)
+	check.State, check.SuggestedLineRange = notebooks.CheckPinnedLineRange(hunks, lineRange)
+	return check, nil
+}
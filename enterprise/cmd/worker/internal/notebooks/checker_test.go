@@ -0,0 +1,78 @@
+package notebooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+)
+
+func TestPinCheckerCheck(t *testing.T) {
+	pinnedCommit := "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+	headCommit := api.CommitID("cafebabecafebabecafebabecafebabecafebabe")
+	pinned := &notebooks.PinnedNotebookBlock{
+		NotebookID: 1,
+		Block: notebooks.NotebookBlock{ID: "b", Type: notebooks.NotebookFileBlockType, FileInput: &notebooks.NotebookFileBlockInput{
+			RepositoryName: "github.com/sourcegraph/sourcegraph",
+			FilePath:       "README.md",
+			LineRange:      &notebooks.LineRange{StartLine: 5, EndLine: 7},
+			PinnedCommit:   &pinnedCommit,
+		}},
+	}
+	// Adds a line at the top of the file.
+	hunks, err := diff.ParseHunks([]byte("@@ -1,3 +1,4 @@\n+a\n l1\n l2\n l3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("moved", func(t *testing.T) {
+		client := gitserver.NewMockClient()
+		client.GetDefaultBranchFunc.SetDefaultReturn("main", headCommit, nil)
+		client.ResolveRevisionFunc.SetDefaultReturn(api.CommitID(pinnedCommit), nil)
+		client.DiffPathFunc.SetDefaultReturn(hunks, nil)
+
+		checker := &pinChecker{client: client}
+		check, err := checker.check(context.Background(), pinned)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &notebooks.NotebookBlockPinCheck{
+			NotebookID:         1,
+			BlockID:            "b",
+			PinnedCommit:       pinnedCommit,
+			HeadCommit:         string(headCommit),
+			State:              notebooks.NotebookBlockPinMoved,
+			SuggestedLineRange: &notebooks.LineRange{StartLine: 6, EndLine: 8},
+		}
+		if diff := cmp.Diff(want, check); diff != "" {
+			t.Fatalf("unexpected check (-want +got):\n%s", diff)
+		}
+		if got := client.DiffPathFunc.History()[0]; got.Arg3 != pinnedCommit || got.Arg4 != string(headCommit) || got.Arg5 != "README.md" {
+			t.Fatalf("unexpected diff arguments %+v", got)
+		}
+	})
+
+	t.Run("pinned commit not found", func(t *testing.T) {
+		client := gitserver.NewMockClient()
+		client.GetDefaultBranchFunc.SetDefaultReturn("main", headCommit, nil)
+		client.ResolveRevisionFunc.SetDefaultReturn("", &gitdomain.RevisionNotFoundError{Repo: "github.com/sourcegraph/sourcegraph", Spec: pinnedCommit})
+
+		checker := &pinChecker{client: client}
+		check, err := checker.check(context.Background(), pinned)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if check.State != notebooks.NotebookBlockPinUnavailable {
+			t.Fatalf("expected the block to be unavailable, got %q", check.State)
+		}
+		if len(client.DiffPathFunc.History()) != 0 {
+			t.Fatal("expected no diff to be requested")
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package notebooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type config struct {
+	env.BaseConfig
+
+	CheckInterval time.Duration
+}
+
+func (c *config) Load() {
+	c.CheckInterval = c.GetInterval("NOTEBOOKS_PIN_CHECK_INTERVAL", "1h", "How often notebook blocks pinned to a commit are compared with the default branch of their repository.")
+}
+
+// pinCheckerJob compares the notebook blocks pinned to a commit with the
+// default branch of their repository.
+type pinCheckerJob struct {
+	config *config
+}
+
+var _ job.Job = &pinCheckerJob{}
+
+func NewPinCheckerJob() job.Job {
+	return &pinCheckerJob{config: &config{}}
+}
+
+func (j *pinCheckerJob) Description() string {
+	return "Compares the notebook blocks pinned to a commit with the default branch of their repository."
+}
+
+func (j *pinCheckerJob) Config() []env.Config {
+	return []env.Config{j.config}
+}
+
+func (j *pinCheckerJob) Routines(ctx context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	mainAppDB, err := workerdb.Init()
+	if err != nil {
+		return nil, err
+	}
+	db := database.NewDB(logger, mainAppDB)
+
+	c := &pinChecker{
+		logger:   logger.Scoped("notebooks.PinChecker", "compares pinned notebook blocks with the default branch"),
+		store:    notebooks.Notebooks(db),
+		client:   gitserver.NewClient(db),
+		interval: j.config.CheckInterval,
+	}
+	return []goroutine.BackgroundRoutine{
+		// We look for blocks to check more often than the check interval, so
+		// that newly pinned blocks are checked soon.
+		goroutine.NewPeriodicGoroutine(context.Background(), 1*time.Minute, c),
+	}, nil
+}
@@ -0,0 +1,248 @@
+package automerge
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const mergeInterval = 1 * time.Minute
+
+// NewMerger creates a new goroutine.PeriodicGoroutine that merges the
+// changesets of batch changes with a merge policy, once their checks have
+// passed and they have been approved.
+func NewMerger(ctx context.Context, logger log.Logger, s *store.Store, sourcer sources.Sourcer) goroutine.BackgroundRoutine {
+	m := &merger{
+		logger:  logger,
+		store:   s,
+		sourcer: sourcer,
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		mergeInterval,
+		goroutine.NewHandlerWithErrorMessage("merging changesets of batch changes with a merge policy", m.run),
+	)
+}
+
+type merger struct {
+	logger  log.Logger
+	store   *store.Store
+	sourcer sources.Sourcer
+}
+
+func (m *merger) run(ctx context.Context) error {
+	policies, err := m.store.ListBatchChangeMergePolicies(ctx, store.ListBatchChangeMergePoliciesOpts{OnlyOpen: true})
+	if err != nil {
+		return errors.Wrap(err, "listing merge policies")
+	}
+
+	var errs error
+	for _, p := range policies {
+		if err := m.mergeChangesets(ctx, p); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "batch change %d", p.BatchChangeID))
+		}
+	}
+	return errs
+}
+
+// mergeChangesets merges the changesets of the batch change of the given merge
+// policy that are ready to be merged, as long as the policy allows merging.
+func (m *merger) mergeChangesets(ctx context.Context, p *btypes.BatchChangeMergePolicy) error {
+	if p.CreatorID == 0 {
+		// The creator of the policy has been deleted, so there are no
+		// credentials to merge the changesets with.
+		m.logger.Debug("skipping merge policy without creator", log.Int64("batchChangeID", p.BatchChangeID))
+		return nil
+	}
+
+	now := m.store.Clock()()
+
+	cfg, err := window.NewConfiguration(&p.Windows)
+	if err != nil {
+		return errors.Wrap(err, "parsing merge windows")
+	}
+	if !cfg.IsOpen(now) {
+		return nil
+	}
+
+	// A negative budget means that there's no limit.
+	budget := -1
+	if p.MaxMergesPerHour > 0 {
+		merged, err := m.store.CountAutoMergedChangesets(ctx, p.BatchChangeID, now.Add(-1*time.Hour))
+		if err != nil {
+			return errors.Wrap(err, "counting merged changesets")
+		}
+		budget = int(p.MaxMergesPerHour) - merged
+		if budget <= 0 {
+			return nil
+		}
+	}
+
+	published := btypes.ChangesetPublicationStatePublished
+	approved := btypes.ChangesetReviewStateApproved
+	passed := btypes.ChangesetCheckStatePassed
+	cs, _, err := m.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		BatchChangeID:        p.BatchChangeID,
+		OwnedByBatchChangeID: p.BatchChangeID,
+		PublicationState:     &published,
+		ReconcilerStates:     []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+		ExternalStates:       []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+		ExternalReviewState:  &approved,
+		ExternalCheckState:   &passed,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+
+	var errs error
+	for _, c := range cs {
+		if budget == 0 {
+			break
+		}
+
+		merged, err := m.mergeChangeset(ctx, p, c, now)
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "changeset %d", c.ID))
+			continue
+		}
+		if merged && budget > 0 {
+			budget--
+		}
+	}
+	return errs
+}
+
+// mergeChangeset merges the given changeset if it has enough approvals, and
+// records the outcome as a changeset event. It returns whether the changeset
+// was merged.
+//
+// A changeset that can't be merged, for example because of a merge conflict,
+// isn't retried until its head commit changes. Other errors are returned, so
+// that the changeset is retried on the next run.
+func (m *merger) mergeChangeset(ctx context.Context, p *btypes.BatchChangeMergePolicy, c *btypes.Changeset, now time.Time) (bool, error) {
+	headRefOid, err := c.HeadRefOid()
+	if err != nil {
+		return false, err
+	}
+	key := autoMergeEventKey(c, headRefOid)
+
+	_, err = m.store.GetChangesetEvent(ctx, store.GetChangesetEventOpts{
+		ChangesetID: c.ID,
+		Kind:        btypes.ChangesetEventKindAutoMergeFailed,
+		Key:         key,
+	})
+	if err == nil {
+		return false, nil
+	} else if err != store.ErrNoResults {
+		return false, errors.Wrap(err, "loading previous merge failure")
+	}
+
+	events, _, err := m.store.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{ChangesetIDs: []int64{c.ID}})
+	if err != nil {
+		return false, errors.Wrap(err, "loading changeset events")
+	}
+	sort.Sort(state.ChangesetEvents(events))
+
+	approvals, err := state.CountApprovals(c, events)
+	if err != nil {
+		return false, errors.Wrap(err, "counting approvals")
+	}
+	if approvals < int(p.RequiredApprovals) {
+		return false, nil
+	}
+
+	// Use the creator of the merge policy for the merge to enforce repository
+	// permissions.
+	ctx = actor.WithActor(ctx, actor.FromUser(p.CreatorID))
+
+	repo, err := m.store.Repos().Get(ctx, c.RepoID)
+	if err != nil {
+		return false, errors.Wrap(err, "loading repo")
+	}
+
+	css, err := m.sourcer.ForRepo(ctx, m.store, repo)
+	if err != nil {
+		return false, errors.Wrap(err, "loading ChangesetSource")
+	}
+	css, err = sources.WithAuthenticatorForUser(ctx, m.store, css, p.CreatorID, repo)
+	if err != nil {
+		return false, errors.Wrap(err, "authenticating ChangesetSource")
+	}
+
+	remoteRepo, err := sources.GetRemoteRepo(ctx, css, repo, c, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "loading remote repo")
+	}
+
+	audit := &btypes.ChangesetEvent{
+		ChangesetID: c.ID,
+		Kind:        btypes.ChangesetEventKindAutoMergeMerged,
+		Key:         key,
+	}
+	metadata := &btypes.AutoMergeEvent{
+		BatchChangeID: p.BatchChangeID,
+		MergeMethod:   p.MergeMethod,
+		HeadRefOid:    headRefOid,
+		Approvals:     approvals,
+		CreatedAt:     now,
+	}
+	audit.Metadata = metadata
+
+	cs := &sources.Changeset{
+		Changeset:  c,
+		TargetRepo: repo,
+		RemoteRepo: remoteRepo,
+	}
+	if err := css.MergeChangeset(ctx, cs, p.MergeMethod.Squash()); err != nil {
+		if !errcode.IsNonRetryable(err) {
+			return false, errors.Wrap(err, "merging changeset")
+		}
+
+		audit.Kind = btypes.ChangesetEventKindAutoMergeFailed
+		metadata.Error = err.Error()
+		if err := m.store.UpsertChangesetEvents(ctx, audit); err != nil {
+			return false, errors.Wrap(err, "recording merge failure")
+		}
+		m.logger.Warn("merging changeset failed", log.Int64("changesetID", c.ID), log.Error(err))
+		return false, nil
+	}
+
+	events, err = cs.Changeset.Events()
+	if err != nil {
+		return true, errors.Wrap(err, "computing changeset events")
+	}
+	state.SetDerivedState(ctx, m.store.Repos(), cs.Changeset, events)
+
+	if err := m.store.UpsertChangesetEvents(ctx, append(events, audit)...); err != nil {
+		return true, errors.Wrap(err, "upserting changeset events")
+	}
+	if err := m.store.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
+		return true, errors.Wrap(err, "updating changeset")
+	}
+
+	return true, nil
+}
+
+// autoMergeEventKey returns the key of the events that record the outcome of
+// merging the given changeset at the given head commit. Code hosts that don't
+// tell us the head commit fall back to the time the changeset was last
+// updated.
+func autoMergeEventKey(c *btypes.Changeset, headRefOid string) string {
+	if headRefOid != "" {
+		return headRefOid
+	}
+	return c.ExternalUpdatedAt.UTC().Format(time.RFC3339)
+}
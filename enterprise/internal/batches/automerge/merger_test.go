@@ -0,0 +1,280 @@
+package automerge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	stesting "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/testing"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMerger(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+
+	ctx := context.Background()
+	sqlDB := dbtest.NewDB(logger, t)
+	tx := dbtest.NewTx(t, sqlDB)
+	db := database.NewDB(logger, sqlDB)
+
+	// Monday, 10:00 UTC.
+	now := time.Date(2021, 4, 5, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	bstore := store.NewWithClock(database.NewDBWith(logger, basestore.NewWithHandle(basestore.NewHandleWithTx(tx, sql.TxOptions{}))), &observation.TestContext, nil, clock)
+
+	user := bt.CreateTestUser(t, db, true)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+	bt.CreateTestSiteCredential(t, bstore, repo)
+	batchSpec := bt.CreateBatchSpec(t, ctx, bstore, "test-automerge", user.ID, 0)
+
+	var changesets int
+	createChangeset := func(t *testing.T, batchChange *btypes.BatchChange) *btypes.Changeset {
+		t.Helper()
+
+		changesets++
+		return bt.CreateChangeset(t, ctx, bstore, bt.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChange:         batchChange.ID,
+			OwnedByBatchChange:  batchChange.ID,
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalID:          fmt.Sprintf("automerge-%d", changesets),
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalReviewState: btypes.ChangesetReviewStateApproved,
+			ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:     btypes.ReconcilerStateCompleted,
+			Metadata: &github.PullRequest{
+				State:      "OPEN",
+				HeadRefOid: fmt.Sprintf("deadbeef%d", changesets),
+			},
+		})
+	}
+
+	createPolicy := func(t *testing.T, name string, p *btypes.BatchChangeMergePolicy) *btypes.BatchChange {
+		t.Helper()
+
+		batchChange := bt.CreateBatchChange(t, ctx, bstore, name, user.ID, batchSpec.ID)
+		p.BatchChangeID = batchChange.ID
+		p.CreatorID = user.ID
+		if p.MergeMethod == "" {
+			p.MergeMethod = btypes.MergePolicyMethodMerge
+		}
+		if err := bstore.UpsertBatchChangeMergePolicy(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+		// Delete the policy afterwards, so that the merger doesn't pick up
+		// the changesets of other tests.
+		t.Cleanup(func() {
+			if err := bstore.DeleteBatchChangeMergePolicy(ctx, batchChange.ID); err != nil {
+				t.Fatal(err)
+			}
+		})
+		return batchChange
+	}
+
+	assertAuditEvent := func(t *testing.T, c *btypes.Changeset, kind btypes.ChangesetEventKind) *btypes.AutoMergeEvent {
+		t.Helper()
+
+		headRefOid, err := c.HeadRefOid()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ev, err := bstore.GetChangesetEvent(ctx, store.GetChangesetEventOpts{
+			ChangesetID: c.ID,
+			Kind:        kind,
+			Key:         headRefOid,
+		})
+		if err != nil {
+			t.Fatalf("loading %s event: %s", kind, err)
+		}
+		return ev.Metadata.(*btypes.AutoMergeEvent)
+	}
+
+	assertNoAuditEvents := func(t *testing.T, c *btypes.Changeset) {
+		t.Helper()
+
+		events, _, err := bstore.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
+			ChangesetIDs: []int64{c.ID},
+			Kinds: []btypes.ChangesetEventKind{
+				btypes.ChangesetEventKindAutoMergeMerged,
+				btypes.ChangesetEventKindAutoMergeFailed,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 0 {
+			t.Fatalf("unexpected audit events: %d", len(events))
+		}
+	}
+
+	t.Run("merges ready changesets", func(t *testing.T) {
+		batchChange := createPolicy(t, "merges-ready", &btypes.BatchChangeMergePolicy{
+			MergeMethod: btypes.MergePolicyMethodSquash,
+		})
+		changeset := createChangeset(t, batchChange)
+
+		fake := &stesting.FakeChangesetSource{}
+		m := &merger{logger: logger, store: bstore, sourcer: stesting.NewFakeSourcer(nil, fake)}
+		if err := m.run(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if !fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset to be called but wasn't")
+		}
+		ev := assertAuditEvent(t, changeset, btypes.ChangesetEventKindAutoMergeMerged)
+		if ev.BatchChangeID != batchChange.ID || ev.MergeMethod != btypes.MergePolicyMethodSquash {
+			t.Fatalf("unexpected audit event: %+v", ev)
+		}
+
+		merged, err := bstore.CountAutoMergedChangesets(ctx, batchChange.ID, now.Add(-1*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if merged != 1 {
+			t.Fatalf("unexpected number of merged changesets: %d", merged)
+		}
+	})
+
+	t.Run("outside of windows", func(t *testing.T) {
+		batchChange := createPolicy(t, "outside-windows", &btypes.BatchChangeMergePolicy{
+			Windows: []*schema.BatchChangeRolloutWindow{
+				{Days: []string{"tuesday"}, Rate: "unlimited"},
+			},
+		})
+		changeset := createChangeset(t, batchChange)
+
+		fake := &stesting.FakeChangesetSource{}
+		m := &merger{logger: logger, store: bstore, sourcer: stesting.NewFakeSourcer(nil, fake)}
+		if err := m.run(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset not to be called but was")
+		}
+		assertNoAuditEvents(t, changeset)
+	})
+
+	t.Run("missing approvals", func(t *testing.T) {
+		batchChange := createPolicy(t, "missing-approvals", &btypes.BatchChangeMergePolicy{
+			RequiredApprovals: 2,
+		})
+		changeset := createChangeset(t, batchChange)
+		if err := bstore.UpsertChangesetEvents(ctx, &btypes.ChangesetEvent{
+			ChangesetID: changeset.ID,
+			Kind:        btypes.ChangesetEventKindGitHubReviewed,
+			Key:         "review",
+			Metadata: &github.PullRequestReview{
+				State:     "APPROVED",
+				Author:    github.Actor{Login: "alice"},
+				UpdatedAt: now.Add(-1 * time.Hour),
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		fake := &stesting.FakeChangesetSource{}
+		m := &merger{logger: logger, store: bstore, sourcer: stesting.NewFakeSourcer(nil, fake)}
+		if err := m.run(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset not to be called but was")
+		}
+		assertNoAuditEvents(t, changeset)
+	})
+
+	t.Run("max merges per hour", func(t *testing.T) {
+		batchChange := createPolicy(t, "max-merges", &btypes.BatchChangeMergePolicy{
+			MaxMergesPerHour: 1,
+		})
+		first := createChangeset(t, batchChange)
+		second := createChangeset(t, batchChange)
+
+		fake := &stesting.FakeChangesetSource{}
+		m := &merger{logger: logger, store: bstore, sourcer: stesting.NewFakeSourcer(nil, fake)}
+		for i := 0; i < 2; i++ {
+			if err := m.run(ctx); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		merged, err := bstore.CountAutoMergedChangesets(ctx, batchChange.ID, now.Add(-1*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if merged != 1 {
+			t.Fatalf("unexpected number of merged changesets: %d", merged)
+		}
+		assertAuditEvent(t, first, btypes.ChangesetEventKindAutoMergeMerged)
+		assertNoAuditEvents(t, second)
+	})
+
+	t.Run("merge fails", func(t *testing.T) {
+		batchChange := createPolicy(t, "merge-fails", &btypes.BatchChangeMergePolicy{})
+		changeset := createChangeset(t, batchChange)
+
+		mergeErr := sources.ChangesetNotMergeableError{ErrorMsg: "merge conflict"}
+		fake := &stesting.FakeChangesetSource{Err: mergeErr}
+		m := &merger{logger: logger, store: bstore, sourcer: stesting.NewFakeSourcer(nil, fake)}
+		if err := m.run(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		ev := assertAuditEvent(t, changeset, btypes.ChangesetEventKindAutoMergeFailed)
+		if ev.Error != mergeErr.Error() {
+			t.Fatalf("unexpected error in audit event: %q", ev.Error)
+		}
+
+		// The changeset isn't retried until its head commit changes.
+		fake.MergeChangesetCalled = false
+		if err := m.run(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset not to be called but was")
+		}
+	})
+
+	t.Run("merge fails with transient error", func(t *testing.T) {
+		batchChange := createPolicy(t, "merge-fails-transient", &btypes.BatchChangeMergePolicy{})
+		changeset := createChangeset(t, batchChange)
+
+		fake := &stesting.FakeChangesetSource{Err: errors.New("connection reset by peer")}
+		m := &merger{logger: logger, store: bstore, sourcer: stesting.NewFakeSourcer(nil, fake)}
+		if err := m.run(ctx); err == nil {
+			t.Fatal("expected error but got none")
+		}
+		assertNoAuditEvents(t, changeset)
+
+		// The changeset is retried on the next run.
+		fake.MergeChangesetCalled = false
+		fake.Err = nil
+		if err := m.run(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if !fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset to be called but wasn't")
+		}
+	})
+}
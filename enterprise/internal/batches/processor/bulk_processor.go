@@ -34,6 +34,8 @@ func (e unknownJobTypeErr) NonRetryable() bool {
 
 var changesetIsProcessingErr = errors.New("cannot update a changeset that is currently being processed; will retry")
 
+var changesetIsIssueErr = errcode.MakeNonRetryable(errors.New("cannot comment on or merge an issue"))
+
 func New(tx *store.Store, sourcer sources.Sourcer) BulkProcessor {
 	return &bulkProcessor{
 		tx:      tx,
@@ -113,6 +115,10 @@ func (b *bulkProcessor) comment(ctx context.Context, job *btypes.ChangesetJob) e
 		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobCommentPayload{}, job.Payload)
 	}
 
+	if b.ch.IsIssue() {
+		return changesetIsIssueErr
+	}
+
 	remoteRepo, err := sources.GetRemoteRepo(ctx, b.css, b.repo, b.ch, nil)
 	if err != nil {
 		return errors.Wrap(err, "loading remote repo")
@@ -168,6 +174,10 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobMergePayload{}, job.Payload)
 	}
 
+	if b.ch.IsIssue() {
+		return changesetIsIssueErr
+	}
+
 	remoteRepo, err := sources.GetRemoteRepo(ctx, b.css, b.repo, b.ch, nil)
 	if err != nil {
 		return errors.Wrap(err, "loading remote repo")
@@ -213,7 +223,16 @@ func (b *bulkProcessor) closeChangeset(ctx context.Context) (err error) {
 		TargetRepo: b.repo,
 		RemoteRepo: remoteRepo,
 	}
-	if err := b.css.CloseChangeset(ctx, cs); err != nil {
+	closeFn := b.css.CloseChangeset
+	if b.ch.IsIssue() {
+		ics, err := sources.ToIssueChangesetSource(b.css)
+		if err != nil {
+			return errcode.MakeNonRetryable(err)
+		}
+		closeFn = ics.CloseIssue
+	}
+
+	if err := closeFn(ctx, cs); err != nil {
 		return err
 	}
 
@@ -3,6 +3,7 @@ package reconciler
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/log"
@@ -25,6 +27,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
+	batchestemplate "github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -91,7 +97,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 			err = e.importChangeset(ctx)
 
 		case btypes.ReconcilerOperationPush:
-			err = e.pushChangesetPatch(ctx)
+			if err = e.pushChangesetPatch(ctx); err == nil {
+				e.completeRefresh()
+			}
 
 		case btypes.ReconcilerOperationPublish:
 			err = e.publishChangeset(ctx, false)
@@ -123,6 +131,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationReattach:
 			e.reattachChangeset()
 
+		case btypes.ReconcilerOperationRefresh:
+			err = e.refreshChangeset(ctx)
+
 		default:
 			err = errors.Errorf("executor operation %q not implemented", op)
 		}
@@ -192,10 +203,19 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	prcss, isPushRef := css.(sources.PushRefChangesetSource)
+	if isPushRef {
+		prcss.PreparePush(&opts, e.ch, e.spec)
+	}
 
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
 	if errors.As(err, &pce) {
+		// Pushing a commit again, for example when retrying, is rejected by
+		// code hosts such as Gerrit, but the changeset is up to date.
+		if isPushRef && prcss.IsUnchangedPushError(pce.CombinedOutput) {
+			return nil
+		}
 		if acss, ok := css.(sources.ArchivableChangesetSource); ok {
 			if acss.IsArchivedPushError(pce.CombinedOutput) {
 				if err := e.handleArchivedRepo(ctx); err != nil {
@@ -238,6 +258,18 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 		Changeset:  e.ch,
 	}
 
+	if e.ch.IsIssue() {
+		ics, err := sources.ToIssueChangesetSource(css)
+		if err != nil {
+			return err
+		}
+		if err := ics.CreateIssue(ctx, cs); err != nil {
+			return errors.Wrap(err, "creating issue")
+		}
+		e.ch.PublicationState = btypes.ChangesetPublicationStatePublished
+		return nil
+	}
+
 	var exists bool
 	if asDraft {
 		// If the changeset shall be published in draft mode, make sure the changeset source implements DraftChangesetSource.
@@ -321,6 +353,15 @@ func (e *executor) loadChangeset(ctx context.Context) error {
 		TargetRepo: e.targetRepo,
 		Changeset:  e.ch,
 	}
+
+	if e.ch.IsIssue() {
+		ics, err := sources.ToIssueChangesetSource(css)
+		if err != nil {
+			return err
+		}
+		return ics.LoadIssue(ctx, repoChangeset)
+	}
+
 	return css.LoadChangeset(ctx, repoChangeset)
 }
 
@@ -356,7 +397,16 @@ func (e *executor) updateChangeset(ctx context.Context) (err error) {
 		Changeset:  e.ch,
 	}
 
-	if err := css.UpdateChangeset(ctx, &cs); err != nil {
+	updateFn := css.UpdateChangeset
+	if e.ch.IsIssue() {
+		ics, err := sources.ToIssueChangesetSource(css)
+		if err != nil {
+			return err
+		}
+		updateFn = ics.UpdateIssue
+	}
+
+	if err := updateFn(ctx, &cs); err != nil {
 		if errcode.IsArchived(err) {
 			if err := e.handleArchivedRepo(ctx); err != nil {
 				return err
@@ -390,7 +440,16 @@ func (e *executor) reopenChangeset(ctx context.Context) (err error) {
 		TargetRepo: e.targetRepo,
 		Changeset:  e.ch,
 	}
-	if err := css.ReopenChangeset(ctx, &cs); err != nil {
+	reopenFn := css.ReopenChangeset
+	if e.ch.IsIssue() {
+		ics, err := sources.ToIssueChangesetSource(css)
+		if err != nil {
+			return err
+		}
+		reopenFn = ics.ReopenIssue
+	}
+
+	if err := reopenFn(ctx, &cs); err != nil {
 		return errors.Wrap(err, "updating changeset")
 	}
 	return nil
@@ -426,6 +485,146 @@ func (e *executor) reattachChangeset() {
 	}
 }
 
+// refreshChangeset re-executes the steps of the workspace that produced the
+// changeset spec against the new commit of the base branch. Once the execution
+// finished, the changeset spec is refreshed with the new diff and the
+// changeset is enqueued again to push it.
+func (e *executor) refreshChangeset(ctx context.Context) error {
+	workspace, err := e.tx.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ChangesetSpecID: e.spec.ID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			// The changeset spec wasn't created by a server-side execution,
+			// so there is nothing we could re-execute.
+			e.ch.RefreshBaseRev = ""
+			return nil
+		}
+		return errors.Wrap(err, "loading batch spec workspace")
+	}
+
+	job, err := e.tx.GetBatchSpecWorkspaceExecutionJob(ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{
+		BatchSpecWorkspaceID: workspace.ID,
+		ExcludeRank:          true,
+	})
+	if err != nil && err != store.ErrNoResults {
+		return errors.Wrap(err, "loading batch spec workspace execution job")
+	}
+	if job != nil && job.State == btypes.BatchSpecWorkspaceExecutionJobStateProcessing {
+		// The changeset is enqueued again when the running execution
+		// finished, and refreshed onto the new commit then.
+		return nil
+	}
+
+	batchSpec, err := e.tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: workspace.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+
+	stepCacheResults, err := e.loadStepCacheResults(ctx, batchSpec, workspace, e.ch.RefreshBaseRev)
+	if err != nil {
+		return errors.Wrap(err, "loading cached step results")
+	}
+
+	return e.tx.RefreshBatchSpecWorkspace(ctx, workspace.ID, e.ch.RefreshBaseRev, stepCacheResults)
+}
+
+// loadStepCacheResults looks up the cached results of the steps of the given
+// workspace when executed against the given commit, so that only the steps
+// whose results aren't cached yet need to be re-executed.
+func (e *executor) loadStepCacheResults(ctx context.Context, batchSpec *btypes.BatchSpec, workspace *btypes.BatchSpecWorkspace, commit string) (map[int]btypes.StepCacheResult, error) {
+	results := make(map[int]btypes.StepCacheResult)
+	if batchSpec.NoCache {
+		return results, nil
+	}
+
+	skippedSteps, err := batcheslib.SkippedStepsForRepo(batchSpec.Spec, string(e.targetRepo.Name), workspace.FileMatches)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := batcheslib.Repository{
+		ID:          string(relay.MarshalID("Repository", e.targetRepo.ID)),
+		Name:        string(e.targetRepo.Name),
+		BaseRef:     workspace.Branch,
+		BaseRev:     commit,
+		FileMatches: workspace.FileMatches,
+	}
+
+	keys := make(map[int]string, len(batchSpec.Spec.Steps))
+	allKeys := make([]string, 0, len(batchSpec.Spec.Steps))
+	for i := range batchSpec.Spec.Steps {
+		if _, ok := skippedSteps[int32(i)]; ok {
+			continue
+		}
+
+		key, err := cache.KeyForWorkspace(
+			&batchestemplate.BatchChangeAttributes{
+				Name:        batchSpec.Spec.Name,
+				Description: batchSpec.Spec.Description,
+			},
+			repo,
+			workspace.Path,
+			workspace.OnlyFetchWorkspace,
+			batchSpec.Spec.Steps,
+			i,
+		).Key()
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+		allKeys = append(allKeys, key)
+	}
+	if len(allKeys) == 0 {
+		return results, nil
+	}
+
+	entries, err := e.tx.ListBatchSpecExecutionCacheEntries(ctx, store.ListBatchSpecExecutionCacheEntriesOpts{
+		UserID: batchSpec.UserID,
+		Keys:   allKeys,
+	})
+	if err != nil {
+		return nil, err
+	}
+	entriesByKey := make(map[string]*btypes.BatchSpecExecutionCacheEntry, len(entries))
+	for _, entry := range entries {
+		entriesByKey[entry.Key] = entry
+	}
+
+	usedEntries := []int64{}
+	for i := range batchSpec.Spec.Steps {
+		key, ok := keys[i]
+		if !ok {
+			continue
+		}
+		entry, ok := entriesByKey[key]
+		if !ok {
+			// Only use cached results up until the first step that needs to
+			// be executed again.
+			break
+		}
+
+		var res execution.AfterStepResult
+		if err := json.Unmarshal([]byte(entry.Value), &res); err != nil {
+			return nil, err
+		}
+		results[i+1] = btypes.StepCacheResult{Key: key, Value: &res}
+		usedEntries = append(usedEntries, entry.ID)
+	}
+
+	return results, e.tx.MarkUsedBatchSpecExecutionCacheEntries(ctx, usedEntries)
+}
+
+// completeRefresh marks the pending refresh of the changeset as done once the
+// commit of the refreshed changeset spec has been pushed.
+func (e *executor) completeRefresh() {
+	if e.ch.RefreshBaseRev == "" || e.ch.RefreshBaseRev != e.spec.BaseRev {
+		return
+	}
+	e.ch.RefreshBaseRev = ""
+
+	diffStat := e.spec.DiffStat()
+	e.ch.SetDiffStat(&diffStat)
+}
+
 // closeChangeset closes the given changeset on its code host if its ExternalState is OPEN or DRAFT.
 func (e *executor) closeChangeset(ctx context.Context) (err error) {
 	e.ch.Closing = false
@@ -450,7 +649,16 @@ func (e *executor) closeChangeset(ctx context.Context) (err error) {
 		TargetRepo: e.targetRepo,
 	}
 
-	if err := css.CloseChangeset(ctx, cs); err != nil {
+	closeFn := css.CloseChangeset
+	if e.ch.IsIssue() {
+		ics, err := sources.ToIssueChangesetSource(css)
+		if err != nil {
+			return err
+		}
+		closeFn = ics.CloseIssue
+	}
+
+	if err := closeFn(ctx, cs); err != nil {
 		return errors.Wrap(err, "closing changeset")
 	}
 	return nil
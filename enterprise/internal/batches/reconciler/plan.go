@@ -15,6 +15,7 @@ var operationPrecedence = map[btypes.ReconcilerOperation]int{
 	btypes.ReconcilerOperationDetach:       0,
 	btypes.ReconcilerOperationArchive:      0,
 	btypes.ReconcilerOperationReattach:     0,
+	btypes.ReconcilerOperationRefresh:      0,
 	btypes.ReconcilerOperationImport:       1,
 	btypes.ReconcilerOperationPublish:      1,
 	btypes.ReconcilerOperationPublishDraft: 1,
@@ -103,6 +104,38 @@ type Plan struct {
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
 func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 
+// Publishes returns whether the plan publishes the changeset on the code host.
+func (p *Plan) Publishes() bool {
+	for _, op := range p.Ops {
+		if op == btypes.ReconcilerOperationPublish || op == btypes.ReconcilerOperationPublishDraft {
+			return true
+		}
+	}
+	return false
+}
+
+// HoldBackPublication removes the operations that publish the changeset from
+// the plan, so that the changeset stays unpublished. This is used to hold back
+// the changesets of a later rollout stage until the changesets of the earlier
+// stages are done.
+func (p *Plan) HoldBackPublication() {
+	if !p.Publishes() {
+		return
+	}
+
+	ops := Operations{}
+	for _, op := range p.Ops {
+		switch op {
+		case btypes.ReconcilerOperationPublish,
+			btypes.ReconcilerOperationPublishDraft,
+			btypes.ReconcilerOperationPush:
+			continue
+		}
+		ops = append(ops, op)
+	}
+	p.Ops = ops
+}
+
 // DeterminePlan looks at the given changeset to determine what action the
 // reconciler should take.
 // It consumes the current and the previous changeset spec, if they exist. If
@@ -187,8 +220,11 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 		calc := calculatePublicationState(currentSpec.Published, wantedChangeset.UiPublicationState)
 		if calc.IsPublished() {
 			pl.SetOp(btypes.ReconcilerOperationPublish)
-			pl.AddOp(btypes.ReconcilerOperationPush)
-		} else if calc.IsDraft() && wantedChangeset.SupportsDraft() {
+			// Issues don't have a branch that needs to be pushed.
+			if !wantedChangeset.IsIssue() {
+				pl.AddOp(btypes.ReconcilerOperationPush)
+			}
+		} else if calc.IsDraft() && wantedChangeset.SupportsDraft() && !wantedChangeset.IsIssue() {
 			// If configured to be opened as draft, and the changeset supports
 			// draft mode, publish as draft. Otherwise, take no action.
 			pl.SetOp(btypes.ReconcilerOperationPublishDraft)
@@ -213,7 +249,7 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 		// applied, which would mean delta.Undraft is set, or because the UI
 		// publication state has been changed, for which we need to compare the
 		// current changeset state against the desired state.
-		if btypes.ExternalServiceSupports(wantedChangeset.ExternalServiceType, btypes.CodehostCapabilityDraftChangesets) {
+		if btypes.ExternalServiceSupports(wantedChangeset.ExternalServiceType, btypes.CodehostCapabilityDraftChangesets) && !wantedChangeset.IsIssue() {
 			if delta.Undraft {
 				pl.AddOp(btypes.ReconcilerOperationUndraft)
 			} else if calc := calculatePublicationState(currentSpec.Published, wantedChangeset.UiPublicationState); calc.IsPublished() && wantedChangeset.ExternalState == btypes.ChangesetExternalStateDraft {
@@ -221,6 +257,16 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 			}
 		}
 
+		// If the base branch of the changeset moved, we first re-execute the
+		// steps against the new base commit. The changeset is enqueued again
+		// once its changeset spec has been refreshed in place with the result,
+		// so we don't push the outdated commit in the meantime.
+		refresh := wantedChangeset.RefreshBaseRev != "" && !wantedChangeset.Complete()
+		if refresh && wantedChangeset.RefreshBaseRev != currentSpec.BaseRev {
+			pl.AddOp(btypes.ReconcilerOperationRefresh)
+			return pl, nil
+		}
+
 		if delta.AttributesChanged() {
 			if delta.NeedCommitUpdate() {
 				pl.AddOp(btypes.ReconcilerOperationPush)
@@ -248,6 +294,14 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 			}
 		}
 
+		// Once the changeset spec has been refreshed, we push the refreshed
+		// commit, unless we already do so because the spec changed.
+		if refresh && !delta.NeedCommitUpdate() {
+			pl.AddOp(btypes.ReconcilerOperationPush)
+			pl.AddOp(btypes.ReconcilerOperationSleep)
+			pl.AddOp(btypes.ReconcilerOperationSync)
+		}
+
 	default:
 		return pl, errors.Errorf("unknown changeset publication state: %s", wantedChangeset.PublicationState)
 	}
@@ -280,6 +280,88 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationReopen,
 			},
 		},
+		{
+			name:         "refresh",
+			previousSpec: &bt.TestSpecOpts{Published: true, BaseRev: "old"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BaseRev: "old"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				RefreshBaseRev:   "new",
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationRefresh,
+			},
+		},
+		{
+			name:         "push refreshed spec",
+			previousSpec: &bt.TestSpecOpts{Published: true, BaseRev: "old"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BaseRev: "new"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				RefreshBaseRev:   "new",
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "refresh with changed spec",
+			previousSpec: &bt.TestSpecOpts{Published: true, BaseRev: "old", CommitDiff: "previous diff"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BaseRev: "old", CommitDiff: "current diff"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				RefreshBaseRev:   "new",
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationRefresh,
+			},
+		},
+		{
+			name:         "refresh closed changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, BaseRev: "old"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BaseRev: "old"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateClosed,
+				RefreshBaseRev:   "new",
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:        "publish issue",
+			currentSpec: &bt.TestSpecOpts{Published: true},
+			changeset: bt.TestChangesetOpts{
+				Kind:             btypes.ChangesetKindIssue,
+				PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationPublish},
+		},
+		{
+			name:        "publish issue as draft",
+			currentSpec: &bt.TestSpecOpts{Published: "draft"},
+			changeset: bt.TestChangesetOpts{
+				Kind:             btypes.ChangesetKindIssue,
+				PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			},
+			// issues can't be drafts
+			wantOperations: Operations{},
+		},
+		{
+			name:         "title changed on published issue",
+			previousSpec: &bt.TestSpecOpts{Published: true, Title: "Before"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Title: "After"},
+			changeset: bt.TestChangesetOpts{
+				Kind:             btypes.ChangesetKindIssue,
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
 		{
 			name:         "closing",
 			previousSpec: &bt.TestSpecOpts{Published: true},
@@ -460,6 +542,56 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 	}
 }
 
+func TestPlan_HoldBackPublication(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name           string
+		ops            Operations
+		wantPublishes  bool
+		wantOperations Operations
+	}{
+		{
+			name:           "publish",
+			ops:            Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantPublishes:  true,
+			wantOperations: Operations{},
+		},
+		{
+			name:           "publish as draft",
+			ops:            Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublishDraft},
+			wantPublishes:  true,
+			wantOperations: Operations{},
+		},
+		{
+			name:           "reattach and publish",
+			ops:            Operations{btypes.ReconcilerOperationReattach, btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantPublishes:  true,
+			wantOperations: Operations{btypes.ReconcilerOperationReattach},
+		},
+		{
+			name:           "update",
+			ops:            Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationUpdate},
+			wantPublishes:  false,
+			wantOperations: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationUpdate},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := &Plan{Ops: tc.ops}
+			if have := plan.Publishes(); have != tc.wantPublishes {
+				t.Fatalf("wrong Publishes. want=%t, have=%t", tc.wantPublishes, have)
+			}
+
+			plan.HoldBackPublication()
+			if have, want := plan.Ops, tc.wantOperations; !have.Equal(want) {
+				t.Fatalf("incorrect plan after holding back publication, want=%v have=%v", want, have)
+			}
+		})
+	}
+}
+
 func uiPublicationStatePtr(state btypes.ChangesetUiPublicationState) *btypes.ChangesetUiPublicationState {
 	return &state
 }
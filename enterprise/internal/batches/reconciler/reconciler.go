@@ -84,6 +84,20 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return err
 	}
 
+	// Changesets of a later rollout stage are held back until the changesets
+	// of the earlier stages are done. Once they are, the changesets are
+	// enqueued again by the rollout stager.
+	if curr != nil && curr.RolloutStage > 1 && plan.Publishes() {
+		released, err := rolloutStageReleased(ctx, tx, curr)
+		if err != nil {
+			return err
+		}
+		if !released {
+			logger.Info("Holding back changeset of later rollout stage", log.Int64("changeset", ch.ID), log.Int32("stage", curr.RolloutStage))
+			plan.HoldBackPublication()
+		}
+	}
+
 	logger.Info("Reconciler processing changeset", log.Int64("changeset", ch.ID), log.String("operations", fmt.Sprintf("%+v", plan.Ops)))
 
 	return executePlan(
@@ -112,3 +126,24 @@ func loadChangesetSpecs(ctx context.Context, tx *store.Store, ch *btypes.Changes
 	}
 	return
 }
+
+// rolloutStageReleased returns whether the changesets of the rollout stage of
+// the given changeset spec may be published, because all changesets of the
+// earlier stages are done.
+func rolloutStageReleased(ctx context.Context, tx *store.Store, spec *btypes.ChangesetSpec) (bool, error) {
+	batchSpec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: spec.BatchSpecID})
+	if err != nil {
+		return false, err
+	}
+	rollout := batchSpec.Rollout()
+	if rollout == nil {
+		return true, nil
+	}
+
+	stages, err := tx.ListRolloutStages(ctx, batchSpec.ID, rollout.WaitForOrDefault())
+	if err != nil {
+		return false, err
+	}
+
+	return stages.Released(spec.RolloutStage), nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 func TestReconcilerProcess_IntegrationTest(t *testing.T) {
@@ -169,3 +170,122 @@ func TestReconcilerProcess_IntegrationTest(t *testing.T) {
 		bt.TruncateTables(t, db, "changeset_events", "changesets", "batch_changes", "batch_specs", "changeset_specs")
 	}
 }
+
+func TestReconcilerProcess_RolloutStages(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	store := store.New(db, &observation.TestContext, nil)
+
+	admin := bt.CreateTestUser(t, db, true)
+
+	repos, extSvc := bt.CreateTestRepos(t, ctx, db, 2)
+	bt.CreateTestSiteCredential(t, store, repos[1])
+
+	state := bt.MockChangesetSyncState(&protocol.RepoInfo{
+		Name: repos[1].Name,
+		VCS:  protocol.VCSInfo{URL: repos[1].URI},
+	})
+	defer state.Unmock()
+
+	internalClient = &mockInternalClient{externalURL: "https://sourcegraph.test"}
+	defer func() { internalClient = internalapi.Client }()
+
+	githubPR := buildGithubPR(time.Now(), btypes.ChangesetExternalStateOpen)
+
+	for name, tc := range map[string]struct {
+		firstStageState btypes.ChangesetExternalState
+		wantPublished   bool
+	}{
+		"earlier stage not done": {
+			firstStageState: btypes.ChangesetExternalStateOpen,
+			wantPublished:   false,
+		},
+		"earlier stage done": {
+			firstStageState: btypes.ChangesetExternalStateMerged,
+			wantPublished:   true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			batchSpec := bt.CreateBatchSpec(t, ctx, store, "reconciler-rollout", admin.ID, 0)
+			batchSpec.Spec.ChangesetTemplate.Rollout = &batcheslib.Rollout{
+				WaitFor: batcheslib.RolloutWaitForMerged,
+				Stages: []batcheslib.RolloutStage{
+					{Repositories: []string{string(repos[0].Name)}},
+				},
+			}
+			if err := store.UpdateBatchSpec(ctx, batchSpec); err != nil {
+				t.Fatal(err)
+			}
+			batchChange := bt.CreateBatchChange(t, ctx, store, "reconciler-rollout", admin.ID, batchSpec.ID)
+
+			specs := make([]*btypes.ChangesetSpec, 0, len(repos))
+			for i, repo := range repos {
+				specs = append(specs, bt.CreateChangesetSpec(t, ctx, store, bt.TestSpecOpts{
+					User:         admin.ID,
+					Repo:         repo.ID,
+					BatchSpec:    batchSpec.ID,
+					HeadRef:      "refs/heads/head-ref-on-github",
+					Typ:          btypes.ChangesetSpecTypeBranch,
+					Published:    true,
+					RolloutStage: int32(i + 1),
+				}))
+			}
+
+			bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+				Repo:               repos[0].ID,
+				BatchChange:        batchChange.ID,
+				OwnedByBatchChange: batchChange.ID,
+				CurrentSpec:        specs[0].ID,
+				PublicationState:   btypes.ChangesetPublicationStatePublished,
+				ExternalID:         "12345",
+				ExternalState:      tc.firstStageState,
+				ReconcilerState:    btypes.ReconcilerStateCompleted,
+			})
+			changeset := bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+				Repo:               repos[1].ID,
+				BatchChange:        batchChange.ID,
+				OwnedByBatchChange: batchChange.ID,
+				CurrentSpec:        specs[1].ID,
+				PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+				ReconcilerState:    btypes.ReconcilerStateProcessing,
+			})
+
+			fakeSource := &stesting.FakeChangesetSource{
+				Svc:          extSvc,
+				FakeMetadata: githubPR,
+				WantHeadRef:  specs[1].HeadRef,
+				WantBaseRef:  specs[1].BaseRef,
+			}
+			rec := Reconciler{
+				noSleepBeforeSync: true,
+				gitserverClient:   &bt.FakeGitserverClient{Response: specs[1].HeadRef},
+				sourcer:           stesting.NewFakeSourcer(nil, fakeSource),
+				store:             store,
+			}
+			if err := rec.process(ctx, logger, store, changeset); err != nil {
+				t.Fatalf("reconciler process failed: %s", err)
+			}
+
+			if fakeSource.CreateChangesetCalled != tc.wantPublished {
+				t.Fatalf("wrong CreateChangesetCalled. want=%t, have=%t", tc.wantPublished, fakeSource.CreateChangesetCalled)
+			}
+
+			reloaded, err := store.GetChangesetByID(ctx, changeset.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reloaded.Published() != tc.wantPublished {
+				t.Fatalf("wrong publication state. want published=%t, have=%s", tc.wantPublished, reloaded.PublicationState)
+			}
+		})
+
+		// Clean up database.
+		bt.TruncateTables(t, db, "changeset_events", "changesets", "batch_changes", "batch_specs", "changeset_specs")
+	}
+}
@@ -71,13 +71,13 @@ func (r *ChangesetRewirer) Rewire() (changesets []*btypes.Changeset, err error)
 			changeset = m.Changeset
 			if spec.Type == btypes.ChangesetSpecTypeExisting {
 				r.attachTrackingChangeset(changeset)
-			} else if spec.Type == btypes.ChangesetSpecTypeBranch {
+			} else if spec.Type == btypes.ChangesetSpecTypeBranch || spec.Type == btypes.ChangesetSpecTypeIssue {
 				r.updateChangesetToNewSpec(changeset, spec)
 			}
 		} else {
 			if spec.Type == btypes.ChangesetSpecTypeExisting {
-				changeset = r.createTrackingChangeset(repo, spec.ExternalID)
-			} else if spec.Type == btypes.ChangesetSpecTypeBranch {
+				changeset = r.createTrackingChangeset(repo, spec.ExternalID, spec.Kind)
+			} else if spec.Type == btypes.ChangesetSpecTypeBranch || spec.Type == btypes.ChangesetSpecTypeIssue {
 				changeset = r.createChangesetForSpec(repo, spec)
 			}
 		}
@@ -91,6 +91,7 @@ func (r *ChangesetRewirer) createChangesetForSpec(repo *types.Repo, spec *btypes
 	newChangeset := &btypes.Changeset{
 		RepoID:              spec.BaseRepoID,
 		ExternalServiceType: repo.ExternalRepo.ServiceType,
+		Kind:                spec.Kind,
 
 		BatchChanges:         []btypes.BatchChangeAssoc{{BatchChangeID: r.batchChangeID}},
 		OwnedByBatchChangeID: r.batchChangeID,
@@ -122,10 +123,11 @@ func (r *ChangesetRewirer) updateChangesetToNewSpec(c *btypes.Changeset, spec *b
 	c.ResetReconcilerState(global.DefaultReconcilerEnqueueState())
 }
 
-func (r *ChangesetRewirer) createTrackingChangeset(repo *types.Repo, externalID string) *btypes.Changeset {
+func (r *ChangesetRewirer) createTrackingChangeset(repo *types.Repo, externalID string, kind btypes.ChangesetKind) *btypes.Changeset {
 	newChangeset := &btypes.Changeset{
 		RepoID:              repo.ID,
 		ExternalServiceType: repo.ExternalRepo.ServiceType,
+		Kind:                kind,
 
 		BatchChanges: []btypes.BatchChangeAssoc{{BatchChangeID: r.batchChangeID}},
 		ExternalID:   externalID,
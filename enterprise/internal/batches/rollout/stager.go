@@ -0,0 +1,72 @@
+package rollout
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const stageInterval = 1 * time.Minute
+
+// NewStager creates a new goroutine.PeriodicGoroutine that enqueues the
+// changesets of later rollout stages for publication, once the changesets of
+// the earlier stages have reached the state the rollout waits for.
+func NewStager(ctx context.Context, logger log.Logger, s *store.Store) goroutine.BackgroundRoutine {
+	st := &stager{logger: logger, store: s}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		stageInterval,
+		goroutine.NewHandlerWithErrorMessage("releasing rollout stages of batch changes", st.run),
+	)
+}
+
+type stager struct {
+	logger log.Logger
+	store  *store.Store
+}
+
+func (st *stager) run(ctx context.Context) error {
+	specs, err := st.store.ListBatchSpecsWithRolloutStages(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing batch specs with rollout stages")
+	}
+
+	var errs error
+	for _, spec := range specs {
+		if err := st.releaseStages(ctx, spec); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "batch spec %d", spec.ID))
+		}
+	}
+	return errs
+}
+
+// releaseStages enqueues the held back changesets of all rollout stages of the
+// given batch spec that have been released.
+func (st *stager) releaseStages(ctx context.Context, spec *btypes.BatchSpec) error {
+	rollout := spec.Rollout()
+	if rollout == nil {
+		return nil
+	}
+
+	stages, err := st.store.ListRolloutStages(ctx, spec.ID, rollout.WaitForOrDefault())
+	if err != nil {
+		return errors.Wrap(err, "listing rollout stages")
+	}
+
+	count, err := st.store.EnqueueReleasedRolloutChangesets(ctx, spec.ID, stages.LastReleased(), global.DefaultReconcilerEnqueueState())
+	if err != nil {
+		return errors.Wrap(err, "enqueueing changesets")
+	}
+	if count > 0 {
+		st.logger.Info("released changesets of rollout stage", log.Int64("batchSpecID", spec.ID), log.Int("count", count))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestStager(t *testing.T) {
+	logger := logtest.Scoped(t)
+
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	bstore := store.NewWithClock(db, &observation.TestContext, nil, timeutil.Now)
+
+	user := bt.CreateTestUser(t, db, true)
+	repos, _ := bt.CreateTestRepos(t, ctx, db, 3)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, bstore, "test-rollout", user.ID, 0)
+	batchSpec.Spec.ChangesetTemplate.Rollout = &batcheslib.Rollout{
+		WaitFor: batcheslib.RolloutWaitForMerged,
+		Stages: []batcheslib.RolloutStage{
+			{Repositories: []string{string(repos[0].Name)}},
+			{Repositories: []string{string(repos[1].Name)}},
+		},
+	}
+	if err := bstore.UpdateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+	batchChange := bt.CreateBatchChange(t, ctx, bstore, "test-rollout", user.ID, batchSpec.ID)
+
+	st := &stager{logger: logger, store: bstore}
+
+	changesets := make([]*btypes.Changeset, 0, len(repos))
+	for i, repo := range repos {
+		spec := bt.CreateChangesetSpec(t, ctx, bstore, bt.TestSpecOpts{
+			User:         user.ID,
+			Repo:         repo.ID,
+			BatchSpec:    batchSpec.ID,
+			HeadRef:      "refs/heads/test-rollout",
+			Published:    true,
+			Typ:          btypes.ChangesetSpecTypeBranch,
+			RolloutStage: int32(i + 1),
+		})
+
+		opts := bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			CurrentSpec:        spec.ID,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+		}
+		if i == 0 {
+			opts.PublicationState = btypes.ChangesetPublicationStatePublished
+			opts.ExternalState = btypes.ChangesetExternalStateOpen
+		}
+		changesets = append(changesets, bt.CreateChangeset(t, ctx, bstore, opts))
+	}
+
+	assertReconcilerStates := func(t *testing.T, want ...btypes.ReconcilerState) {
+		t.Helper()
+
+		for i, c := range changesets {
+			have, err := bstore.GetChangesetByID(ctx, c.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.ReconcilerState != want[i] {
+				t.Fatalf("changeset %d: wrong reconciler state. want=%s, have=%s", i, want[i], have.ReconcilerState)
+			}
+		}
+	}
+
+	// The changeset of the first stage hasn't been merged yet, so nothing is
+	// released.
+	if err := st.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assertReconcilerStates(t, btypes.ReconcilerStateCompleted, btypes.ReconcilerStateCompleted, btypes.ReconcilerStateCompleted)
+
+	changesets[0].ExternalState = btypes.ChangesetExternalStateMerged
+	if err := bstore.UpdateChangeset(ctx, changesets[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	// Now the second stage is released, but not the third one.
+	if err := st.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assertReconcilerStates(t, btypes.ReconcilerStateCompleted, btypes.ReconcilerStateQueued, btypes.ReconcilerStateCompleted)
+}
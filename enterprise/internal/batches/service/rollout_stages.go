@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// resolveRolloutStages returns the 1-based rollout stage of every repository
+// matched by a stage of the given rollout. If a repository is matched by more
+// than one stage, the earliest stage is used. Repositories that aren't matched
+// by any stage are published in the returned defaultStage, after all other
+// stages.
+func (s *Service) resolveRolloutStages(ctx context.Context, rollout *batcheslib.Rollout) (stages map[api.RepoID]int32, defaultStage int32, err error) {
+	stages = make(map[api.RepoID]int32)
+	setStage := func(id api.RepoID, stage int32) {
+		if _, ok := stages[id]; !ok {
+			stages[id] = stage
+		}
+	}
+
+	for i, rs := range rollout.Stages {
+		stage := int32(i + 1)
+
+		if len(rs.Repositories) > 0 {
+			// 🚨 SECURITY: database.Repos.List only returns the repositories
+			// the current user has access to.
+			repos, err := s.store.Repos().List(ctx, database.ReposListOptions{Names: rs.Repositories})
+			if err != nil {
+				return nil, 0, errors.Wrapf(err, "resolving repositories of rollout stage %d", stage)
+			}
+			for _, repo := range repos {
+				setStage(repo.ID, stage)
+			}
+		}
+
+		if rs.RepositoriesMatchingQuery != "" {
+			revs, err := s.newWorkspaceResolver(s.store).ResolveRepositoriesMatchingQuery(ctx, rs.RepositoriesMatchingQuery)
+			if err != nil {
+				return nil, 0, errors.Wrapf(err, "resolving repositories of rollout stage %d", stage)
+			}
+			for _, rev := range revs {
+				setStage(rev.Repo.ID, stage)
+			}
+		}
+	}
+
+	return stages, int32(len(rollout.Stages) + 1), nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -28,6 +29,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 // ErrNameNotUnique is returned by CreateEmptyBatchChange if the combination of name and
@@ -49,8 +51,9 @@ func NewWithClock(store *store.Store, clock func() time.Time) *Service {
 		sourcer: sources.NewSourcer(httpcli.NewExternalClientFactory(
 			httpcli.NewLoggingMiddleware(logger.Scoped("sourcer", "batches sourcer")),
 		)),
-		clock:      clock,
-		operations: newOperations(store.ObservationContext()),
+		newWorkspaceResolver: NewWorkspaceResolver,
+		clock:                clock,
+		operations:           newOperations(store.ObservationContext()),
 	}
 
 	return svc
@@ -62,6 +65,10 @@ type Service struct {
 	sourcer    sources.Sourcer
 	operations *operations
 	clock      func() time.Time
+
+	// newWorkspaceResolver is used to resolve the repositories matched by the
+	// search queries of rollout stages.
+	newWorkspaceResolver WorkspaceResolverBuilder
 }
 
 type operations struct {
@@ -79,6 +86,8 @@ type operations struct {
 	moveBatchChange                      *observation.Operation
 	closeBatchChange                     *observation.Operation
 	deleteBatchChange                    *observation.Operation
+	setBatchChangeMergePolicy            *observation.Operation
+	deleteBatchChangeMergePolicy         *observation.Operation
 	enqueueChangesetSync                 *observation.Operation
 	reenqueueChangeset                   *observation.Operation
 	checkNamespaceAccess                 *observation.Operation
@@ -129,6 +138,8 @@ func newOperations(observationContext *observation.Context) *operations {
 			moveBatchChange:                      op("MoveBatchChange"),
 			closeBatchChange:                     op("CloseBatchChange"),
 			deleteBatchChange:                    op("DeleteBatchChange"),
+			setBatchChangeMergePolicy:            op("SetBatchChangeMergePolicy"),
+			deleteBatchChangeMergePolicy:         op("DeleteBatchChangeMergePolicy"),
 			enqueueChangesetSync:                 op("EnqueueChangesetSync"),
 			reenqueueChangeset:                   op("ReenqueueChangeset"),
 			checkNamespaceAccess:                 op("CheckNamespaceAccess"),
@@ -147,7 +158,7 @@ func newOperations(observationContext *observation.Context) *operations {
 // WithStore returns a copy of the Service with its store attribute set to the
 // given Store.
 func (s *Service) WithStore(store *store.Store) *Service {
-	return &Service{logger: s.logger, store: store, sourcer: s.sourcer, clock: s.clock, operations: s.operations}
+	return &Service{logger: s.logger, store: store, sourcer: s.sourcer, clock: s.clock, operations: s.operations, newWorkspaceResolver: s.newWorkspaceResolver}
 }
 
 type CreateEmptyBatchChangeOpts struct {
@@ -986,6 +997,79 @@ func (s *Service) DeleteBatchChange(ctx context.Context, id int64) (err error) {
 	return s.store.DeleteBatchChange(ctx, id)
 }
 
+type SetBatchChangeMergePolicyOpts struct {
+	BatchChangeID     int64
+	MergeMethod       btypes.MergePolicyMethod
+	RequiredApprovals int32
+	Windows           []*schema.BatchChangeRolloutWindow
+	MaxMergesPerHour  int32
+}
+
+// SetBatchChangeMergePolicy creates or replaces the merge policy of the batch
+// change with the given ID. The changesets of the batch change are merged on
+// behalf of the user that sets the policy.
+func (s *Service) SetBatchChangeMergePolicy(ctx context.Context, opts SetBatchChangeMergePolicyOpts) (policy *btypes.BatchChangeMergePolicy, err error) {
+	ctx, _, endObservation := s.operations.setBatchChangeMergePolicy.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: opts.BatchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return nil, err
+	}
+
+	if !opts.MergeMethod.Valid() {
+		return nil, errors.Errorf("invalid merge method %q", opts.MergeMethod)
+	}
+	if opts.RequiredApprovals < 0 {
+		return nil, errors.New("required approvals must not be negative")
+	}
+	if opts.MaxMergesPerHour < 0 {
+		return nil, errors.New("max merges per hour must not be negative")
+	}
+	if _, err := window.NewConfiguration(&opts.Windows); err != nil {
+		return nil, errors.Wrap(err, "invalid merge windows")
+	}
+
+	policy = &btypes.BatchChangeMergePolicy{
+		BatchChangeID:     batchChange.ID,
+		MergeMethod:       opts.MergeMethod,
+		RequiredApprovals: opts.RequiredApprovals,
+		Windows:           opts.Windows,
+		MaxMergesPerHour:  opts.MaxMergesPerHour,
+		CreatorID:         actor.FromContext(ctx).UID,
+	}
+	if err := s.store.UpsertBatchChangeMergePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// DeleteBatchChangeMergePolicy deletes the merge policy of the batch change
+// with the given ID, if it has one.
+func (s *Service) DeleteBatchChangeMergePolicy(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchChangeMergePolicy.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "getting batch change")
+	}
+
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteBatchChangeMergePolicy(ctx, batchChange.ID); err != nil && err != store.ErrNoResults {
+		return err
+	}
+	return nil
+}
+
 // EnqueueChangesetSync loads the given changeset from the database, checks
 // whether the actor in the context has permission to enqueue a sync and then
 // enqueues a sync by calling the repoupdater client.
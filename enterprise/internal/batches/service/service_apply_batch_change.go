@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/locker"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -95,6 +96,17 @@ func (s *Service) ApplyBatchChange(
 		return batchChange, nil
 	}
 
+	// Resolve the rollout stages before opening the transaction, since that
+	// can involve running search queries.
+	var rolloutStages map[api.RepoID]int32
+	var defaultRolloutStage int32
+	if rollout := batchSpec.Rollout(); rollout != nil {
+		rolloutStages, defaultRolloutStage, err = s.resolveRolloutStages(ctx, rollout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Before we write to the database in a transaction, we cancel all
 	// currently enqueued/errored-and-retryable changesets the batch change might
 	// have.
@@ -131,6 +143,12 @@ func (s *Service) ApplyBatchChange(
 		}
 	}
 
+	if rolloutStages != nil {
+		if err := tx.SetChangesetSpecRolloutStages(ctx, batchSpec.ID, rolloutStages, defaultRolloutStage); err != nil {
+			return nil, err
+		}
+	}
+
 	// Now we need to wire up the ChangesetSpecs of the new BatchSpec
 	// correctly with the Changesets so that the reconciler can create/update
 	// them.
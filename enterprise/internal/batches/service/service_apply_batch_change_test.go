@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -1182,6 +1183,50 @@ func TestServiceApplyBatchChange(t *testing.T) {
 		})
 	})
 
+	t.Run("batch spec with rollout stages", func(t *testing.T) {
+		bt.TruncateTables(t, db, "changeset_events", "changesets", "batch_changes", "batch_specs", "changeset_specs")
+
+		// The search query of the second stage also matches the repository
+		// of the first stage, which stays in the first stage.
+		svc := New(store)
+		svc.newWorkspaceResolver = newFakeRolloutWorkspaceResolver([]*RepoRevision{{Repo: repos[0]}, {Repo: repos[1]}})
+
+		batchSpec := bt.CreateBatchSpec(t, ctx, store, "rollout", admin.ID, 0)
+		batchSpec.Spec.ChangesetTemplate.Rollout = &batcheslib.Rollout{
+			Stages: []batcheslib.RolloutStage{
+				{Repositories: []string{string(repos[0].Name)}},
+				{RepositoriesMatchingQuery: "repo:rollout"},
+			},
+		}
+		if err := store.UpdateBatchSpec(ctx, batchSpec); err != nil {
+			t.Fatal(err)
+		}
+
+		specs := make([]*btypes.ChangesetSpec, 0, len(repos))
+		for _, repo := range repos {
+			specs = append(specs, bt.CreateChangesetSpec(t, ctx, store, bt.TestSpecOpts{
+				User:      admin.ID,
+				Repo:      repo.ID,
+				BatchSpec: batchSpec.ID,
+				HeadRef:   "refs/heads/rollout",
+				Published: true,
+				Typ:       btypes.ChangesetSpecTypeBranch,
+			}))
+		}
+
+		applyAndListChangesets(adminCtx, t, svc, batchSpec.RandID, len(repos))
+
+		for i, want := range []int32{1, 2, 3, 3} {
+			spec, err := store.GetChangesetSpecByID(ctx, specs[i].ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if spec.RolloutStage != want {
+				t.Fatalf("spec %d: wrong rollout stage. want=%d, have=%d", i, want, spec.RolloutStage)
+			}
+		}
+	})
+
 	t.Run("applying to closed batch change", func(t *testing.T) {
 		bt.TruncateTables(t, db, "changeset_events", "changesets", "batch_changes", "batch_specs", "changeset_specs")
 		batchSpec := bt.CreateBatchSpec(t, ctx, store, "closed-batch-change", admin.ID, 0)
@@ -1229,3 +1274,21 @@ func applyAndListChangesets(ctx context.Context, t *testing.T, svc *Service, bat
 
 	return batchChange, changesets
 }
+
+func newFakeRolloutWorkspaceResolver(repos []*RepoRevision) WorkspaceResolverBuilder {
+	return func(*store.Store) WorkspaceResolver {
+		return &fakeRolloutWorkspaceResolver{repos: repos}
+	}
+}
+
+type fakeRolloutWorkspaceResolver struct {
+	repos []*RepoRevision
+}
+
+func (r *fakeRolloutWorkspaceResolver) ResolveWorkspacesForBatchSpec(context.Context, *batcheslib.BatchSpec) ([]*RepoWorkspace, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *fakeRolloutWorkspaceResolver) ResolveRepositoriesMatchingQuery(context.Context, string) ([]*RepoRevision, error) {
+	return r.repos, nil
+}
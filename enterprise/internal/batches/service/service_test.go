@@ -33,6 +33,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestServicePermissionLevels(t *testing.T) {
@@ -128,6 +129,19 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("SetBatchChangeMergePolicy", func(t *testing.T) {
+				_, err := svc.SetBatchChangeMergePolicy(currentUserCtx, SetBatchChangeMergePolicyOpts{
+					BatchChangeID: batchChange.ID,
+					MergeMethod:   btypes.MergePolicyMethodMerge,
+				})
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("DeleteBatchChangeMergePolicy", func(t *testing.T) {
+				err := svc.DeleteBatchChangeMergePolicy(currentUserCtx, batchChange.ID)
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("DeleteBatchChange", func(t *testing.T) {
 				err := svc.DeleteBatchChange(currentUserCtx, batchChange.ID)
 				tc.assertFunc(t, err)
@@ -316,6 +330,76 @@ func TestService(t *testing.T) {
 		})
 	})
 
+	t.Run("SetBatchChangeMergePolicy", func(t *testing.T) {
+		spec := testBatchSpec(user.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := testBatchChange(user.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("invalid", func(t *testing.T) {
+			for name, opts := range map[string]SetBatchChangeMergePolicyOpts{
+				"merge method":        {MergeMethod: "REBASE"},
+				"required approvals":  {MergeMethod: btypes.MergePolicyMethodMerge, RequiredApprovals: -1},
+				"max merges per hour": {MergeMethod: btypes.MergePolicyMethodMerge, MaxMergesPerHour: -1},
+				"windows": {
+					MergeMethod: btypes.MergePolicyMethodMerge,
+					Windows:     []*schema.BatchChangeRolloutWindow{{Days: []string{"caturday"}, Rate: "unlimited"}},
+				},
+			} {
+				t.Run(name, func(t *testing.T) {
+					opts.BatchChangeID = batchChange.ID
+					if _, err := svc.SetBatchChangeMergePolicy(userCtx, opts); err == nil {
+						t.Fatal("unexpected nil error")
+					}
+				})
+			}
+		})
+
+		t.Run("valid", func(t *testing.T) {
+			windows := []*schema.BatchChangeRolloutWindow{
+				{Days: []string{"monday"}, Start: "09:00", End: "17:00", Rate: "unlimited"},
+			}
+			policy, err := svc.SetBatchChangeMergePolicy(userCtx, SetBatchChangeMergePolicyOpts{
+				BatchChangeID:     batchChange.ID,
+				MergeMethod:       btypes.MergePolicyMethodSquash,
+				RequiredApprovals: 2,
+				Windows:           windows,
+				MaxMergesPerHour:  10,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			have, err := s.GetBatchChangeMergePolicy(ctx, batchChange.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, policy); diff != "" {
+				t.Fatal(diff)
+			}
+			if have.CreatorID != user.ID {
+				t.Fatalf("wrong creator. want=%d, have=%d", user.ID, have.CreatorID)
+			}
+
+			if err := svc.DeleteBatchChangeMergePolicy(userCtx, batchChange.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.GetBatchChangeMergePolicy(ctx, batchChange.ID); err != store.ErrNoResults {
+				t.Fatalf("merge policy not deleted: %v", err)
+			}
+
+			// Deleting a policy that doesn't exist is a noop.
+			if err := svc.DeleteBatchChangeMergePolicy(userCtx, batchChange.ID); err != nil {
+				t.Fatal(err)
+			}
+		})
+	})
+
 	t.Run("EnqueueChangesetSync", func(t *testing.T) {
 		spec := testBatchSpec(user.ID)
 		if err := s.CreateBatchSpec(ctx, spec); err != nil {
@@ -596,6 +680,7 @@ func TestService(t *testing.T) {
 			want := &btypes.ChangesetSpec{
 				ID:   5,
 				Type: btypes.ChangesetSpecTypeBranch,
+				Kind: btypes.ChangesetKindPullRequest,
 				Diff: []byte(`diff --git INSTALL.md INSTALL.md
 index e5af166..d44c3fc 100644
 --- INSTALL.md
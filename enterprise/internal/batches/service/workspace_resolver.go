@@ -66,6 +66,13 @@ type WorkspaceResolver interface {
 		workspaces []*RepoWorkspace,
 		err error,
 	)
+	ResolveRepositoriesMatchingQuery(
+		ctx context.Context,
+		query string,
+	) (
+		repos []*RepoRevision,
+		err error,
+	)
 }
 
 type WorkspaceResolverBuilder func(tx *store.Store) WorkspaceResolver
@@ -322,6 +329,12 @@ func (wr *workspaceResolver) resolveRepositoryNameAndBranch(ctx context.Context,
 	}, nil
 }
 
+// ResolveRepositoriesMatchingQuery returns the repositories matched by the
+// given search query that the current user has access to.
+func (wr *workspaceResolver) ResolveRepositoriesMatchingQuery(ctx context.Context, query string) ([]*RepoRevision, error) {
+	return wr.resolveRepositoriesMatchingQuery(ctx, query)
+}
+
 func (wr *workspaceResolver) resolveRepositoriesMatchingQuery(ctx context.Context, query string) (_ []*RepoRevision, err error) {
 	tr, ctx := trace.New(ctx, "workspaceResolver.resolveRepositorySearch", "")
 	defer func() {
@@ -49,6 +49,39 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A PushRefChangesetSource is the source of a code host, such as Gerrit, that
+// creates and updates changesets from pushes to a ref other than their head ref.
+type PushRefChangesetSource interface {
+	ChangesetSource
+
+	// PreparePush adapts the request that creates and pushes the commit of the
+	// given changeset, for example to push it to a different ref.
+	PreparePush(opts *protocol.CreateCommitFromPatchRequest, ch *btypes.Changeset, spec *btypes.ChangesetSpec)
+	// IsUnchangedPushError parses the given error output from `git push` to
+	// detect whether the push was rejected because the changeset already has
+	// the pushed commit.
+	IsUnchangedPushError(output string) bool
+}
+
+// An IssueChangesetSource can load, create and update issues tracked as
+// changesets.
+type IssueChangesetSource interface {
+	ChangesetSource
+
+	// LoadIssue loads the issue of the given Changeset from the source and
+	// updates it. If the issue could not be found on the source, a
+	// ChangesetNotFoundError is returned.
+	LoadIssue(context.Context, *Changeset) error
+	// CreateIssue will create the issue of the given Changeset on the source.
+	CreateIssue(context.Context, *Changeset) error
+	// UpdateIssue updates the title and body of the issue on the source.
+	UpdateIssue(context.Context, *Changeset) error
+	// CloseIssue will close the issue on the source.
+	CloseIssue(context.Context, *Changeset) error
+	// ReopenIssue will reopen the issue on the source, if it's closed.
+	ReopenIssue(context.Context, *Changeset) error
+}
+
 type ForkableChangesetSource interface {
 	ChangesetSource
 
@@ -154,6 +187,11 @@ func (c *Changeset) IsOutdated() (bool, error) {
 		return true, nil
 	}
 
+	// Issues don't have a base branch.
+	if c.Changeset.IsIssue() {
+		return false, nil
+	}
+
 	currentBaseRef, err := c.Changeset.BaseRef()
 	if err != nil {
 		return false, err
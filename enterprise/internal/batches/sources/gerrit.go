@@ -0,0 +1,331 @@
+package sources
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is the changeset source of Gerrit code hosts. Gerrit has no
+// pull requests: a changeset is a change, which is created and updated by
+// pushing a commit with a Change-Id footer to refs/for/<base branch>. The
+// reconciler does that by way of PreparePush, so that CreateChangeset only
+// needs to look up the change and sync its commit message.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var (
+	_ DraftChangesetSource   = GerritSource{}
+	_ PushRefChangesetSource = GerritSource{}
+)
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(ctx context.Context, store database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(ctx, store, repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+	return &GerritSource{client: client}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedAccount(ctx)
+	return err
+}
+
+// PreparePush adds the Change-Id footer of the changeset to the commit message
+// and pushes the commit to refs/for/<base branch>, which creates the change or
+// a new patch set of it.
+func (s GerritSource) PreparePush(opts *protocol.CreateCommitFromPatchRequest, ch *btypes.Changeset, spec *btypes.ChangesetSpec) {
+	changeID := gerritbatches.ChangeID(string(opts.Repo), ch.ID)
+	opts.CommitInfo.Message = strings.TrimSpace(opts.CommitInfo.Message) + "\n\nChange-Id: " + changeID + "\n"
+
+	pushRef := gerritbatches.PushRef(spec.BaseRef, spec.HeadRef)
+	opts.PushRef = &pushRef
+}
+
+// IsUnchangedPushError returns true if Gerrit rejected a push because the
+// change already has the pushed commit as a patch set.
+func (s GerritSource) IsUnchangedPushError(output string) bool {
+	return strings.Contains(output, "no new changes")
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	project, err := gerritProjectName(cs.TargetRepo)
+	if err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(cs.ExternalID)
+	if err != nil {
+		return errors.Wrapf(err, "converting external ID %q", cs.ExternalID)
+	}
+
+	change, err := s.client.GetChange(ctx, gerrit.ChangeIdentifier(project, number))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+//
+// The change is created by pushing its commit, so it must exist at this point;
+// its commit message is updated to the title and body of the changeset. The
+// push creates a change with the Change-Id of the changeset with one patch
+// set, so a change with more patch sets already existed before the push, for
+// example because publishing the changeset is retried.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	project, err := gerritProjectName(cs.TargetRepo)
+	if err != nil {
+		return false, err
+	}
+	changeID := gerritbatches.ChangeID(string(cs.TargetRepo.Name), cs.Changeset.ID)
+
+	change, err := s.client.GetChange(ctx, gerrit.ChangeIdentifierForBranch(project, cs.BaseRef, changeID))
+	if err != nil {
+		return false, errors.Wrap(err, "getting change created by push")
+	}
+	exists := change.Revisions[change.CurrentRevision].Number > 1
+
+	change, err = s.syncCommitMessage(ctx, change, cs)
+	if err != nil {
+		return exists, err
+	}
+
+	return exists, s.setChangesetMetadata(change, cs)
+}
+
+// CreateDraftChangeset creates the given changeset on the code host in draft
+// mode, which is a work in progress change on Gerrit.
+func (s GerritSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	exists, err := s.CreateChangeset(ctx, cs)
+	if err != nil {
+		return exists, err
+	}
+
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if change.WorkInProgress {
+		return exists, nil
+	}
+	if err := s.client.SetWorkInProgress(ctx, change.Identifier()); err != nil {
+		return exists, errors.Wrap(err, "marking change as work in progress")
+	}
+
+	return exists, s.LoadChangeset(ctx, cs)
+}
+
+// UndraftChangeset marks the change of the given changeset as ready for
+// review.
+func (s GerritSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if err := s.client.SetReadyForReview(ctx, change.Identifier()); err != nil {
+		return errors.Wrap(err, "marking change as ready for review")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Gerrit).
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if change.Status == gerrit.ChangeStatusNew {
+		if err := s.client.AbandonChange(ctx, change.Identifier()); err != nil {
+			return errors.Wrap(err, "abandoning change")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange).Change
+
+	var err error
+	if base := gitdomain.AbbreviateRef(cs.BaseRef); change.Branch != base {
+		change, err = s.moveChange(ctx, change, base)
+		if err != nil {
+			return err
+		}
+	}
+
+	change, err = s.syncCommitMessage(ctx, change, cs)
+	if err != nil {
+		return err
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if change.Status == gerrit.ChangeStatusAbandoned {
+		if err := s.client.RestoreChange(ctx, change.Identifier()); err != nil {
+			return errors.Wrap(err, "restoring change")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	return s.client.SetReview(ctx, change.Identifier(), gerrit.ReviewInput{Message: comment})
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// The squash parameter is ignored, as Gerrit submits changes with the submit
+// type of their project, and a change is a single commit anyway. If the
+// changeset cannot be merged, because it is in an unmergeable state,
+// ChangesetNotMergeableError must be returned.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	if err := s.client.SubmitChange(ctx, change.Identifier()); err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "submitting change")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// moveChange moves the change to the given base branch. Pushing the commit of
+// the changeset to the new base branch creates a new change there, in which
+// case that change replaces the old one, which is abandoned.
+func (s GerritSource) moveChange(ctx context.Context, change *gerrit.Change, base string) (*gerrit.Change, error) {
+	moved, err := s.client.GetChange(ctx, gerrit.ChangeIdentifierForBranch(change.Project, base, change.ChangeID))
+	if err == nil {
+		if change.Status == gerrit.ChangeStatusNew {
+			if err := s.client.AbandonChange(ctx, change.Identifier()); err != nil {
+				return nil, errors.Wrap(err, "abandoning change on previous base branch")
+			}
+		}
+		return moved, nil
+	} else if !errcode.IsNotFound(err) {
+		return nil, errors.Wrap(err, "getting change on new base branch")
+	}
+
+	if err := s.client.MoveChange(ctx, change.Identifier(), base); err != nil {
+		return nil, errors.Wrap(err, "moving change")
+	}
+	return s.client.GetChange(ctx, change.Identifier())
+}
+
+// syncCommitMessage updates the commit message of the change to the title and
+// body of the changeset, if they differ, and returns the updated change.
+func (s GerritSource) syncCommitMessage(ctx context.Context, change *gerrit.Change, cs *Changeset) (*gerrit.Change, error) {
+	annotated := &gerritbatches.AnnotatedChange{Change: change}
+	if change.Subject == strings.TrimSpace(cs.Title) && annotated.Body() == strings.TrimSpace(cs.Body) {
+		return change, nil
+	}
+
+	message := gerritbatches.CommitMessage(cs.Title, cs.Body, change.ChangeID)
+	if err := s.client.SetCommitMessage(ctx, change.Identifier(), message); err != nil {
+		return nil, errors.Wrap(err, "setting commit message")
+	}
+
+	updated, err := s.client.GetChange(ctx, change.Identifier())
+	if err != nil {
+		return nil, errors.Wrap(err, "getting change")
+	}
+	return updated, nil
+}
+
+func (s GerritSource) setChangesetMetadata(change *gerrit.Change, cs *Changeset) error {
+	if err := cs.SetMetadata(&gerritbatches.AnnotatedChange{
+		Change:      change,
+		CodeHostURL: s.client.URL.String(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// gerritProjectName returns the name of the Gerrit project of the given repo.
+// Gerrit encodes slashes in the IDs of projects.
+func gerritProjectName(repo *types.Repo) (string, error) {
+	project, ok := repo.Metadata.(*gerrit.Project)
+	if !ok {
+		return "", errors.Errorf("repo %q is not a Gerrit project", repo.Name)
+	}
+	return url.PathUnescape(project.ID)
+}
@@ -0,0 +1,102 @@
+package gerrit
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API alongside the change. This type is used as
+// the primary metadata type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	// CodeHostURL is the base URL of the Gerrit instance, which the Gerrit
+	// API doesn't return with changes.
+	CodeHostURL string `json:"code_host_url"`
+}
+
+// URL returns the URL of the change in the Gerrit web UI.
+func (c *AnnotatedChange) URL() string {
+	return strings.TrimSuffix(c.CodeHostURL, "/") + "/c/" + c.Project + "/+/" + strconv.Itoa(c.Number)
+}
+
+// CurrentRevisionInfo returns the current patch set of the change, or the zero
+// value if the change was loaded without it.
+func (c *AnnotatedChange) CurrentRevisionInfo() gerrit.Revision {
+	return c.Revisions[c.CurrentRevision]
+}
+
+// Body returns the commit message of the current patch set without its subject
+// and its Change-Id footer, which is what Sourcegraph shows as the body of a
+// changeset.
+func (c *AnnotatedChange) Body() string {
+	return ParseCommitMessage(c.CurrentRevisionInfo().Commit.Message)
+}
+
+// HeadRef returns the full ref of the head of the change. Changes created by
+// batch changes have the name of their branch as topic, and other changes are
+// identified by the ref of their current patch set.
+func (c *AnnotatedChange) HeadRef() string {
+	if c.Topic != "" {
+		return gitdomain.EnsureRefPrefix(c.Topic)
+	}
+	return c.CurrentRevisionInfo().Ref
+}
+
+// BaseRefOid returns the parent commit of the current patch set, or an empty
+// string if it's unknown.
+func (c *AnnotatedChange) BaseRefOid() string {
+	if parents := c.CurrentRevisionInfo().Commit.Parents; len(parents) > 0 {
+		return parents[0].Commit
+	}
+	return ""
+}
+
+var changeIDFooter = lazyregexp.New(`^Change-Id: I[0-9a-f]{40}\s*$`)
+
+// ParseCommitMessage returns the body of a commit message: the lines after
+// the subject, without the Change-Id footer.
+func ParseCommitMessage(message string) string {
+	_, body, _ := strings.Cut(message, "\n")
+
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if changeIDFooter.MatchString(lines[i]) {
+			lines = append(lines[:i], lines[i+1:]...)
+			break
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// CommitMessage returns the commit message of a change with the given title
+// and body, which identifies the change by the given Change-Id.
+func CommitMessage(title, body, changeID string) string {
+	message := strings.TrimSpace(title)
+	if body = strings.TrimSpace(body); body != "" {
+		message += "\n\n" + body
+	}
+	return message + "\n\nChange-Id: " + changeID + "\n"
+}
+
+// ChangeID returns the Change-Id of the change of the changeset with the given
+// ID. Gerrit identifies the changes of a branch by the Change-Id footer of
+// their commit message, so the ID must be the same for every push of a
+// changeset.
+func ChangeID(repoName string, changesetID int64) string {
+	sum := sha1.Sum([]byte(repoName + "\x00" + strconv.FormatInt(changesetID, 10)))
+	return "I" + hex.EncodeToString(sum[:])
+}
+
+// PushRef returns the ref that creates or updates a change on the given base
+// branch when a commit is pushed to it. The branch of the changeset is set as
+// the topic of the change.
+func PushRef(baseRef, headRef string) string {
+	return "refs/for/" + gitdomain.AbbreviateRef(baseRef) + "%topic=" + gitdomain.AbbreviateRef(headRef)
+}
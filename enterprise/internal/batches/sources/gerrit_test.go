@@ -0,0 +1,407 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// The test fixtures and golden files are recorded against gerrit.sgdev.org,
+// which has the project batch-changes/test-repo. To record them, set
+// GERRIT_USERNAME and GERRIT_PASSWORD to an account with an HTTP password and
+// run the tests with -update=GerritSource. Changes are created by pushing, so
+// push the commit with the Change-Id of the test changeset to
+// refs/for/main of the project first.
+const gerritInstanceURL = "https://gerrit.sgdev.org"
+
+func TestNewGerritSource(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, input := range map[string]string{
+			"invalid JSON":   "invalid JSON",
+			"invalid schema": `{"password": ["not a string"]}`,
+			"bad URL":        `{"url": "http://[::1]:namedport"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctx := context.Background()
+				s, err := NewGerritSource(ctx, &types.ExternalService{
+					Config: extsvc.NewUnencryptedConfig(input),
+				}, nil)
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := context.Background()
+		s, err := NewGerritSource(ctx, &types.ExternalService{Config: extsvc.NewEmptyConfig()}, nil)
+		assert.NotNil(t, s)
+		assert.Nil(t, err)
+	})
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	s := newGerritSourceForTest(t, nil)
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.OAuthBearerToken{},
+			&auth.OAuthBearerTokenWithSSH{},
+			&auth.OAuthClient{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, newSource)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+
+	t.Run("supported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.BasicAuth{Username: "user", Password: "pass"},
+			&auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "pass"}},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, err)
+				assert.Equal(t, &auth.BasicAuth{Username: "user", Password: "pass"}, newSource.(*GerritSource).client.Authenticator())
+			})
+		}
+	})
+}
+
+func TestGerritSource_PreparePush(t *testing.T) {
+	s := newGerritSourceForTest(t, nil)
+	repo := gerritTestRepo()
+
+	opts := protocol.CreateCommitFromPatchRequest{
+		Repo:       repo.Name,
+		TargetRef:  "refs/heads/batch-gerrit-readme",
+		CommitInfo: protocol.PatchCommitInfo{Message: "Update the README\n"},
+	}
+	s.PreparePush(&opts, &btypes.Changeset{ID: 1}, &btypes.ChangesetSpec{
+		BaseRef: "refs/heads/main",
+		HeadRef: "refs/heads/batch-gerrit-readme",
+	})
+
+	assert.Equal(t, "Update the README\n\nChange-Id: I5aa314e8900f760c019d3a77b0fdc8f284cacead\n", opts.CommitInfo.Message)
+	assert.Equal(t, "refs/for/main%topic=batch-gerrit-readme", *opts.PushRef)
+	// The ref on gitserver is still the head ref of the changeset.
+	assert.Equal(t, "refs/heads/batch-gerrit-readme", opts.TargetRef)
+
+	assert.True(t, s.IsUnchangedPushError(" ! [remote rejected] HEAD -> refs/for/main%topic=batch-gerrit-readme (no new changes)"))
+	assert.False(t, s.IsUnchangedPushError(" ! [remote rejected] HEAD -> refs/for/main (prohibited by Gerrit: not permitted: create change)"))
+}
+
+func TestGerritSource_LoadChangeset(t *testing.T) {
+	repo := gerritTestRepo()
+
+	testCases := []struct {
+		name string
+		cs   *Changeset
+		err  string
+	}{
+		{
+			name: "found",
+			cs:   &Changeset{RemoteRepo: repo, TargetRepo: repo, Changeset: &btypes.Changeset{ExternalID: "42"}},
+		},
+		{
+			name: "not-found",
+			cs:   &Changeset{RemoteRepo: repo, TargetRepo: repo, Changeset: &btypes.Changeset{ExternalID: "999"}},
+			err:  "Changeset with external ID 999 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GerritSource_LoadChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			cf, save := newClientFactory(t, tc.name)
+			defer save(t)
+
+			s := newGerritSourceForTest(t, cf)
+
+			err := s.LoadChangeset(context.Background(), tc.cs)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.Nil(t, err)
+
+			assert.Equal(t, "42", tc.cs.ExternalID)
+			assert.Equal(t, extsvc.TypeGerrit, tc.cs.ExternalServiceType)
+			assert.Equal(t, "refs/heads/batch-gerrit-readme", tc.cs.ExternalBranch)
+
+			testutil.AssertGolden(
+				t,
+				"testdata/golden/"+tc.name,
+				update(tc.name),
+				tc.cs.Changeset.Metadata.(*gerritbatches.AnnotatedChange),
+			)
+		})
+	}
+}
+
+func TestGerritSource_CreateChangeset(t *testing.T) {
+	repo := gerritTestRepo()
+
+	newChangeset := func() *Changeset {
+		return &Changeset{
+			Title:      "Update the README",
+			Body:       "This changeset updates the README.",
+			HeadRef:    "refs/heads/batch-gerrit-readme",
+			BaseRef:    "refs/heads/main",
+			RemoteRepo: repo,
+			TargetRepo: repo,
+			Changeset:  &btypes.Changeset{ID: 1},
+		}
+	}
+
+	t.Run("GerritSource_CreateChangeset_success", func(t *testing.T) {
+		name := "GerritSource_CreateChangeset_success"
+		cf, save := newClientFactory(t, name)
+		defer save(t)
+
+		s := newGerritSourceForTest(t, cf)
+		cs := newChangeset()
+
+		// The commit message of the pushed change has no body, so it is
+		// updated to the title and body of the changeset.
+		exists, err := s.CreateChangeset(context.Background(), cs)
+		assert.Nil(t, err)
+		assert.False(t, exists)
+
+		outdated, err := cs.IsOutdated()
+		assert.Nil(t, err)
+		assert.False(t, outdated)
+
+		testutil.AssertGolden(
+			t,
+			"testdata/golden/"+name,
+			update(name),
+			cs.Changeset.Metadata.(*gerritbatches.AnnotatedChange),
+		)
+	})
+
+	t.Run("GerritSource_CreateChangeset_not-pushed", func(t *testing.T) {
+		cf, save := newClientFactory(t, "GerritSource_CreateChangeset_not-pushed")
+		defer save(t)
+
+		s := newGerritSourceForTest(t, cf)
+
+		_, err := s.CreateChangeset(context.Background(), newChangeset())
+		assert.ErrorContains(t, err, "getting change created by push")
+	})
+}
+
+func TestGerritSource_CreateDraftChangeset(t *testing.T) {
+	cf, save := newClientFactory(t, "GerritSource_CreateDraftChangeset_success")
+	defer save(t)
+
+	s := newGerritSourceForTest(t, cf)
+	repo := gerritTestRepo()
+	cs := &Changeset{
+		Title:      "Update the README",
+		Body:       "This changeset updates the README.",
+		HeadRef:    "refs/heads/batch-gerrit-readme",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{ID: 1},
+	}
+
+	_, err := s.CreateDraftChangeset(context.Background(), cs)
+	assert.Nil(t, err)
+	assert.True(t, cs.Metadata.(*gerritbatches.AnnotatedChange).WorkInProgress)
+}
+
+func TestGerritSource_ChangesetActions(t *testing.T) {
+	repo := gerritTestRepo()
+
+	// newChangeset returns a changeset of the change as it was synced before
+	// the action.
+	newChangeset := func(status gerrit.ChangeStatus, wip bool) *Changeset {
+		cs := &Changeset{
+			Title:      "Update the README",
+			Body:       "This changeset updates the README.",
+			HeadRef:    "refs/heads/batch-gerrit-readme",
+			BaseRef:    "refs/heads/main",
+			RemoteRepo: repo,
+			TargetRepo: repo,
+			Changeset:  &btypes.Changeset{ID: 1},
+		}
+		if err := cs.SetMetadata(&gerritbatches.AnnotatedChange{
+			Change: &gerrit.Change{
+				Project:         "batch-changes/test-repo",
+				Branch:          "main",
+				Topic:           "batch-gerrit-readme",
+				ChangeID:        "I5aa314e8900f760c019d3a77b0fdc8f284cacead",
+				Subject:         "Update the README",
+				Status:          status,
+				WorkInProgress:  wip,
+				Number:          42,
+				CurrentRevision: "9f1e2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6",
+				Revisions: map[string]gerrit.Revision{
+					"9f1e2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6": {
+						Number: 2,
+						Ref:    "refs/changes/42/42/2",
+						Commit: gerrit.CommitInfo{
+							Subject: "Update the README",
+							Message: "Update the README\n\nThis changeset updates the README.\n\nChange-Id: I5aa314e8900f760c019d3a77b0fdc8f284cacead\n",
+						},
+					},
+				},
+			},
+			CodeHostURL: gerritInstanceURL,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return cs
+	}
+
+	for _, tc := range []struct {
+		name   string
+		cs     *Changeset
+		action func(context.Context, *GerritSource, *Changeset) error
+		want   gerrit.ChangeStatus
+		err    string
+	}{
+		{
+			name: "CloseChangeset_success",
+			cs:   newChangeset(gerrit.ChangeStatusNew, false),
+			action: func(ctx context.Context, s *GerritSource, cs *Changeset) error {
+				return s.CloseChangeset(ctx, cs)
+			},
+			want: gerrit.ChangeStatusAbandoned,
+		},
+		{
+			name: "ReopenChangeset_success",
+			cs:   newChangeset(gerrit.ChangeStatusAbandoned, false),
+			action: func(ctx context.Context, s *GerritSource, cs *Changeset) error {
+				return s.ReopenChangeset(ctx, cs)
+			},
+			want: gerrit.ChangeStatusNew,
+		},
+		{
+			name: "UpdateChangeset_success",
+			cs: func() *Changeset {
+				cs := newChangeset(gerrit.ChangeStatusNew, false)
+				cs.Title = "Update the README and the CHANGELOG"
+				return cs
+			}(),
+			action: func(ctx context.Context, s *GerritSource, cs *Changeset) error {
+				return s.UpdateChangeset(ctx, cs)
+			},
+			want: gerrit.ChangeStatusNew,
+		},
+		{
+			name: "UndraftChangeset_success",
+			cs:   newChangeset(gerrit.ChangeStatusNew, true),
+			action: func(ctx context.Context, s *GerritSource, cs *Changeset) error {
+				return s.UndraftChangeset(ctx, cs)
+			},
+			want: gerrit.ChangeStatusNew,
+		},
+		{
+			name: "CreateComment_success",
+			cs:   newChangeset(gerrit.ChangeStatusNew, false),
+			action: func(ctx context.Context, s *GerritSource, cs *Changeset) error {
+				return s.CreateComment(ctx, cs, "another comment")
+			},
+			want: gerrit.ChangeStatusNew,
+		},
+		{
+			name: "MergeChangeset_success",
+			cs:   newChangeset(gerrit.ChangeStatusNew, false),
+			action: func(ctx context.Context, s *GerritSource, cs *Changeset) error {
+				return s.MergeChangeset(ctx, cs, true)
+			},
+			want: gerrit.ChangeStatusMerged,
+		},
+		{
+			name: "MergeChangeset_not-mergeable",
+			cs:   newChangeset(gerrit.ChangeStatusNew, false),
+			action: func(ctx context.Context, s *GerritSource, cs *Changeset) error {
+				return s.MergeChangeset(ctx, cs, false)
+			},
+			err: "changeset cannot be merged",
+		},
+	} {
+		tc := tc
+		tc.name = "GerritSource_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			cf, save := newClientFactory(t, tc.name)
+			defer save(t)
+
+			s := newGerritSourceForTest(t, cf)
+
+			err := tc.action(context.Background(), s, tc.cs)
+			if tc.err != "" {
+				assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.Nil(t, err)
+
+			change := tc.cs.Metadata.(*gerritbatches.AnnotatedChange)
+			assert.Equal(t, tc.want, change.Status)
+			assert.False(t, change.WorkInProgress)
+
+			outdated, err := tc.cs.IsOutdated()
+			assert.Nil(t, err)
+			assert.False(t, outdated)
+		})
+	}
+}
+
+func gerritTestRepo() *types.Repo {
+	return &types.Repo{
+		Name: "gerrit.sgdev.org/batch-changes/test-repo",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "batch-changes%2Ftest-repo",
+			ServiceType: extsvc.TypeGerrit,
+			ServiceID:   gerritInstanceURL + "/",
+		},
+		Metadata: &gerrit.Project{
+			ID:   "batch-changes%2Ftest-repo",
+			Name: "batch-changes/test-repo",
+		},
+	}
+}
+
+func newGerritSourceForTest(t *testing.T, cf *httpcli.Factory) *GerritSource {
+	t.Helper()
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindGerrit,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.GerritConnection{
+			Url:      gerritInstanceURL,
+			Username: os.Getenv("GERRIT_USERNAME"),
+			Password: os.Getenv("GERRIT_PASSWORD"),
+		})),
+	}
+
+	s, err := NewGerritSource(context.Background(), svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
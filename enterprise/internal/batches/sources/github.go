@@ -26,6 +26,7 @@ type GithubSource struct {
 }
 
 var _ ForkableChangesetSource = GithubSource{}
+var _ IssueChangesetSource = GithubSource{}
 
 func NewGithubSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GithubSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -294,6 +295,100 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// LoadIssue loads the latest state of the issue of the given Changeset from
+// the codehost.
+func (s GithubSource) LoadIssue(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*github.Repository)
+	number, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "parsing changeset external id")
+	}
+
+	issue := &github.Issue{
+		RepoWithOwner: repo.NameWithOwner,
+		Number:        number,
+	}
+
+	if err := s.client.LoadIssue(ctx, issue); err != nil {
+		if github.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return err
+	}
+
+	if err := cs.SetMetadata(issue); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// CreateIssue creates the issue of the given Changeset on the code host.
+func (s GithubSource) CreateIssue(ctx context.Context, c *Changeset) error {
+	issue, err := s.client.CreateIssue(ctx, &github.CreateIssueInput{
+		RepositoryID: c.TargetRepo.Metadata.(*github.Repository).ID,
+		Title:        c.Title,
+		Body:         c.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.SetMetadata(issue); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// UpdateIssue updates the title and body of the issue of the given Changeset
+// on the code host.
+func (s GithubSource) UpdateIssue(ctx context.Context, c *Changeset) error {
+	issue, ok := c.Changeset.Metadata.(*github.Issue)
+	if !ok {
+		return errors.New("Changeset is not a GitHub issue")
+	}
+
+	updated, err := s.client.UpdateIssue(ctx, &github.UpdateIssueInput{
+		ID:    issue.ID,
+		Title: c.Title,
+		Body:  c.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// CloseIssue closes the issue of the given Changeset on the code host.
+func (s GithubSource) CloseIssue(ctx context.Context, c *Changeset) error {
+	issue, ok := c.Changeset.Metadata.(*github.Issue)
+	if !ok {
+		return errors.New("Changeset is not a GitHub issue")
+	}
+
+	if err := s.client.CloseIssue(ctx, issue); err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(issue)
+}
+
+// ReopenIssue reopens the issue of the given Changeset on the code host.
+func (s GithubSource) ReopenIssue(ctx context.Context, c *Changeset) error {
+	issue, ok := c.Changeset.Metadata.(*github.Issue)
+	if !ok {
+		return errors.New("Changeset is not a GitHub issue")
+	}
+
+	if err := s.client.ReopenIssue(ctx, issue); err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(issue)
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
@@ -27,6 +27,7 @@ type GitLabSource struct {
 var _ ChangesetSource = &GitLabSource{}
 var _ DraftChangesetSource = &GitLabSource{}
 var _ ForkableChangesetSource = &GitLabSource{}
+var _ IssueChangesetSource = &GitLabSource{}
 
 // NewGitLabSource returns a new GitLabSource from the given external service.
 func NewGitLabSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GitLabSource, error) {
@@ -498,6 +499,105 @@ func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, squash
 	return c.Changeset.SetMetadata(updated)
 }
 
+// LoadIssue loads the given issue from GitLab and updates it.
+func (s *GitLabSource) LoadIssue(ctx context.Context, cs *Changeset) error {
+	project := cs.TargetRepo.Metadata.(*gitlab.Project)
+
+	iid, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "parsing changeset external ID %s", cs.ExternalID)
+	}
+
+	issue, err := s.client.GetIssue(ctx, project, gitlab.ID(iid))
+	if err != nil {
+		if errors.Is(err, gitlab.ErrIssueNotFound) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrapf(err, "retrieving issue %d", iid)
+	}
+
+	if err := s.decorateIssueData(ctx, project, issue); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for issue %d", iid)
+	}
+
+	if err := cs.SetMetadata(issue); err != nil {
+		return errors.Wrapf(err, "setting changeset metadata for issue %d", iid)
+	}
+
+	return nil
+}
+
+// CreateIssue creates the issue of the given Changeset on GitLab.
+func (s *GitLabSource) CreateIssue(ctx context.Context, c *Changeset) error {
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	issue, err := s.client.CreateIssue(ctx, project, gitlab.CreateIssueOpts{
+		Title:       c.Title,
+		Description: c.Body,
+	})
+	if err != nil {
+		return errors.Wrap(err, "creating GitLab issue")
+	}
+
+	if err := c.SetMetadata(issue); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
+// UpdateIssue updates the issue on GitLab to reflect the local state of the
+// Changeset.
+func (s *GitLabSource) UpdateIssue(ctx context.Context, c *Changeset) error {
+	return s.updateIssue(ctx, c, gitlab.UpdateIssueOpts{
+		Title:       c.Title,
+		Description: c.Body,
+	})
+}
+
+// CloseIssue closes the issue on GitLab.
+func (s *GitLabSource) CloseIssue(ctx context.Context, c *Changeset) error {
+	return s.updateIssue(ctx, c, gitlab.UpdateIssueOpts{
+		StateEvent: gitlab.UpdateIssueStateEventClose,
+	})
+}
+
+// ReopenIssue reopens the issue on GitLab.
+func (s *GitLabSource) ReopenIssue(ctx context.Context, c *Changeset) error {
+	return s.updateIssue(ctx, c, gitlab.UpdateIssueOpts{
+		StateEvent: gitlab.UpdateIssueStateEventReopen,
+	})
+}
+
+func (s *GitLabSource) updateIssue(ctx context.Context, c *Changeset, opts gitlab.UpdateIssueOpts) error {
+	issue, ok := c.Changeset.Metadata.(*gitlab.Issue)
+	if !ok {
+		return errors.New("Changeset is not a GitLab issue")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	updated, err := s.client.UpdateIssue(ctx, project, issue, opts)
+	if err != nil {
+		return errors.Wrap(err, "updating GitLab issue")
+	}
+
+	if err := s.decorateIssueData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for issue %d", updated.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// decorateIssueData retrieves the resource state events of the issue, which
+// aren't part of the issue response.
+func (s *GitLabSource) decorateIssueData(ctx context.Context, project *gitlab.Project, issue *gitlab.Issue) error {
+	events, err := readMergeRequestResourceStateEvents(s.client.GetIssueResourceStateEvents(ctx, project, issue.IID))
+	if err != nil {
+		return errors.Wrap(err, "reading resource state events pages")
+	}
+	issue.ResourceStateEvents = events
+	return nil
+}
+
 func (s *GitLabSource) GetNamespaceFork(ctx context.Context, targetRepo *types.Repo, namespace string) (*types.Repo, error) {
 	return s.getFork(ctx, targetRepo, &namespace)
 }
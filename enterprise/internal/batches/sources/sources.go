@@ -187,6 +187,16 @@ func ToDraftChangesetSource(css ChangesetSource) (DraftChangesetSource, error) {
 	return draftCss, nil
 }
 
+// ToIssueChangesetSource returns an IssueChangesetSource, if the underlying
+// source supports it. Returns an error if not.
+func ToIssueChangesetSource(css ChangesetSource) (IssueChangesetSource, error) {
+	issueCss, ok := css.(IssueChangesetSource)
+	if !ok {
+		return nil, errors.New("changeset source doesn't implement IssueChangesetSource")
+	}
+	return issueCss, nil
+}
+
 // WithAuthenticatorForChangeset authenticates the given ChangesetSource with a
 // credential appropriate to sync or reconcile the given changeset. If the
 // changeset was created by a batch change, then authentication will be based on
@@ -326,6 +336,10 @@ func loadExternalService(ctx context.Context, s database.ExternalServiceStore, o
 			if cfg.AppPassword != "" {
 				return e, nil
 			}
+		case *schema.GerritConnection:
+			if cfg.Password != "" {
+				return e, nil
+			}
 		}
 	}
 
@@ -345,6 +359,8 @@ func buildChangesetSource(ctx context.Context, cf *httpcli.Factory, externalServ
 		return NewBitbucketServerSource(ctx, externalService, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(ctx, externalService, cf)
+	case extsvc.KindGerrit:
+		return NewGerritSource(ctx, externalService, cf)
 	default:
 		return nil, errors.Errorf("unsupported external service type %q", extsvc.KindToType(externalService.Kind))
 	}
@@ -410,7 +426,7 @@ func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	case extsvc.TypeGitHub, extsvc.TypeGitLab:
 		return errors.New("need token to push commits to " + extSvcType)
 
-	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud:
+	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeGerrit:
 		u.User = url.UserPassword(username, password)
 
 	default:
@@ -61,6 +61,15 @@ type FakeChangesetSource struct {
 	MergeChangesetCalled        bool
 	IsArchivedPushErrorCalled   bool
 
+	// The issue counterparts of the calls above. Issues passed to the
+	// IssueChangesetSource methods are recorded in the same slices as
+	// changesets.
+	LoadIssueCalled   bool
+	CreateIssueCalled bool
+	UpdateIssueCalled bool
+	CloseIssueCalled  bool
+	ReopenIssueCalled bool
+
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
 	// The Changeset.BaseRef to be expected in CreateChangeset/UpdateChangeset calls.
@@ -111,6 +120,7 @@ var (
 	_ sources.ChangesetSource           = &FakeChangesetSource{}
 	_ sources.ArchivableChangesetSource = &FakeChangesetSource{}
 	_ sources.DraftChangesetSource      = &FakeChangesetSource{}
+	_ sources.IssueChangesetSource      = &FakeChangesetSource{}
 )
 
 func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *sources.Changeset) (bool, error) {
@@ -292,6 +302,45 @@ func (s *FakeChangesetSource) ReopenChangeset(ctx context.Context, c *sources.Ch
 	return c.SetMetadata(s.FakeMetadata)
 }
 
+func (s *FakeChangesetSource) LoadIssue(ctx context.Context, c *sources.Changeset) error {
+	s.LoadIssueCalled = true
+	return s.recordIssue(c, &s.LoadedChangesets)
+}
+
+func (s *FakeChangesetSource) CreateIssue(ctx context.Context, c *sources.Changeset) error {
+	s.CreateIssueCalled = true
+	return s.recordIssue(c, &s.CreatedChangesets)
+}
+
+func (s *FakeChangesetSource) UpdateIssue(ctx context.Context, c *sources.Changeset) error {
+	s.UpdateIssueCalled = true
+	return s.recordIssue(c, &s.UpdatedChangesets)
+}
+
+func (s *FakeChangesetSource) CloseIssue(ctx context.Context, c *sources.Changeset) error {
+	s.CloseIssueCalled = true
+	return s.recordIssue(c, &s.ClosedChangesets)
+}
+
+func (s *FakeChangesetSource) ReopenIssue(ctx context.Context, c *sources.Changeset) error {
+	s.ReopenIssueCalled = true
+	return s.recordIssue(c, &s.ReopenedChangesets)
+}
+
+func (s *FakeChangesetSource) recordIssue(c *sources.Changeset, recorded *[]*sources.Changeset) error {
+	if s.Err != nil {
+		return s.Err
+	}
+
+	if c.TargetRepo == nil {
+		return noReposErr{name: "target"}
+	}
+
+	*recorded = append(*recorded, c)
+
+	return c.SetMetadata(s.FakeMetadata)
+}
+
 func (s *FakeChangesetSource) CreateComment(ctx context.Context, c *sources.Changeset, body string) error {
 	s.CreateCommentCalled = true
 	return s.Err
@@ -1425,6 +1425,26 @@ func TestCalcCounts(t *testing.T) {
 				{Time: daysAgo(0), Total: 1, Draft: 1},
 			},
 		},
+		{
+			codehosts: extsvc.TypeGitHub,
+			name:      "pull request and issue",
+			changesets: []*btypes.Changeset{
+				ghChangeset(1, daysAgo(3)),
+				ghIssueChangeset(2, daysAgo(3)),
+			},
+			start: daysAgo(3),
+			events: []*btypes.ChangesetEvent{
+				event(t, daysAgo(2), btypes.ChangesetEventKindGitHubClosed, 2),
+				event(t, daysAgo(1), btypes.ChangesetEventKindGitHubMerged, 1),
+				event(t, daysAgo(0), btypes.ChangesetEventKindGitHubReopened, 2),
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(3), Total: 2, Open: 2, OpenPending: 2},
+				{Time: daysAgo(2), Total: 2, Open: 1, OpenPending: 1, Closed: 1},
+				{Time: daysAgo(1), Total: 2, Merged: 1, Closed: 1},
+				{Time: daysAgo(0), Total: 2, Merged: 1, Open: 1, OpenPending: 1},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1468,6 +1488,10 @@ func ghChangeset(id int64, t time.Time) *btypes.Changeset {
 	return &btypes.Changeset{ID: id, Metadata: &github.PullRequest{CreatedAt: t}}
 }
 
+func ghIssueChangeset(id int64, t time.Time) *btypes.Changeset {
+	return &btypes.Changeset{ID: id, Kind: btypes.ChangesetKindIssue, Metadata: &github.Issue{CreatedAt: t}}
+}
+
 func bbsChangeset(id int64, t time.Time) *btypes.Changeset {
 	return &btypes.Changeset{
 		ID:       id,
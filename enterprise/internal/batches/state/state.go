@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -20,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -65,6 +67,12 @@ func SetDerivedState(ctx context.Context, repoStore database.RepoStore, c *btype
 		c.ExternalReviewState = state
 	}
 
+	// Issues have no commits, so there's no sync state or diffstat to
+	// compute.
+	if c.IsIssue() {
+		return
+	}
+
 	// If the changeset was "complete" (that is, not open) the last time we
 	// synced, and it's still complete, then we don't need to do any further
 	// work: the diffstat should still be correct, and this way we don't need to
@@ -113,6 +121,9 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 
 	case *bbcs.AnnotatedPullRequest:
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
+
+	case *gerritbatches.AnnotatedChange:
+		return computeGerritCheckState(m)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -157,6 +168,84 @@ func computeReviewState(c *btypes.Changeset, history []changesetStatesAtTime) (b
 	return newestDataPoint.reviewState, nil
 }
 
+// CountApprovals returns the number of reviewers whose latest review of the
+// changeset approves it. The events should be presorted.
+func CountApprovals(c *btypes.Changeset, events ChangesetEvents) (int, error) {
+	approvals := 0
+
+	switch m := c.Metadata.(type) {
+	case *bitbucketserver.PullRequest:
+		for _, r := range m.Reviewers {
+			if r.Status == "APPROVED" {
+				approvals++
+			}
+		}
+		return approvals, nil
+
+	case *bbcs.AnnotatedPullRequest:
+		for _, participant := range m.Participants {
+			if participant.State == bitbucketcloud.ParticipantStateApproved {
+				approvals++
+			}
+		}
+		return approvals, nil
+
+	case *gerritbatches.AnnotatedChange:
+		for _, vote := range m.Labels["Code-Review"].All {
+			if vote.Value >= 2 {
+				approvals++
+			}
+		}
+		return approvals, nil
+
+	case *github.PullRequest, *gitlab.MergeRequest:
+		// GitHub and GitLab don't keep the reviews on the changeset, so we
+		// replay the review events like computeHistory does.
+		if !sort.IsSorted(events) {
+			return 0, errors.New("changeset events not sorted")
+		}
+
+		lastReviewByAuthor := map[string]btypes.ChangesetReviewState{}
+		for _, e := range events {
+			switch e.Kind {
+			case btypes.ChangesetEventKindGitHubReviewed,
+				btypes.ChangesetEventKindGitLabApproved:
+				s, err := e.ReviewState()
+				if err != nil {
+					return 0, err
+				}
+
+				author := e.ReviewAuthor()
+				if author == "" {
+					continue
+				}
+
+				switch s {
+				case btypes.ChangesetReviewStateApproved, btypes.ChangesetReviewStateChangesRequested:
+					lastReviewByAuthor[author] = s
+				case btypes.ChangesetReviewStateDismissed:
+					delete(lastReviewByAuthor, author)
+				}
+
+			case btypes.ChangesetEventKindGitLabUnapproved:
+				if author := e.ReviewAuthor(); author != "" {
+					delete(lastReviewByAuthor, author)
+				}
+			}
+		}
+
+		for _, s := range lastReviewByAuthor {
+			if s == btypes.ChangesetReviewStateApproved {
+				approvals++
+			}
+		}
+		return approvals, nil
+
+	default:
+		return 0, errors.New("unknown changeset type")
+	}
+}
+
 func computeBitbucketServerBuildStatus(lastSynced time.Time, pr *bitbucketserver.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	var latestCommit bitbucketserver.Commit
 	for _, c := range pr.Commits {
@@ -252,6 +341,35 @@ func parseBitbucketCloudBuildState(s bitbucketcloud.PullRequestStatusState) btyp
 	}
 }
 
+// computeGerritCheckState computes the check state of a Gerrit change from the
+// votes on its verification labels, which CI systems vote on.
+func computeGerritCheckState(change *gerritbatches.AnnotatedChange) btypes.ChangesetCheckState {
+	var states []btypes.ChangesetCheckState
+	for name, label := range change.Labels {
+		if isGerritCheckLabel(name) {
+			states = append(states, parseGerritCheckLabel(label))
+		}
+	}
+	return combineCheckStates(states)
+}
+
+// isGerritCheckLabel returns true for the labels that CI systems vote on: the
+// Verified label of Gerrit, and labels such as Presubmit-Verified.
+func isGerritCheckLabel(name string) bool {
+	return name == "Verified" || strings.HasSuffix(name, "-Verified")
+}
+
+func parseGerritCheckLabel(label gerrit.LabelInfo) btypes.ChangesetCheckState {
+	switch {
+	case label.Rejected != nil, label.Disliked != nil:
+		return btypes.ChangesetCheckStateFailed
+	case label.Approved != nil:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStatePending
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -516,6 +634,32 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *gerritbatches.AnnotatedChange:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			if m.WorkInProgress {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
+	case *github.Issue:
+		s = btypes.ChangesetExternalState(m.State)
+	case *gitlab.Issue:
+		switch m.State {
+		case gitlab.IssueStateClosed:
+			s = btypes.ChangesetExternalStateClosed
+		case gitlab.IssueStateOpened:
+			s = btypes.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown GitLab issue state: %s", m.State)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -540,6 +684,10 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 		// For GitHub we need to use `ChangesetEvents.ReviewState`.
 		return btypes.ChangesetReviewStatePending, nil
 
+	case *github.Issue, *gitlab.Issue:
+		// Issues aren't reviewed.
+		return btypes.ChangesetReviewStatePending, nil
+
 	case *bitbucketserver.PullRequest:
 		for _, r := range m.Reviewers {
 			switch r.Status {
@@ -585,6 +733,19 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *gerritbatches.AnnotatedChange:
+		// The Code-Review label has the votes of the reviewers: a -1 or -2
+		// vote requests changes, and a +2 vote approves the change.
+		label := m.Labels["Code-Review"]
+		switch {
+		case label.Rejected != nil, label.Disliked != nil:
+			states[btypes.ChangesetReviewStateChangesRequested] = true
+		case label.Approved != nil:
+			states[btypes.ChangesetReviewStateApproved] = true
+		default:
+			states[btypes.ChangesetReviewStatePending] = true
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -7,9 +7,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -407,6 +409,68 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeGerritCheckState(t *testing.T) {
+	t.Parallel()
+
+	ci := &gerrit.Account{Username: "ci"}
+
+	for name, tc := range map[string]struct {
+		labels map[string]gerrit.LabelInfo
+		want   btypes.ChangesetCheckState
+	}{
+		"no labels": {
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		"only Code-Review": {
+			labels: map[string]gerrit.LabelInfo{
+				"Code-Review": {Approved: ci},
+			},
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		"Verified without votes": {
+			labels: map[string]gerrit.LabelInfo{
+				"Verified": {},
+			},
+			want: btypes.ChangesetCheckStatePending,
+		},
+		"Verified approved": {
+			labels: map[string]gerrit.LabelInfo{
+				"Verified": {Approved: ci},
+			},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+		"Verified rejected": {
+			labels: map[string]gerrit.LabelInfo{
+				"Verified": {Approved: ci, Rejected: ci},
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+		"several CI labels": {
+			labels: map[string]gerrit.LabelInfo{
+				"Verified":           {Approved: ci},
+				"Presubmit-Verified": {},
+			},
+			want: btypes.ChangesetCheckStatePending,
+		},
+		"several CI labels, one failed": {
+			labels: map[string]gerrit.LabelInfo{
+				"Verified":           {Approved: ci},
+				"Presubmit-Verified": {Disliked: ci},
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have := computeGerritCheckState(&gerritbatches.AnnotatedChange{
+				Change: &gerrit.Change{Labels: tc.labels},
+			})
+			if have != tc.want {
+				t.Errorf("unexpected check state: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
@@ -536,6 +600,39 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gerrit - no votes",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - approved",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				"Code-Review": {Approved: &gerrit.Account{Username: "reviewer"}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gerrit - changes requested",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				"Code-Review": {
+					Approved: &gerrit.Account{Username: "reviewer"},
+					Disliked: &gerrit.Account{Username: "other-reviewer"},
+				},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name: "gerrit - only CI votes",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				"Verified": {Approved: &gerrit.Account{Username: "ci"}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
 	}
 
 	for i, tc := range tests {
@@ -554,6 +651,88 @@ func TestComputeReviewState(t *testing.T) {
 	}
 }
 
+func TestCountApprovals(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	tests := []struct {
+		name      string
+		changeset *btypes.Changeset
+		events    ChangesetEvents
+		want      int
+	}{
+		{
+			name:      "github - no reviews",
+			changeset: githubChangeset(daysAgo(0), "OPEN"),
+			want:      0,
+		},
+		{
+			name:      "github - approvals by different authors",
+			changeset: githubChangeset(daysAgo(0), "OPEN"),
+			events: ChangesetEvents{
+				ghReview(1, daysAgo(3), "alice", "APPROVED"),
+				ghReview(1, daysAgo(2), "bob", "APPROVED"),
+				ghReview(1, daysAgo(1), "carol", "CHANGES_REQUESTED"),
+			},
+			want: 2,
+		},
+		{
+			name:      "github - approval superseded by later review",
+			changeset: githubChangeset(daysAgo(0), "OPEN"),
+			events: ChangesetEvents{
+				ghReview(1, daysAgo(3), "alice", "APPROVED"),
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				ghReview(1, daysAgo(1), "bob", "APPROVED"),
+				ghReview(1, daysAgo(0), "bob", "DISMISSED"),
+			},
+			want: 1,
+		},
+		{
+			name:      "github - comments don't count",
+			changeset: githubChangeset(daysAgo(0), "OPEN"),
+			events: ChangesetEvents{
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				ghReview(1, daysAgo(1), "alice", "COMMENTED"),
+			},
+			want: 1,
+		},
+		{
+			name:      "bitbucketserver",
+			changeset: bitbucketChangeset(daysAgo(0), "OPEN", "APPROVED"),
+			want:      1,
+		},
+		{
+			name: "gerrit - +2 votes",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				"Code-Review": {All: []gerrit.ApprovalInfo{
+					{Account: gerrit.Account{Username: "alice"}, Value: 2},
+					{Account: gerrit.Account{Username: "bob"}, Value: 1},
+					{Account: gerrit.Account{Username: "carol"}, Value: 2},
+				}},
+				"Verified": {All: []gerrit.ApprovalInfo{
+					{Account: gerrit.Account{Username: "ci"}, Value: 1},
+				}},
+			}),
+			want: 2,
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := CountApprovals(tc.changeset, tc.events)
+			if err != nil {
+				t.Fatalf("got error: %s", err)
+			}
+
+			if have != tc.want {
+				t.Errorf("%d: wrong number of approvals. have=%d, want=%d", i, have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeExternalState(t *testing.T) {
 	t.Parallel()
 
@@ -744,6 +923,56 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateReadOnly,
 		},
+		{
+			name:      "gerrit - new",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gerrit - work in progress",
+			changeset: setDraft(gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "gerrit - abandoned",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusAbandoned, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "gerrit - merged",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusMerged, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "github issue - open",
+			changeset: githubIssueChangeset(daysAgo(0), "OPEN"),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "github issue - changeset older than events",
+			changeset: githubIssueChangeset(daysAgo(10), "OPEN"),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(0), externalState: btypes.ChangesetExternalStateClosed},
+			},
+			want: btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "gitlab issue - opened",
+			changeset: gitLabIssueChangeset(daysAgo(0), gitlab.IssueStateOpened),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gitlab issue - closed",
+			changeset: gitLabIssueChangeset(daysAgo(0), gitlab.IssueStateClosed),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
 	}
 
 	for i, tc := range tests {
@@ -900,6 +1129,37 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func githubIssueChangeset(updatedAt time.Time, state string) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGitHub,
+		Kind:                btypes.ChangesetKindIssue,
+		UpdatedAt:           updatedAt,
+		Metadata:            &github.Issue{State: state},
+	}
+}
+
+func gitLabIssueChangeset(updatedAt time.Time, state gitlab.IssueState) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGitLab,
+		Kind:                btypes.ChangesetKindIssue,
+		UpdatedAt:           updatedAt,
+		Metadata:            &gitlab.Issue{State: state},
+	}
+}
+
+func gerritChangeset(updatedAt time.Time, status gerrit.ChangeStatus, labels map[string]gerrit.LabelInfo) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGerrit,
+		UpdatedAt:           updatedAt,
+		Metadata: &gerritbatches.AnnotatedChange{
+			Change: &gerrit.Change{
+				Status: status,
+				Labels: labels,
+			},
+		},
+	}
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
@@ -405,6 +405,20 @@ func (s *Store) CancelBatchSpecWorkspaceExecutionJobs(ctx context.Context, opts
 		return nil, err
 	}
 
+	// Queued jobs are canceled directly and never reach the worker, so any
+	// refresh of the changesets of their workspaces is resolved here.
+	var canceledWorkspaceIDs []int64
+	for _, j := range jobs {
+		if j.State == btypes.BatchSpecWorkspaceExecutionJobStateCanceled {
+			canceledWorkspaceIDs = append(canceledWorkspaceIDs, j.BatchSpecWorkspaceID)
+		}
+	}
+	if len(canceledWorkspaceIDs) > 0 {
+		if err := s.FailChangesetRefreshes(ctx, canceledWorkspaceIDs, "refreshing changeset was canceled"); err != nil {
+			return nil, err
+		}
+	}
+
 	return jobs, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"sort"
+	"strconv"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
@@ -145,13 +146,15 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 
 // GetBatchSpecWorkspaceOpts captures the query options needed for getting a BatchSpecWorkspace
 type GetBatchSpecWorkspaceOpts struct {
-	ID int64
+	ID              int64
+	ChangesetSpecID int64
 }
 
 // GetBatchSpecWorkspace gets a BatchSpecWorkspace matching the given options.
 func (s *Store) GetBatchSpecWorkspace(ctx context.Context, opts GetBatchSpecWorkspaceOpts) (job *btypes.BatchSpecWorkspace, err error) {
 	ctx, _, endObservation := s.operations.getBatchSpecWorkspace.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.Int("ID", int(opts.ID)),
+		log.Int("ChangesetSpecID", int(opts.ChangesetSpecID)),
 	}})
 	defer endObservation(1, observation.Args{})
 
@@ -182,7 +185,14 @@ LIMIT 1
 func getBatchSpecWorkspaceQuery(opts *GetBatchSpecWorkspaceOpts) *sqlf.Query {
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("repo.deleted_at IS NULL"),
-		sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID),
+	}
+
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID))
+	}
+
+	if opts.ChangesetSpecID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.changeset_spec_ids ? %s", strconv.FormatInt(opts.ChangesetSpecID, 10)))
 	}
 
 	return sqlf.Sprintf(
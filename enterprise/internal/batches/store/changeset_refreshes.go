@@ -0,0 +1,300 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// RequestChangesetRefresh requests a refresh of the changeset with the given ID
+// onto the commit baseRev of its base branch baseRef, and puts it into the
+// given reconciler state. It returns whether a refresh was requested.
+//
+// A refresh is only requested for open changesets owned by a batch change
+// whose batch spec opted into auto refreshing, that were created from a
+// server-side execution, that aren't being reconciled right now and that
+// aren't already based on, or being refreshed onto, baseRev.
+func (s *Store) RequestChangesetRefresh(ctx context.Context, changesetID int64, baseRef, baseRev string, state btypes.ReconcilerState) (requested bool, err error) {
+	ctx, _, endObservation := s.operations.requestChangesetRefresh.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("changesetID", int(changesetID)),
+		log.String("baseRev", baseRev),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		requestChangesetRefreshQueryFmtstr,
+		baseRev,
+		state.ToDB(),
+		s.now(),
+		changesetID,
+		btypes.ChangesetPublicationStatePublished,
+		btypes.ChangesetExternalStateOpen,
+		btypes.ChangesetExternalStateDraft,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		baseRef,
+		baseRev,
+		baseRev,
+	)
+
+	ids, err := basestore.ScanInts(s.Query(ctx, q))
+	return len(ids) > 0, err
+}
+
+var requestChangesetRefreshQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_refreshes.go:RequestChangesetRefresh
+UPDATE changesets
+SET
+	refresh_base_rev = %s,
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	failure_message = NULL,
+	updated_at = %s
+FROM changeset_specs
+JOIN batch_specs ON batch_specs.id = changeset_specs.batch_spec_id
+WHERE
+	changesets.id = %s
+	AND
+	changeset_specs.id = changesets.current_spec_id
+	AND
+	changesets.owned_by_batch_change_id IS NOT NULL
+	AND
+	changesets.publication_state = %s
+	AND
+	changesets.external_state IN (%s, %s)
+	AND
+	changesets.reconciler_state = %s
+	AND
+	changeset_specs.base_ref = %s
+	AND
+	changeset_specs.base_rev != %s
+	AND
+	changesets.refresh_base_rev != %s
+	AND
+	(batch_specs.spec->'changesetTemplate'->>'autoRefresh')::boolean IS TRUE
+	AND
+	EXISTS (
+		SELECT 1
+		FROM batch_spec_workspaces
+		WHERE
+			batch_spec_workspaces.batch_spec_id = batch_specs.id
+			AND
+			batch_spec_workspaces.changeset_spec_ids ? changeset_specs.id::text
+	)
+RETURNING changesets.id
+`
+
+// ChangesetAutoRefreshEnabled returns whether the changeset with the given ID
+// was created from a server-side execution of a batch spec that opted into
+// auto refreshing.
+func (s *Store) ChangesetAutoRefreshEnabled(ctx context.Context, changesetID int64) (enabled bool, err error) {
+	ctx, _, endObservation := s.operations.changesetAutoRefreshEnabled.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("changesetID", int(changesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	enabled, _, err = basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(
+		changesetAutoRefreshEnabledQueryFmtstr,
+		changesetID,
+	)))
+	return enabled, err
+}
+
+var changesetAutoRefreshEnabledQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_refreshes.go:ChangesetAutoRefreshEnabled
+SELECT EXISTS (
+	SELECT 1
+	FROM changesets
+	JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+	JOIN batch_specs ON batch_specs.id = changeset_specs.batch_spec_id
+	WHERE
+		changesets.id = %s
+		AND
+		(batch_specs.spec->'changesetTemplate'->>'autoRefresh')::boolean IS TRUE
+		AND
+		EXISTS (
+			SELECT 1
+			FROM batch_spec_workspaces
+			WHERE
+				batch_spec_workspaces.batch_spec_id = batch_specs.id
+				AND
+				batch_spec_workspaces.changeset_spec_ids ? changeset_specs.id::text
+		)
+)
+`
+
+// RefreshBatchSpecWorkspace points the workspace with the given ID at the given
+// commit, replaces its step cache results and enqueues a new execution job for
+// it. Any previous execution jobs of the workspace are deleted.
+func (s *Store) RefreshBatchSpecWorkspace(ctx context.Context, workspaceID int64, commit string, stepCacheResults map[int]btypes.StepCacheResult) (err error) {
+	ctx, _, endObservation := s.operations.refreshBatchSpecWorkspace.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("workspaceID", int(workspaceID)),
+		log.String("commit", commit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	marshaledStepCacheResults, err := json.Marshal(stepCacheResults)
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		refreshBatchSpecWorkspaceQueryFmtstr,
+		commit,
+		marshaledStepCacheResults,
+		s.now(),
+		workspaceID,
+	))
+}
+
+var refreshBatchSpecWorkspaceQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_refreshes.go:RefreshBatchSpecWorkspace
+WITH workspace AS (
+	UPDATE batch_spec_workspaces
+	SET
+		commit = %s,
+		step_cache_results = %s,
+		cached_result_found = FALSE,
+		updated_at = %s
+	WHERE id = %s
+	RETURNING id, batch_spec_id
+),
+deleted_jobs AS (
+	DELETE FROM batch_spec_workspace_execution_jobs
+	WHERE batch_spec_workspace_id IN (SELECT id FROM workspace)
+)
+INSERT INTO
+	batch_spec_workspace_execution_jobs (batch_spec_workspace_id, user_id)
+SELECT
+	workspace.id,
+	batch_specs.user_id
+FROM
+	workspace
+JOIN
+	batch_specs ON batch_specs.id = workspace.batch_spec_id
+`
+
+// RefreshChangesetSpec overwrites the diff, the base revision and the diff
+// stat of the changeset spec with the ID of the given spec with the ones of
+// the given spec, and puts the changesets that are being refreshed onto it
+// into the given reconciler state.
+func (s *Store) RefreshChangesetSpec(ctx context.Context, spec *btypes.ChangesetSpec, state btypes.ReconcilerState) (err error) {
+	ctx, _, endObservation := s.operations.refreshChangesetSpec.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(spec.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	spec.UpdatedAt = s.now()
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		refreshChangesetSpecQueryFmtstr,
+		spec.Diff,
+		spec.BaseRev,
+		spec.DiffStatAdded,
+		spec.DiffStatChanged,
+		spec.DiffStatDeleted,
+		spec.UpdatedAt,
+		spec.ID,
+		state.ToDB(),
+		spec.UpdatedAt,
+	))
+}
+
+var refreshChangesetSpecQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_refreshes.go:RefreshChangesetSpec
+WITH spec AS (
+	UPDATE changeset_specs
+	SET
+		diff = %s,
+		base_rev = %s,
+		diff_stat_added = %s,
+		diff_stat_changed = %s,
+		diff_stat_deleted = %s,
+		updated_at = %s
+	WHERE id = %s
+	RETURNING id
+)
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	failure_message = NULL,
+	updated_at = %s
+FROM spec
+WHERE
+	changesets.current_spec_id = spec.id
+	AND
+	changesets.refresh_base_rev != ''
+`
+
+// CancelChangesetRefreshes cancels the pending refreshes of the changesets
+// whose current spec is one of the given changeset specs.
+func (s *Store) CancelChangesetRefreshes(ctx context.Context, changesetSpecIDs []int64) (err error) {
+	ctx, _, endObservation := s.operations.cancelChangesetRefreshes.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("count", len(changesetSpecIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		cancelChangesetRefreshesQueryFmtstr,
+		s.now(),
+		pq.Array(changesetSpecIDs),
+	))
+}
+
+var cancelChangesetRefreshesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_refreshes.go:CancelChangesetRefreshes
+UPDATE changesets
+SET
+	refresh_base_rev = '',
+	updated_at = %s
+WHERE
+	current_spec_id = ANY (%s)
+	AND
+	refresh_base_rev != ''
+`
+
+// FailChangesetRefreshes resolves the pending refreshes of the changesets
+// whose current spec was produced by one of the given workspaces as failed:
+// the refresh is cleared so that it isn't requested again by the reconciler,
+// and the given message is recorded as the changesets' failure message.
+func (s *Store) FailChangesetRefreshes(ctx context.Context, workspaceIDs []int64, message string) (err error) {
+	ctx, _, endObservation := s.operations.failChangesetRefreshes.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("count", len(workspaceIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		failChangesetRefreshesQueryFmtstr,
+		btypes.ReconcilerStateFailed.ToDB(),
+		message,
+		s.now(),
+		pq.Array(workspaceIDs),
+	))
+}
+
+var failChangesetRefreshesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_refreshes.go:FailChangesetRefreshes
+UPDATE changesets
+SET
+	refresh_base_rev = '',
+	reconciler_state = %s,
+	failure_message = %s,
+	updated_at = %s
+WHERE
+	current_spec_id IN (
+		SELECT jsonb_object_keys(changeset_spec_ids)::bigint
+		FROM batch_spec_workspaces
+		WHERE id = ANY (%s)
+	)
+	AND
+	refresh_base_rev != ''
+`
@@ -0,0 +1,239 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+)
+
+func testStoreChangesetRefreshes(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	repos, _ := bt.CreateTestRepos(t, ctx, s.DatabaseDB(), 1)
+	repo := repos[0]
+
+	batchSpec := &btypes.BatchSpec{
+		UserID:          user.ID,
+		NamespaceUserID: user.ID,
+		Spec: &batcheslib.BatchSpec{
+			Name: "auto-refresh",
+			ChangesetTemplate: &batcheslib.ChangesetTemplate{
+				Branch:      "auto-refresh",
+				AutoRefresh: true,
+			},
+		},
+	}
+	if err := s.CreateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+	batchChange := bt.CreateBatchChange(t, ctx, s, "auto-refresh", user.ID, batchSpec.ID)
+
+	spec := bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+		User:       user.ID,
+		Repo:       repo.ID,
+		BatchSpec:  batchSpec.ID,
+		HeadRef:    "refs/heads/auto-refresh",
+		BaseRef:    "refs/heads/main",
+		BaseRev:    "old",
+		CommitDiff: "old diff",
+		Published:  true,
+		Typ:        btypes.ChangesetSpecTypeBranch,
+	})
+
+	workspace := &btypes.BatchSpecWorkspace{
+		BatchSpecID:      batchSpec.ID,
+		ChangesetSpecIDs: []int64{spec.ID},
+		RepoID:           repo.ID,
+		Branch:           "refs/heads/main",
+		Commit:           "old",
+		Path:             "",
+		FileMatches:      []string{},
+	}
+	if err := s.CreateBatchSpecWorkspace(ctx, workspace); err != nil {
+		t.Fatal(err)
+	}
+
+	changeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChange:        batchChange.ID,
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        spec.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+
+	assertChangeset := func(t *testing.T, wantRefreshBaseRev string, wantState btypes.ReconcilerState) {
+		t.Helper()
+
+		have, err := s.GetChangesetByID(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.RefreshBaseRev != wantRefreshBaseRev {
+			t.Fatalf("wrong refresh base rev. want=%q, have=%q", wantRefreshBaseRev, have.RefreshBaseRev)
+		}
+		if have.ReconcilerState != wantState {
+			t.Fatalf("wrong reconciler state. want=%s, have=%s", wantState, have.ReconcilerState)
+		}
+	}
+
+	t.Run("GetBatchSpecWorkspace by changeset spec", func(t *testing.T) {
+		have, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ChangesetSpecID: spec.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.ID != workspace.ID {
+			t.Fatalf("wrong workspace returned. want=%d, have=%d", workspace.ID, have.ID)
+		}
+
+		if _, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ChangesetSpecID: spec.ID + 1}); err != ErrNoResults {
+			t.Fatalf("unexpected error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+
+	t.Run("ChangesetAutoRefreshEnabled", func(t *testing.T) {
+		enabled, err := s.ChangesetAutoRefreshEnabled(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !enabled {
+			t.Fatal("auto refresh not enabled for changeset")
+		}
+
+		enabled, err = s.ChangesetAutoRefreshEnabled(ctx, changeset.ID+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if enabled {
+			t.Fatal("auto refresh enabled for unknown changeset")
+		}
+	})
+
+	t.Run("RequestChangesetRefresh", func(t *testing.T) {
+		tcs := []struct {
+			name          string
+			baseRef       string
+			baseRev       string
+			wantRequested bool
+		}{
+			{name: "base branch didn't move", baseRef: "refs/heads/main", baseRev: "old"},
+			{name: "different base branch", baseRef: "refs/heads/other", baseRev: "new"},
+			{name: "base branch moved", baseRef: "refs/heads/main", baseRev: "new", wantRequested: true},
+			{name: "refresh already requested", baseRef: "refs/heads/main", baseRev: "new"},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				requested, err := s.RequestChangesetRefresh(ctx, changeset.ID, tc.baseRef, tc.baseRev, btypes.ReconcilerStateQueued)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if requested != tc.wantRequested {
+					t.Fatalf("wrong requested. want=%t, have=%t", tc.wantRequested, requested)
+				}
+			})
+		}
+
+		assertChangeset(t, "new", btypes.ReconcilerStateQueued)
+	})
+
+	t.Run("RefreshBatchSpecWorkspace", func(t *testing.T) {
+		stepCacheResults := map[int]btypes.StepCacheResult{
+			1: {Key: "step-1", Value: &execution.AfterStepResult{StepIndex: 0}},
+		}
+		if err := s.RefreshBatchSpecWorkspace(ctx, workspace.ID, "new", stepCacheResults); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ID: workspace.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.Commit != "new" {
+			t.Fatalf("wrong commit. want=%q, have=%q", "new", have.Commit)
+		}
+		if res, ok := have.StepCacheResult(1); !ok || res.Key != "step-1" {
+			t.Fatalf("step cache result not stored: %+v", have.StepCacheResults)
+		}
+
+		job, err := s.GetBatchSpecWorkspaceExecutionJob(ctx, GetBatchSpecWorkspaceExecutionJobOpts{BatchSpecWorkspaceID: workspace.ID, ExcludeRank: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.State != btypes.BatchSpecWorkspaceExecutionJobStateQueued {
+			t.Fatalf("wrong job state. want=%s, have=%s", btypes.BatchSpecWorkspaceExecutionJobStateQueued, job.State)
+		}
+
+		// Refreshing again replaces the job.
+		if err := s.RefreshBatchSpecWorkspace(ctx, workspace.ID, "newer", nil); err != nil {
+			t.Fatal(err)
+		}
+		jobs, err := s.ListBatchSpecWorkspaceExecutionJobs(ctx, ListBatchSpecWorkspaceExecutionJobsOpts{BatchSpecWorkspaceIDs: []int64{workspace.ID}, ExcludeRank: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 1 || jobs[0].ID == job.ID {
+			t.Fatalf("execution job not replaced: %+v", jobs)
+		}
+	})
+
+	t.Run("RefreshChangesetSpec", func(t *testing.T) {
+		changeset.ReconcilerState = btypes.ReconcilerStateCompleted
+		changeset.RefreshBaseRev = "new"
+		if err := s.UpdateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		refreshed := spec.Clone()
+		refreshed.BaseRev = "new"
+		refreshed.Diff = []byte("new diff")
+		refreshed.DiffStatAdded = 1
+		if err := s.RefreshChangesetSpec(ctx, refreshed, btypes.ReconcilerStateQueued); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetChangesetSpecByID(ctx, spec.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.BaseRev != "new" || string(have.Diff) != "new diff" || have.DiffStatAdded != 1 {
+			t.Fatalf("changeset spec not refreshed: %+v", have)
+		}
+
+		assertChangeset(t, "new", btypes.ReconcilerStateQueued)
+	})
+
+	t.Run("CancelChangesetRefreshes", func(t *testing.T) {
+		if err := s.CancelChangesetRefreshes(ctx, []int64{spec.ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		assertChangeset(t, "", btypes.ReconcilerStateQueued)
+	})
+
+	t.Run("FailChangesetRefreshes", func(t *testing.T) {
+		changeset.ReconcilerState = btypes.ReconcilerStateCompleted
+		changeset.RefreshBaseRev = "new"
+		if err := s.UpdateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.FailChangesetRefreshes(ctx, []int64{workspace.ID}, "refreshing changeset failed"); err != nil {
+			t.Fatal(err)
+		}
+
+		assertChangeset(t, "", btypes.ReconcilerStateFailed)
+
+		have, err := s.GetChangesetByID(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.FailureMessage == nil || *have.FailureMessage != "refreshing changeset failed" {
+			t.Fatalf("wrong failure message: %v", have.FailureMessage)
+		}
+	})
+}
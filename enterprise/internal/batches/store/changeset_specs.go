@@ -46,6 +46,8 @@ var changesetSpecInsertColumns = []string{
 	"commit_author_name",
 	"commit_author_email",
 	"type",
+	"rollout_stage",
+	"kind",
 }
 
 // changesetSpecColumns are used by the changeset spec related Store methods to
@@ -74,6 +76,8 @@ var changesetSpecColumns = SQLColumns{
 	"changeset_specs.commit_author_name",
 	"changeset_specs.commit_author_email",
 	"changeset_specs.type",
+	"changeset_specs.rollout_stage",
+	"changeset_specs.kind",
 }
 
 var oneGigabyte = 1000000000
@@ -140,6 +144,8 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				dbutil.NewNullString(c.CommitAuthorName),
 				dbutil.NewNullString(c.CommitAuthorEmail),
 				c.Type,
+				c.RolloutStage,
+				changesetKindColumn(c.Kind),
 			); err != nil {
 				return err
 			}
@@ -575,6 +581,8 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &c.CommitAuthorName},
 		&dbutil.NullString{S: &c.CommitAuthorEmail},
 		&typ,
+		&c.RolloutStage,
+		&c.Kind,
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset spec")
@@ -808,8 +816,8 @@ SELECT mappings.changeset_spec_id, mappings.changeset_id, mappings.repo_id FROM
 
 	UNION ALL
 
-	-- Fetch all changeset specs in the batch spec that are of type ChangesetSpecDescriptionTypeBranch.
-	-- Match the entries to changesets in the target batch change by head ref and repo.
+	-- Fetch all changeset specs in the batch spec that are of type ChangesetSpecDescriptionTypeBranch or ChangesetSpecDescriptionTypeIssue.
+	-- Match the entries to changesets in the target batch change by head ref, kind and repo.
 	SELECT
 		changeset_spec_id, MAX(CASE WHEN owner_batch_change_id = %s THEN changeset_id ELSE 0 END), repo_id
 	FROM
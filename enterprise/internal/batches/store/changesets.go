@@ -16,6 +16,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/search"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -24,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -69,6 +71,8 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
 	sqlf.Sprintf("changesets.detached_at"),
+	sqlf.Sprintf("changesets.refresh_base_rev"),
+	sqlf.Sprintf("changesets.kind"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -107,6 +111,8 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("refresh_base_rev"),
+	sqlf.Sprintf("kind"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -191,6 +197,8 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		c.RefreshBaseRev,
+		changesetKindColumn(c.Kind),
 		nullStringColumn(title),
 	}
 
@@ -235,7 +243,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) (err e
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store/changesets.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -368,6 +376,7 @@ type GetChangesetOpts struct {
 	ExternalID          string
 	ExternalServiceType string
 	ExternalBranch      string
+	Kind                btypes.ChangesetKind
 	ReconcilerState     btypes.ReconcilerState
 	PublicationState    btypes.ChangesetPublicationState
 }
@@ -423,6 +432,9 @@ func getChangesetQuery(opts *GetChangesetOpts) *sqlf.Query {
 	if opts.ExternalBranch != "" {
 		preds = append(preds, sqlf.Sprintf("changesets.external_branch = %s", opts.ExternalBranch))
 	}
+	if opts.Kind != "" {
+		preds = append(preds, sqlf.Sprintf("changesets.kind = %s", opts.Kind))
+	}
 	if opts.ReconcilerState != "" {
 		preds = append(preds, sqlf.Sprintf("changesets.reconciler_state = %s", opts.ReconcilerState.ToDB()))
 	}
@@ -742,7 +754,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store_changesets.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1157,6 +1169,8 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
 		&dbutil.NullTime{Time: &t.DetachedAt},
+		&t.RefreshBaseRev,
+		&t.Kind,
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1175,16 +1189,29 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 
 	switch t.ExternalServiceType {
 	case extsvc.TypeGitHub:
+		if t.IsIssue() {
+			t.Metadata = new(github.Issue)
+			break
+		}
 		t.Metadata = new(github.PullRequest)
 	case extsvc.TypeBitbucketServer:
 		t.Metadata = new(bitbucketserver.PullRequest)
 	case extsvc.TypeGitLab:
+		if t.IsIssue() {
+			t.Metadata = new(gitlab.Issue)
+			break
+		}
 		t.Metadata = new(gitlab.MergeRequest)
 	case extsvc.TypeBitbucketCloud:
 		m := new(bbcs.AnnotatedPullRequest)
 		// Ensure the inner PR is initialized, it should never be nil.
 		m.PullRequest = &bitbucketcloud.PullRequest{}
 		t.Metadata = m
+	case extsvc.TypeGerrit:
+		m := new(gerritbatches.AnnotatedChange)
+		// Ensure the inner change is initialized, it should never be nil.
+		m.Change = &gerrit.Change{}
+		t.Metadata = m
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -1450,6 +1477,15 @@ func uiPublicationStateColumn(c *btypes.Changeset) *string {
 	return uiPublicationState
 }
 
+// changesetKindColumn returns the kind to store for a changeset, defaulting to
+// pull requests when it isn't set.
+func changesetKindColumn(kind btypes.ChangesetKind) btypes.ChangesetKind {
+	if kind == "" {
+		return btypes.ChangesetKindPullRequest
+	}
+	return kind
+}
+
 // CleanDetachedChangesets deletes changesets that have been detached after duration specified.
 func (s *Store) CleanDetachedChangesets(ctx context.Context, retention time.Duration) (err error) {
 	ctx, _, endObservation := s.operations.cleanDetachedChangesets.With(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -43,6 +43,9 @@ func TestIntegration(t *testing.T) {
 		t.Run("BatchSpecWorkspaceExecutionJobs", storeTest(db, nil, testStoreBatchSpecWorkspaceExecutionJobs))
 		t.Run("BatchSpecResolutionJobs", storeTest(db, nil, testStoreBatchSpecResolutionJobs))
 		t.Run("BatchSpecExecutionCacheEntries", storeTest(db, nil, testStoreBatchSpecExecutionCacheEntries))
+		t.Run("BatchChangeMergePolicies", storeTest(db, nil, testStoreBatchChangeMergePolicies))
+		t.Run("RolloutStages", storeTest(db, nil, testStoreRolloutStages))
+		t.Run("ChangesetRefreshes", storeTest(db, nil, testStoreChangesetRefreshes))
 
 		for name, key := range map[string]encryption.Key{
 			"no key":   nil,
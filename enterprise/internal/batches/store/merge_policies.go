@@ -0,0 +1,270 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// mergePolicyColumns are used by the merge policy related Store methods to
+// query merge policies.
+var mergePolicyColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_merge_policies.batch_change_id"),
+	sqlf.Sprintf("batch_change_merge_policies.merge_method"),
+	sqlf.Sprintf("batch_change_merge_policies.required_approvals"),
+	sqlf.Sprintf("batch_change_merge_policies.windows"),
+	sqlf.Sprintf("batch_change_merge_policies.max_merges_per_hour"),
+	sqlf.Sprintf("batch_change_merge_policies.creator_id"),
+	sqlf.Sprintf("batch_change_merge_policies.created_at"),
+	sqlf.Sprintf("batch_change_merge_policies.updated_at"),
+}
+
+// UpsertBatchChangeMergePolicy creates the given merge policy, or replaces the
+// merge policy of its batch change if there already is one.
+func (s *Store) UpsertBatchChangeMergePolicy(ctx context.Context, p *btypes.BatchChangeMergePolicy) (err error) {
+	ctx, _, endObservation := s.operations.upsertBatchChangeMergePolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(p.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q, err := s.upsertBatchChangeMergePolicyQuery(p)
+	if err != nil {
+		return err
+	}
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeMergePolicy(p, sc)
+	})
+}
+
+var upsertBatchChangeMergePolicyQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_policies.go:UpsertBatchChangeMergePolicy
+INSERT INTO batch_change_merge_policies (
+	batch_change_id,
+	merge_method,
+	required_approvals,
+	windows,
+	max_merges_per_hour,
+	creator_id,
+	created_at,
+	updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (batch_change_id)
+DO UPDATE SET
+	merge_method = EXCLUDED.merge_method,
+	required_approvals = EXCLUDED.required_approvals,
+	windows = EXCLUDED.windows,
+	max_merges_per_hour = EXCLUDED.max_merges_per_hour,
+	creator_id = EXCLUDED.creator_id,
+	updated_at = EXCLUDED.updated_at
+RETURNING %s
+`
+
+func (s *Store) upsertBatchChangeMergePolicyQuery(p *btypes.BatchChangeMergePolicy) (*sqlf.Query, error) {
+	now := s.now()
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = now
+	}
+	p.UpdatedAt = now
+
+	windows := p.Windows
+	if windows == nil {
+		windows = []*schema.BatchChangeRolloutWindow{}
+	}
+	windowsColumn, err := jsonbColumn(windows)
+	if err != nil {
+		return nil, err
+	}
+
+	return sqlf.Sprintf(
+		upsertBatchChangeMergePolicyQueryFmtstr,
+		p.BatchChangeID,
+		p.MergeMethod,
+		p.RequiredApprovals,
+		windowsColumn,
+		p.MaxMergesPerHour,
+		nullInt32Column(p.CreatorID),
+		p.CreatedAt,
+		p.UpdatedAt,
+		sqlf.Join(mergePolicyColumns, ", "),
+	), nil
+}
+
+// GetBatchChangeMergePolicy returns the merge policy of the batch change with
+// the given ID. ErrNoResults is returned if the batch change has no merge
+// policy.
+func (s *Store) GetBatchChangeMergePolicy(ctx context.Context, batchChangeID int64) (p *btypes.BatchChangeMergePolicy, err error) {
+	ctx, _, endObservation := s.operations.getBatchChangeMergePolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getBatchChangeMergePolicyQueryFmtstr,
+		sqlf.Join(mergePolicyColumns, ", "),
+		batchChangeID,
+	)
+
+	var policy btypes.BatchChangeMergePolicy
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeMergePolicy(&policy, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.BatchChangeID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &policy, nil
+}
+
+var getBatchChangeMergePolicyQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_policies.go:GetBatchChangeMergePolicy
+SELECT %s
+FROM batch_change_merge_policies
+WHERE batch_change_id = %s
+`
+
+// DeleteBatchChangeMergePolicy deletes the merge policy of the batch change
+// with the given ID. ErrNoResults is returned if the batch change has no merge
+// policy.
+func (s *Store) DeleteBatchChangeMergePolicy(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchChangeMergePolicy.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteBatchChangeMergePolicyQueryFmtstr, batchChangeID))
+	if err != nil {
+		return err
+	}
+
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteBatchChangeMergePolicyQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_policies.go:DeleteBatchChangeMergePolicy
+DELETE FROM batch_change_merge_policies
+WHERE batch_change_id = %s
+`
+
+// ListBatchChangeMergePoliciesOpts captures the query options needed for
+// listing merge policies.
+type ListBatchChangeMergePoliciesOpts struct {
+	// OnlyOpen only includes the merge policies of batch changes that have
+	// been applied and are not closed.
+	OnlyOpen bool
+}
+
+// ListBatchChangeMergePolicies lists the merge policies of batch changes.
+func (s *Store) ListBatchChangeMergePolicies(ctx context.Context, opts ListBatchChangeMergePoliciesOpts) (ps []*btypes.BatchChangeMergePolicy, err error) {
+	ctx, _, endObservation := s.operations.listBatchChangeMergePolicies.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.OnlyOpen {
+		preds = append(preds,
+			sqlf.Sprintf("batch_changes.closed_at IS NULL"),
+			sqlf.Sprintf("batch_changes.last_applied_at IS NOT NULL"),
+		)
+	}
+
+	q := sqlf.Sprintf(
+		listBatchChangeMergePoliciesQueryFmtstr,
+		sqlf.Join(mergePolicyColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var p btypes.BatchChangeMergePolicy
+		if err := scanBatchChangeMergePolicy(&p, sc); err != nil {
+			return err
+		}
+		ps = append(ps, &p)
+		return nil
+	})
+
+	return ps, err
+}
+
+var listBatchChangeMergePoliciesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_policies.go:ListBatchChangeMergePolicies
+SELECT %s
+FROM batch_change_merge_policies
+JOIN batch_changes ON batch_changes.id = batch_change_merge_policies.batch_change_id
+WHERE %s
+ORDER BY batch_change_merge_policies.batch_change_id ASC
+`
+
+// CountAutoMergedChangesets returns the number of changesets of the batch
+// change with the given ID that its merge policy merged since the given time.
+func (s *Store) CountAutoMergedChangesets(ctx context.Context, batchChangeID int64, since time.Time) (count int, err error) {
+	ctx, _, endObservation := s.operations.countAutoMergedChangesets.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.queryCount(ctx, sqlf.Sprintf(
+		countAutoMergedChangesetsQueryFmtstr,
+		strconv.Itoa(int(batchChangeID)),
+		btypes.ChangesetEventKindAutoMergeMerged,
+		batchChangeID,
+		since,
+	))
+}
+
+// The changesets of the batch change are found through the index on
+// batch_change_ids, and their events through the unique index on
+// (changeset_id, kind, key), so that the events metadata is only read for the
+// events of the batch change.
+var countAutoMergedChangesetsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_policies.go:CountAutoMergedChangesets
+SELECT COUNT(*)
+FROM changesets
+INNER JOIN changeset_events ON changeset_events.changeset_id = changesets.id
+WHERE
+	changesets.batch_change_ids ? %s
+	AND changeset_events.kind = %s
+	AND (changeset_events.metadata->>'batch_change_id')::bigint = %s
+	AND changeset_events.created_at >= %s
+`
+
+func scanBatchChangeMergePolicy(p *btypes.BatchChangeMergePolicy, s dbutil.Scanner) error {
+	var windows json.RawMessage
+
+	if err := s.Scan(
+		&p.BatchChangeID,
+		&p.MergeMethod,
+		&p.RequiredApprovals,
+		&windows,
+		&p.MaxMergesPerHour,
+		&dbutil.NullInt32{N: &p.CreatorID},
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(windows, &p.Windows); err != nil {
+		return errors.Wrap(err, "scanBatchChangeMergePolicy: failed to unmarshal windows")
+	}
+	return nil
+}
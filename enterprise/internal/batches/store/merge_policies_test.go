@@ -0,0 +1,216 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func testStoreBatchChangeMergePolicies(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "merge-policies", user.ID, 0)
+	openBatchChange := bt.CreateBatchChange(t, ctx, s, "open", user.ID, batchSpec.ID)
+	closedBatchChange := bt.CreateBatchChange(t, ctx, s, "closed", user.ID, batchSpec.ID)
+	closedBatchChange.ClosedAt = clock.Now()
+	if err := s.UpdateBatchChange(ctx, closedBatchChange); err != nil {
+		t.Fatal(err)
+	}
+	otherBatchChange := bt.CreateBatchChange(t, ctx, s, "other", user.ID, batchSpec.ID)
+
+	policies := make([]*btypes.BatchChangeMergePolicy, 0, 2)
+
+	t.Run("Upsert", func(t *testing.T) {
+		for _, bc := range []*btypes.BatchChange{openBatchChange, closedBatchChange} {
+			p := &btypes.BatchChangeMergePolicy{
+				BatchChangeID:     bc.ID,
+				MergeMethod:       btypes.MergePolicyMethodSquash,
+				RequiredApprovals: 2,
+				Windows: []*schema.BatchChangeRolloutWindow{
+					{Days: []string{"monday"}, Start: "09:00", End: "17:00", Rate: "unlimited"},
+				},
+				MaxMergesPerHour: 5,
+				CreatorID:        user.ID,
+			}
+
+			if err := s.UpsertBatchChangeMergePolicy(ctx, p); err != nil {
+				t.Fatal(err)
+			}
+
+			have := p
+			want := &btypes.BatchChangeMergePolicy{
+				BatchChangeID:     bc.ID,
+				MergeMethod:       btypes.MergePolicyMethodSquash,
+				RequiredApprovals: 2,
+				Windows: []*schema.BatchChangeRolloutWindow{
+					{Days: []string{"monday"}, Start: "09:00", End: "17:00", Rate: "unlimited"},
+				},
+				MaxMergesPerHour: 5,
+				CreatorID:        user.ID,
+				CreatedAt:        clock.Now(),
+				UpdatedAt:        clock.Now(),
+			}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			policies = append(policies, p)
+		}
+
+		t.Run("Update", func(t *testing.T) {
+			clock.Add(1 * time.Second)
+
+			p := policies[0].Clone()
+			p.MergeMethod = btypes.MergePolicyMethodMerge
+			p.RequiredApprovals = 0
+			p.Windows = nil
+			p.MaxMergesPerHour = 0
+
+			if err := s.UpsertBatchChangeMergePolicy(ctx, p); err != nil {
+				t.Fatal(err)
+			}
+
+			want := policies[0].Clone()
+			want.MergeMethod = btypes.MergePolicyMethodMerge
+			want.RequiredApprovals = 0
+			want.Windows = []*schema.BatchChangeRolloutWindow{}
+			want.MaxMergesPerHour = 0
+			want.UpdatedAt = clock.Now()
+
+			if diff := cmp.Diff(p, want); diff != "" {
+				t.Fatal(diff)
+			}
+			policies[0] = p
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for _, want := range policies {
+			have, err := s.GetBatchChangeMergePolicy(ctx, want.BatchChangeID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, err := s.GetBatchChangeMergePolicy(ctx, otherBatchChange.ID)
+			if err != ErrNoResults {
+				t.Fatalf("unexpected error: want=%v have=%v", ErrNoResults, err)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListBatchChangeMergePolicies(ctx, ListBatchChangeMergePoliciesOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, policies); diff != "" {
+			t.Fatal(diff)
+		}
+
+		t.Run("OnlyOpen", func(t *testing.T) {
+			have, err := s.ListBatchChangeMergePolicies(ctx, ListBatchChangeMergePoliciesOpts{OnlyOpen: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, policies[:1]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+
+	t.Run("CountAutoMergedChangesets", func(t *testing.T) {
+		repos, _ := bt.CreateTestRepos(t, ctx, s.DatabaseDB(), 1)
+		changesets := make([]*btypes.Changeset, 0, 4)
+		for _, bc := range []*btypes.BatchChange{openBatchChange, openBatchChange, otherBatchChange, openBatchChange} {
+			changesets = append(changesets, bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+				Repo:        repos[0].ID,
+				BatchChange: bc.ID,
+			}))
+		}
+
+		events := []*btypes.ChangesetEvent{
+			{
+				ChangesetID: changesets[0].ID,
+				Kind:        btypes.ChangesetEventKindAutoMergeMerged,
+				Key:         "old",
+				Metadata:    &btypes.AutoMergeEvent{BatchChangeID: openBatchChange.ID},
+			},
+			{
+				ChangesetID: changesets[1].ID,
+				Kind:        btypes.ChangesetEventKindAutoMergeFailed,
+				Key:         "failed",
+				Metadata:    &btypes.AutoMergeEvent{BatchChangeID: openBatchChange.ID, Error: "conflict"},
+			},
+			{
+				ChangesetID: changesets[2].ID,
+				Kind:        btypes.ChangesetEventKindAutoMergeMerged,
+				Key:         "other",
+				Metadata:    &btypes.AutoMergeEvent{BatchChangeID: otherBatchChange.ID},
+			},
+		}
+		if err := s.UpsertChangesetEvents(ctx, events...); err != nil {
+			t.Fatal(err)
+		}
+
+		since := clock.Now()
+		clock.Add(1 * time.Second)
+
+		recent := &btypes.ChangesetEvent{
+			ChangesetID: changesets[3].ID,
+			Kind:        btypes.ChangesetEventKindAutoMergeMerged,
+			Key:         "recent",
+			Metadata:    &btypes.AutoMergeEvent{BatchChangeID: openBatchChange.ID},
+		}
+		if err := s.UpsertChangesetEvents(ctx, recent); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, tc := range map[string]struct {
+			batchChangeID int64
+			since         time.Time
+			want          int
+		}{
+			"all":   {batchChangeID: openBatchChange.ID, since: time.Time{}, want: 2},
+			"since": {batchChangeID: openBatchChange.ID, since: since.Add(1 * time.Millisecond), want: 1},
+			"other": {batchChangeID: otherBatchChange.ID, since: time.Time{}, want: 1},
+			"none":  {batchChangeID: closedBatchChange.ID, since: time.Time{}, want: 0},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := s.CountAutoMergedChangesets(ctx, tc.batchChangeID, tc.since)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have != tc.want {
+					t.Fatalf("unexpected count: want=%d have=%d", tc.want, have)
+				}
+			})
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteBatchChangeMergePolicy(ctx, policies[0].BatchChangeID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := s.GetBatchChangeMergePolicy(ctx, policies[0].BatchChangeID); err != ErrNoResults {
+			t.Fatalf("unexpected error: want=%v have=%v", ErrNoResults, err)
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			if err := s.DeleteBatchChangeMergePolicy(ctx, otherBatchChange.ID); err != ErrNoResults {
+				t.Fatalf("unexpected error: want=%v have=%v", ErrNoResults, err)
+			}
+		})
+	})
+}
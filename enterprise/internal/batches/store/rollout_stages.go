@@ -0,0 +1,281 @@
+package store
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// SetChangesetSpecRolloutStages sets the rollout stage of the changeset specs
+// of the given batch spec that create a new changeset. The stage of each spec
+// is looked up by its repository in stages, and specs for repositories without
+// a stage are assigned to defaultStage.
+func (s *Store) SetChangesetSpecRolloutStages(ctx context.Context, batchSpecID int64, stages map[api.RepoID]int32, defaultStage int32) (err error) {
+	ctx, _, endObservation := s.operations.setChangesetSpecRolloutStages.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchSpecID", int(batchSpecID)),
+		log.Int("count", len(stages)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	repoIDs := make([]int32, 0, len(stages))
+	repoStages := make([]int32, 0, len(stages))
+	for repoID, stage := range stages {
+		repoIDs = append(repoIDs, int32(repoID))
+		repoStages = append(repoStages, stage)
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		setChangesetSpecRolloutStagesQueryFmtstr,
+		pq.Array(repoIDs),
+		pq.Array(repoStages),
+		defaultStage,
+		batchSpecID,
+	))
+}
+
+var setChangesetSpecRolloutStagesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/rollout_stages.go:SetChangesetSpecRolloutStages
+WITH stages AS (
+	SELECT * FROM unnest(%s::integer[], %s::integer[]) AS stages(repo_id, stage)
+)
+UPDATE changeset_specs
+SET rollout_stage = COALESCE((SELECT MIN(stages.stage) FROM stages WHERE stages.repo_id = changeset_specs.repo_id), %s)
+WHERE
+	changeset_specs.batch_spec_id = %s
+	AND
+	changeset_specs.external_id IS NULL
+`
+
+// ListRolloutStages returns the changeset counts of each rollout stage of the
+// batch spec with the given ID. Changesets count as done once they reached the
+// state given in waitFor, or once they were closed or deleted on the code host,
+// since they won't ever reach that state then.
+//
+// Only changesets that are published or meant to be published are counted, so
+// that changesets that are unpublished on purpose don't hold back later stages.
+func (s *Store) ListRolloutStages(ctx context.Context, batchSpecID int64, waitFor batcheslib.RolloutWaitFor) (stages btypes.RolloutStages, err error) {
+	ctx, _, endObservation := s.operations.listRolloutStages.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchSpecID", int(batchSpecID)),
+		log.String("waitFor", string(waitFor)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listRolloutStagesQueryFmtstr,
+		btypes.ChangesetPublicationStatePublished,
+		rolloutDoneQuery(waitFor),
+		batchSpecID,
+		btypes.ChangesetPublicationStatePublished,
+		meantToBePublishedQuery(),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var stage btypes.RolloutStage
+		if err := sc.Scan(&stage.Stage, &stage.Total, &stage.Published, &stage.Done); err != nil {
+			return err
+		}
+		stages = append(stages, &stage)
+		return nil
+	})
+
+	return stages, err
+}
+
+var listRolloutStagesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/rollout_stages.go:ListRolloutStages
+SELECT
+	changeset_specs.rollout_stage,
+	COUNT(*) AS total,
+	COUNT(*) FILTER (WHERE changesets.publication_state = %s) AS published,
+	COUNT(*) FILTER (WHERE %s) AS done
+FROM changesets
+JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+JOIN repo ON repo.id = changesets.repo_id
+WHERE
+	changeset_specs.batch_spec_id = %s
+	AND
+	changeset_specs.rollout_stage > 0
+	AND
+	repo.deleted_at IS NULL
+	AND
+	(changesets.publication_state = %s OR %s)
+GROUP BY changeset_specs.rollout_stage
+ORDER BY changeset_specs.rollout_stage ASC
+`
+
+func rolloutDoneQuery(waitFor batcheslib.RolloutWaitFor) *sqlf.Query {
+	// Merged, closed and deleted changesets are final, whatever the rollout
+	// waits for. Issues can't be merged, so they are done once they're closed.
+	final := sqlf.Sprintf(
+		"changesets.external_state IN (%s, %s, %s)",
+		btypes.ChangesetExternalStateMerged,
+		btypes.ChangesetExternalStateClosed,
+		btypes.ChangesetExternalStateDeleted,
+	)
+	if waitFor == batcheslib.RolloutWaitForApproved {
+		return sqlf.Sprintf(
+			"(%s OR (changesets.external_state = %s AND changesets.external_review_state = %s))",
+			final,
+			btypes.ChangesetExternalStateOpen,
+			btypes.ChangesetReviewStateApproved,
+		)
+	}
+	return final
+}
+
+// meantToBePublishedQuery matches the changesets whose current spec asks for
+// them to be published, either in the spec or in the UI.
+func meantToBePublishedQuery() *sqlf.Query {
+	draftTypes := pq.Array(draftChangesetsExternalServiceTypes())
+	return sqlf.Sprintf(
+		meantToBePublishedQueryFmtstr,
+		draftTypes,
+		btypes.ChangesetUiPublicationStatePublished,
+		btypes.ChangesetUiPublicationStateDraft,
+		draftTypes,
+	)
+}
+
+const meantToBePublishedQueryFmtstr = `
+(
+	changeset_specs.published = 'true'
+	OR
+	(changeset_specs.published = '"draft"' AND changesets.external_service_type = ANY(%s))
+	OR
+	(
+		changeset_specs.published IS NULL
+		AND
+		(
+			changesets.ui_publication_state = %s
+			OR
+			(changesets.ui_publication_state = %s AND changesets.external_service_type = ANY(%s))
+		)
+	)
+)
+`
+
+// ListBatchSpecsWithRolloutStages lists the batch specs of open batch changes
+// that still hold back unpublished changesets of a later rollout stage.
+func (s *Store) ListBatchSpecsWithRolloutStages(ctx context.Context) (cs []*btypes.BatchSpec, err error) {
+	ctx, _, endObservation := s.operations.listBatchSpecsWithRolloutStages.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listBatchSpecsWithRolloutStagesQueryFmtstr,
+		sqlf.Join(batchSpecColumns, ", "),
+		btypes.ChangesetPublicationStateUnpublished,
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.BatchSpec
+		if err := scanBatchSpec(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listBatchSpecsWithRolloutStagesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/rollout_stages.go:ListBatchSpecsWithRolloutStages
+SELECT %s
+FROM batch_specs
+JOIN batch_changes ON batch_changes.batch_spec_id = batch_specs.id
+WHERE
+	batch_changes.closed_at IS NULL
+	AND
+	EXISTS (
+		SELECT 1
+		FROM changesets
+		JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+		WHERE
+			changeset_specs.batch_spec_id = batch_specs.id
+			AND
+			changeset_specs.rollout_stage > 1
+			AND
+			changesets.publication_state = %s
+	)
+ORDER BY batch_specs.id ASC
+`
+
+// EnqueueReleasedRolloutChangesets enqueues the changesets of the given batch
+// spec that were held back because of their rollout stage, up to and including
+// the given stage. The changesets are put into the given reconciler state. It
+// returns the number of enqueued changesets.
+//
+// Only changesets that are meant to be published are enqueued, so that
+// changesets that are unpublished on purpose aren't enqueued over and over
+// again.
+func (s *Store) EnqueueReleasedRolloutChangesets(ctx context.Context, batchSpecID int64, lastStage int32, state btypes.ReconcilerState) (count int, err error) {
+	ctx, _, endObservation := s.operations.enqueueReleasedRolloutChangesets.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchSpecID", int(batchSpecID)),
+		log.Int("lastStage", int(lastStage)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		enqueueReleasedRolloutChangesetsQueryFmtstr,
+		state.ToDB(),
+		s.now(),
+		batchSpecID,
+		lastStage,
+		btypes.ChangesetPublicationStateUnpublished,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		meantToBePublishedQuery(),
+	)
+
+	ids, err := basestore.ScanInts(s.Query(ctx, q))
+	return len(ids), err
+}
+
+var enqueueReleasedRolloutChangesetsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/rollout_stages.go:EnqueueReleasedRolloutChangesets
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	failure_message = NULL,
+	updated_at = %s
+FROM changeset_specs
+WHERE
+	changeset_specs.id = changesets.current_spec_id
+	AND
+	changeset_specs.batch_spec_id = %s
+	AND
+	changeset_specs.rollout_stage > 1
+	AND
+	changeset_specs.rollout_stage <= %s
+	AND
+	changesets.publication_state = %s
+	AND
+	changesets.reconciler_state = %s
+	AND
+	%s
+RETURNING changesets.id
+`
+
+// draftChangesetsExternalServiceTypes returns the external service types that
+// support draft changesets.
+func draftChangesetsExternalServiceTypes() []string {
+	var types []string
+	for t := range btypes.SupportedExternalServices {
+		if btypes.ExternalServiceSupports(t, btypes.CodehostCapabilityDraftChangesets) {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
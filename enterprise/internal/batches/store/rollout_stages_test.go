@@ -0,0 +1,221 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func testStoreRolloutStages(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	repos, _ := bt.CreateTestRepos(t, ctx, s.DatabaseDB(), 4)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "rollout-stages", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "rollout-stages", user.ID, batchSpec.ID)
+
+	specs := make([]*btypes.ChangesetSpec, 0, len(repos))
+	for _, repo := range repos {
+		specs = append(specs, bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+			User:      user.ID,
+			Repo:      repo.ID,
+			BatchSpec: batchSpec.ID,
+			HeadRef:   "refs/heads/rollout",
+			Published: true,
+			Typ:       btypes.ChangesetSpecTypeBranch,
+		}))
+	}
+
+	t.Run("SetChangesetSpecRolloutStages", func(t *testing.T) {
+		stages := map[api.RepoID]int32{
+			repos[0].ID: 1,
+			repos[1].ID: 1,
+			repos[2].ID: 2,
+		}
+		if err := s.SetChangesetSpecRolloutStages(ctx, batchSpec.ID, stages, 3); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, want := range []int32{1, 1, 2, 3} {
+			spec, err := s.GetChangesetSpecByID(ctx, specs[i].ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if spec.RolloutStage != want {
+				t.Fatalf("spec %d: wrong rollout stage. want=%d, have=%d", i, want, spec.RolloutStage)
+			}
+		}
+	})
+
+	changesetOpts := []bt.TestChangesetOpts{
+		{
+			PublicationState: btypes.ChangesetPublicationStatePublished,
+			ExternalState:    btypes.ChangesetExternalStateMerged,
+		},
+		{
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalReviewState: btypes.ChangesetReviewStateApproved,
+		},
+		{PublicationState: btypes.ChangesetPublicationStateUnpublished},
+		{PublicationState: btypes.ChangesetPublicationStateUnpublished},
+	}
+	changesets := make([]*btypes.Changeset, 0, len(changesetOpts))
+	for i, opts := range changesetOpts {
+		opts.Repo = repos[i].ID
+		opts.BatchChange = batchChange.ID
+		opts.OwnedByBatchChange = batchChange.ID
+		opts.CurrentSpec = specs[i].ID
+		opts.ReconcilerState = btypes.ReconcilerStateCompleted
+		changesets = append(changesets, bt.CreateChangeset(t, ctx, s, opts))
+	}
+
+	t.Run("ListRolloutStages", func(t *testing.T) {
+		tcs := map[batcheslib.RolloutWaitFor]btypes.RolloutStages{
+			batcheslib.RolloutWaitForMerged: {
+				{Stage: 1, Total: 2, Published: 2, Done: 1},
+				{Stage: 2, Total: 1},
+				{Stage: 3, Total: 1},
+			},
+			batcheslib.RolloutWaitForApproved: {
+				{Stage: 1, Total: 2, Published: 2, Done: 2},
+				{Stage: 2, Total: 1},
+				{Stage: 3, Total: 1},
+			},
+		}
+
+		for waitFor, want := range tcs {
+			t.Run(string(waitFor), func(t *testing.T) {
+				have, err := s.ListRolloutStages(ctx, batchSpec.ID, waitFor)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(want, have); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		}
+	})
+
+	t.Run("ListBatchSpecsWithRolloutStages", func(t *testing.T) {
+		have, err := s.ListBatchSpecsWithRolloutStages(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ID != batchSpec.ID {
+			t.Fatalf("wrong batch specs returned: %+v", have)
+		}
+	})
+
+	t.Run("EnqueueReleasedRolloutChangesets", func(t *testing.T) {
+		count, err := s.EnqueueReleasedRolloutChangesets(ctx, batchSpec.ID, 2, btypes.ReconcilerStateQueued)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Fatalf("wrong number of enqueued changesets. want=%d, have=%d", 1, count)
+		}
+
+		for i, want := range []btypes.ReconcilerState{
+			btypes.ReconcilerStateCompleted,
+			btypes.ReconcilerStateCompleted,
+			btypes.ReconcilerStateQueued,
+			btypes.ReconcilerStateCompleted,
+		} {
+			have, err := s.GetChangesetByID(ctx, changesets[i].ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.ReconcilerState != want {
+				t.Fatalf("changeset %d: wrong reconciler state. want=%s, have=%s", i, want, have.ReconcilerState)
+			}
+		}
+	})
+
+	t.Run("ListRolloutStages with unpublished and closed changesets", func(t *testing.T) {
+		batchSpec := bt.CreateBatchSpec(t, ctx, s, "rollout-stages-final", user.ID, 0)
+		batchChange := bt.CreateBatchChange(t, ctx, s, "rollout-stages-final", user.ID, batchSpec.ID)
+
+		for i, tc := range []struct {
+			stage int32
+			spec  bt.TestSpecOpts
+			opts  bt.TestChangesetOpts
+		}{
+			// Unpublished on purpose, so it isn't counted.
+			{
+				stage: 1,
+				spec:  bt.TestSpecOpts{HeadRef: "refs/heads/final", Published: false, Typ: btypes.ChangesetSpecTypeBranch},
+				opts:  bt.TestChangesetOpts{PublicationState: btypes.ChangesetPublicationStateUnpublished},
+			},
+			// Closed on the code host, so it's done.
+			{
+				stage: 1,
+				spec:  bt.TestSpecOpts{HeadRef: "refs/heads/final", Published: true, Typ: btypes.ChangesetSpecTypeBranch},
+				opts: bt.TestChangesetOpts{
+					ExternalID:       "final-1",
+					PublicationState: btypes.ChangesetPublicationStatePublished,
+					ExternalState:    btypes.ChangesetExternalStateClosed,
+				},
+			},
+			// Closed issue, which can't ever be merged or approved, so it's done.
+			{
+				stage: 1,
+				spec:  bt.TestSpecOpts{Published: true, Typ: btypes.ChangesetSpecTypeIssue, Kind: btypes.ChangesetKindIssue},
+				opts: bt.TestChangesetOpts{
+					ExternalID:       "final-2",
+					Kind:             btypes.ChangesetKindIssue,
+					PublicationState: btypes.ChangesetPublicationStatePublished,
+					ExternalState:    btypes.ChangesetExternalStateClosed,
+				},
+			},
+			// Held back by the rollout.
+			{
+				stage: 2,
+				spec:  bt.TestSpecOpts{HeadRef: "refs/heads/final", Published: true, Typ: btypes.ChangesetSpecTypeBranch},
+				opts:  bt.TestChangesetOpts{PublicationState: btypes.ChangesetPublicationStateUnpublished},
+			},
+		} {
+			specOpts := tc.spec
+			specOpts.User = user.ID
+			specOpts.Repo = repos[i].ID
+			specOpts.BatchSpec = batchSpec.ID
+			specOpts.RolloutStage = tc.stage
+			spec := bt.CreateChangesetSpec(t, ctx, s, specOpts)
+
+			opts := tc.opts
+			opts.Repo = repos[i].ID
+			opts.BatchChange = batchChange.ID
+			opts.OwnedByBatchChange = batchChange.ID
+			opts.CurrentSpec = spec.ID
+			opts.ReconcilerState = btypes.ReconcilerStateCompleted
+			bt.CreateChangeset(t, ctx, s, opts)
+		}
+
+		for _, waitFor := range []batcheslib.RolloutWaitFor{
+			batcheslib.RolloutWaitForMerged,
+			batcheslib.RolloutWaitForApproved,
+		} {
+			t.Run(string(waitFor), func(t *testing.T) {
+				have, err := s.ListRolloutStages(ctx, batchSpec.ID, waitFor)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := btypes.RolloutStages{
+					{Stage: 1, Total: 2, Published: 2, Done: 2},
+					{Stage: 2, Total: 1},
+				}
+				if diff := cmp.Diff(want, have); diff != "" {
+					t.Fatal(diff)
+				}
+				if lastReleased := have.LastReleased(); lastReleased != 2 {
+					t.Fatalf("wrong last released stage. want=%d, have=%d", 2, lastReleased)
+				}
+			})
+		}
+	})
+}
@@ -250,6 +250,24 @@ type operations struct {
 	listSiteCredentials  *observation.Operation
 	updateSiteCredential *observation.Operation
 
+	upsertBatchChangeMergePolicy *observation.Operation
+	getBatchChangeMergePolicy    *observation.Operation
+	deleteBatchChangeMergePolicy *observation.Operation
+	listBatchChangeMergePolicies *observation.Operation
+	countAutoMergedChangesets    *observation.Operation
+
+	setChangesetSpecRolloutStages    *observation.Operation
+	listRolloutStages                *observation.Operation
+	listBatchSpecsWithRolloutStages  *observation.Operation
+	enqueueReleasedRolloutChangesets *observation.Operation
+
+	changesetAutoRefreshEnabled *observation.Operation
+	requestChangesetRefresh     *observation.Operation
+	refreshBatchSpecWorkspace   *observation.Operation
+	refreshChangesetSpec        *observation.Operation
+	cancelChangesetRefreshes    *observation.Operation
+	failChangesetRefreshes      *observation.Operation
+
 	createBatchSpecWorkspace       *observation.Operation
 	getBatchSpecWorkspace          *observation.Operation
 	listBatchSpecWorkspaces        *observation.Operation
@@ -386,6 +404,24 @@ func newOperations(observationContext *observation.Context) *operations {
 			listSiteCredentials:  op("ListSiteCredentials"),
 			updateSiteCredential: op("UpdateSiteCredential"),
 
+			upsertBatchChangeMergePolicy: op("UpsertBatchChangeMergePolicy"),
+			getBatchChangeMergePolicy:    op("GetBatchChangeMergePolicy"),
+			deleteBatchChangeMergePolicy: op("DeleteBatchChangeMergePolicy"),
+			listBatchChangeMergePolicies: op("ListBatchChangeMergePolicies"),
+			countAutoMergedChangesets:    op("CountAutoMergedChangesets"),
+
+			setChangesetSpecRolloutStages:    op("SetChangesetSpecRolloutStages"),
+			listRolloutStages:                op("ListRolloutStages"),
+			listBatchSpecsWithRolloutStages:  op("ListBatchSpecsWithRolloutStages"),
+			enqueueReleasedRolloutChangesets: op("EnqueueReleasedRolloutChangesets"),
+
+			changesetAutoRefreshEnabled: op("ChangesetAutoRefreshEnabled"),
+			requestChangesetRefresh:     op("RequestChangesetRefresh"),
+			refreshBatchSpecWorkspace:   op("RefreshBatchSpecWorkspace"),
+			refreshChangesetSpec:        op("RefreshChangesetSpec"),
+			cancelChangesetRefreshes:    op("CancelChangesetRefreshes"),
+			failChangesetRefreshes:      op("FailChangesetRefreshes"),
+
 			createBatchSpecWorkspace:       op("CreateBatchSpecWorkspace"),
 			getBatchSpecWorkspace:          op("GetBatchSpecWorkspace"),
 			listBatchSpecWorkspaces:        op("ListBatchSpecWorkspaces"),
@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -77,7 +78,7 @@ type batchSpecWorkspaceExecutionWorkerStore struct {
 
 type markFinal func(ctx context.Context, tx dbworkerstore.Store) (_ bool, err error)
 
-func (s *batchSpecWorkspaceExecutionWorkerStore) markFinal(ctx context.Context, id int, fn markFinal) (ok bool, err error) {
+func (s *batchSpecWorkspaceExecutionWorkerStore) markFinal(ctx context.Context, id int, failureMessage string, fn markFinal) (ok bool, err error) {
 	batchesStore := New(database.NewDBWith(s.logger, s.Store), s.observationContext, nil)
 	tx, err := batchesStore.Transact(ctx)
 	if err != nil {
@@ -124,17 +125,30 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) markFinal(ctx context.Context,
 		return false, err
 	}
 
+	// If the workspace was executed again to refresh its changesets, the
+	// refresh can't complete anymore, so we resolve it as failed instead of
+	// leaving it pending.
+	refresh, err := isChangesetRefresh(ctx, tx, workspace, spec)
+	if err != nil {
+		return false, err
+	}
+	if refresh {
+		if err := tx.FailChangesetRefreshes(ctx, []int64{workspace.ID}, "refreshing changeset failed: "+failureMessage); err != nil {
+			return false, errors.Wrap(err, "failed to fail changeset refreshes")
+		}
+	}
+
 	return fn(ctx, s.Store.With(tx))
 }
 
 func (s *batchSpecWorkspaceExecutionWorkerStore) MarkErrored(ctx context.Context, id int, failureMessage string, options dbworkerstore.MarkFinalOptions) (_ bool, err error) {
-	return s.markFinal(ctx, id, func(ctx context.Context, tx dbworkerstore.Store) (bool, error) {
+	return s.markFinal(ctx, id, failureMessage, func(ctx context.Context, tx dbworkerstore.Store) (bool, error) {
 		return tx.MarkErrored(ctx, id, failureMessage, options)
 	})
 }
 
 func (s *batchSpecWorkspaceExecutionWorkerStore) MarkFailed(ctx context.Context, id int, failureMessage string, options dbworkerstore.MarkFinalOptions) (_ bool, err error) {
-	return s.markFinal(ctx, id, func(ctx context.Context, tx dbworkerstore.Store) (bool, error) {
+	return s.markFinal(ctx, id, failureMessage, func(ctx context.Context, tx dbworkerstore.Store) (bool, error) {
 		return tx.MarkFailed(ctx, id, failureMessage, options)
 	})
 }
@@ -239,6 +253,20 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		specs = append(specs, changesetSpec)
 	}
 
+	// If the changesets of the workspace were already created by applying the
+	// batch spec, the workspace has been executed again to refresh them onto
+	// a new commit of their base branch.
+	refresh, err := isChangesetRefresh(ctx, tx, workspace, batchSpec)
+	if err != nil {
+		return false, err
+	}
+	if refresh {
+		if err := refreshChangesetSpecs(ctx, tx, workspace, specs); err != nil {
+			return false, errors.Wrap(err, "failed to refresh changeset specs")
+		}
+		return s.Store.With(tx).MarkComplete(ctx, id, options)
+	}
+
 	changesetSpecIDs := []int64{}
 	if len(specs) > 0 {
 		if err := tx.CreateChangesetSpec(ctx, specs...); err != nil {
@@ -256,6 +284,65 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 	return s.Store.With(tx).MarkComplete(ctx, id, options)
 }
 
+// isChangesetRefresh returns whether the given workspace has been executed
+// again to refresh the changesets that were created from its changeset specs.
+func isChangesetRefresh(ctx context.Context, tx *Store, workspace *btypes.BatchSpecWorkspace, batchSpec *btypes.BatchSpec) (bool, error) {
+	if len(workspace.ChangesetSpecIDs) == 0 {
+		return false, nil
+	}
+
+	batchChange, err := tx.GetBatchChange(ctx, GetBatchChangeOpts{BatchSpecID: batchSpec.ID})
+	if err != nil {
+		if err == ErrNoResults {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "loading batch change")
+	}
+
+	return !batchChange.IsDraft(), nil
+}
+
+// refreshChangesetSpecs overwrites the diffs of the changeset specs the given
+// workspace produced before with the ones of the given specs, which were built
+// from executing the workspace against a new commit of the base branch. The
+// specs are matched by their head ref, and the changesets of the refreshed
+// specs are enqueued to push the new diff. Refreshes of changesets whose spec
+// isn't produced anymore are canceled.
+func refreshChangesetSpecs(ctx context.Context, tx *Store, workspace *btypes.BatchSpecWorkspace, specs []*btypes.ChangesetSpec) error {
+	previousSpecs, _, err := tx.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{IDs: workspace.ChangesetSpecIDs})
+	if err != nil {
+		return err
+	}
+
+	specsByHeadRef := make(map[string]*btypes.ChangesetSpec, len(specs))
+	for _, spec := range specs {
+		specsByHeadRef[spec.HeadRef] = spec
+	}
+
+	var canceled []int64
+	for _, previous := range previousSpecs {
+		spec, ok := specsByHeadRef[previous.HeadRef]
+		if !ok {
+			canceled = append(canceled, previous.ID)
+			continue
+		}
+
+		previous.Diff = spec.Diff
+		previous.BaseRev = spec.BaseRev
+		previous.DiffStatAdded = spec.DiffStatAdded
+		previous.DiffStatChanged = spec.DiffStatChanged
+		previous.DiffStatDeleted = spec.DiffStatDeleted
+		if err := tx.RefreshChangesetSpec(ctx, previous, global.DefaultReconcilerEnqueueState()); err != nil {
+			return err
+		}
+	}
+
+	if len(canceled) == 0 {
+		return nil
+	}
+	return tx.CancelChangesetRefreshes(ctx, canceled)
+}
+
 func (s *batchSpecWorkspaceExecutionWorkerStore) setChangesetSpecIDs(ctx context.Context, tx *Store, batchSpecWorkspaceID int64, changesetSpecIDs []int64) error {
 	// Marshal changeset spec IDs for database JSON column.
 	m := make(map[int64]struct{}, len(changesetSpecIDs))
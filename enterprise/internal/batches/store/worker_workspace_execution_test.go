@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -216,6 +217,75 @@ stdout: {"operation":"CACHE_AFTER_STEP_RESULT","timestamp":"2021-11-04T12:43:19.
 
 		assertNoChangesetSpecsCreated(t)
 	})
+
+	t.Run("refresh", func(t *testing.T) {
+		job, workspace := setupEntities(t)
+		setProcessing(t, job)
+
+		ok, err := executionStore.MarkComplete(context.Background(), int(job.ID), opts)
+		if !ok || err != nil {
+			t.Fatalf("MarkComplete failed. ok=%t, err=%s", ok, err)
+		}
+
+		specs, _, err := s.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{BatchSpecID: batchSpec.ID})
+		if err != nil {
+			t.Fatalf("failed to load changeset specs: %s", err)
+		}
+		if have, want := len(specs), 1; have != want {
+			t.Fatalf("invalid number of changeset specs created: have=%d want=%d", have, want)
+		}
+		spec := specs[0]
+
+		// Apply the batch spec and request a refresh of the resulting changeset.
+		batchChange := bt.CreateBatchChange(t, ctx, s, "refresh", user.ID, batchSpec.ID)
+		changeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			CurrentSpec:        spec.ID,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			RefreshBaseRev:     "refreshed",
+		})
+
+		if err := s.RefreshBatchSpecWorkspace(ctx, workspace.ID, "refreshed", nil); err != nil {
+			t.Fatal(err)
+		}
+		refreshJob, err := s.GetBatchSpecWorkspaceExecutionJob(ctx, GetBatchSpecWorkspaceExecutionJobOpts{BatchSpecWorkspaceID: workspace.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := workStore.AddExecutionLogEntry(ctx, int(refreshJob.ID), entry, dbworkerstore.ExecutionLogEntryOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		setProcessing(t, refreshJob)
+
+		ok, err = executionStore.MarkComplete(context.Background(), int(refreshJob.ID), opts)
+		if !ok || err != nil {
+			t.Fatalf("MarkComplete failed. ok=%t, err=%s", ok, err)
+		}
+
+		assertJobState(t, refreshJob, btypes.BatchSpecWorkspaceExecutionJobStateCompleted)
+
+		// The existing changeset spec is refreshed instead of creating a new one.
+		assertWorkspaceChangesets(t, refreshJob, []int64{spec.ID})
+		reloadedSpec, err := s.GetChangesetSpecByID(ctx, spec.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloadedSpec.BaseRev, "refreshed"; have != want {
+			t.Fatalf("wrong base rev of refreshed changeset spec: have=%q want=%q", have, want)
+		}
+
+		reloadedChangeset, err := s.GetChangesetByID(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloadedChangeset.ReconcilerState, global.DefaultReconcilerEnqueueState(); have != want {
+			t.Fatalf("wrong reconciler state of refreshed changeset: have=%s want=%s", have, want)
+		}
+	})
 }
 
 func TestBatchSpecWorkspaceExecutionWorkerStore_MarkFailed(t *testing.T) {
@@ -21,6 +21,10 @@ import (
 // github.com/sourcegraph/sourcegraph/enterprise/internal/batches/syncer)
 // used for unit testing.
 type MockSyncStore struct {
+	// ChangesetAutoRefreshEnabledFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ChangesetAutoRefreshEnabled.
+	ChangesetAutoRefreshEnabledFunc *SyncStoreChangesetAutoRefreshEnabledFunc
 	// ClockFunc is an instance of a mock function object controlling the
 	// behavior of the method Clock.
 	ClockFunc *SyncStoreClockFunc
@@ -54,6 +58,9 @@ type MockSyncStore struct {
 	// ReposFunc is an instance of a mock function object controlling the
 	// behavior of the method Repos.
 	ReposFunc *SyncStoreReposFunc
+	// RequestChangesetRefreshFunc is an instance of a mock function object
+	// controlling the behavior of the method RequestChangesetRefresh.
+	RequestChangesetRefreshFunc *SyncStoreRequestChangesetRefreshFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *SyncStoreTransactFunc
@@ -73,6 +80,11 @@ type MockSyncStore struct {
 // methods return zero values for all results, unless overwritten.
 func NewMockSyncStore() *MockSyncStore {
 	return &MockSyncStore{
+		ChangesetAutoRefreshEnabledFunc: &SyncStoreChangesetAutoRefreshEnabledFunc{
+			defaultHook: func(context.Context, int64) (r0 bool, r1 error) {
+				return
+			},
+		},
 		ClockFunc: &SyncStoreClockFunc{
 			defaultHook: func() (r0 func() time.Time) {
 				return
@@ -128,6 +140,11 @@ func NewMockSyncStore() *MockSyncStore {
 				return
 			},
 		},
+		RequestChangesetRefreshFunc: &SyncStoreRequestChangesetRefreshFunc{
+			defaultHook: func(context.Context, int64, string, string, types.ReconcilerState) (r0 bool, r1 error) {
+				return
+			},
+		},
 		TransactFunc: &SyncStoreTransactFunc{
 			defaultHook: func(context.Context) (r0 *store.Store, r1 error) {
 				return
@@ -155,6 +172,11 @@ func NewMockSyncStore() *MockSyncStore {
 // methods panic on invocation, unless overwritten.
 func NewStrictMockSyncStore() *MockSyncStore {
 	return &MockSyncStore{
+		ChangesetAutoRefreshEnabledFunc: &SyncStoreChangesetAutoRefreshEnabledFunc{
+			defaultHook: func(context.Context, int64) (bool, error) {
+				panic("unexpected invocation of MockSyncStore.ChangesetAutoRefreshEnabled")
+			},
+		},
 		ClockFunc: &SyncStoreClockFunc{
 			defaultHook: func() func() time.Time {
 				panic("unexpected invocation of MockSyncStore.Clock")
@@ -210,6 +232,11 @@ func NewStrictMockSyncStore() *MockSyncStore {
 				panic("unexpected invocation of MockSyncStore.Repos")
 			},
 		},
+		RequestChangesetRefreshFunc: &SyncStoreRequestChangesetRefreshFunc{
+			defaultHook: func(context.Context, int64, string, string, types.ReconcilerState) (bool, error) {
+				panic("unexpected invocation of MockSyncStore.RequestChangesetRefresh")
+			},
+		},
 		TransactFunc: &SyncStoreTransactFunc{
 			defaultHook: func(context.Context) (*store.Store, error) {
 				panic("unexpected invocation of MockSyncStore.Transact")
@@ -237,6 +264,9 @@ func NewStrictMockSyncStore() *MockSyncStore {
 // All methods delegate to the given implementation, unless overwritten.
 func NewMockSyncStoreFrom(i SyncStore) *MockSyncStore {
 	return &MockSyncStore{
+		ChangesetAutoRefreshEnabledFunc: &SyncStoreChangesetAutoRefreshEnabledFunc{
+			defaultHook: i.ChangesetAutoRefreshEnabled,
+		},
 		ClockFunc: &SyncStoreClockFunc{
 			defaultHook: i.Clock,
 		},
@@ -270,6 +300,9 @@ func NewMockSyncStoreFrom(i SyncStore) *MockSyncStore {
 		ReposFunc: &SyncStoreReposFunc{
 			defaultHook: i.Repos,
 		},
+		RequestChangesetRefreshFunc: &SyncStoreRequestChangesetRefreshFunc{
+			defaultHook: i.RequestChangesetRefresh,
+		},
 		TransactFunc: &SyncStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
@@ -285,6 +318,117 @@ func NewMockSyncStoreFrom(i SyncStore) *MockSyncStore {
 	}
 }
 
+// SyncStoreChangesetAutoRefreshEnabledFunc describes the behavior when the
+// ChangesetAutoRefreshEnabled method of the parent MockSyncStore instance
+// is invoked.
+type SyncStoreChangesetAutoRefreshEnabledFunc struct {
+	defaultHook func(context.Context, int64) (bool, error)
+	hooks       []func(context.Context, int64) (bool, error)
+	history     []SyncStoreChangesetAutoRefreshEnabledFuncCall
+	mutex       sync.Mutex
+}
+
+// ChangesetAutoRefreshEnabled delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSyncStore) ChangesetAutoRefreshEnabled(v0 context.Context, v1 int64) (bool, error) {
+	r0, r1 := m.ChangesetAutoRefreshEnabledFunc.nextHook()(v0, v1)
+	m.ChangesetAutoRefreshEnabledFunc.appendCall(SyncStoreChangesetAutoRefreshEnabledFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ChangesetAutoRefreshEnabled method of the parent MockSyncStore instance
+// is invoked and the hook queue is empty.
+func (f *SyncStoreChangesetAutoRefreshEnabledFunc) SetDefaultHook(hook func(context.Context, int64) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ChangesetAutoRefreshEnabled method of the parent MockSyncStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SyncStoreChangesetAutoRefreshEnabledFunc) PushHook(hook func(context.Context, int64) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SyncStoreChangesetAutoRefreshEnabledFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SyncStoreChangesetAutoRefreshEnabledFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int64) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *SyncStoreChangesetAutoRefreshEnabledFunc) nextHook() func(context.Context, int64) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SyncStoreChangesetAutoRefreshEnabledFunc) appendCall(r0 SyncStoreChangesetAutoRefreshEnabledFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SyncStoreChangesetAutoRefreshEnabledFuncCall
+// objects describing the invocations of this function.
+func (f *SyncStoreChangesetAutoRefreshEnabledFunc) History() []SyncStoreChangesetAutoRefreshEnabledFuncCall {
+	f.mutex.Lock()
+	history := make([]SyncStoreChangesetAutoRefreshEnabledFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SyncStoreChangesetAutoRefreshEnabledFuncCall is an object that describes
+// an invocation of method ChangesetAutoRefreshEnabled on an instance of
+// MockSyncStore.
+type SyncStoreChangesetAutoRefreshEnabledFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SyncStoreChangesetAutoRefreshEnabledFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SyncStoreChangesetAutoRefreshEnabledFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SyncStoreClockFunc describes the behavior when the Clock method of the
 // parent MockSyncStore instance is invoked.
 type SyncStoreClockFunc struct {
@@ -1441,6 +1585,126 @@ func (c SyncStoreReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// SyncStoreRequestChangesetRefreshFunc describes the behavior when the
+// RequestChangesetRefresh method of the parent MockSyncStore instance is
+// invoked.
+type SyncStoreRequestChangesetRefreshFunc struct {
+	defaultHook func(context.Context, int64, string, string, types.ReconcilerState) (bool, error)
+	hooks       []func(context.Context, int64, string, string, types.ReconcilerState) (bool, error)
+	history     []SyncStoreRequestChangesetRefreshFuncCall
+	mutex       sync.Mutex
+}
+
+// RequestChangesetRefresh delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSyncStore) RequestChangesetRefresh(v0 context.Context, v1 int64, v2 string, v3 string, v4 types.ReconcilerState) (bool, error) {
+	r0, r1 := m.RequestChangesetRefreshFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.RequestChangesetRefreshFunc.appendCall(SyncStoreRequestChangesetRefreshFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// RequestChangesetRefresh method of the parent MockSyncStore instance is
+// invoked and the hook queue is empty.
+func (f *SyncStoreRequestChangesetRefreshFunc) SetDefaultHook(hook func(context.Context, int64, string, string, types.ReconcilerState) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RequestChangesetRefresh method of the parent MockSyncStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SyncStoreRequestChangesetRefreshFunc) PushHook(hook func(context.Context, int64, string, string, types.ReconcilerState) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SyncStoreRequestChangesetRefreshFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, string, types.ReconcilerState) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SyncStoreRequestChangesetRefreshFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int64, string, string, types.ReconcilerState) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *SyncStoreRequestChangesetRefreshFunc) nextHook() func(context.Context, int64, string, string, types.ReconcilerState) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SyncStoreRequestChangesetRefreshFunc) appendCall(r0 SyncStoreRequestChangesetRefreshFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SyncStoreRequestChangesetRefreshFuncCall
+// objects describing the invocations of this function.
+func (f *SyncStoreRequestChangesetRefreshFunc) History() []SyncStoreRequestChangesetRefreshFuncCall {
+	f.mutex.Lock()
+	history := make([]SyncStoreRequestChangesetRefreshFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SyncStoreRequestChangesetRefreshFuncCall is an object that describes an
+// invocation of method RequestChangesetRefresh on an instance of
+// MockSyncStore.
+type SyncStoreRequestChangesetRefreshFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 types.ReconcilerState
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SyncStoreRequestChangesetRefreshFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SyncStoreRequestChangesetRefreshFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SyncStoreTransactFunc describes the behavior when the Transact method of
 // the parent MockSyncStore instance is invoked.
 type SyncStoreTransactFunc struct {
@@ -25,4 +25,6 @@ type SyncStore interface {
 	GetExternalServiceIDs(ctx context.Context, opts store.GetExternalServiceIDsOpts) ([]int64, error)
 	UserCredentials() database.UserCredentialsStore
 	GetBatchChange(ctx context.Context, opts store.GetBatchChangeOpts) (*btypes.BatchChange, error)
+	ChangesetAutoRefreshEnabled(ctx context.Context, changesetID int64) (bool, error)
+	RequestChangesetRefresh(ctx context.Context, changesetID int64, baseRef, baseRev string, state btypes.ReconcilerState) (bool, error)
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
@@ -17,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/batches"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
@@ -492,14 +494,65 @@ func (s *changesetSyncer) SyncChangeset(ctx context.Context, id int64) error {
 		return err
 	}
 
-	return SyncChangeset(ctx, s.syncStore, source, repo, cs)
+	if err := SyncChangeset(ctx, s.syncStore, source, repo, cs); err != nil {
+		return err
+	}
+
+	s.requestRefresh(ctx, syncLogger, repo, cs)
+	return nil
+}
+
+// requestRefresh requests a refresh of the given changeset onto the current
+// commit of its base branch, in case the base branch moved since the changeset
+// spec was created. Whether the changeset is eligible for a refresh is decided
+// by the store. Errors are only logged, since they don't affect the sync.
+func (s *changesetSyncer) requestRefresh(ctx context.Context, logger log.Logger, repo *types.Repo, cs *btypes.Changeset) {
+	// Issues don't have a base branch to be refreshed onto.
+	if cs.OwnedByBatchChangeID == 0 || cs.CurrentSpecID == 0 || cs.IsIssue() {
+		return
+	}
+	if cs.ExternalState != btypes.ChangesetExternalStateOpen && cs.ExternalState != btypes.ChangesetExternalStateDraft {
+		return
+	}
+
+	// Only resolve the base branch for changesets that opted into auto
+	// refreshing, so that we don't hit gitserver for every synced changeset.
+	enabled, err := s.syncStore.ChangesetAutoRefreshEnabled(ctx, cs.ID)
+	if err != nil {
+		logger.Warn("checking whether changeset auto refreshes", log.Error(err))
+		return
+	}
+	if !enabled {
+		return
+	}
+
+	baseRef, err := cs.BaseRef()
+	if err != nil {
+		logger.Warn("loading base ref of changeset", log.Error(err))
+		return
+	}
+
+	baseRev, err := gitserver.NewClient(s.syncStore.DatabaseDB()).ResolveRevision(ctx, repo.Name, baseRef, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		logger.Warn("resolving base ref of changeset", log.String("baseRef", baseRef), log.Error(err))
+		return
+	}
+
+	requested, err := s.syncStore.RequestChangesetRefresh(ctx, cs.ID, baseRef, string(baseRev), global.DefaultReconcilerEnqueueState())
+	if err != nil {
+		logger.Warn("requesting refresh of changeset", log.Error(err))
+		return
+	}
+	if requested {
+		logger.Debug("requested refresh of changeset", log.String("baseRev", string(baseRev)))
+	}
 }
 
 // SyncChangeset refreshes the metadata of the given changeset and
 // updates them in the database.
 func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.ChangesetSource, repo *types.Repo, c *btypes.Changeset) (err error) {
 	repoChangeset := &sources.Changeset{TargetRepo: repo, Changeset: c}
-	if err := source.LoadChangeset(ctx, repoChangeset); err != nil {
+	if err := loadChangeset(ctx, source, repoChangeset); err != nil {
 		if !errors.HasType(err, sources.ChangesetNotFoundError{}) {
 			// Store the error as the syncer error.
 			errMsg := err.Error()
@@ -538,6 +591,20 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.Chan
 	return tx.UpsertChangesetEvents(ctx, events...)
 }
 
+// loadChangeset loads the given changeset from the source, using the issue
+// API of the source if the changeset is an issue.
+func loadChangeset(ctx context.Context, source sources.ChangesetSource, cs *sources.Changeset) error {
+	if !cs.IsIssue() {
+		return source.LoadChangeset(ctx, cs)
+	}
+
+	issueSource, err := sources.ToIssueChangesetSource(source)
+	if err != nil {
+		return err
+	}
+	return issueSource.LoadIssue(ctx, cs)
+}
+
 func loadChangesetSource(
 	ctx context.Context, cf *httpcli.Factory, syncStore SyncStore,
 	ch *btypes.Changeset, repo *types.Repo,
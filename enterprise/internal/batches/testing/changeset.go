@@ -23,6 +23,7 @@ type TestChangesetOpts struct {
 
 	ExternalServiceType   string
 	ExternalID            string
+	Kind                  btypes.ChangesetKind
 	ExternalBranch        string
 	ExternalForkNamespace string
 	ExternalState         btypes.ChangesetExternalState
@@ -49,6 +50,8 @@ type TestChangesetOpts struct {
 	IsArchived bool
 	Archive    bool
 
+	RefreshBaseRev string
+
 	Metadata any
 }
 
@@ -78,6 +81,10 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 		opts.ExternalServiceType = extsvc.TypeGitHub
 	}
 
+	if opts.Kind == "" {
+		opts.Kind = btypes.ChangesetKindPullRequest
+	}
+
 	changeset := &btypes.Changeset{
 		RepoID:         opts.Repo,
 		CurrentSpecID:  opts.CurrentSpec,
@@ -86,6 +93,7 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 
 		ExternalServiceType: opts.ExternalServiceType,
 		ExternalID:          opts.ExternalID,
+		Kind:                opts.Kind,
 		ExternalState:       opts.ExternalState,
 		ExternalReviewState: opts.ExternalReviewState,
 		ExternalCheckState:  opts.ExternalCheckState,
@@ -97,6 +105,8 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 
 		Closing: opts.Closing,
 
+		RefreshBaseRev: opts.RefreshBaseRev,
+
 		ReconcilerState: opts.ReconcilerState,
 		NumFailures:     opts.NumFailures,
 		NumResets:       opts.NumResets,
@@ -40,6 +40,11 @@ type TestSpecOpts struct {
 	BaseRef string
 
 	Typ btypes.ChangesetSpecType
+
+	// Kind defaults to btypes.ChangesetKindPullRequest.
+	Kind btypes.ChangesetKind
+
+	RolloutStage int32
 }
 
 var TestChangsetSpecDiffStat = &diff.Stat{Added: 10, Changed: 5, Deleted: 2}
@@ -56,6 +61,10 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 		t.Fatal("empty typ on changeset spec in test helper")
 	}
 
+	if opts.Kind == "" {
+		opts.Kind = btypes.ChangesetKindPullRequest
+	}
+
 	spec := &btypes.ChangesetSpec{
 		ID:                opts.ID,
 		UserID:            opts.User,
@@ -64,6 +73,7 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 		BaseRev:           opts.BaseRev,
 		BaseRef:           opts.BaseRef,
 		ExternalID:        opts.ExternalID,
+		Kind:              opts.Kind,
 		HeadRef:           opts.HeadRef,
 		Published:         published,
 		Title:             opts.Title,
@@ -76,6 +86,7 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 		DiffStatChanged:   TestChangsetSpecDiffStat.Changed,
 		DiffStatDeleted:   TestChangsetSpecDiffStat.Deleted,
 		Type:              opts.Typ,
+		RolloutStage:      opts.RolloutStage,
 	}
 
 	return spec
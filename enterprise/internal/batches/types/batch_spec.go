@@ -59,6 +59,15 @@ func (cs *BatchSpec) ExpiresAt() time.Time {
 	return cs.CreatedAt.Add(BatchSpecTTL)
 }
 
+// Rollout returns the rollout stages of the changesets of the BatchSpec, or nil
+// if the changesets aren't published in stages.
+func (cs *BatchSpec) Rollout() *batcheslib.Rollout {
+	if cs.Spec == nil || cs.Spec.ChangesetTemplate == nil {
+		return nil
+	}
+	return cs.Spec.ChangesetTemplate.Rollout
+}
+
 type BatchSpecStats struct {
 	ResolutionDone bool
 
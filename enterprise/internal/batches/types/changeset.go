@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
@@ -87,6 +88,27 @@ func (s ChangesetPublicationState) Unpublished() bool {
 	return s == ChangesetPublicationStateUnpublished
 }
 
+// ChangesetKind defines the possible kinds of a Changeset on the code host.
+type ChangesetKind string
+
+// ChangesetKind constants.
+const (
+	ChangesetKindPullRequest ChangesetKind = "pull_request"
+	ChangesetKindIssue       ChangesetKind = "issue"
+)
+
+// ChangesetKindFromSpec returns the ChangesetKind of the given changeset spec
+// kind.
+func ChangesetKindFromSpec(kind batches.ChangesetKind) ChangesetKind {
+	if kind == batches.ChangesetKindIssue {
+		return ChangesetKindIssue
+	}
+	return ChangesetKindPullRequest
+}
+
+// ToGraphQL returns the GraphQL representation of the kind.
+func (k ChangesetKind) ToGraphQL() string { return strings.ToUpper(string(k)) }
+
 type ChangesetUiPublicationState string
 
 var (
@@ -254,6 +276,9 @@ type Changeset struct {
 	BatchChanges        []BatchChangeAssoc
 	ExternalID          string
 	ExternalServiceType string
+	// Kind is the kind of the changeset on the code host. Issues have no
+	// branches, diffs, reviews or checks.
+	Kind ChangesetKind
 	// ExternalBranch should always be prefixed with refs/heads/. Call git.EnsureRefPrefix before setting this value.
 	ExternalBranch string
 	// ExternalForkNamespace is only set if the changeset is opened on a fork.
@@ -298,6 +323,11 @@ type Changeset struct {
 
 	// DetachedAt is the time when the changeset became "detached".
 	DetachedAt time.Time
+
+	// RefreshBaseRev is set by the syncer to the new commit of the base
+	// branch when the base branch moved. The reconciler then re-executes the
+	// steps against it and pushes the refreshed commit.
+	RefreshBaseRev string
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -337,9 +367,14 @@ func (c *Changeset) IsImporting() bool { return c.Unpublished() && c.CurrentSpec
 // IsImported returns whether the Changeset is imported
 func (c *Changeset) IsImported() bool { return c.OwnedByBatchChangeID == 0 }
 
+// IsIssue returns whether the Changeset is an issue rather than a pull request.
+func (c *Changeset) IsIssue() bool { return c.Kind == ChangesetKindIssue }
+
 // SetCurrentSpec sets the CurrentSpecID field and copies the diff stat over from the spec.
 func (c *Changeset) SetCurrentSpec(spec *ChangesetSpec) {
 	c.CurrentSpecID = spec.ID
+	// A new spec supersedes any pending refresh of the previous one.
+	c.RefreshBaseRev = ""
 
 	// Copy over diff stat from the spec.
 	diffStat := spec.DiffStat()
@@ -426,6 +461,30 @@ func (c *Changeset) SetMetadata(meta any) error {
 		} else {
 			c.ExternalForkNamespace = ""
 		}
+	case *gerritbatches.AnnotatedChange:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.Number)
+		c.ExternalServiceType = extsvc.TypeGerrit
+		c.ExternalBranch = pr.HeadRef()
+		c.ExternalUpdatedAt = pr.Updated.Time
+		// Gerrit has no forks.
+		c.ExternalForkNamespace = ""
+	case *github.Issue:
+		c.Metadata = pr
+		c.Kind = ChangesetKindIssue
+		c.ExternalID = strconv.FormatInt(pr.Number, 10)
+		c.ExternalServiceType = extsvc.TypeGitHub
+		c.ExternalBranch = ""
+		c.ExternalUpdatedAt = pr.UpdatedAt
+		c.ExternalForkNamespace = ""
+	case *gitlab.Issue:
+		c.Metadata = pr
+		c.Kind = ChangesetKindIssue
+		c.ExternalID = strconv.FormatInt(int64(pr.IID), 10)
+		c.ExternalServiceType = extsvc.TypeGitLab
+		c.ExternalBranch = ""
+		c.ExternalUpdatedAt = pr.UpdatedAt.Time
+		c.ExternalForkNamespace = ""
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -453,6 +512,12 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Title, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Subject, nil
+	case *github.Issue:
+		return m.Title, nil
+	case *gitlab.Issue:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -472,6 +537,12 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Author.Username, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Username, nil
+	case *github.Issue:
+		return m.Author.Login, nil
+	case *gitlab.Issue:
+		return m.Author.Username, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -499,6 +570,13 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		// Bitbucket Cloud does not provide the e-mail of the author under any
 		// circumstances.
 		return "", nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Email, nil
+	case *github.Issue:
+		// See the comment on *github.PullRequest above.
+		return "", nil
+	case *gitlab.Issue:
+		return m.Author.Email, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -517,6 +595,12 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt.Time
 	case *bbcs.AnnotatedPullRequest:
 		return m.CreatedOn
+	case *gerritbatches.AnnotatedChange:
+		return m.Created.Time
+	case *github.Issue:
+		return m.CreatedAt
+	case *gitlab.Issue:
+		return m.CreatedAt.Time
 	default:
 		return time.Time{}
 	}
@@ -533,6 +617,12 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Rendered.Description.Raw, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Body(), nil
+	case *github.Issue:
+		return m.Body, nil
+	case *gitlab.Issue:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -578,6 +668,12 @@ func (c *Changeset) URL() (s string, err error) {
 		// pull request ID, but since the link _should_ be there, we'll error
 		// instead.
 		return "", errors.New("Bitbucket Cloud pull request does not have a html link")
+	case *gerritbatches.AnnotatedChange:
+		return m.URL(), nil
+	case *github.Issue:
+		return m.URL, nil
+	case *gitlab.Issue:
+		return m.WebURL, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -750,6 +846,39 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    status,
 			})
 		}
+
+	case *gerritbatches.AnnotatedChange:
+		// Gerrit changes have no events: the review and check states are
+		// computed from the votes on the labels of the change.
+
+	case *github.Issue:
+		events = make([]*ChangesetEvent, 0, len(m.TimelineItems))
+		for _, ti := range m.TimelineItems {
+			ev := ChangesetEvent{ChangesetID: c.ID, Key: ti.Item.(Keyer).Key()}
+			if ev.Kind, err = ChangesetEventKindFor(ti.Item); err != nil {
+				return
+			}
+			ev.Metadata = ti.Item
+			appendEvent(&ev)
+		}
+
+	case *gitlab.Issue:
+		events = make([]*ChangesetEvent, 0, len(m.ResourceStateEvents))
+		var kind ChangesetEventKind
+
+		for _, e := range m.ResourceStateEvents {
+			if event := e.ToEvent(); event != nil {
+				if kind, err = ChangesetEventKindFor(event); err != nil {
+					return
+				}
+				appendEvent(&ChangesetEvent{
+					ChangesetID: c.ID,
+					Key:         event.(Keyer).Key(),
+					Kind:        kind,
+					Metadata:    event,
+				})
+			}
+		}
 	}
 	return events, nil
 }
@@ -767,6 +896,10 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.DiffRefs.HeadSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Source.Commit.Hash, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.CurrentRevision, nil
+	case *github.Issue, *gitlab.Issue:
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -784,6 +917,10 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.SourceBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.HeadRef(), nil
+	case *github.Issue, *gitlab.Issue:
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -802,6 +939,10 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.DiffRefs.BaseSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Destination.Commit.Hash, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.BaseRefOid(), nil
+	case *github.Issue, *gitlab.Issue:
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -819,6 +960,10 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.TargetBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *gerritbatches.AnnotatedChange:
+		return "refs/heads/" + m.Branch, nil
+	case *github.Issue, *gitlab.Issue:
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -929,11 +1074,48 @@ func (c *Changeset) Labels() []ChangesetLabel {
 			labels[i] = ChangesetLabel{Name: l, Color: "000000"}
 		}
 		return labels
+	case *github.Issue:
+		labels := make([]ChangesetLabel, len(m.Labels.Nodes))
+		for i, l := range m.Labels.Nodes {
+			labels[i] = ChangesetLabel{
+				Name:        l.Name,
+				Color:       l.Color,
+				Description: l.Description,
+			}
+		}
+		return labels
+	case *gitlab.Issue:
+		labels := make([]ChangesetLabel, len(m.Labels))
+		for i, l := range m.Labels {
+			labels[i] = ChangesetLabel{Name: l, Color: "000000"}
+		}
+		return labels
 	default:
 		return []ChangesetLabel{}
 	}
 }
 
+// Assignees returns the usernames of the users the changeset is assigned to on
+// the code host. Only issues have assignees.
+func (c *Changeset) Assignees() []string {
+	switch m := c.Metadata.(type) {
+	case *github.Issue:
+		assignees := make([]string, len(m.Assignees))
+		for i, a := range m.Assignees {
+			assignees[i] = a.Login
+		}
+		return assignees
+	case *gitlab.Issue:
+		assignees := make([]string, len(m.Assignees))
+		for i, a := range m.Assignees {
+			assignees[i] = a.Username
+		}
+		return assignees
+	default:
+		return []string{}
+	}
+}
+
 // ResetReconcilerState resets the failure message and reset count and sets the
 // changeset's ReconcilerState to the given value.
 func (c *Changeset) ResetReconcilerState(state ReconcilerState) {
@@ -1250,6 +1432,11 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (any, error) {
 		case ChangesetEventKindGitLabReopened:
 			return new(gitlab.MergeRequestReopenedEvent), nil
 		}
+	case strings.HasPrefix(string(k), "batches"):
+		switch k {
+		case ChangesetEventKindAutoMergeMerged, ChangesetEventKindAutoMergeFailed:
+			return new(AutoMergeEvent), nil
+		}
 	}
 	return nil, errors.Errorf("unknown changeset event kind %q", k)
 }
@@ -102,6 +102,12 @@ const (
 	ChangesetEventKindBitbucketCloudRepoCommitStatusCreated          ChangesetEventKind = "bitbucketcloud:repo:commit_status_created"          // RepoCommitStatusCreatedEvent
 	ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated          ChangesetEventKind = "bitbucketcloud:repo:commit_status_updated"          // RepoCommitStatusUpdatedEvent
 
+	// These changeset events are created by Sourcegraph when the merge policy
+	// of a batch change merges a changeset, or fails to, so that there's a
+	// record of what was merged automatically.
+	ChangesetEventKindAutoMergeMerged ChangesetEventKind = "batches:auto_merge:merged"
+	ChangesetEventKindAutoMergeFailed ChangesetEventKind = "batches:auto_merge:failed"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
@@ -316,6 +322,8 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CommitStatus.CreatedOn
 	case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
 		t = ev.CommitStatus.UpdatedOn
+	case *AutoMergeEvent:
+		t = ev.CreatedAt
 	}
 
 	return t
@@ -32,6 +32,7 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 	c := &ChangesetSpec{
 		BaseRepoID: baseRepoID,
 		ExternalID: spec.ExternalID,
+		Kind:       ChangesetKindFromSpec(spec.Kind),
 		Title:      spec.Title,
 		Body:       spec.Body,
 		Published:  spec.Published,
@@ -39,6 +40,11 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 
 	if spec.IsImportingExisting() {
 		c.Type = ChangesetSpecTypeExisting
+	} else if spec.IsIssue() {
+		// Issues are created without a branch, so there's no diff to push
+		// and no fork to push it to.
+		c.Type = ChangesetSpecTypeIssue
+		return c, nil
 	} else {
 		headRepoID, err := graphqlbackend.UnmarshalRepositoryID(graphql.ID(spec.HeadRepository))
 		if err != nil {
@@ -83,6 +89,7 @@ type ChangesetSpecType string
 const (
 	ChangesetSpecTypeBranch   ChangesetSpecType = "branch"
 	ChangesetSpecTypeExisting ChangesetSpecType = "existing"
+	ChangesetSpecTypeIssue    ChangesetSpecType = "issue"
 )
 
 type ChangesetSpec struct {
@@ -103,6 +110,7 @@ type ChangesetSpec struct {
 	UpdatedAt time.Time
 
 	ExternalID        string
+	Kind              ChangesetKind
 	BaseRev           string
 	BaseRef           string
 	HeadRef           string
@@ -115,6 +123,10 @@ type ChangesetSpec struct {
 	CommitAuthorEmail string
 
 	ForkNamespace *string
+
+	// RolloutStage is the 1-based rollout stage the changeset is published
+	// in. It's 0 if the batch spec doesn't define rollout stages.
+	RolloutStage int32
 }
 
 // Clone returns a clone of a ChangesetSpec.
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// MergePolicyMethod defines how changesets are merged by a merge policy.
+type MergePolicyMethod string
+
+// MergePolicyMethod constants.
+const (
+	MergePolicyMethodMerge  MergePolicyMethod = "MERGE"
+	MergePolicyMethodSquash MergePolicyMethod = "SQUASH"
+)
+
+// Valid returns true if the given MergePolicyMethod is valid.
+func (m MergePolicyMethod) Valid() bool {
+	switch m {
+	case MergePolicyMethodMerge, MergePolicyMethodSquash:
+		return true
+	default:
+		return false
+	}
+}
+
+// Squash returns true if changesets should be squash merged.
+func (m MergePolicyMethod) Squash() bool { return m == MergePolicyMethodSquash }
+
+// BatchChangeMergePolicy configures the automatic merging of the changesets
+// of a batch change once their checks have passed and their reviews have been
+// approved.
+type BatchChangeMergePolicy struct {
+	BatchChangeID int64
+
+	MergeMethod MergePolicyMethod
+	// RequiredApprovals is the number of reviewers that must have approved a
+	// changeset before it's merged, in addition to its review state being
+	// approved.
+	RequiredApprovals int32
+	// Windows are the windows in which changesets may be merged, in the same
+	// format as the rollout windows of the site configuration. If there are no
+	// windows, changesets may be merged at any time.
+	Windows []*schema.BatchChangeRolloutWindow
+	// MaxMergesPerHour is the maximum number of changesets that are merged
+	// within an hour. Zero means that there's no limit.
+	MaxMergesPerHour int32
+
+	CreatorID int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a BatchChangeMergePolicy.
+func (p *BatchChangeMergePolicy) Clone() *BatchChangeMergePolicy {
+	pp := *p
+	return &pp
+}
+
+// AutoMergeEvent is the metadata of the changeset events that are created
+// when a merge policy merges a changeset, or fails to.
+type AutoMergeEvent struct {
+	BatchChangeID int64             `json:"batch_change_id"`
+	MergeMethod   MergePolicyMethod `json:"merge_method"`
+	// HeadRefOid is the commit of the changeset when it was merged.
+	HeadRefOid string `json:"head_ref_oid"`
+	Approvals  int    `json:"approvals"`
+	// Error is the reason the changeset couldn't be merged, if it failed.
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
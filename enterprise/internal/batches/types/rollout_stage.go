@@ -0,0 +1,67 @@
+package types
+
+import "math"
+
+// RolloutStageState defines the possible states of a rollout stage of a batch
+// change.
+type RolloutStageState string
+
+const (
+	// RolloutStageStatePending means that the changesets of an earlier stage
+	// aren't done yet, so the changesets of the stage are held back.
+	RolloutStageStatePending RolloutStageState = "PENDING"
+	// RolloutStageStateActive means that the changesets of the stage are
+	// published, but not all of them are done yet.
+	RolloutStageStateActive RolloutStageState = "ACTIVE"
+	// RolloutStageStateCompleted means that all changesets of the stage are
+	// done.
+	RolloutStageStateCompleted RolloutStageState = "COMPLETED"
+)
+
+// RolloutStage holds the changeset counts of a rollout stage of a batch spec.
+type RolloutStage struct {
+	Stage int32
+
+	// Total is the number of changesets in the stage that are published or
+	// meant to be published.
+	Total int32
+	// Published is the number of published changesets in the stage.
+	Published int32
+	// Done is the number of changesets in the stage that reached the state
+	// the rollout waits for, or that were closed or deleted.
+	Done int32
+}
+
+// Completed reports whether all changesets of the stage are done.
+func (s *RolloutStage) Completed() bool { return s.Done >= s.Total }
+
+// RolloutStages is a list of rollout stages, ordered by stage.
+type RolloutStages []*RolloutStage
+
+// LastReleased returns the last stage whose changesets may be published, which
+// is the first stage that isn't completed yet. If all stages are completed,
+// math.MaxInt32 is returned.
+func (ss RolloutStages) LastReleased() int32 {
+	for _, s := range ss {
+		if !s.Completed() {
+			return s.Stage
+		}
+	}
+	return math.MaxInt32
+}
+
+// Released reports whether the changesets of the given stage may be published.
+func (ss RolloutStages) Released(stage int32) bool {
+	return stage <= ss.LastReleased()
+}
+
+// State returns the state of the given stage.
+func (ss RolloutStages) State(s *RolloutStage) RolloutStageState {
+	if s.Completed() {
+		return RolloutStageStateCompleted
+	}
+	if ss.Released(s.Stage) {
+		return RolloutStageStateActive
+	}
+	return RolloutStageStatePending
+}
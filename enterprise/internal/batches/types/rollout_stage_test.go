@@ -0,0 +1,47 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRolloutStages(t *testing.T) {
+	stages := RolloutStages{
+		{Stage: 1, Total: 2, Published: 2, Done: 2},
+		{Stage: 2, Total: 3, Published: 3, Done: 1},
+		{Stage: 3, Total: 1},
+	}
+
+	if have, want := stages.LastReleased(), int32(2); have != want {
+		t.Fatalf("wrong last released stage. want=%d, have=%d", want, have)
+	}
+
+	tests := []struct {
+		stage        *RolloutStage
+		wantReleased bool
+		wantState    RolloutStageState
+	}{
+		{stage: stages[0], wantReleased: true, wantState: RolloutStageStateCompleted},
+		{stage: stages[1], wantReleased: true, wantState: RolloutStageStateActive},
+		{stage: stages[2], wantReleased: false, wantState: RolloutStageStatePending},
+	}
+
+	for _, tc := range tests {
+		if have := stages.Released(tc.stage.Stage); have != tc.wantReleased {
+			t.Errorf("stage %d: wrong released. want=%t, have=%t", tc.stage.Stage, tc.wantReleased, have)
+		}
+		if have := stages.State(tc.stage); have != tc.wantState {
+			t.Errorf("stage %d: wrong state. want=%s, have=%s", tc.stage.Stage, tc.wantState, have)
+		}
+	}
+
+	t.Run("all completed", func(t *testing.T) {
+		stages := RolloutStages{
+			{Stage: 1, Total: 1, Published: 1, Done: 1},
+			{Stage: 2, Total: 1, Published: 1, Done: 1},
+		}
+		if have, want := stages.LastReleased(), int32(math.MaxInt32); have != want {
+			t.Fatalf("wrong last released stage. want=%d, have=%d", want, have)
+		}
+	})
+}
@@ -197,6 +197,68 @@ func TestConfiguration_Schedule(t *testing.T) {
 	}
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	// Monday, 10:00 UTC.
+	monday := time.Date(2021, 4, 5, 10, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		cfg  *Configuration
+		at   time.Time
+		want bool
+	}{
+		"no windows": {
+			cfg:  &Configuration{windows: []Window{}},
+			at:   monday,
+			want: true,
+		},
+		"within a window": {
+			cfg: &Configuration{windows: []Window{
+				{
+					days:  newWeekdaySet(time.Monday),
+					start: timeOfDayPtr(9, 0),
+					end:   timeOfDayPtr(17, 0),
+					rate:  makeUnlimitedRate(),
+				},
+			}},
+			at:   monday,
+			want: true,
+		},
+		"outside of the hours of a window": {
+			cfg: &Configuration{windows: []Window{
+				{
+					days:  newWeekdaySet(time.Monday),
+					start: timeOfDayPtr(12, 0),
+					end:   timeOfDayPtr(17, 0),
+					rate:  makeUnlimitedRate(),
+				},
+			}},
+			at:   monday,
+			want: false,
+		},
+		"outside of the days of a window": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(time.Tuesday), rate: makeUnlimitedRate()},
+			}},
+			at:   monday,
+			want: false,
+		},
+		"within a zero rate window": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(), rate: makeUnlimitedRate()},
+				{days: newWeekdaySet(time.Monday), rate: rate{n: 0}},
+			}},
+			at:   monday,
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.cfg.IsOpen(tc.at); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestConfiguration_currentFor(t *testing.T) {
 	// Let's set up some common windows to simplify defining the test cases.
 
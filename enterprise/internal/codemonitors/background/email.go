@@ -10,6 +10,7 @@ import (
 	"github.com/graph-gophers/graphql-go/relay"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
@@ -79,10 +80,16 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction)
 	}
 
 	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedContentResults, contentTotalCount, contentTruncatedCount := truncateContentResults(args.ContentResults, 5)
+	totalCount += contentTotalCount
+	truncatedCount += contentTruncatedCount
 
-	displayResults := make([]*DisplayResult, len(truncatedResults))
-	for i, result := range truncatedResults {
-		displayResults[i] = toDisplayResult(result, args.ExternalURL)
+	displayResults := make([]*DisplayResult, 0, len(truncatedResults)+len(truncatedContentResults))
+	for _, result := range truncatedResults {
+		displayResults = append(displayResults, toDisplayResult(result, args.ExternalURL))
+	}
+	for _, result := range truncatedContentResults {
+		displayResults = append(displayResults, toContentDisplayResult(result, args.ExternalURL))
 	}
 
 	return &TemplateDataNewSearchResults{
@@ -151,6 +158,10 @@ func getCommitURL(externalURL *url.URL, repoName, oid, utmSource string) string
 	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
 }
 
+func getFileURL(externalURL *url.URL, repoName, oid, path, utmSource string) string {
+	return sourcegraphURL(externalURL, fmt.Sprintf("%s@%s/-/blob/%s", repoName, oid, path), "", utmSource)
+}
+
 var (
 	externalURLOnce  sync.Once
 	externalURLValue *url.URL
@@ -198,6 +209,7 @@ type DisplayResult struct {
 	CommitURL  string
 	RepoName   string
 	CommitID   string
+	Path       string
 	Content    string
 }
 
@@ -222,3 +234,14 @@ func toDisplayResult(result *result.CommitMatch, externalURL *url.URL) *DisplayR
 		Content:    content,
 	}
 }
+
+func toContentDisplayResult(result *edb.ContentMatch, externalURL *url.URL) *DisplayResult {
+	return &DisplayResult{
+		ResultType: "Content",
+		CommitURL:  getFileURL(externalURL, result.RepoName, result.CommitID, result.Path, utmSourceEmail),
+		RepoName:   result.RepoName,
+		CommitID:   api.CommitID(result.CommitID).Short(),
+		Path:       result.Path,
+		Content:    truncateString(contentPreview(result), 10),
+	}
+}
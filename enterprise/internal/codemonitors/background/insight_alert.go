@@ -0,0 +1,116 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+const utmSourceInsightAlert = "code-insights-alert"
+
+// InsightAlertArgs describes a code insights alert that triggered. Insight alerts are delivered
+// through the same email, Slack and webhook actions as code monitors.
+type InsightAlertArgs struct {
+	// Condition is a human readable description of the alert condition, e.g. "went above 0".
+	Condition string
+	Query     string
+	Value     float64
+}
+
+func (a InsightAlertArgs) searchURL(ctx context.Context) (string, error) {
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return "", err
+	}
+	return getSearchURL(externalURL, a.Query, utmSourceInsightAlert), nil
+}
+
+var insightAlertEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph code insight for {{.Query}} {{.Condition}}`,
+	Text: `The code insight for {{.Query}} {{.Condition}}. Its current value is {{.Value}}.
+
+View search on Sourcegraph: {{.SearchURL}}
+
+__
+You are receiving this notification because you created an alert on a code insight.
+`,
+	HTML: `<p>The code insight for <code>{{.Query}}</code> {{.Condition}}. Its current value is <strong>{{.Value}}</strong>.</p>
+<p><a href="{{.SearchURL}}">View search on Sourcegraph</a></p>
+<p>You are receiving this notification because you created an alert on a code insight.</p>
+`,
+})
+
+type TemplateDataInsightAlert struct {
+	Condition string
+	Query     string
+	Value     string
+	SearchURL string
+}
+
+// SendInsightAlertEmail sends an insight alert to the primary email address of a user.
+func SendInsightAlertEmail(ctx context.Context, db database.DB, userID int32, args InsightAlertArgs) error {
+	searchURL, err := args.searchURL(ctx)
+	if err != nil {
+		return err
+	}
+	return sendEmail(ctx, db, userID, insightAlertEmailTemplates, &TemplateDataInsightAlert{
+		Condition: args.Condition,
+		Query:     args.Query,
+		Value:     formatValue(args.Value),
+		SearchURL: searchURL,
+	})
+}
+
+// SendInsightAlertSlackWebhook posts an insight alert to a Slack webhook.
+func SendInsightAlertSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, args InsightAlertArgs) error {
+	searchURL, err := args.searchURL(ctx)
+	if err != nil {
+		return err
+	}
+	return postSlackWebhook(ctx, doer, url, insightAlertSlackPayload(args, searchURL))
+}
+
+func insightAlertSlackPayload(args InsightAlertArgs, searchURL string) *slack.WebhookMessage {
+	text := fmt.Sprintf(
+		"The Sourcegraph code insight for `%s` %s. Its current value is *%s*.\n<%s|View results>",
+		args.Query,
+		args.Condition,
+		formatValue(args.Value),
+		searchURL,
+	)
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil),
+	}}}
+}
+
+// SendInsightAlertWebhook posts an insight alert to a webhook.
+func SendInsightAlertWebhook(ctx context.Context, doer httpcli.Doer, url string, args InsightAlertArgs) error {
+	searchURL, err := args.searchURL(ctx)
+	if err != nil {
+		return err
+	}
+	return postWebhook(ctx, doer, url, insightAlertWebhookPayload{
+		Condition: args.Condition,
+		Query:     args.Query,
+		Value:     args.Value,
+		SearchURL: searchURL,
+	})
+}
+
+type insightAlertWebhookPayload struct {
+	Condition string  `json:"condition"`
+	Query     string  `json:"query"`
+	Value     float64 `json:"value"`
+	SearchURL string  `json:"searchURL"`
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/slack-go/slack"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -26,6 +28,9 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 	}
 
 	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedContentResults, contentTotalCount, contentTruncatedCount := truncateContentResults(args.ContentResults, 5)
+	totalCount += contentTotalCount
+	truncatedCount += contentTruncatedCount
 
 	blocks := []slack.Block{
 		newMarkdownSection(fmt.Sprintf(
@@ -57,6 +62,18 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 			}
 			blocks = append(blocks, newMarkdownSection(formatCodeBlock(contentRaw)))
 		}
+		for _, result := range truncatedContentResults {
+			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+				"Content match: <%s|%s@%s:%s>",
+				getFileURL(args.ExternalURL, result.RepoName, result.CommitID, result.Path, args.UTMSource),
+				result.RepoName,
+				api.CommitID(result.CommitID).Short(),
+				result.Path,
+			)))
+			if len(result.Lines) > 0 {
+				blocks = append(blocks, newMarkdownSection(formatCodeBlock(truncateString(contentPreview(result), 10))))
+			}
+		}
 		if truncatedCount > 0 {
 			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
 				"...and <%s|%d more matches>.",
@@ -114,6 +131,38 @@ func truncateResults(results []*result.CommitMatch, maxResults int) (_ []*result
 	return output, totalCount, totalCount - outputCount
 }
 
+func truncateContentResults(results []*edb.ContentMatch, maxResults int) (_ []*edb.ContentMatch, totalCount, truncatedCount int) {
+	for _, res := range results {
+		totalCount += res.ResultCount()
+	}
+
+	output := make([]*edb.ContentMatch, 0, len(results))
+	remaining := maxResults
+	for _, res := range results {
+		if remaining <= 0 {
+			break
+		}
+		if res.ResultCount() > remaining {
+			truncated := *res
+			truncated.Lines = truncated.Lines[:remaining]
+			res = &truncated
+		}
+		output = append(output, res)
+		remaining -= res.ResultCount()
+	}
+
+	return output, totalCount, totalCount - (maxResults - remaining)
+}
+
+// contentPreview returns the matched lines of a content match.
+func contentPreview(result *edb.ContentMatch) string {
+	lines := make([]string, len(result.Lines))
+	for i, line := range result.Lines {
+		lines[i] = line.Preview
+	}
+	return strings.Join(lines, "\n")
+}
+
 // adapted from slack.PostWebhookCustomHTTPContext
 func postSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *slack.WebhookMessage) error {
 	raw, err := json.Marshal(msg)
@@ -12,6 +12,7 @@ import (
 	"github.com/hexops/autogold"
 	"github.com/stretchr/testify/require"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -85,6 +86,32 @@ func TestSlackWebhook(t *testing.T) {
 	})
 }
 
+func TestTruncateContentResults(t *testing.T) {
+	t.Parallel()
+
+	lines := func(n int) []edb.ContentMatchLine {
+		out := make([]edb.ContentMatchLine, n)
+		for i := range out {
+			out[i] = edb.ContentMatchLine{LineNumber: int32(i), Preview: "KEY=value"}
+		}
+		return out
+	}
+
+	results := []*edb.ContentMatch{
+		{RepoName: "a", Path: "a.env", Lines: lines(3)},
+		{RepoName: "b", Path: "id_rsa"},
+		{RepoName: "c", Path: "c.env", Lines: lines(4)},
+	}
+
+	truncated, totalCount, truncatedCount := truncateContentResults(results, 5)
+	require.Equal(t, 8, totalCount)
+	require.Equal(t, 3, truncatedCount)
+	require.Len(t, truncated, 3)
+	require.Len(t, truncated[2].Lines, 1)
+	// The input should not be modified
+	require.Len(t, results[2].Lines, 4)
+}
+
 func TestTriggerTestSlackWebhookAction(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		b, err := io.ReadAll(r.Body)
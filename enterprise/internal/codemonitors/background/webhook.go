@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -17,7 +18,7 @@ func sendWebhookNotification(ctx context.Context, url string, args actionArgs) e
 	return postWebhook(ctx, httpcli.ExternalDoer, url, generateWebhookPayload(args))
 }
 
-func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload webhookPayload) error {
+func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload any) error {
 	raw, err := json.Marshal(payload)
 	if err != nil {
 		return errors.Wrap(err, "marshal failed")
@@ -72,6 +73,7 @@ func generateWebhookPayload(args actionArgs) webhookPayload {
 
 	if args.IncludeResults {
 		p.Results = generateResults(args.Results)
+		p.Results = append(p.Results, generateContentResults(args.ContentResults)...)
 	}
 
 	return p
@@ -84,6 +86,8 @@ type webhookResult struct {
 	MatchedMessageRanges [][2]int `json:"matchedMessageRanges,omitempty"`
 	Diff                 string   `json:"diff,omitempty"`
 	MatchedDiffRanges    [][2]int `json:"matchedDiffRanges,omitempty"`
+	Path                 string   `json:"path,omitempty"`
+	Content              string   `json:"content,omitempty"`
 }
 
 func generateResults(in []*result.CommitMatch) []webhookResult {
@@ -106,6 +110,19 @@ func generateResults(in []*result.CommitMatch) []webhookResult {
 	return out
 }
 
+func generateContentResults(in []*edb.ContentMatch) []webhookResult {
+	out := make([]webhookResult, len(in))
+	for i, match := range in {
+		out[i] = webhookResult{
+			Repository: match.RepoName,
+			Commit:     match.CommitID,
+			Path:       match.Path,
+			Content:    contentPreview(match),
+		}
+	}
+	return out
+}
+
 func rangesToInts(ranges result.Ranges) [][2]int {
 	out := make([][2]int, len(ranges))
 	for i, r := range ranges {
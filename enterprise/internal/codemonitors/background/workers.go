@@ -17,7 +17,6 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
-	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -191,12 +190,18 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, record work
 	}
 
 	// Log the actual query we ran and whether we got any new results.
-	err = s.UpdateTriggerJobWithResults(ctx, triggerJob.ID, query, results)
+	err = s.UpdateTriggerJobWithResults(ctx, triggerJob.ID, query, results.Commits)
 	if err != nil {
 		return errors.Wrap(err, "UpdateTriggerJobWithResults")
 	}
+	if len(results.Contents) > 0 {
+		err = s.UpdateTriggerJobWithContentResults(ctx, triggerJob.ID, query, results.Contents)
+		if err != nil {
+			return errors.Wrap(err, "UpdateTriggerJobWithContentResults")
+		}
+	}
 
-	if len(results) > 0 {
+	if results.Len() > 0 {
 		_, err := s.EnqueueActionJobsForMonitor(ctx, m.ID, triggerJob.ID)
 		if err != nil {
 			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
@@ -269,6 +274,7 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentResults:     m.ContentResults,
 		IncludeResults:     e.IncludeResults,
 	}
 
@@ -322,6 +328,7 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) erro
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentResults:     m.ContentResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
@@ -358,6 +365,7 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentResults:     m.ContentResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
@@ -393,8 +401,8 @@ func newQueryWithAfterFilter(q *edb.QueryTrigger) string {
 	return strings.Join([]string{q.QueryString, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
 }
 
-func latestResultTime(previousLastResult *time.Time, results []*result.CommitMatch, searchErr error) time.Time {
-	if searchErr != nil || len(results) == 0 {
+func latestResultTime(previousLastResult *time.Time, results codemonitors.Results, searchErr error) time.Time {
+	if searchErr != nil || results.Len() == 0 {
 		// Error performing the search, or there were no results. Assume the
 		// previous info's result time.
 		if previousLastResult != nil {
@@ -403,8 +411,14 @@ func latestResultTime(previousLastResult *time.Time, results []*result.CommitMat
 		return time.Now()
 	}
 
-	if results[0].Commit.Committer != nil {
-		return results[0].Commit.Committer.Date
+	// Content matches have no timestamp of their own, so we use the time at
+	// which we first saw them.
+	if len(results.Commits) == 0 {
+		return time.Now()
+	}
+
+	if results.Commits[0].Commit.Committer != nil {
+		return results.Commits[0].Commit.Committer.Date
 	}
 	return time.Now()
 }
@@ -107,18 +107,38 @@ func Settings(ctx context.Context) (_ *schema.Settings, err error) {
 	return &unmarshaledSettings, nil
 }
 
-func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ []*result.CommitMatch, err error) {
+// Results are the new results found by a code monitor search. Commit and diff
+// monitors populate Commits, content-match monitors populate Contents.
+type Results struct {
+	Commits  []*result.CommitMatch
+	Contents []*edb.ContentMatch
+}
+
+// Len returns the number of new results.
+func (r Results) Len() int {
+	return len(r.Commits) + len(r.Contents)
+}
+
+func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ Results, err error) {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(ctx, "V3", nil, query, search.Streaming, settings, envvar.SourcegraphDotComMode())
 	if err != nil {
-		return nil, errcode.MakeNonRetryable(err)
+		return Results{}, errcode.MakeNonRetryable(err)
 	}
 
 	// Inline job creation so we can mutate the commit job before running it
 	clients := searchClient.JobClients()
 	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan)
 	if err != nil {
-		return nil, errcode.MakeNonRetryable(err)
+		return Results{}, errcode.MakeNonRetryable(err)
+	}
+
+	if isContentMonitorJob(planJob) {
+		contents, err := searchContent(ctx, db, clients, planJob, monitorID, false)
+		if err != nil {
+			return Results{}, err
+		}
+		return Results{Contents: contents}, nil
 	}
 
 	if featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
@@ -143,7 +163,7 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 			// searched repos rather than searching them.
 			hasAnyLastSearched, err := edb.NewEnterpriseDB(db).CodeMonitors().HasAnyLastSearched(ctx, monitorID)
 			if err != nil {
-				return nil, err
+				return Results{}, err
 			} else if !hasAnyLastSearched {
 				hook = func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 					return snapshotHook(ctx, db, gs, args, monitorID, repoID)
@@ -152,7 +172,7 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		}
 		planJob, err = addCodeMonitorHook(planJob, hook)
 		if err != nil {
-			return nil, errcode.MakeNonRetryable(err)
+			return Results{}, errcode.MakeNonRetryable(err)
 		}
 	}
 
@@ -160,24 +180,25 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 	agg := streaming.NewAggregatingStream()
 	_, err = planJob.Run(ctx, clients, agg)
 	if err != nil {
-		return nil, err
+		return Results{}, err
 	}
 
 	results := make([]*result.CommitMatch, len(agg.Results))
 	for i, res := range agg.Results {
 		cm, ok := res.(*result.CommitMatch)
 		if !ok {
-			return nil, errors.Errorf("expected search to only return commit matches, but got type %T", res)
+			return Results{}, errors.Errorf("expected search to only return commit matches, but got type %T", res)
 		}
 		results[i] = cm
 	}
 
-	return results, nil
+	return Results{Commits: results}, nil
 }
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
-// be searched from the beginning.
+// be searched from the beginning. For content-match monitors, the current matches are saved so that only
+// matches that appear after the snapshot are reported.
 func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) error {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(ctx, "V3", nil, query, search.Streaming, settings, envvar.SourcegraphDotComMode())
@@ -191,6 +212,11 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return err
 	}
 
+	if isContentMonitorJob(planJob) {
+		_, err := searchContent(ctx, db, clients, planJob, monitorID, true)
+		return err
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
@@ -0,0 +1,216 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var ErrNoContentSearch = errors.New("code monitor query must search commits, diffs or file contents")
+
+// isContentMonitorJob returns true if the job searches file contents rather
+// than commits. Content-match monitors alert on file and line matches that
+// were not present the last time the monitor ran.
+func isContentMonitorJob(j job.Job) bool {
+	return !job.HasDescendent[*commit.SearchJob](j)
+}
+
+// validateContentMonitorJob checks that the only atom jobs in the tree search
+// file contents, and removes the ones that content monitors don't use.
+func validateContentMonitorJob(in job.Job) (_ job.Job, err error) {
+	textSearchJobCount := 0
+	out := job.Map(in, func(j job.Job) job.Job {
+		switch j.(type) {
+		case *zoekt.GlobalTextSearchJob, *zoekt.RepoSubsetTextSearchJob, *searcher.TextSearchJob:
+			textSearchJobCount++
+			return j
+		case *repos.ComputeExcludedJob, *jobutil.RepoSearchJob, *jobutil.NoopJob:
+			// Content monitors only report file matches, so repo matches
+			// and excluded repo counts are not needed.
+			return jobutil.NewNoopJob()
+		default:
+			if len(j.Children()) == 0 {
+				if err == nil {
+					err = errors.Errorf("found invalid atom job type %T for code monitor search", j)
+				}
+			}
+			return j
+		}
+	})
+	if err == nil && textSearchJobCount == 0 {
+		err = ErrNoContentSearch
+	}
+	return out, err
+}
+
+// searchContent runs a content search and diffs the file matches of each repo
+// against the snapshot stored on the previous run. The snapshot is replaced
+// with the current matches and only the matches that are not in the previous
+// snapshot are returned. If the search hit a limit or timed out, the current
+// matches are added to the snapshot instead, since matches missing from the
+// results may still exist. If snapshotOnly is true, the snapshot is updated
+// without returning any matches.
+func searchContent(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64, snapshotOnly bool) ([]*edb.ContentMatch, error) {
+	planJob, err := validateContentMonitorJob(planJob)
+	if err != nil {
+		return nil, errcode.MakeNonRetryable(err)
+	}
+
+	agg := streaming.NewAggregatingStream()
+	_, err = planJob.Run(ctx, clients, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	byRepo := make(map[api.RepoID][]*result.FileMatch)
+	for _, res := range agg.Results {
+		fm, ok := res.(*result.FileMatch)
+		if !ok {
+			return nil, errors.Errorf("expected search to only return file matches, but got type %T", res)
+		}
+		byRepo[fm.Repo.ID] = append(byRepo[fm.Repo.ID], fm)
+	}
+
+	// Only treat matches missing from the results as gone if we know we saw
+	// every match. Otherwise, matches that were skipped because of a limit or
+	// timeout would be reported as new on the next run.
+	complete := !agg.Stats.IsLimitHit && !agg.Stats.Status.Any(search.RepoStatusTimedout)
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+
+	repoIDs := make([]api.RepoID, 0, len(byRepo))
+	var newMatches []*edb.ContentMatch
+	for repoID, fileMatches := range byRepo {
+		repoIDs = append(repoIDs, repoID)
+
+		lastHashes, err := cm.GetLastContentMatches(ctx, monitorID, repoID)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]struct{}, len(lastHashes))
+		for _, hash := range lastHashes {
+			seen[hash] = struct{}{}
+		}
+
+		var hashes []string
+		for _, fm := range fileMatches {
+			match, matchHashes := diffContentMatch(fm, seen)
+			hashes = append(hashes, matchHashes...)
+			if match != nil {
+				newMatches = append(newMatches, match)
+			}
+		}
+
+		if !complete {
+			hashes = mergeHashes(hashes, lastHashes)
+		}
+		if err := cm.UpsertLastContentMatches(ctx, monitorID, repoID, hashes); err != nil {
+			return nil, err
+		}
+	}
+
+	if complete {
+		if err := cm.DeleteLastContentMatchesExcept(ctx, monitorID, repoIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if snapshotOnly {
+		return nil, nil
+	}
+
+	sort.Slice(newMatches, func(i, j int) bool {
+		if newMatches[i].RepoName != newMatches[j].RepoName {
+			return newMatches[i].RepoName < newMatches[j].RepoName
+		}
+		return newMatches[i].Path < newMatches[j].Path
+	})
+	return newMatches, nil
+}
+
+// mergeHashes returns the union of the given hashes.
+func mergeHashes(hashes, other []string) []string {
+	seen := make(map[string]struct{}, len(hashes)+len(other))
+	merged := make([]string, 0, len(hashes)+len(other))
+	for _, hs := range [][]string{hashes, other} {
+		for _, hash := range hs {
+			if _, ok := seen[hash]; ok {
+				continue
+			}
+			seen[hash] = struct{}{}
+			merged = append(merged, hash)
+		}
+	}
+	return merged
+}
+
+// diffContentMatch returns the hashes of all the matches in fm, along with a
+// ContentMatch containing the matches whose hashes are not in seen. The
+// returned ContentMatch is nil if there are no new matches.
+func diffContentMatch(fm *result.FileMatch, seen map[string]struct{}) (*edb.ContentMatch, []string) {
+	match := &edb.ContentMatch{
+		RepoName: string(fm.Repo.Name),
+		CommitID: string(fm.CommitID),
+		Path:     fm.Path,
+	}
+
+	if fm.IsPathMatch() {
+		hash := contentMatchHash(fm.Path)
+		if _, ok := seen[hash]; ok {
+			return nil, []string{hash}
+		}
+		return match, []string{hash}
+	}
+
+	lineMatches := fm.ChunkMatches.AsLineMatches()
+	hashes := make([]string, 0, len(lineMatches))
+	for _, lm := range lineMatches {
+		hash := contentMatchHash(fm.Path, lm.Preview)
+		hashes = append(hashes, hash)
+		if _, ok := seen[hash]; ok {
+			continue
+		}
+		match.Lines = append(match.Lines, edb.ContentMatchLine{
+			LineNumber:       lm.LineNumber,
+			Preview:          lm.Preview,
+			OffsetAndLengths: lm.OffsetAndLengths,
+		})
+	}
+
+	if len(match.Lines) == 0 {
+		return nil, hashes
+	}
+	return match, hashes
+}
+
+// contentMatchHash fingerprints a match by its path and matched line content.
+// Line numbers are deliberately left out so that edits elsewhere in the file
+// don't make an existing match look new. The snapshot of seen matches only
+// holds these hashes. The matched lines of new matches are still stored in the
+// content_results of the trigger job, so that notifications can show them, and
+// are deleted along with the trigger job after the event retention period.
+func contentMatchHash(path string, lines ...string) string {
+	h := sha256.New()
+	h.Write([]byte(path))
+	for _, line := range lines {
+		h.Write([]byte{0})
+		h.Write([]byte(line))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
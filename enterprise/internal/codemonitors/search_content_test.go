@@ -0,0 +1,127 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestValidateContentMonitorJob(t *testing.T) {
+	t.Parallel()
+
+	t.Run("errors on non-content search", func(t *testing.T) {
+		erroringJobs := []job.Job{
+			jobutil.NewParallelJob(&zoekt.GlobalTextSearchJob{}, &searcher.SymbolSearchJob{}),
+			&jobutil.RepoSearchJob{},
+			jobutil.NewTimeoutJob(0, &repos.ComputeExcludedJob{}),
+		}
+
+		for _, j := range erroringJobs {
+			t.Run("", func(t *testing.T) {
+				_, err := validateContentMonitorJob(j)
+				require.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("no errors on content search", func(t *testing.T) {
+		nonErroringJobs := []job.Job{
+			&zoekt.GlobalTextSearchJob{},
+			jobutil.NewParallelJob(&zoekt.RepoSubsetTextSearchJob{}, &searcher.TextSearchJob{}, &repos.ComputeExcludedJob{}),
+			jobutil.NewTimeoutJob(0, jobutil.NewParallelJob(&zoekt.GlobalTextSearchJob{}, &jobutil.RepoSearchJob{})),
+		}
+
+		for _, j := range nonErroringJobs {
+			t.Run("", func(t *testing.T) {
+				_, err := validateContentMonitorJob(j)
+				require.NoError(t, err)
+			})
+		}
+	})
+
+	t.Run("commit searches are not content searches", func(t *testing.T) {
+		require.False(t, isContentMonitorJob(jobutil.NewTimeoutJob(0, &commit.SearchJob{})))
+		require.True(t, isContentMonitorJob(jobutil.NewTimeoutJob(0, &zoekt.GlobalTextSearchJob{})))
+	})
+}
+
+func TestDiffContentMatch(t *testing.T) {
+	t.Parallel()
+
+	fileMatch := func(path string, lines ...string) *result.FileMatch {
+		fm := &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{ID: 1, Name: "repo"},
+				CommitID: "deadbeef",
+				Path:     path,
+			},
+		}
+		for i, line := range lines {
+			fm.ChunkMatches = append(fm.ChunkMatches, result.ChunkMatch{
+				Content:      line,
+				ContentStart: result.Location{Line: i},
+				Ranges: result.Ranges{{
+					Start: result.Location{Line: i, Column: 0},
+					End:   result.Location{Line: i, Column: len(line)},
+				}},
+			})
+		}
+		return fm
+	}
+
+	t.Run("all matches are new without a snapshot", func(t *testing.T) {
+		match, hashes := diffContentMatch(fileMatch("a.env", "KEY=1", "KEY=2"), nil)
+		require.Len(t, hashes, 2)
+		require.Equal(t, &edb.ContentMatch{
+			RepoName: "repo",
+			CommitID: "deadbeef",
+			Path:     "a.env",
+			Lines: []edb.ContentMatchLine{
+				{LineNumber: 0, Preview: "KEY=1", OffsetAndLengths: [][2]int32{{0, 5}}},
+				{LineNumber: 1, Preview: "KEY=2", OffsetAndLengths: [][2]int32{{0, 5}}},
+			},
+		}, match)
+	})
+
+	t.Run("only lines missing from the snapshot are new", func(t *testing.T) {
+		seen := map[string]struct{}{contentMatchHash("a.env", "KEY=1"): {}}
+		match, hashes := diffContentMatch(fileMatch("a.env", "KEY=2", "KEY=1"), seen)
+		require.Len(t, hashes, 2)
+		require.Len(t, match.Lines, 1)
+		require.Equal(t, "KEY=2", match.Lines[0].Preview)
+	})
+
+	t.Run("moved lines are not new", func(t *testing.T) {
+		_, hashes := diffContentMatch(fileMatch("a.env", "KEY=1"), nil)
+		seen := map[string]struct{}{hashes[0]: {}}
+		match, _ := diffContentMatch(fileMatch("a.env", "unrelated", "KEY=1"), seen)
+		require.Len(t, match.Lines, 1)
+		require.Equal(t, "unrelated", match.Lines[0].Preview)
+	})
+
+	t.Run("path matches", func(t *testing.T) {
+		match, hashes := diffContentMatch(fileMatch("id_rsa"), nil)
+		require.NotNil(t, match)
+		require.Empty(t, match.Lines)
+
+		seen := map[string]struct{}{hashes[0]: {}}
+		match, _ = diffContentMatch(fileMatch("id_rsa"), seen)
+		require.Nil(t, match)
+	})
+}
+
+func TestMergeHashes(t *testing.T) {
+	require.Equal(t, []string{"a", "b", "c"}, mergeHashes([]string{"a", "b"}, []string{"b", "c"}))
+	require.Equal(t, []string{"a"}, mergeHashes(nil, []string{"a"}))
+	require.Empty(t, mergeHashes(nil, nil))
+}
@@ -0,0 +1,130 @@
+package compute
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/git"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ChangesetTemplate describes the changesets created from the diffs of a
+// replace command. It mirrors the changesetTemplate of a batch spec.
+type ChangesetTemplate struct {
+	Title         string
+	Branch        string
+	CommitMessage string
+
+	// Body defaults to Title if unset.
+	Body string
+
+	// AuthorName and AuthorEmail default to the batch changes author if
+	// unset.
+	AuthorName  string
+	AuthorEmail string
+}
+
+// ChangesetSpecs returns one changeset spec per repository, which applies the
+// diffs for that repository on top of the commit they were computed on. The
+// changesets target the branch that was searched, which is the default branch
+// of the repository unless the query names another one.
+func ChangesetSpecs(ctx context.Context, db database.DB, diffs []*FileDiff, tmpl ChangesetTemplate) ([]*batches.ChangesetSpec, error) {
+	if tmpl.Title == "" || tmpl.Branch == "" || tmpl.CommitMessage == "" {
+		return nil, errors.New("changeset template requires a title, branch and commit message")
+	}
+	body := tmpl.Body
+	if body == "" {
+		body = tmpl.Title
+	}
+	authorName, authorEmail := tmpl.AuthorName, tmpl.AuthorEmail
+	if authorName == "" && authorEmail == "" {
+		authorName = "Sourcegraph"
+		authorEmail = "batch-changes@sourcegraph.com"
+	}
+
+	byRepo := make(map[int32][]*FileDiff)
+	var repoIDs []int32
+	for _, d := range diffs {
+		if d.Value == "" {
+			continue
+		}
+		if _, ok := byRepo[d.RepositoryID]; !ok {
+			repoIDs = append(repoIDs, d.RepositoryID)
+		}
+		byRepo[d.RepositoryID] = append(byRepo[d.RepositoryID], d)
+	}
+	sort.Slice(repoIDs, func(i, j int) bool { return repoIDs[i] < repoIDs[j] })
+
+	client := gitserver.NewClient(db)
+	specs := make([]*batches.ChangesetSpec, 0, len(repoIDs))
+	for _, id := range repoIDs {
+		repoDiffs := byRepo[id]
+		sort.Slice(repoDiffs, func(i, j int) bool { return repoDiffs[i].Path < repoDiffs[j].Path })
+
+		first := repoDiffs[0]
+		var diff strings.Builder
+		for _, d := range repoDiffs {
+			if d.Commit != first.Commit || d.Rev != first.Rev {
+				return nil, errors.Newf("diffs for repository %s were computed on more than one commit", first.Repository)
+			}
+			diff.WriteString(d.Value)
+		}
+
+		baseRef, err := changesetBaseRef(ctx, client, first)
+		if err != nil {
+			return nil, err
+		}
+
+		repoID := string(relay.MarshalID("Repository", id))
+		specs = append(specs, &batches.ChangesetSpec{
+			BaseRepository: repoID,
+			HeadRepository: repoID,
+			BaseRef:        baseRef,
+			BaseRev:        first.Commit,
+			HeadRef:        git.EnsureRefPrefix(tmpl.Branch),
+			Title:          tmpl.Title,
+			Body:           body,
+			Commits: []batches.GitCommitDescription{{
+				Message:     tmpl.CommitMessage,
+				Diff:        diff.String(),
+				AuthorName:  authorName,
+				AuthorEmail: authorEmail,
+			}},
+		})
+	}
+	return specs, nil
+}
+
+// changesetBaseRef returns the ref of the branch that d was computed on.
+func changesetBaseRef(ctx context.Context, client gitserver.Client, d *FileDiff) (string, error) {
+	repo := api.RepoName(d.Repository)
+	if d.Rev == "" || d.Rev == "HEAD" {
+		baseRef, _, err := client.GetDefaultBranch(ctx, repo, false)
+		if err != nil {
+			return "", errors.Wrapf(err, "getting default branch of %s", d.Repository)
+		}
+		return baseRef, nil
+	}
+
+	// Changesets can only target branches, so the diffs of searches of a
+	// commit or a tag can't be turned into changesets.
+	if gitdomain.IsAbsoluteRevision(d.Rev) {
+		return "", errors.Newf("revision %s of repository %s is not a branch", d.Rev, d.Repository)
+	}
+	baseRef := git.EnsureRefPrefix(d.Rev)
+	if _, err := client.ResolveRevision(ctx, repo, baseRef, gitserver.ResolveRevisionOptions{NoEnsureRevision: true}); err != nil {
+		if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) {
+			return "", errors.Newf("revision %s of repository %s is not a branch", d.Rev, d.Repository)
+		}
+		return "", errors.Wrapf(err, "resolving branch %s of %s", d.Rev, d.Repository)
+	}
+	return baseRef, nil
+}
@@ -0,0 +1,124 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+)
+
+func TestChangesetSpecs(t *testing.T) {
+	gitserver.Mocks.GetDefaultBranch = func(repo api.RepoName) (string, api.CommitID, error) {
+		return "refs/heads/main", "", nil
+	}
+	gitserver.Mocks.ResolveRevision = func(spec string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		if spec == "refs/heads/release" {
+			return "abc", nil
+		}
+		return "", &gitdomain.RevisionNotFoundError{Spec: spec}
+	}
+	t.Cleanup(gitserver.ResetMocks)
+
+	test := func(tmpl ChangesetTemplate, diffs ...*FileDiff) string {
+		specs, err := ChangesetSpecs(context.Background(), database.NewMockDB(), diffs, tmpl)
+		if err != nil {
+			return err.Error()
+		}
+		v, _ := json.MarshalIndent(specs, "", "  ")
+		return string(v)
+	}
+
+	tmpl := ChangesetTemplate{Title: "Rename foo", Branch: "rename-foo", CommitMessage: "Rename foo to bar"}
+
+	autogold.Want("one changeset per repository", `[
+  {
+    "baseRepository": "UmVwb3NpdG9yeTox",
+    "baseRev": "abc",
+    "baseRef": "refs/heads/main",
+    "headRepository": "UmVwb3NpdG9yeTox",
+    "headRef": "refs/heads/rename-foo",
+    "title": "Rename foo",
+    "body": "Rename foo",
+    "commits": [
+      {
+        "message": "Rename foo to bar",
+        "diff": "diff a.go\ndiff b.go\n",
+        "authorName": "Sourcegraph",
+        "authorEmail": "batch-changes@sourcegraph.com"
+      }
+    ]
+  },
+  {
+    "baseRepository": "UmVwb3NpdG9yeToy",
+    "baseRev": "def",
+    "baseRef": "refs/heads/main",
+    "headRepository": "UmVwb3NpdG9yeToy",
+    "headRef": "refs/heads/rename-foo",
+    "title": "Rename foo",
+    "body": "Rename foo",
+    "commits": [
+      {
+        "message": "Rename foo to bar",
+        "diff": "diff c.go\n",
+        "authorName": "Sourcegraph",
+        "authorEmail": "batch-changes@sourcegraph.com"
+      }
+    ]
+  }
+]`).Equal(t, test(tmpl,
+		&FileDiff{Value: "diff c.go\n", RepositoryID: 2, Repository: "github.com/b/b", Commit: "def", Path: "c.go"},
+		&FileDiff{Value: "diff b.go\n", RepositoryID: 1, Repository: "github.com/a/a", Commit: "abc", Path: "b.go"},
+		&FileDiff{Value: "diff a.go\n", RepositoryID: 1, Repository: "github.com/a/a", Commit: "abc", Path: "a.go"},
+	))
+
+	autogold.Want("diffs on different commits",
+		"diffs for repository github.com/a/a were computed on more than one commit").
+		Equal(t, test(tmpl,
+			&FileDiff{Value: "diff a.go\n", RepositoryID: 1, Repository: "github.com/a/a", Commit: "abc", Path: "a.go"},
+			&FileDiff{Value: "diff b.go\n", RepositoryID: 1, Repository: "github.com/a/a", Commit: "def", Path: "b.go"},
+		))
+
+	autogold.Want("searched branch", `[
+  {
+    "baseRepository": "UmVwb3NpdG9yeTox",
+    "baseRev": "abc",
+    "baseRef": "refs/heads/release",
+    "headRepository": "UmVwb3NpdG9yeTox",
+    "headRef": "refs/heads/rename-foo",
+    "title": "Rename foo",
+    "body": "Rename foo",
+    "commits": [
+      {
+        "message": "Rename foo to bar",
+        "diff": "diff a.go\n",
+        "authorName": "Sourcegraph",
+        "authorEmail": "batch-changes@sourcegraph.com"
+      }
+    ]
+  }
+]`).Equal(t, test(tmpl,
+		&FileDiff{Value: "diff a.go\n", RepositoryID: 1, Repository: "github.com/a/a", Commit: "abc", Path: "a.go", Rev: "release"},
+	))
+
+	autogold.Want("searched tag",
+		"revision v1.0 of repository github.com/a/a is not a branch").
+		Equal(t, test(tmpl,
+			&FileDiff{Value: "diff a.go\n", RepositoryID: 1, Repository: "github.com/a/a", Commit: "abc", Path: "a.go", Rev: "v1.0"},
+		))
+
+	autogold.Want("searched commit",
+		"revision deadbeefdeadbeefdeadbeefdeadbeefdeadbeef of repository github.com/a/a is not a branch").
+		Equal(t, test(tmpl,
+			&FileDiff{Value: "diff a.go\n", RepositoryID: 1, Repository: "github.com/a/a", Commit: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", Path: "a.go", Rev: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"},
+		))
+
+	autogold.Want("incomplete template",
+		"changeset template requires a title, branch and commit message").
+		Equal(t, test(ChangesetTemplate{Title: "Rename foo"}))
+}
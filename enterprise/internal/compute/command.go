@@ -14,12 +14,30 @@ type Command interface {
 	String() string
 }
 
+// AggregatingCommand is a Command whose results are reduced to a single
+// result on the server, instead of being returned for every search match.
+type AggregatingCommand interface {
+	Command
+	NewAggregator() Aggregator
+}
+
+// Aggregator combines the results of running a command over search matches.
+// Add may be called concurrently.
+type Aggregator interface {
+	Add(Result)
+	Result() Result
+}
+
 var (
 	_ Command = (*MatchOnly)(nil)
 	_ Command = (*Replace)(nil)
 	_ Command = (*Output)(nil)
+	_ Command = (*CountBy)(nil)
+
+	_ AggregatingCommand = (*CountBy)(nil)
 )
 
 func (MatchOnly) command() {}
 func (Replace) command()   {}
 func (Output) command()    {}
+func (CountBy) command()   {}
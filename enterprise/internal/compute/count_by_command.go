@@ -0,0 +1,92 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// CountBy counts search pattern matches, keyed by the value of KeyPattern
+// rendered for each match. The counts of all matches are reduced into a single
+// Table by the aggregator returned from NewAggregator.
+type CountBy struct {
+	SearchPattern MatchPattern
+	KeyPattern    string
+	Selector      string
+	TypeValue     string
+	Kind          string
+}
+
+func (c *CountBy) ToSearchPattern() string {
+	return c.SearchPattern.String()
+}
+
+func (c *CountBy) String() string {
+	return fmt.Sprintf("Count by: (%s) -> (%s)", c.SearchPattern.String(), c.KeyPattern)
+}
+
+func (c *CountBy) NewAggregator() Aggregator {
+	return newTableAggregator(c.Kind, countByTableLimit, countByMaxKeys)
+}
+
+const (
+	// countByTableLimit is the number of rows in the table count.by returns.
+	countByTableLimit = 100
+
+	// countByMaxKeys is the number of distinct keys count.by keeps track of.
+	// Matches for other keys are only reflected in the table's OtherCount.
+	countByMaxKeys = 10_000
+)
+
+// keys returns the key rendered for every match of matchPattern in content.
+func keys(ctx context.Context, content string, matchPattern MatchPattern, keyPattern string) ([]string, error) {
+	switch match := matchPattern.(type) {
+	case *Regexp:
+		var keys []string
+		for _, submatches := range match.Value.FindAllStringSubmatchIndex(content, -1) {
+			keys = append(keys, string(match.Value.ExpandString(nil, keyPattern, content, submatches)))
+		}
+		return keys, nil
+	case *Comby:
+		out, err := output(ctx, content, match, keyPattern, "\n")
+		if err != nil {
+			return nil, err
+		}
+		return strings.FieldsFunc(out, func(r rune) bool { return r == '\n' }), nil
+	}
+	return nil, nil
+}
+
+func (c *CountBy) Run(ctx context.Context, _ database.DB, r result.Match) (Result, error) {
+	onlyPath := c.TypeValue == "path" // don't read file contents for file matches when we only want type:path
+	chunks := resultChunks(r, c.Kind, onlyPath)
+
+	counts := make(map[string]int)
+	for _, content := range chunks {
+		env := NewMetaEnvironment(r, content)
+		keyPattern, err := substituteMetaVariables(c.KeyPattern, env)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.Selector != "" {
+			// Like output, don't run the search pattern over the search
+			// result content when there's an explicit `select:` value.
+			counts[keyPattern]++
+			continue
+		}
+
+		ks, err := keys(ctx, content, c.SearchPattern, keyPattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range ks {
+			counts[k]++
+		}
+	}
+
+	return newTable(c.Kind, counts, len(counts), 0), nil
+}
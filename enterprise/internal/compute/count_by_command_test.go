@@ -0,0 +1,71 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestCountBy(t *testing.T) {
+	test := func(q string, matches ...result.Match) string {
+		computeQuery, err := Parse(q)
+		if err != nil {
+			return err.Error()
+		}
+		cmd, ok := computeQuery.Command.(*CountBy)
+		if !ok {
+			return "Error, not a count.by command"
+		}
+
+		aggregator := cmd.NewAggregator()
+		for _, m := range matches {
+			res, err := cmd.Run(context.Background(), database.NewMockDB(), m)
+			if err != nil {
+				return err.Error()
+			}
+			aggregator.Add(res)
+		}
+		v, _ := json.Marshal(aggregator.Result())
+		return string(v)
+	}
+
+	autogold.Want(
+		"count by capture group",
+		`{"kind":"count.by","rows":[{"key":"a","count":3},{"key":"b","count":2},{"key":"c","count":1}],"otherCount":0}`).
+		Equal(t, test(`content:count.by((\w) -> $1)`, fileMatch("a b c"), fileMatch("b a", "a")))
+
+	autogold.Want(
+		"count by repo",
+		`{"kind":"count.by","rows":[{"key":"my/awesome/repo","count":3}],"otherCount":0}`).
+		Equal(t, test(`content:count.by(\d -> $repo)`, fileMatch("1 2 3")))
+
+	autogold.Want(
+		"count by repo with select",
+		`{"kind":"count.by","rows":[{"key":"my/awesome/repo","count":2}],"otherCount":0}`).
+		Equal(t, test(`content:count.by(\d -> $repo) select:repo`, fileMatch("1 2 3"), fileMatch("4")))
+
+	autogold.Want(
+		"count by commit author",
+		`{"kind":"count.by.regexp","rows":[{"key":"bob","count":2}],"otherCount":0}`).
+		Equal(t, test(`content:count.by.regexp(fix -> $author)`, commitMatch("fix fix")))
+}
+
+func TestTableAggregator(t *testing.T) {
+	a := newTableAggregator("count.by", 2, 3)
+	a.Add(newTable("count.by", map[string]int{"a": 1, "b": 5, "c": 2}, 3, 0))
+	a.Add(newTable("count.by", map[string]int{"a": 3, "d": 4}, 2, 0))
+	a.Add(&Text{Value: "ignored"})
+
+	// d is never tracked because we already saw three keys, and c is left
+	// out of the table because of the limit.
+	v, _ := json.Marshal(a.Result())
+	autogold.Want(
+		"bounded table",
+		`{"kind":"count.by","rows":[{"key":"b","count":5},{"key":"a","count":4}],"otherCount":6}`).
+		Equal(t, string(v))
+}
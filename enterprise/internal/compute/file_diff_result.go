@@ -0,0 +1,59 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// FileDiff is a unified diff of the changes a command makes to a file, in the
+// format `git apply` expects.
+type FileDiff struct {
+	Value        string `json:"value"`
+	Kind         string `json:"kind"`
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	Commit       string `json:"commit"`
+	Path         string `json:"path"`
+
+	// Rev is the revision of the repository that was searched, as given in
+	// the query. It is empty if the default branch was searched.
+	Rev string `json:"rev,omitempty"`
+}
+
+// unifiedDiff returns the unified diff between the before and after content
+// of the file at path. It returns an empty string if the content is unchanged.
+func unifiedDiff(path, before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	from, to := "a/"+path, "b/"+path
+	edits := myers.ComputeEdits(span.URIFromPath(from), before, after)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git %s %s\n", from, to)
+	fmt.Fprint(&b, gotextdiff.ToUnified(from, to, before, edits))
+	return b.String()
+}
+
+func toFileDiff(m *result.FileMatch, before, after string) *FileDiff {
+	var rev string
+	if m.InputRev != nil {
+		rev = *m.InputRev
+	}
+	return &FileDiff{
+		Value:        unifiedDiff(m.Path, before, after),
+		Kind:         "replace-diff",
+		RepositoryID: int32(m.Repo.ID),
+		Repository:   string(m.Repo.Name),
+		Commit:       string(m.CommitID),
+		Path:         m.Path,
+		Rev:          rev,
+	}
+}
@@ -0,0 +1,21 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	autogold.Want("unchanged", "").Equal(t, unifiedDiff("main.go", "a\nb\n", "a\nb\n"))
+
+	autogold.Want("changed line", `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ a
+-b
++c
+ d
+`).Equal(t, unifiedDiff("main.go", "a\nb\nd\n", "a\nc\nd\n"))
+}
@@ -77,7 +77,7 @@ func resultChunks(r result.Match, kind string, onlyPath bool) []string {
 			}
 		}
 
-		if kind == "output.structural" {
+		if kind == "output.structural" || kind == "count.by.structural" {
 			// concatenate all chunk matches into one string so we
 			// don't invoke comby for every result.
 			return []string{strings.Join(chunks, "")}
@@ -2,6 +2,7 @@ package compute
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/regexp"
 
@@ -35,6 +36,21 @@ func (q Query) ToSearchQuery() (string, error) {
 	return query.StringHuman(expression), nil
 }
 
+// WithCountAll returns a copy of q whose search query returns all matches. Any
+// count: parameter of q is replaced.
+func (q Query) WithCountAll() Query {
+	found := false
+	parameters := query.MapField(q.Parameters, query.FieldCount, func(_ string, negated bool, annotation query.Annotation) query.Node {
+		found = true
+		return query.Parameter{Field: query.FieldCount, Value: "all", Negated: negated, Annotation: annotation}
+	})
+	if !found {
+		parameters = append(parameters, query.Parameter{Field: query.FieldCount, Value: "all"})
+	}
+	q.Parameters = parameters
+	return q
+}
+
 type MatchPattern interface {
 	pattern()
 	String() string
@@ -97,13 +113,19 @@ func toRegexpPattern(value string) (MatchPattern, error) {
 
 var ComputePredicateRegistry = query.PredicateRegistry{
 	query.FieldContent: {
-		"replace":            func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.structural": func() query.Predicate { return query.EmptyPredicate{} },
-		"output":             func() query.Predicate { return query.EmptyPredicate{} },
-		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
-		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
-		"output.extra":       func() query.Predicate { return query.EmptyPredicate{} },
+		"replace":                 func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.regexp":          func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.structural":      func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.diff":            func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.diff.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.diff.structural": func() query.Predicate { return query.EmptyPredicate{} },
+		"output":                  func() query.Predicate { return query.EmptyPredicate{} },
+		"output.regexp":           func() query.Predicate { return query.EmptyPredicate{} },
+		"output.structural":       func() query.Predicate { return query.EmptyPredicate{} },
+		"output.extra":            func() query.Predicate { return query.EmptyPredicate{} },
+		"count.by":                func() query.Predicate { return query.EmptyPredicate{} },
+		"count.by.regexp":         func() query.Predicate { return query.EmptyPredicate{} },
+		"count.by.structural":     func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
@@ -147,13 +169,13 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 
 	var matchPattern MatchPattern
 	switch name {
-	case "replace", "replace.regexp":
+	case "replace", "replace.regexp", "replace.diff", "replace.diff.regexp":
 		var err error
 		matchPattern, err = toRegexpPattern(left)
 		if err != nil {
 			return nil, false, errors.Wrap(err, "replace command")
 		}
-	case "replace.structural":
+	case "replace.structural", "replace.diff.structural":
 		// structural search doesn't do any match pattern validation
 		matchPattern = &Comby{Value: left}
 	default:
@@ -161,7 +183,11 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 		return nil, false, nil
 	}
 
-	return &Replace{SearchPattern: matchPattern, ReplacePattern: right}, true, nil
+	return &Replace{
+		SearchPattern:  matchPattern,
+		ReplacePattern: right,
+		Diff:           strings.HasPrefix(name, "replace.diff"),
+	}, true, nil
 }
 
 func parseOutput(q *query.Basic) (Command, bool, error) {
@@ -217,6 +243,56 @@ func parseOutput(q *query.Basic) (Command, bool, error) {
 	}, true, nil
 }
 
+func parseCountBy(q *query.Basic) (Command, bool, error) {
+	pattern, err := extractPattern(q)
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+	left, right, err := parseArrowSyntax(args)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var matchPattern MatchPattern
+	switch name {
+	case "count.by", "count.by.regexp":
+		var err error
+		matchPattern, err = toRegexpPattern(left)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "count.by command")
+		}
+	case "count.by.structural":
+		// structural search doesn't do any match pattern validation
+		matchPattern = &Comby{Value: left}
+	default:
+		// unrecognized name
+		return nil, false, nil
+	}
+
+	var typeValue string
+	query.VisitField(q.ToParseTree(), query.FieldType, func(value string, _ bool, _ query.Annotation) {
+		typeValue = value
+	})
+
+	var selector string
+	query.VisitField(q.ToParseTree(), query.FieldSelect, func(value string, _ bool, _ query.Annotation) {
+		selector = value
+	})
+
+	return &CountBy{
+		SearchPattern: matchPattern,
+		KeyPattern:    right,
+		TypeValue:     typeValue,
+		Selector:      selector,
+		Kind:          name,
+	}, true, nil
+}
+
 func parseMatchOnly(q *query.Basic) (Command, bool, error) {
 	pattern, err := extractPattern(q)
 	if err != nil {
@@ -260,6 +336,7 @@ func first(parsers ...commandParser) commandParser {
 var parseCommand = first(
 	parseReplace,
 	parseOutput,
+	parseCountBy,
 	parseMatchOnly,
 )
 
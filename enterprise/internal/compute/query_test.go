@@ -50,6 +50,14 @@ func TestParse(t *testing.T) {
 	autogold.Want("replace no left hand side",
 		"Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Want("replace as diff",
+		"Command: `Replace as diff: (a) -> (b)`").
+		Equal(t, test("content:replace.diff(a -> b)"))
+
+	autogold.Want("count.by",
+		"Command: `Count by: ((\\w+)) -> ($1)`, Parameters: `repo:foo`").
+		Equal(t, test(`content:count.by((\w+) -> $1) repo:foo`))
 }
 
 func TestToSearchQuery(t *testing.T) {
@@ -74,3 +82,22 @@ func TestToSearchQuery(t *testing.T) {
 		"((repo:foo file:bar lang:go OR repo:foo file:bar lang:text) AND colarado)").
 		Equal(t, test("content:replace(colarado -> colorodo) repo:foo file:bar (lang:go or lang:text)"))
 }
+
+func TestWithCountAll(t *testing.T) {
+	test := func(input string) string {
+		q, err := Parse(input)
+		if err != nil {
+			return err.Error()
+		}
+		s, _ := q.WithCountAll().ToSearchQuery()
+		return s
+	}
+
+	autogold.Want("adds count:all",
+		"repo:foo count:all colarado").
+		Equal(t, test("content:replace(colarado -> colorodo) repo:foo"))
+
+	autogold.Want("replaces count",
+		"(repo:foo count:all AND colarado)").
+		Equal(t, test("content:replace(colarado -> colorodo) repo:foo count:10"))
+}
@@ -15,6 +15,10 @@ import (
 type Replace struct {
 	SearchPattern  MatchPattern
 	ReplacePattern string
+
+	// Diff is set when the command returns a unified diff of the changes to
+	// each file, instead of the file's replaced content.
+	Diff bool
 }
 
 func (c *Replace) ToSearchPattern() string {
@@ -22,6 +26,9 @@ func (c *Replace) ToSearchPattern() string {
 }
 
 func (c *Replace) String() string {
+	if c.Diff {
+		return fmt.Sprintf("Replace as diff: (%s) -> (%s)", c.SearchPattern.String(), c.ReplacePattern)
+	}
 	return fmt.Sprintf("Replace in place: (%s) -> (%s)", c.SearchPattern.String(), c.ReplacePattern)
 }
 
@@ -57,7 +64,16 @@ func (c *Replace) Run(ctx context.Context, db database.DB, r result.Match) (Resu
 		if err != nil {
 			return nil, err
 		}
-		return replace(ctx, content, c.SearchPattern, c.ReplacePattern)
+		text, err := replace(ctx, content, c.SearchPattern, c.ReplacePattern)
+		if err != nil || !c.Diff {
+			return text, err
+		}
+		diff := toFileDiff(m, string(content), text.Value)
+		if diff.Value == "" {
+			// The replacement didn't change the file.
+			return nil, nil
+		}
+		return diff, nil
 	}
 	return nil, nil
 }
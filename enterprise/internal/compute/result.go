@@ -8,8 +8,12 @@ var (
 	_ Result = (*MatchContext)(nil)
 	_ Result = (*Text)(nil)
 	_ Result = (*TextExtra)(nil)
+	_ Result = (*Table)(nil)
+	_ Result = (*FileDiff)(nil)
 )
 
 func (*MatchContext) result() {}
 func (*Text) result()         {}
 func (*TextExtra) result()    {}
+func (*Table) result()        {}
+func (*FileDiff) result()     {}
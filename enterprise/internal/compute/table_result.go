@@ -0,0 +1,92 @@
+package compute
+
+import (
+	"sort"
+	"sync"
+)
+
+// Table is a result that aggregates over all search matches, with rows sorted
+// by descending count.
+type Table struct {
+	Kind string     `json:"kind"`
+	Rows []TableRow `json:"rows"`
+
+	// OtherCount is the sum of the counts of keys that were left out of Rows
+	// to keep the table bounded.
+	OtherCount int `json:"otherCount"`
+}
+
+type TableRow struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// newTable returns a table of the limit largest counts. Ties are broken by
+// key, so that the table is deterministic.
+func newTable(kind string, counts map[string]int, limit, otherCount int) *Table {
+	rows := make([]TableRow, 0, len(counts))
+	for key, count := range counts {
+		rows = append(rows, TableRow{Key: key, Count: count})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Key < rows[j].Key
+	})
+	if len(rows) > limit {
+		for _, row := range rows[limit:] {
+			otherCount += row.Count
+		}
+		rows = rows[:limit]
+	}
+	return &Table{Kind: kind, Rows: rows, OtherCount: otherCount}
+}
+
+// tableAggregator sums the tables of individual matches. It keeps track of at
+// most maxKeys keys, so that its memory use is bounded however many distinct
+// keys a query produces.
+type tableAggregator struct {
+	kind    string
+	limit   int
+	maxKeys int
+
+	mu         sync.Mutex
+	counts     map[string]int
+	otherCount int
+}
+
+func newTableAggregator(kind string, limit, maxKeys int) *tableAggregator {
+	return &tableAggregator{
+		kind:    kind,
+		limit:   limit,
+		maxKeys: maxKeys,
+		counts:  make(map[string]int),
+	}
+}
+
+func (a *tableAggregator) Add(r Result) {
+	t, ok := r.(*Table)
+	if !ok {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.otherCount += t.OtherCount
+	for _, row := range t.Rows {
+		if _, ok := a.counts[row.Key]; !ok && len(a.counts) >= a.maxKeys {
+			a.otherCount += row.Count
+			continue
+		}
+		a.counts[row.Key] += row.Count
+	}
+}
+
+func (a *tableAggregator) Result() Result {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return newTable(a.kind, a.counts, a.limit, a.otherCount)
+}
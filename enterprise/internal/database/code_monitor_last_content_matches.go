@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (s *codeMonitorStore) UpsertLastContentMatches(ctx context.Context, monitorID int64, repoID api.RepoID, matchHashes []string) error {
+	rawQuery := `
+	INSERT INTO cm_last_content_matches (monitor_id, repo_id, match_hashes)
+	VALUES (%s, %s, %s)
+	ON CONFLICT (monitor_id, repo_id) DO UPDATE
+	SET match_hashes = %s
+	`
+
+	// Appease non-null constraint on column
+	if matchHashes == nil {
+		matchHashes = []string{}
+	}
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(repoID), pq.StringArray(matchHashes), pq.StringArray(matchHashes))
+	return s.Exec(ctx, q)
+}
+
+func (s *codeMonitorStore) GetLastContentMatches(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error) {
+	rawQuery := `
+	SELECT match_hashes
+	FROM cm_last_content_matches
+	WHERE monitor_id = %s
+		AND repo_id = %s
+	LIMIT 1
+	`
+
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(repoID))
+	var matchHashes []string
+	err := s.QueryRow(ctx, q).Scan((*pq.StringArray)(&matchHashes))
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return matchHashes, err
+}
+
+func (s *codeMonitorStore) DeleteLastContentMatchesExcept(ctx context.Context, monitorID int64, repoIDs []api.RepoID) error {
+	rawQuery := `
+	DELETE FROM cm_last_content_matches
+	WHERE monitor_id = %s
+		AND NOT repo_id = ANY(%s)
+	`
+
+	ids := make([]int64, 0, len(repoIDs))
+	for _, id := range repoIDs {
+		ids = append(ids, int64(id))
+	}
+	q := sqlf.Sprintf(rawQuery, monitorID, pq.Int64Array(ids))
+	return s.Exec(ctx, q)
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreLastContentMatches(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	t.Run("insert get upsert get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		// Insert
+		insertHashes := []string{"hash1", "hash2"}
+		err := cm.UpsertLastContentMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, insertHashes)
+		require.NoError(t, err)
+
+		// Get
+		hashes, err := cm.GetLastContentMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Equal(t, insertHashes, hashes)
+
+		// Update
+		updateHashes := []string{"hash3"}
+		err = cm.UpsertLastContentMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, updateHashes)
+		require.NoError(t, err)
+
+		// Get
+		hashes, err = cm.GetLastContentMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Equal(t, updateHashes, hashes)
+	})
+
+	t.Run("no error for missing get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		hashes, err := cm.GetLastContentMatches(ctx, fixtures.Monitor.ID+1, 19793)
+		require.NoError(t, err)
+		require.Empty(t, hashes)
+	})
+
+	t.Run("delete except", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		err := cm.UpsertLastContentMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"hash1"})
+		require.NoError(t, err)
+
+		// Keeping the repo should not delete its snapshot
+		err = cm.DeleteLastContentMatchesExcept(ctx, fixtures.Monitor.ID, []api.RepoID{fixtures.Repo.ID})
+		require.NoError(t, err)
+		hashes, err := cm.GetLastContentMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"hash1"}, hashes)
+
+		// Not keeping the repo should delete its snapshot
+		err = cm.DeleteLastContentMatchesExcept(ctx, fixtures.Monitor.ID, nil)
+		require.NoError(t, err)
+		hashes, err = cm.GetLastContentMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Empty(t, hashes)
+	})
+}
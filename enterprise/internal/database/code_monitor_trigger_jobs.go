@@ -21,6 +21,10 @@ type TriggerJob struct {
 
 	SearchResults []*result.CommitMatch
 
+	// ContentResults are the new file content matches found by a
+	// content-match code monitor.
+	ContentResults []*ContentMatch
+
 	// Fields demanded for any dbworker.
 	State          string
 	FailureMessage *string
@@ -36,6 +40,32 @@ func (r *TriggerJob) RecordID() int {
 	return int(r.ID)
 }
 
+// ContentMatch is a file that has new content matches for a content-match code
+// monitor. We store our own representation rather than result.FileMatch since
+// the latter does not serialize its repository and commit.
+type ContentMatch struct {
+	RepoName string             `json:"repoName"`
+	CommitID string             `json:"commitID"`
+	Path     string             `json:"path"`
+	Lines    []ContentMatchLine `json:"lines,omitempty"`
+}
+
+// ContentMatchLine is a single matched line of a ContentMatch.
+type ContentMatchLine struct {
+	LineNumber       int32      `json:"lineNumber"`
+	Preview          string     `json:"preview"`
+	OffsetAndLengths [][2]int32 `json:"offsetAndLengths,omitempty"`
+}
+
+// ResultCount returns the number of matches in the file, counting a path
+// match as a single match.
+func (m *ContentMatch) ResultCount() int {
+	if len(m.Lines) == 0 {
+		return 1
+	}
+	return len(m.Lines)
+}
+
 const enqueueTriggerQueryFmtStr = `
 WITH due AS (
     SELECT cm_queries.id as id
@@ -82,6 +112,26 @@ func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, trig
 	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, resultsJSON, triggerJobID))
 }
 
+const logContentSearchFmtStr = `
+UPDATE cm_trigger_jobs
+SET query_string = %s,
+    content_results = %s
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) UpdateTriggerJobWithContentResults(ctx context.Context, triggerJobID int32, queryString string, results []*ContentMatch) error {
+	if results == nil {
+		// appease db array constraint
+		results = []*ContentMatch{}
+	}
+
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logContentSearchFmtStr, queryString, resultsJSON, triggerJobID))
+}
+
 const deleteOldJobLogsFmtStr = `
 DELETE FROM cm_trigger_jobs
 WHERE finished_at < (NOW() - (%s * '1 day'::interval));
@@ -143,7 +193,7 @@ func (s *codeMonitorStore) ListQueryTriggerJobs(ctx context.Context, opts ListTr
 const totalCountEventsForQueryIDInt64FmtStr = `
 SELECT COUNT(*)
 FROM cm_trigger_jobs
-WHERE ((state = 'completed' AND jsonb_array_length(search_results) > 0) OR (state != 'completed'))
+WHERE ((state = 'completed' AND (jsonb_array_length(search_results) > 0 OR jsonb_array_length(content_results) > 0)) OR (state != 'completed'))
 AND query = %s
 `
 
@@ -170,13 +220,14 @@ func scanTriggerJobs(rows *sql.Rows) ([]*TriggerJob, error) {
 }
 
 func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
-	var resultsJSON []byte
+	var resultsJSON, contentResultsJSON []byte
 	m := &TriggerJob{}
 	err := scanner.Scan(
 		&m.ID,
 		&m.Query,
 		&m.QueryString,
 		&resultsJSON,
+		&contentResultsJSON,
 		&m.State,
 		&m.FailureMessage,
 		&m.StartedAt,
@@ -196,6 +247,12 @@ func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 		}
 	}
 
+	if len(contentResultsJSON) > 0 {
+		if err := json.Unmarshal(contentResultsJSON, &m.ContentResults); err != nil {
+			return nil, err
+		}
+	}
+
 	return m, nil
 }
 
@@ -204,6 +261,7 @@ var TriggerJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_trigger_jobs.query"),
 	sqlf.Sprintf("cm_trigger_jobs.query_string"),
 	sqlf.Sprintf("cm_trigger_jobs.search_results"),
+	sqlf.Sprintf("cm_trigger_jobs.content_results"),
 	sqlf.Sprintf("cm_trigger_jobs.state"),
 	sqlf.Sprintf("cm_trigger_jobs.failure_message"),
 	sqlf.Sprintf("cm_trigger_jobs.started_at"),
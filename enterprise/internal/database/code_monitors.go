@@ -50,6 +50,7 @@ type CodeMonitorStore interface {
 	CountQueryTriggerJobs(ctx context.Context, queryID int64) (int32, error)
 
 	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error
+	UpdateTriggerJobWithContentResults(ctx context.Context, triggerJobID int32, queryString string, results []*ContentMatch) error
 	DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error
 
 	UpdateEmailAction(_ context.Context, id int64, _ *EmailActionArgs) (*EmailAction, error)
@@ -90,6 +91,15 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	// UpsertLastContentMatches and GetLastContentMatches store the snapshot of
+	// matches that a content-match code monitor saw in a repo on its last run.
+	UpsertLastContentMatches(ctx context.Context, monitorID int64, repoID api.RepoID, matchHashes []string) error
+	GetLastContentMatches(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+	// DeleteLastContentMatchesExcept deletes the snapshots of all repos not in
+	// repoIDs, so a repo that stops matching is treated as new once it
+	// matches again.
+	DeleteLastContentMatchesExcept(ctx context.Context, monitorID int64, repoIDs []api.RepoID) error
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
@@ -70,6 +70,10 @@ type MockCodeMonitorStore struct {
 	// DeleteEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteEmailActions.
 	DeleteEmailActionsFunc *CodeMonitorStoreDeleteEmailActionsFunc
+	// DeleteLastContentMatchesExceptFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteLastContentMatchesExcept.
+	DeleteLastContentMatchesExceptFunc *CodeMonitorStoreDeleteLastContentMatchesExceptFunc
 	// DeleteMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteMonitor.
 	DeleteMonitorFunc *CodeMonitorStoreDeleteMonitorFunc
@@ -108,6 +112,9 @@ type MockCodeMonitorStore struct {
 	// GetEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetEmailAction.
 	GetEmailActionFunc *CodeMonitorStoreGetEmailActionFunc
+	// GetLastContentMatchesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetLastContentMatches.
+	GetLastContentMatchesFunc *CodeMonitorStoreGetLastContentMatchesFunc
 	// GetLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method GetLastSearched.
 	GetLastSearchedFunc *CodeMonitorStoreGetLastSearchedFunc
@@ -182,6 +189,10 @@ type MockCodeMonitorStore struct {
 	// UpdateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSlackWebhookAction.
 	UpdateSlackWebhookActionFunc *CodeMonitorStoreUpdateSlackWebhookActionFunc
+	// UpdateTriggerJobWithContentResultsFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// UpdateTriggerJobWithContentResults.
+	UpdateTriggerJobWithContentResultsFunc *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc
 	// UpdateTriggerJobWithResultsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateTriggerJobWithResults.
@@ -189,6 +200,9 @@ type MockCodeMonitorStore struct {
 	// UpdateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateWebhookAction.
 	UpdateWebhookActionFunc *CodeMonitorStoreUpdateWebhookActionFunc
+	// UpsertLastContentMatchesFunc is an instance of a mock function object
+	// controlling the behavior of the method UpsertLastContentMatches.
+	UpsertLastContentMatchesFunc *CodeMonitorStoreUpsertLastContentMatchesFunc
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
@@ -269,6 +283,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteLastContentMatchesExceptFunc: &CodeMonitorStoreDeleteLastContentMatchesExceptFunc{
+			defaultHook: func(context.Context, int64, []api.RepoID) (r0 error) {
+				return
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -329,6 +348,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetLastContentMatchesFunc: &CodeMonitorStoreGetLastContentMatchesFunc{
+			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
+				return
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
 				return
@@ -449,6 +473,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateTriggerJobWithContentResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc{
+			defaultHook: func(context.Context, int32, string, []*ContentMatch) (r0 error) {
+				return
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) (r0 error) {
 				return
@@ -459,6 +488,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpsertLastContentMatchesFunc: &CodeMonitorStoreUpsertLastContentMatchesFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string) (r0 error) {
+				return
+			},
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID, []string) (r0 error) {
 				return
@@ -541,6 +575,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteEmailActions")
 			},
 		},
+		DeleteLastContentMatchesExceptFunc: &CodeMonitorStoreDeleteLastContentMatchesExceptFunc{
+			defaultHook: func(context.Context, int64, []api.RepoID) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteLastContentMatchesExcept")
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteMonitor")
@@ -601,6 +640,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetEmailAction")
 			},
 		},
+		GetLastContentMatchesFunc: &CodeMonitorStoreGetLastContentMatchesFunc{
+			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetLastContentMatches")
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetLastSearched")
@@ -721,6 +765,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateSlackWebhookAction")
 			},
 		},
+		UpdateTriggerJobWithContentResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc{
+			defaultHook: func(context.Context, int32, string, []*ContentMatch) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithContentResults")
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
@@ -731,6 +780,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateWebhookAction")
 			},
 		},
+		UpsertLastContentMatchesFunc: &CodeMonitorStoreUpsertLastContentMatchesFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastContentMatches")
+			},
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
@@ -786,6 +840,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: i.DeleteEmailActions,
 		},
+		DeleteLastContentMatchesExceptFunc: &CodeMonitorStoreDeleteLastContentMatchesExceptFunc{
+			defaultHook: i.DeleteLastContentMatchesExcept,
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: i.DeleteMonitor,
 		},
@@ -822,6 +879,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: i.GetEmailAction,
 		},
+		GetLastContentMatchesFunc: &CodeMonitorStoreGetLastContentMatchesFunc{
+			defaultHook: i.GetLastContentMatches,
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: i.GetLastSearched,
 		},
@@ -894,12 +954,18 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
 			defaultHook: i.UpdateSlackWebhookAction,
 		},
+		UpdateTriggerJobWithContentResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc{
+			defaultHook: i.UpdateTriggerJobWithContentResults,
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: i.UpdateTriggerJobWithResults,
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
 			defaultHook: i.UpdateWebhookAction,
 		},
+		UpsertLastContentMatchesFunc: &CodeMonitorStoreUpsertLastContentMatchesFunc{
+			defaultHook: i.UpsertLastContentMatches,
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
@@ -2479,6 +2545,118 @@ func (c CodeMonitorStoreDeleteEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreDeleteLastContentMatchesExceptFunc describes the behavior
+// when the DeleteLastContentMatchesExcept method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreDeleteLastContentMatchesExceptFunc struct {
+	defaultHook func(context.Context, int64, []api.RepoID) error
+	hooks       []func(context.Context, int64, []api.RepoID) error
+	history     []CodeMonitorStoreDeleteLastContentMatchesExceptFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteLastContentMatchesExcept delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteLastContentMatchesExcept(v0 context.Context, v1 int64, v2 []api.RepoID) error {
+	r0 := m.DeleteLastContentMatchesExceptFunc.nextHook()(v0, v1, v2)
+	m.DeleteLastContentMatchesExceptFunc.appendCall(CodeMonitorStoreDeleteLastContentMatchesExceptFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteLastContentMatchesExcept method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteLastContentMatchesExceptFunc) SetDefaultHook(hook func(context.Context, int64, []api.RepoID) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteLastContentMatchesExcept method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteLastContentMatchesExceptFunc) PushHook(hook func(context.Context, int64, []api.RepoID) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteLastContentMatchesExceptFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []api.RepoID) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteLastContentMatchesExceptFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []api.RepoID) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteLastContentMatchesExceptFunc) nextHook() func(context.Context, int64, []api.RepoID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteLastContentMatchesExceptFunc) appendCall(r0 CodeMonitorStoreDeleteLastContentMatchesExceptFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteLastContentMatchesExceptFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreDeleteLastContentMatchesExceptFunc) History() []CodeMonitorStoreDeleteLastContentMatchesExceptFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteLastContentMatchesExceptFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteLastContentMatchesExceptFuncCall is an object that
+// describes an invocation of method DeleteLastContentMatchesExcept on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteLastContentMatchesExceptFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreDeleteLastContentMatchesExceptFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteLastContentMatchesExceptFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreDeleteMonitorFunc describes the behavior when the
 // DeleteMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -3803,6 +3981,121 @@ func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreGetLastContentMatchesFunc describes the behavior when the
+// GetLastContentMatches method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetLastContentMatchesFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID) ([]string, error)
+	hooks       []func(context.Context, int64, api.RepoID) ([]string, error)
+	history     []CodeMonitorStoreGetLastContentMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetLastContentMatches delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetLastContentMatches(v0 context.Context, v1 int64, v2 api.RepoID) ([]string, error) {
+	r0, r1 := m.GetLastContentMatchesFunc.nextHook()(v0, v1, v2)
+	m.GetLastContentMatchesFunc.appendCall(CodeMonitorStoreGetLastContentMatchesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetLastContentMatches method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetLastContentMatchesFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetLastContentMatches method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetLastContentMatchesFunc) PushHook(hook func(context.Context, int64, api.RepoID) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetLastContentMatchesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetLastContentMatchesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetLastContentMatchesFunc) nextHook() func(context.Context, int64, api.RepoID) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetLastContentMatchesFunc) appendCall(r0 CodeMonitorStoreGetLastContentMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetLastContentMatchesFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetLastContentMatchesFunc) History() []CodeMonitorStoreGetLastContentMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetLastContentMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetLastContentMatchesFuncCall is an object that describes
+// an invocation of method GetLastContentMatches on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetLastContentMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetLastContentMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetLastContentMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreGetLastSearchedFunc describes the behavior when the
 // GetLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -6459,6 +6752,121 @@ func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc describes the
+// behavior when the UpdateTriggerJobWithContentResults method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc struct {
+	defaultHook func(context.Context, int32, string, []*ContentMatch) error
+	hooks       []func(context.Context, int32, string, []*ContentMatch) error
+	history     []CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateTriggerJobWithContentResults delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithContentResults(v0 context.Context, v1 int32, v2 string, v3 []*ContentMatch) error {
+	r0 := m.UpdateTriggerJobWithContentResultsFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateTriggerJobWithContentResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateTriggerJobWithContentResults method of the parent
+// MockCodeMonitorStore instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*ContentMatch) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateTriggerJobWithContentResults method of the parent
+// MockCodeMonitorStore instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) PushHook(hook func(context.Context, int32, string, []*ContentMatch) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, []*ContentMatch) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, []*ContentMatch) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) nextHook() func(context.Context, int32, string, []*ContentMatch) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) appendCall(r0 CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall objects
+// describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) History() []CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall is an object
+// that describes an invocation of method UpdateTriggerJobWithContentResults
+// on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []*ContentMatch
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreUpdateTriggerJobWithResultsFunc describes the behavior
 // when the UpdateTriggerJobWithResults method of the parent
 // MockCodeMonitorStore instance is invoked.
@@ -6694,6 +7102,121 @@ func (c CodeMonitorStoreUpdateWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpsertLastContentMatchesFunc describes the behavior when
+// the UpsertLastContentMatches method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpsertLastContentMatchesFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID, []string) error
+	hooks       []func(context.Context, int64, api.RepoID, []string) error
+	history     []CodeMonitorStoreUpsertLastContentMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertLastContentMatches delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertLastContentMatches(v0 context.Context, v1 int64, v2 api.RepoID, v3 []string) error {
+	r0 := m.UpsertLastContentMatchesFunc.nextHook()(v0, v1, v2, v3)
+	m.UpsertLastContentMatchesFunc.appendCall(CodeMonitorStoreUpsertLastContentMatchesFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpsertLastContentMatches method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpsertLastContentMatchesFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertLastContentMatches method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpsertLastContentMatchesFunc) PushHook(hook func(context.Context, int64, api.RepoID, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertLastContentMatchesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertLastContentMatchesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertLastContentMatchesFunc) nextHook() func(context.Context, int64, api.RepoID, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertLastContentMatchesFunc) appendCall(r0 CodeMonitorStoreUpsertLastContentMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpsertLastContentMatchesFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpsertLastContentMatchesFunc) History() []CodeMonitorStoreUpsertLastContentMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertLastContentMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertLastContentMatchesFuncCall is an object that
+// describes an invocation of method UpsertLastContentMatches on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreUpsertLastContentMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastContentMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastContentMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreUpsertLastSearchedFunc describes the behavior when the
 // UpsertLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -2,7 +2,9 @@ package aggregation
 
 import (
 	"context"
+	"path"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/go-enry/go-enry/v2"
@@ -132,6 +134,85 @@ func countAuthor(r result.Match) (map[MatchKey]int, error) {
 	return nil, nil
 }
 
+// countDirectoryFunc returns a count func that groups matches by the leading directories of their
+// path, up to depth directories.
+func countDirectoryFunc(depth int) AggregationCountFunc {
+	return func(r result.Match) (map[MatchKey]int, error) {
+		match := newEventMatch(r)
+		if match.Path != "" {
+			return map[MatchKey]int{{
+				RepoID: match.RepoID,
+				Repo:   match.Repo,
+				Group:  directoryPrefix(match.Path, depth),
+			}: match.ResultCount}, nil
+		}
+		return nil, nil
+	}
+}
+
+// directoryPrefix returns the first depth directories of the directory that contains filePath, with
+// a trailing slash. Files at the root of a repository are grouped as "/".
+func directoryPrefix(filePath string, depth int) string {
+	dir := strings.Trim(path.Dir(filePath), "/")
+	if dir == "" || dir == "." {
+		return "/"
+	}
+	parts := strings.Split(dir, "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/") + "/"
+}
+
+// countDateFunc returns a count func that groups commit matches by the period their author date
+// falls into.
+func countDateFunc(bucket types.SearchAggregationDateBucket) AggregationCountFunc {
+	return func(r result.Match) (map[MatchKey]int, error) {
+		match := newEventMatch(r)
+		if !match.Date.IsZero() {
+			return map[MatchKey]int{{
+				RepoID: match.RepoID,
+				Repo:   match.Repo,
+				Group:  DateBucketLabel(bucket, match.Date),
+			}: match.ResultCount}, nil
+		}
+		return nil, nil
+	}
+}
+
+const (
+	monthBucketLayout = "2006-01"
+	weekBucketLayout  = "2006-01-02"
+)
+
+// DateBucketLabel returns the label of the bucket that t falls into. Months are labelled as
+// 2006-01 and weeks by the date of the Monday they start on.
+func DateBucketLabel(bucket types.SearchAggregationDateBucket, t time.Time) string {
+	t = t.UTC()
+	if bucket == types.WEEK_DATE_BUCKET {
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		monday := time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+		return monday.Format(weekBucketLayout)
+	}
+	return t.Format(monthBucketLayout)
+}
+
+// DateBucketRange returns the start (inclusive) and end (exclusive) of the bucket with the given label.
+func DateBucketRange(bucket types.SearchAggregationDateBucket, label string) (time.Time, time.Time, error) {
+	if bucket == types.WEEK_DATE_BUCKET {
+		start, err := time.Parse(weekBucketLayout, label)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Wrap(err, "parse week")
+		}
+		return start, start.AddDate(0, 0, 7), nil
+	}
+	start, err := time.Parse(monthBucketLayout, label)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.Wrap(err, "parse month")
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
+
 func countCaptureGroupsFunc(querystring string) (AggregationCountFunc, error) {
 	pattern, err := getCasedPattern(querystring)
 	if err != nil {
@@ -168,11 +249,34 @@ func countCaptureGroupsFunc(querystring string) (AggregationCountFunc, error) {
 	}, nil
 }
 
-func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode) (AggregationCountFunc, error) {
+// ModeOptions configure the aggregation modes that take parameters.
+type ModeOptions struct {
+	// DirectoryDepth is the number of leading directories that DIRECTORY aggregations group by.
+	// It defaults to 1.
+	DirectoryDepth int
+	// DateBucket is the period that DATE aggregations group commit dates by. It defaults to months.
+	DateBucket types.SearchAggregationDateBucket
+}
+
+func (o ModeOptions) WithDefaults() ModeOptions {
+	if o.DirectoryDepth < 1 {
+		o.DirectoryDepth = 1
+	}
+	if o.DateBucket == "" {
+		o.DateBucket = types.MONTH_DATE_BUCKET
+	}
+	return o
+}
+
+func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode, opts ModeOptions) (AggregationCountFunc, error) {
+	opts = opts.WithDefaults()
 	modeCountTypes := map[types.SearchAggregationMode]AggregationCountFunc{
-		types.REPO_AGGREGATION_MODE:   countRepo,
-		types.PATH_AGGREGATION_MODE:   countPath,
-		types.AUTHOR_AGGREGATION_MODE: countAuthor,
+		types.REPO_AGGREGATION_MODE:      countRepo,
+		types.PATH_AGGREGATION_MODE:      countPath,
+		types.AUTHOR_AGGREGATION_MODE:    countAuthor,
+		types.LANGUAGE_AGGREGATION_MODE:  countLang,
+		types.DIRECTORY_AGGREGATION_MODE: countDirectoryFunc(opts.DirectoryDepth),
+		types.DATE_AGGREGATION_MODE:      countDateFunc(opts.DateBucket),
 	}
 
 	if mode == types.CAPTURE_GROUP_AGGREGATION_MODE {
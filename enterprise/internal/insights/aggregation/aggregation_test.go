@@ -90,7 +90,7 @@ func commitMatch(repo, author string, date time.Time, repoID, numRanges int32, c
 
 	return &result.CommitMatch{
 		Commit: gitdomain.Commit{
-			Author:    gitdomain.Signature{Name: author},
+			Author:    gitdomain.Signature{Name: author, Date: date},
 			Committer: &gitdomain.Signature{},
 			Message:   gitdomain.Message(content),
 		},
@@ -175,7 +175,7 @@ func TestRepoAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode, ModeOptions{})
 			sra := newTestSearchResultsAggregator(aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -227,7 +227,7 @@ func TestAuthorAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode, ModeOptions{})
 			sra := newTestSearchResultsAggregator(aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -324,7 +324,7 @@ func TestPathAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode, ModeOptions{})
 			sra := newTestSearchResultsAggregator(aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -332,6 +332,122 @@ func TestPathAggregation(t *testing.T) {
 	}
 }
 
+func TestLanguageAggregation(t *testing.T) {
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.LANGUAGE_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					repoMatch("myRepo", 1),
+				},
+			},
+			autogold.Want("no language for commit or repo", map[string]int{}),
+		},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "cmd/main.go", 1, "a", "b"),
+					pathMatch("myRepo", "client/index.ts", 1),
+					symbolMatch("myRepo2", "lib/util.go", 2, "c"),
+					pathMatch("myRepo2", "Unknownfile", 2),
+				},
+			},
+			autogold.Want("Count languages by extension", map[string]int{"Go": 3, "TypeScript": 1}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode, ModeOptions{})
+			sra := newTestSearchResultsAggregator(aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestDirectoryAggregation(t *testing.T) {
+	results := []result.Match{
+		pathMatch("myRepo", "README.md", 1),
+		pathMatch("myRepo", "cmd/frontend/main.go", 1),
+		contentMatch("myRepo", "cmd/frontend/graphqlbackend/schema.go", 1, "a", "b"),
+		symbolMatch("myRepo2", "cmd/gitserver/server.go", 2, "c"),
+		pathMatch("myRepo2", "lib/errors/errors.go", 2),
+		commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+	}
+	testCases := []struct {
+		depth int
+		want  autogold.Value
+	}{
+		{0, autogold.Want("default depth", map[string]int{"/": 1, "cmd/": 4, "lib/": 1})},
+		{1, autogold.Want("top level directories", map[string]int{"/": 1, "cmd/": 4, "lib/": 1})},
+		{2, autogold.Want("two levels of directories", map[string]int{
+			"/": 1, "cmd/frontend/": 3, "cmd/gitserver/": 1,
+			"lib/errors/": 1,
+		})},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", types.DIRECTORY_AGGREGATION_MODE, ModeOptions{DirectoryDepth: tc.depth})
+			sra := newTestSearchResultsAggregator(aggregator.AddResult, countFunc)
+			sra.Send(streaming.SearchEvent{Results: results})
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestDateAggregation(t *testing.T) {
+	results := []result.Match{
+		// sampleDate is Friday 2022-04-01.
+		commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+		commitMatch("repoA", "Author A", sampleDate.AddDate(0, 0, 3), 1, 2, "a"),
+		commitMatch("repoB", "Author B", sampleDate.AddDate(0, 0, -1), 2, 2, "a"),
+		commitMatch("repoB", "Author B", sampleDate.AddDate(0, 1, 0), 2, 2, "a"),
+		contentMatch("myRepo", "file.go", 1, "a", "b"),
+	}
+	testCases := []struct {
+		bucket types.SearchAggregationDateBucket
+		want   autogold.Value
+	}{
+		{"", autogold.Want("default bucket", map[string]int{"2022-03": 1, "2022-04": 2, "2022-05": 1})},
+		{types.MONTH_DATE_BUCKET, autogold.Want("by month", map[string]int{"2022-03": 1, "2022-04": 2, "2022-05": 1})},
+		{types.WEEK_DATE_BUCKET, autogold.Want("by week", map[string]int{"2022-03-28": 2, "2022-04-04": 1, "2022-04-25": 1})},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", types.DATE_AGGREGATION_MODE, ModeOptions{DateBucket: tc.bucket})
+			sra := newTestSearchResultsAggregator(aggregator.AddResult, countFunc)
+			sra.Send(streaming.SearchEvent{Results: results})
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestDateBucketRange(t *testing.T) {
+	for _, bucket := range []types.SearchAggregationDateBucket{types.MONTH_DATE_BUCKET, types.WEEK_DATE_BUCKET} {
+		label := DateBucketLabel(bucket, sampleDate)
+		start, end, err := DateBucketRange(bucket, label)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sampleDate.Before(start) || !sampleDate.Before(end) {
+			t.Errorf("expected %s to fall into %s bucket %s [%s, %s)", sampleDate, bucket, label, start, end)
+		}
+		if got := DateBucketLabel(bucket, end.Add(-time.Nanosecond)); got != label {
+			t.Errorf("expected the end of %s bucket %s to have the same label, got %s", bucket, label, got)
+		}
+	}
+}
+
 func TestCaptureGroupAggregation(t *testing.T) {
 	longCaptureGroup := "111111111|222222222|333333333|444444444|555555555|666666666|777777777|888888888|999999999|000000000|"
 	testCases := []struct {
@@ -441,7 +557,7 @@ func TestCaptureGroupAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode)
+			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode, ModeOptions{})
 			if err != nil {
 				t.Errorf("expected test not to error, got %v", err)
 				t.FailNow()
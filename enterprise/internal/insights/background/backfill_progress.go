@@ -0,0 +1,65 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/queryrunner"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/compression"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	itypes "github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// backfillProgress collects the frames of each series that were handled while backfilling a single
+// repository, so that they can be persisted once the work for the repository is done. Frames that are
+// enqueued as query runner jobs are recorded by the query runner once their job is processed.
+type backfillProgress struct {
+	bySeries map[string]*store.RecordRepoBackfillArgs
+}
+
+func newBackfillProgress(id api.RepoID, definitions []itypes.InsightSeries) *backfillProgress {
+	bySeries := make(map[string]*store.RecordRepoBackfillArgs, len(definitions))
+	for _, series := range definitions {
+		bySeries[series.SeriesID] = &store.RecordRepoBackfillArgs{SeriesID: series.ID, RepoID: id}
+	}
+	return &backfillProgress{bySeries: bySeries}
+}
+
+func (p *backfillProgress) completed(seriesID string, frames ...time.Time) {
+	if args, ok := p.bySeries[seriesID]; ok {
+		args.CompletedFrames = append(args.CompletedFrames, frames...)
+	}
+}
+
+func (p *backfillProgress) failed(seriesID string, err error, frames ...time.Time) {
+	if args, ok := p.bySeries[seriesID]; ok {
+		args.FailedFrames = append(args.FailedFrames, frames...)
+		args.LastError = err
+	}
+}
+
+// enqueueFailed records the frames of job as failed because the job could not be enqueued.
+func (p *backfillProgress) enqueueFailed(job *queryrunner.Job, err error) {
+	p.failed(job.SeriesID, err, append([]time.Time{*job.RecordTime}, job.DependentFrames...)...)
+}
+
+// recordProgress persists the progress made on a repository. It does nothing if the analyzer doesn't
+// track backfill progress.
+func (a *backfillAnalyzer) recordProgress(ctx context.Context, progress *backfillProgress) error {
+	if a.backfillStore == nil || progress == nil {
+		return nil
+	}
+	for _, args := range progress.bySeries {
+		if err := a.backfillStore.RecordRepoBackfill(ctx, *args); err != nil {
+			return errors.Wrap(err, "RecordRepoBackfill")
+		}
+	}
+	return nil
+}
+
+// executionFrames returns all frames a query execution records.
+func executionFrames(execution *compression.QueryExecution) []time.Time {
+	return append([]time.Time{execution.RecordingTime}, execution.SharedRecordings...)
+}
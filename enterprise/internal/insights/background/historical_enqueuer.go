@@ -147,6 +147,7 @@ type ScopedBackfiller struct {
 
 	workerBaseStore *basestore.Store
 	insightsStore   *store.Store
+	backfillStore   store.BackfillStore
 
 	enqueueQueryRunnerJob func(ctx context.Context, job *queryrunner.Job) error
 }
@@ -156,6 +157,7 @@ func NewScopedBackfiller(workerBaseStore *basestore.Store, insightsStore *store.
 		logger:          sglog.Scoped("ScopedBackfiller", ""),
 		insightsStore:   insightsStore,
 		workerBaseStore: workerBaseStore,
+		backfillStore:   store.NewBackfillStore(edb.NewInsightsDBWith(insightsStore)),
 		enqueueQueryRunnerJob: func(ctx context.Context, job *queryrunner.Job) error {
 			_, err := queryrunner.EnqueueJob(ctx, workerBaseStore, job)
 			return err
@@ -194,47 +196,44 @@ func (s *ScopedBackfiller) ScopedBackfill(ctx context.Context, definitions []ity
 		}
 	}
 
-	analyzer := baseAnalyzer(frontend, stats)
-	var totalJobs []*queryrunner.Job
-	var totalPreempted []store.RecordSeriesPointArgs
-	err = iterator.ForEach(ctx, func(repoName string, id api.RepoID) error {
-		jobs, preempted, err, multi := analyzer.buildForRepo(ctx, index[repoName], repoName, id)
+	analyzer := baseAnalyzer(frontend, stats, s.backfillStore)
+	return iterator.ForEach(ctx, func(repoName string, id api.RepoID) error {
+		jobs, preempted, progress, err, multi := analyzer.buildForRepo(ctx, index[repoName], repoName, id)
 		if err != nil {
 			return err
 		} else if multi != nil {
 			return multi
 		}
-		totalJobs = append(totalJobs, jobs...)
-		totalPreempted = append(totalPreempted, preempted...)
 
-		return nil
-	})
-	if err != nil {
-		return err
-	}
-
-	for _, job := range totalJobs {
-		// todo: fix this transactionality
-		job.Priority = int(priority.High)
-		err := s.enqueueQueryRunnerJob(ctx, job)
+		for _, job := range jobs {
+			// todo: fix this transactionality
+			job.Priority = int(priority.High)
+			err := s.enqueueQueryRunnerJob(ctx, job)
+			if err != nil {
+				return err
+			}
+		}
+		err = s.insightsStore.RecordSeriesPoints(ctx, preempted)
 		if err != nil {
 			return err
 		}
-	}
-	err = s.insightsStore.RecordSeriesPoints(ctx, totalPreempted)
-	if err != nil {
-		return err
-	}
-	return nil
+		// Checkpoint the progress after every repository, so that an interrupted backfill resumes from here.
+		return analyzer.recordProgress(ctx, progress)
+	})
 }
 
-func baseAnalyzer(frontend database.DB, statistics statistics) backfillAnalyzer {
+func baseAnalyzer(frontend database.DB, statistics statistics, backfillStore store.BackfillStore) backfillAnalyzer {
 	defaultRateLimit := rate.Limit(20.0)
 	getRateLimit := getRateLimit(defaultRateLimit)
 	limiter := ratelimit.NewInstrumentedLimiter("HistoricalEnqueuer", rate.NewLimiter(getRateLimit(), 1))
 
+	workerBaseStore := basestore.NewWithHandle(frontend.Handle())
 	return backfillAnalyzer{
-		statistics:         statistics,
+		statistics:    statistics,
+		backfillStore: backfillStore,
+		pendingBackfillFrames: func(ctx context.Context, seriesID string, repoID api.RepoID) ([]time.Time, error) {
+			return queryrunner.PendingBackfillFrames(ctx, workerBaseStore, seriesID, repoID)
+		},
 		frameFilter:        &compression.NoopFilter{},
 		limiter:            limiter,
 		gitFirstEverCommit: (&cachedGitFirstEverCommit{impl: discovery.GitFirstEverCommit}).gitFirstEverCommit,
@@ -258,7 +257,7 @@ func globalBackfiller(logger sglog.Logger, workerBaseStore *basestore.Store, dat
 			return err
 		},
 		statistics:       statistics,
-		analyzer:         baseAnalyzer(dbConn, statistics),
+		analyzer:         baseAnalyzer(dbConn, statistics, store.NewBackfillStore(edb.NewInsightsDBWith(insightsStore))),
 		scopedBackfiller: NewScopedBackfiller(workerBaseStore, insightsStore),
 	}
 
@@ -288,6 +287,7 @@ type repoBackfillStatistics struct {
 	Uncompressed int
 	Preempted    int
 	Errored      int
+	Resumed      int
 }
 
 func (s repoBackfillStatistics) String() string {
@@ -363,6 +363,14 @@ type backfillAnalyzer struct {
 	frameFilter         compression.DataFrameFilter
 	limiter             *ratelimit.InstrumentedLimiter
 	db                  database.DB
+
+	// backfillStore persists the progress of the backfill, so that frames that were completed are
+	// skipped when a backfill is resumed. Progress is not tracked if it is nil.
+	backfillStore store.BackfillStore
+
+	// pendingBackfillFrames returns the frames of a series in a repository whose backfill jobs are yet
+	// to be processed, so that they aren't enqueued again when a backfill is resumed.
+	pendingBackfillFrames func(ctx context.Context, seriesID string, repoID api.RepoID) ([]time.Time, error)
 }
 
 func (h *historicalEnqueuer) Handler(ctx context.Context) error {
@@ -431,8 +439,12 @@ func (h *historicalEnqueuer) convertJustInTimeInsights(ctx context.Context) {
 		log15.Info("loaded just in time data series for conversion to backfilled", "series_id", series.SeriesID)
 
 		oldSeriesId := series.SeriesID
-		series.SeriesID = ksuid.New().String()
-		series.CreatedAt = time.Now()
+		if series.BackfillAttempts == 0 {
+			// Earlier attempts already moved the series to a new series ID, and the points and backfill
+			// progress they recorded belong to it. Keep it, so that the backfill resumes.
+			series.SeriesID = ksuid.New().String()
+			series.CreatedAt = time.Now()
+		}
 
 		// Update the backfill attempts adjusts created date and inserts the new series_ID
 		incrementErr := h.dataSeriesStore.StartJustInTimeConversionAttempt(ctx, series)
@@ -452,9 +464,11 @@ func (h *historicalEnqueuer) convertJustInTimeInsights(ctx context.Context) {
 			log15.Error("unable to complete insight from jit to backfilled", "series_id", series.SeriesID, "error", err)
 		}
 
-		err = queryrunner.PurgeJobsForSeries(ctx, h.scopedBackfiller.workerBaseStore, oldSeriesId)
-		if err != nil {
-			log15.Warn("unable to purge jobs for old seriesID", "seriesId", oldSeriesId, "error", err)
+		if oldSeriesId != series.SeriesID {
+			err = queryrunner.PurgeJobsForSeries(ctx, h.scopedBackfiller.workerBaseStore, oldSeriesId)
+			if err != nil {
+				log15.Warn("unable to purge jobs for old seriesID", "seriesId", oldSeriesId, "error", err)
+			}
 		}
 
 	}
@@ -486,7 +500,7 @@ func (h *historicalEnqueuer) buildFrames(ctx context.Context, definitions []ityp
 	var multi error
 
 	hardErr := h.repoIterator(ctx, func(repoName string, id api.RepoID) error {
-		jobs, preempted, err, softErr := h.analyzer.buildForRepo(ctx, definitions, repoName, id)
+		jobs, preempted, progress, err, softErr := h.analyzer.buildForRepo(ctx, definitions, repoName, id)
 		if err != nil {
 			return err
 		}
@@ -500,9 +514,11 @@ func (h *historicalEnqueuer) buildFrames(ctx context.Context, definitions []ityp
 			err := h.enqueueQueryRunnerJob(ctx, job)
 			if err != nil {
 				multi = errors.Append(multi, err)
+				progress.enqueueFailed(job, err)
 			}
 		}
-		return nil
+		// Checkpoint the progress after every repository, so that an interrupted backfill resumes from here.
+		return h.analyzer.recordProgress(ctx, progress)
 	})
 	if multi != nil {
 		log15.Error("historical_enqueuer.buildFrames - multierror", "err", multi)
@@ -510,7 +526,7 @@ func (h *historicalEnqueuer) buildFrames(ctx context.Context, definitions []ityp
 	return hardErr
 }
 
-func (a *backfillAnalyzer) buildForRepo(ctx context.Context, definitions []itypes.InsightSeries, repoName string, id api.RepoID) (jobs []*queryrunner.Job, preempted []store.RecordSeriesPointArgs, err error, softErr error) {
+func (a *backfillAnalyzer) buildForRepo(ctx context.Context, definitions []itypes.InsightSeries, repoName string, id api.RepoID) (jobs []*queryrunner.Job, preempted []store.RecordSeriesPointArgs, progress *backfillProgress, err error, softErr error) {
 	span, ctx := ot.StartSpanFromContext(policy.WithShouldTrace(ctx, true), "historical_enqueuer.buildForRepo")
 	span.SetTag("repo_id", id)
 	defer func() {
@@ -524,6 +540,28 @@ func (a *backfillAnalyzer) buildForRepo(ctx context.Context, definitions []itype
 	// We are encountering a problem where it seems repositories go missing, so this is overly-noisy logging to try and get a complete picture
 	log15.Info("[historical_enqueuer_backfill] buildForRepo start", "repo_id", id, "repo_name", repoName, "traceId", traceId)
 
+	progress = newBackfillProgress(id, definitions)
+	backfills := map[int]*store.RepoBackfill{}
+	pending := map[string][]time.Time{}
+	if a.backfillStore != nil {
+		seriesIDs := make([]int, 0, len(definitions))
+		for _, series := range definitions {
+			seriesIDs = append(seriesIDs, series.ID)
+		}
+		backfills, err = a.backfillStore.GetRepoBackfills(ctx, id, seriesIDs)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "GetRepoBackfills"), nil
+		}
+		if a.pendingBackfillFrames != nil {
+			for _, series := range definitions {
+				pending[series.SeriesID], err = a.pendingBackfillFrames(ctx, series.SeriesID, id)
+				if err != nil {
+					return nil, nil, nil, errors.Wrap(err, "PendingBackfillFrames"), nil
+				}
+			}
+		}
+	}
+
 	// Find the first commit made to the repository on the default branch.
 	firstHEADCommit, err := a.gitFirstEverCommit(ctx, a.db, api.RepoName(repoName))
 	if err != nil {
@@ -535,16 +573,16 @@ func (a *backfillAnalyzer) buildForRepo(ctx context.Context, definitions []itype
 
 		if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) || gitdomain.IsRepoNotExist(err) {
 			log15.Warn("insights backfill repository skipped - missing rev/repo", "repo_id", id, "repo_name", repoName)
-			return nil, nil, nil, softErr // no error - repo may not be cloned yet (or not even pushed to code host yet)
+			return nil, nil, nil, nil, softErr // no error - repo may not be cloned yet (or not even pushed to code host yet)
 		}
 		if errors.Is(err, discovery.EmptyRepoErr) {
 			log15.Warn("insights backfill repository skipped - empty repo", "repo_id", id, "repo_name", repoName)
-			return nil, nil, nil, softErr // repository is empty
+			return nil, nil, nil, nil, softErr // repository is empty
 		}
 		// soft error, repo may be in a bad state but others might be OK.
 		softErr = errors.Append(softErr, errors.Wrap(err, "FirstEverCommit "+repoName))
 		log15.Error("insights backfill repository skipped", "repo_id", id, "repo_name", repoName, "error", err)
-		return nil, nil, nil, softErr
+		return nil, nil, nil, nil, softErr
 	}
 
 	// For every series that we want to potentially gather historical data for, try.
@@ -564,10 +602,21 @@ func (a *backfillAnalyzer) buildForRepo(ctx context.Context, definitions []itype
 		}
 		for i := len(plan.Executions) - 1; i >= 0; i-- {
 			queryExecution := plan.Executions[i]
+			executionFrames := executionFrames(queryExecution)
+			if backfills[series.ID].IsCompleted(executionFrames...) {
+				// This frame was completed by an earlier attempt of the backfill.
+				a.statistics[series.SeriesID].Resumed += 1
+				continue
+			}
+			if store.ContainsFrames(pending[series.SeriesID], executionFrames...) {
+				// This frame was enqueued by an earlier attempt of the backfill, and its job is yet to complete.
+				a.statistics[series.SeriesID].Resumed += 1
+				continue
+			}
 
 			err := a.limiter.Wait(ctx)
 			if err != nil {
-				return nil, nil, errors.Wrap(err, "limiter.Wait"), nil
+				return nil, nil, nil, errors.Wrap(err, "limiter.Wait"), nil
 			}
 
 			// Build historical data for this unique timeframe+repo+series.
@@ -582,16 +631,21 @@ func (a *backfillAnalyzer) buildForRepo(ctx context.Context, definitions []itype
 			if err != nil {
 				softErr = errors.Append(softErr, err)
 				a.statistics[series.SeriesID].Errored += 1
+				progress.failed(series.SeriesID, err, executionFrames...)
 				continue
 			}
+			if len(pre) > 0 {
+				progress.completed(series.SeriesID, executionFrames...)
+			}
 			preempted = append(preempted, pre...)
 			if job != nil {
+				job.BackfillRepoID = &id
 				jobs = append(jobs, job)
 			}
 		}
 	}
 	log15.Info("[historical_enqueuer_backfill] buildForRepo end", "repo_id", id, "repo_name", repoName)
-	return jobs, preempted, nil, softErr
+	return jobs, preempted, progress, nil, softErr
 }
 
 // buildSeriesContext describes context/parameters for a call to analyzeSeries()
@@ -0,0 +1,160 @@
+package queryrunner
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	cmbackground "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// alertNotifier delivers an alert that started to trigger.
+type alertNotifier func(ctx context.Context, alert *types.InsightSeriesAlert, args cmbackground.InsightAlertArgs) error
+
+// alertEvaluator evaluates the alert rules of a series after the series recorded a new value.
+type alertEvaluator struct {
+	alertStore   store.AlertStore
+	seriesPoints func(ctx context.Context, opts store.SeriesPointsOpts) ([]store.SeriesPoint, error)
+	notify       alertNotifier
+}
+
+// codeMonitorActionNotifier delivers alerts through the email, Slack and webhook actions of code monitors.
+func codeMonitorActionNotifier(db database.DB) alertNotifier {
+	return func(ctx context.Context, alert *types.InsightSeriesAlert, args cmbackground.InsightAlertArgs) error {
+		switch alert.ActionType {
+		case types.EmailAlertAction:
+			return cmbackground.SendInsightAlertEmail(ctx, db, alert.CreatedBy, args)
+		case types.SlackWebhookAlertAction:
+			if alert.ActionURL == nil {
+				return errors.Newf("alert %d has no Slack webhook URL", alert.ID)
+			}
+			return cmbackground.SendInsightAlertSlackWebhook(ctx, httpcli.ExternalDoer, *alert.ActionURL, args)
+		case types.WebhookAlertAction:
+			if alert.ActionURL == nil {
+				return errors.Newf("alert %d has no webhook URL", alert.ID)
+			}
+			return cmbackground.SendInsightAlertWebhook(ctx, httpcli.ExternalDoer, *alert.ActionURL, args)
+		}
+		return errors.Newf("unsupported alert action type %q", alert.ActionType)
+	}
+}
+
+// evaluate evaluates all alerts of a series against its value at recordTime. Alerts only notify when
+// their condition starts to hold, so that a series that stays above a threshold doesn't notify on
+// every recording.
+func (e *alertEvaluator) evaluate(ctx context.Context, series *types.InsightSeries, recordTime time.Time) error {
+	alerts, err := e.alertStore.GetAlerts(ctx, store.GetAlertsArgs{SeriesID: series.ID})
+	if err != nil || len(alerts) == 0 {
+		return err
+	}
+
+	maxWindow := 0
+	for _, alert := range alerts {
+		if alert.Kind == types.TrendAlert && alert.WindowDays > maxWindow {
+			maxWindow = alert.WindowDays
+		}
+	}
+	// Look back twice the longest window, so that a previous value exists even if the series
+	// records less often than the window.
+	from := recordTime.AddDate(0, 0, -2*maxWindow)
+	points, err := e.seriesPoints(ctx, store.SeriesPointsOpts{SeriesID: &series.SeriesID, From: &from, To: &recordTime})
+	if err != nil {
+		return errors.Wrap(err, "SeriesPoints")
+	}
+	totals := totalsByTime(points)
+	if len(totals) == 0 {
+		return nil
+	}
+	current := totals[len(totals)-1]
+
+	var errs error
+	for _, alert := range alerts {
+		condition, met, ok := evaluateAlert(alert, totals)
+		if !ok {
+			continue
+		}
+		if met && !alert.Triggered {
+			err := e.notify(ctx, alert, cmbackground.InsightAlertArgs{
+				Condition: condition,
+				Query:     series.Query,
+				Value:     current.value,
+			})
+			if err != nil {
+				// Leave the alert untriggered, so that the notification is retried after the next recording.
+				errs = errors.Append(errs, errors.Wrapf(err, "notify alert %d", alert.ID))
+				continue
+			}
+		}
+		if err := e.alertStore.SetAlertState(ctx, alert.ID, met, current.value); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "SetAlertState"))
+		}
+	}
+	return errs
+}
+
+type seriesTotal struct {
+	time  time.Time
+	value float64
+}
+
+// totalsByTime sums the points of all captures at each time, ordered by time.
+func totalsByTime(points []store.SeriesPoint) []seriesTotal {
+	byTime := make(map[time.Time]float64)
+	for _, p := range points {
+		byTime[p.Time.UTC()] += p.Value
+	}
+	totals := make([]seriesTotal, 0, len(byTime))
+	for t, v := range byTime {
+		totals = append(totals, seriesTotal{time: t, value: v})
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].time.Before(totals[j].time) })
+	return totals
+}
+
+// evaluateAlert returns whether the condition of alert holds for the latest of totals, and a
+// description of the condition. ok is false if the alert can't be evaluated, e.g. because there is
+// no earlier value to compute a trend from.
+func evaluateAlert(alert *types.InsightSeriesAlert, totals []seriesTotal) (condition string, met, ok bool) {
+	current := totals[len(totals)-1]
+
+	switch alert.Kind {
+	case types.ThresholdAlert:
+		condition = fmt.Sprintf("went %s %s", alert.Direction, formatFloat(alert.Value))
+		if alert.Direction == types.Above {
+			return condition, current.value > alert.Value, true
+		}
+		return condition, current.value < alert.Value, true
+
+	case types.TrendAlert:
+		cutoff := current.time.AddDate(0, 0, -alert.WindowDays)
+		var previous *seriesTotal
+		for i := len(totals) - 1; i >= 0; i-- {
+			if !totals[i].time.After(cutoff) {
+				previous = &totals[i]
+				break
+			}
+		}
+		if previous == nil || previous.value == 0 {
+			return "", false, false
+		}
+		change := (current.value - previous.value) / math.Abs(previous.value) * 100
+		if alert.Direction == types.Above {
+			condition = fmt.Sprintf("rose by at least %s%% over %d days", formatFloat(alert.Value), alert.WindowDays)
+			return condition, change >= alert.Value, true
+		}
+		condition = fmt.Sprintf("dropped by at least %s%% over %d days", formatFloat(alert.Value), alert.WindowDays)
+		return condition, change <= -alert.Value, true
+	}
+	return "", false, false
+}
+
+func formatFloat(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
@@ -0,0 +1,135 @@
+package queryrunner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	cmbackground "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+type fakeAlertStore struct {
+	alerts []*types.InsightSeriesAlert
+	states map[int]bool
+}
+
+func (s *fakeAlertStore) GetAlerts(_ context.Context, args store.GetAlertsArgs) ([]*types.InsightSeriesAlert, error) {
+	var alerts []*types.InsightSeriesAlert
+	for _, alert := range s.alerts {
+		if alert.SeriesID == args.SeriesID {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts, nil
+}
+
+func (s *fakeAlertStore) CreateAlert(_ context.Context, alert types.InsightSeriesAlert) (*types.InsightSeriesAlert, error) {
+	return &alert, nil
+}
+
+func (s *fakeAlertStore) DeleteAlert(context.Context, int) error { return nil }
+
+func (s *fakeAlertStore) SetAlertState(_ context.Context, id int, triggered bool, _ float64) error {
+	s.states[id] = triggered
+	for _, alert := range s.alerts {
+		if alert.ID == id {
+			alert.Triggered = triggered
+		}
+	}
+	return nil
+}
+
+func TestAlertEvaluator(t *testing.T) {
+	now := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	weekAgo := now.AddDate(0, 0, -7)
+	capture := "v1"
+
+	var points []store.SeriesPoint
+	seriesPoints := func(_ context.Context, opts store.SeriesPointsOpts) ([]store.SeriesPoint, error) {
+		var filtered []store.SeriesPoint
+		for _, p := range points {
+			if !p.Time.Before(*opts.From) && !p.Time.After(*opts.To) {
+				filtered = append(filtered, p)
+			}
+		}
+		return filtered, nil
+	}
+
+	alertStore := &fakeAlertStore{
+		alerts: []*types.InsightSeriesAlert{
+			{ID: 1, SeriesID: 1, Kind: types.ThresholdAlert, Direction: types.Above, Value: 0},
+			{ID: 2, SeriesID: 1, Kind: types.TrendAlert, Direction: types.Below, Value: 20, WindowDays: 7},
+			{ID: 3, SeriesID: 2, Kind: types.ThresholdAlert, Direction: types.Above, Value: 0},
+		},
+		states: map[int]bool{},
+	}
+	var notified []string
+	e := &alertEvaluator{
+		alertStore:   alertStore,
+		seriesPoints: seriesPoints,
+		notify: func(_ context.Context, alert *types.InsightSeriesAlert, args cmbackground.InsightAlertArgs) error {
+			notified = append(notified, args.Condition)
+			return nil
+		},
+	}
+	series := &types.InsightSeries{ID: 1, SeriesID: "s1", Query: "React.createClass"}
+
+	// The series dropped from 10 to 4, summed over its captures.
+	points = []store.SeriesPoint{
+		{SeriesID: "s1", Time: weekAgo, Value: 10},
+		{SeriesID: "s1", Time: now, Value: 3},
+		{SeriesID: "s1", Time: now, Value: 1, Capture: &capture},
+	}
+	if err := e.evaluate(context.Background(), series, now); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"went above 0", "dropped by at least 20% over 7 days"}
+	if diff := cmp.Diff(want, notified); diff != "" {
+		t.Fatalf("unexpected notifications (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[int]bool{1: true, 2: true}, alertStore.states); diff != "" {
+		t.Fatalf("unexpected alert states (-want +got):\n%s", diff)
+	}
+
+	// The conditions still hold, so the alerts don't notify again.
+	notified = nil
+	if err := e.evaluate(context.Background(), series, now); err != nil {
+		t.Fatal(err)
+	}
+	if len(notified) != 0 {
+		t.Fatalf("expected no notifications, got %v", notified)
+	}
+
+	// The series went back to 0, which re-arms the threshold alert.
+	later := now.AddDate(0, 0, 7)
+	points = append(points, store.SeriesPoint{SeriesID: "s1", Time: later, Value: 0})
+	if err := e.evaluate(context.Background(), series, later); err != nil {
+		t.Fatal(err)
+	}
+	if len(notified) != 0 {
+		t.Fatalf("expected no notifications, got %v", notified)
+	}
+	if alertStore.states[1] {
+		t.Fatal("expected threshold alert to no longer be triggered")
+	}
+}
+
+func TestEvaluateTrendAlertWithoutHistory(t *testing.T) {
+	alert := &types.InsightSeriesAlert{Kind: types.TrendAlert, Direction: types.Above, Value: 50, WindowDays: 7}
+	now := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, _, ok := evaluateAlert(alert, []seriesTotal{{time: now, value: 4}}); ok {
+		t.Error("expected a trend alert without an earlier value not to be evaluated")
+	}
+	if _, _, ok := evaluateAlert(alert, []seriesTotal{{time: now.AddDate(0, 0, -7), value: 0}, {time: now, value: 4}}); ok {
+		t.Error("expected a trend alert from zero not to be evaluated")
+	}
+	_, met, ok := evaluateAlert(alert, []seriesTotal{{time: now.AddDate(0, 0, -7), value: 2}, {time: now, value: 4}})
+	if !ok || !met {
+		t.Error("expected a trend alert that doubled to trigger")
+	}
+}
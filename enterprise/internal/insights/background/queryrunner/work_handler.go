@@ -39,6 +39,13 @@ type workHandler struct {
 	mu          sync.RWMutex
 	seriesCache map[string]*types.InsightSeries
 
+	// backfillStore records the frames of backfill jobs in the backfill progress of their repository once
+	// they are processed. Progress is not recorded if it is nil.
+	backfillStore store.BackfillStore
+
+	// alerts evaluates the alert rules of series after each recording. Alerts are not evaluated if it is nil.
+	alerts *alertEvaluator
+
 	searchStream func(context.Context, string) (*streaming.TabulationResult, error)
 
 	computeSearchStream    func(context.Context, string) (*streaming.ComputeTabulationResult, error)
@@ -340,8 +347,42 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record work
 	}
 
 	recordings, err := executableHandler(ctx, job, series, recordTime)
+	if err == nil {
+		err = r.persistRecordings(ctx, job, series, recordings)
+	}
+	if backfillErr := r.recordBackfill(ctx, job, series, recordTime, err); backfillErr != nil {
+		// The recordings are persisted, so retrying the job would record the same values again.
+		logger.Error("recording insight backfill progress", log.String("seriesID", series.SeriesID), log.Error(backfillErr))
+	}
 	if err != nil {
 		return err
 	}
-	return r.persistRecordings(ctx, job, series, recordings)
+
+	// Only recordings of the current value are evaluated. Backfilled values are historical, and are
+	// recorded one repository at a time.
+	if r.alerts != nil && job.RecordTime == nil && store.PersistMode(job.PersistMode) == store.RecordMode {
+		if err := r.alerts.evaluate(ctx, series, recordTime); err != nil {
+			// Don't fail the job, which would record the same values again when it is retried.
+			logger.Error("evaluating insight alerts", log.String("seriesID", series.SeriesID), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// recordBackfill records the frames of a backfill job in the backfill progress of its repository: as
+// completed if the job succeeded, or as failed if it returned jobErr. Frames that failed are backfilled
+// again when the backfill is resumed, unless the job is retried and succeeds first.
+func (r *workHandler) recordBackfill(ctx context.Context, job *Job, series *types.InsightSeries, recordTime time.Time, jobErr error) error {
+	if r.backfillStore == nil || job.BackfillRepoID == nil {
+		return nil
+	}
+	frames := append([]time.Time{recordTime}, job.DependentFrames...)
+	args := store.RecordRepoBackfillArgs{SeriesID: series.ID, RepoID: *job.BackfillRepoID}
+	if jobErr != nil {
+		args.FailedFrames = frames
+		args.LastError = jobErr
+	} else {
+		args.CompletedFrames = frames
+	}
+	return r.backfillStore.RecordRepoBackfill(ctx, args)
 }
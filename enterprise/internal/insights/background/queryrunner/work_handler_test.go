@@ -12,6 +12,7 @@ import (
 
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hexops/autogold"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
@@ -1132,3 +1133,65 @@ func TestGetSeries(t *testing.T) {
 	})
 
 }
+
+type recordingBackfillStore struct {
+	store.BackfillStore
+	recorded []store.RecordRepoBackfillArgs
+}
+
+func (s *recordingBackfillStore) RecordRepoBackfill(_ context.Context, args store.RecordRepoBackfillArgs) error {
+	s.recorded = append(s.recorded, args)
+	return nil
+}
+
+func TestRecordBackfill(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	dependent := date.AddDate(0, 1, 0)
+	repoID := api.RepoID(11)
+	series := &types.InsightSeries{ID: 3, SeriesID: "testseries1"}
+
+	t.Run("not a backfill job", func(t *testing.T) {
+		backfillStore := &recordingBackfillStore{}
+		handler := workHandler{backfillStore: backfillStore}
+		job := &Job{SeriesID: series.SeriesID, RecordTime: &date}
+
+		if err := handler.recordBackfill(context.Background(), job, series, date, nil); err != nil {
+			t.Fatal(err)
+		}
+		if len(backfillStore.recorded) != 0 {
+			t.Fatalf("unexpected progress recorded: %+v", backfillStore.recorded)
+		}
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		backfillStore := &recordingBackfillStore{}
+		handler := workHandler{backfillStore: backfillStore}
+		job := &Job{SeriesID: series.SeriesID, RecordTime: &date, DependentFrames: []time.Time{dependent}, BackfillRepoID: &repoID}
+
+		if err := handler.recordBackfill(context.Background(), job, series, date, nil); err != nil {
+			t.Fatal(err)
+		}
+		want := []store.RecordRepoBackfillArgs{{SeriesID: 3, RepoID: repoID, CompletedFrames: []time.Time{date, dependent}}}
+		if diff := cmp.Diff(want, backfillStore.recorded); diff != "" {
+			t.Fatalf("unexpected progress (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		backfillStore := &recordingBackfillStore{}
+		handler := workHandler{backfillStore: backfillStore}
+		job := &Job{SeriesID: series.SeriesID, RecordTime: &date, BackfillRepoID: &repoID}
+		jobErr := errors.New("search timed out")
+
+		if err := handler.recordBackfill(context.Background(), job, series, date, jobErr); err != nil {
+			t.Fatal(err)
+		}
+		if len(backfillStore.recorded) != 1 {
+			t.Fatalf("expected progress to be recorded once, got %d", len(backfillStore.recorded))
+		}
+		have := backfillStore.recorded[0]
+		if len(have.CompletedFrames) != 0 || len(have.FailedFrames) != 1 || !have.FailedFrames[0].Equal(date) || have.LastError != jobErr {
+			t.Fatalf("unexpected progress: %+v", have)
+		}
+	})
+}
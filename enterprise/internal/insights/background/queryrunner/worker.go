@@ -14,12 +14,15 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/compression"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/insights/priority"
@@ -87,6 +90,12 @@ func NewWorker(ctx context.Context, logger log.Logger, workerStore dbworkerstore
 		limiter:         limiter,
 		metadadataStore: store.NewInsightStoreWith(insightsStore),
 		seriesCache:     sharedCache,
+		backfillStore:   store.NewBackfillStore(edb.NewInsightsDBWith(insightsStore)),
+		alerts: &alertEvaluator{
+			alertStore:   store.NewAlertStore(edb.NewInsightsDBWith(insightsStore)),
+			seriesPoints: insightsStore.SeriesPoints,
+			notify:       codeMonitorActionNotifier(database.NewDBWith(logger, basestore.NewWithHandle(workerStore.Handle()))),
+		},
 		searchStream: func(ctx context.Context, query string) (*streaming.TabulationResult, error) {
 			decoder, streamResults := streaming.TabulationDecoder()
 			err := streaming.Search(ctx, query, nil, decoder)
@@ -226,6 +235,7 @@ func EnqueueJob(ctx context.Context, workerBaseStore *basestore.Store, job *Job)
 			job.Cost,
 			job.Priority,
 			job.PersistMode,
+			job.BackfillRepoID,
 		),
 	))
 	if err != nil {
@@ -248,8 +258,9 @@ INSERT INTO insights_query_runner_jobs (
 	process_after,
 	cost,
 	priority,
-	persist_mode
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+	persist_mode,
+	backfill_repo_id
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -272,6 +283,25 @@ DELETE FROM insights_query_runner_jobs
 WHERE series_id = %s
 `
 
+// PendingBackfillFrames returns the frames of the backfill jobs of a series in a repository that are yet
+// to be processed or retried.
+func PendingBackfillFrames(ctx context.Context, workerBaseStore *basestore.Store, seriesID string, repoID api.RepoID) ([]time.Time, error) {
+	q := sqlf.Sprintf(pendingBackfillFramesFmtStr, seriesID, repoID, seriesID, repoID)
+	return scanDependencies(workerBaseStore.Query(ctx, q))
+}
+
+const pendingBackfillFramesFmtStr = `
+-- source: enterprise/internal/insights/background/queryrunner/worker.go:PendingBackfillFrames
+SELECT record_time
+FROM insights_query_runner_jobs
+WHERE series_id = %s AND backfill_repo_id = %s AND state IN ('queued', 'processing', 'errored') AND record_time IS NOT NULL
+UNION
+SELECT d.recording_time AT TIME ZONE 'UTC'
+FROM insights_query_runner_jobs_dependencies d
+JOIN insights_query_runner_jobs j ON j.id = d.job_id
+WHERE j.series_id = %s AND j.backfill_repo_id = %s AND j.state IN ('queued', 'processing', 'errored')
+`
+
 func dequeueJob(ctx context.Context, workerBaseStore *basestore.Store, recordID int) (_ *Job, err error) {
 	tx, err := workerBaseStore.Transact(ctx)
 	if err != nil {
@@ -310,6 +340,7 @@ SELECT
 	cost,
 	priority,
 	persist_mode,
+	backfill_repo_id,
 	id,
 	state,
 	failure_message,
@@ -415,6 +446,10 @@ type Job struct {
 	Priority    int
 	PersistMode string
 
+	// BackfillRepoID is the repository that a backfill job searches. The frames of the job are recorded
+	// as completed in the backfill progress of the repository once the job succeeds.
+	BackfillRepoID *api.RepoID
+
 	DependentFrames []time.Time // This field isn't part of the job table, but maps to a table one-many on this job.
 
 	// Standard/required dbworker fields. If enqueuing a job, these may all be zero values except State.
@@ -470,6 +505,7 @@ func scanJob(sc dbutil.Scanner) (*Job, error) {
 		&j.Cost,
 		&j.Priority,
 		&j.PersistMode,
+		&j.BackfillRepoID,
 
 		// Standard/required dbworker fields.
 		&j.ID,
@@ -495,6 +531,7 @@ var jobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("insights_query_runner_jobs.cost"),
 	sqlf.Sprintf("insights_query_runner_jobs.priority"),
 	sqlf.Sprintf("insights_query_runner_jobs.persist_mode"),
+	sqlf.Sprintf("insights_query_runner_jobs.backfill_repo_id"),
 	sqlf.Sprintf("id"),
 	sqlf.Sprintf("state"),
 	sqlf.Sprintf("failure_message"),
@@ -0,0 +1,155 @@
+// Package httpapi serves the HTTP endpoints of code insights that are not part of the GraphQL API.
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/portable"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxDocumentSize is the largest dashboards document accepted by the import endpoint.
+const maxDocumentSize = 10 * 1024 * 1024
+
+// DashboardsHandler serves the endpoints to export and import insights dashboards, so that
+// dashboards can be moved between instances with curl or src-cli.
+type DashboardsHandler struct {
+	resolver graphqlbackend.InsightsResolver
+}
+
+func NewDashboardsHandler(resolver graphqlbackend.InsightsResolver) *DashboardsHandler {
+	return &DashboardsHandler{resolver: resolver}
+}
+
+// ServeExport writes a document with the dashboards given by the id parameters, in the given
+// format (json or yaml).
+func (h *DashboardsHandler) ServeExport(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "insights.ServeDashboardsExport", "")
+	defer tr.Finish()
+
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		http.Error(w, "must be signed in to export dashboards", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ids := r.Form["id"]
+	if len(ids) == 0 {
+		http.Error(w, "no dashboard id found", http.StatusBadRequest)
+		return
+	}
+	format, err := portable.ParseFormat(r.FormValue("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	args := &graphqlbackend.ExportInsightsDashboardsArgs{Format: string(format)}
+	for _, id := range ids {
+		args.Ids = append(args.Ids, graphql.ID(id))
+	}
+	doc, err := h.resolver.ExportInsightsDashboards(ctx, args)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if format == portable.YAML {
+		w.Header().Set("Content-Type", "application/yaml")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	_, _ = io.WriteString(w, doc)
+}
+
+type importResponse struct {
+	Dashboards        []importedDashboard `json:"dashboards"`
+	SkippedDashboards []string            `json:"skippedDashboards"`
+	SkippedInsights   []string            `json:"skippedInsights"`
+}
+
+type importedDashboard struct {
+	ID    graphql.ID `json:"id"`
+	Title string     `json:"title"`
+}
+
+// ServeImport imports the document in the request body. The onConflict parameter (fail, skip or
+// duplicate) decides what happens to insights and dashboards that already exist.
+func (h *DashboardsHandler) ServeImport(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "insights.ServeDashboardsImport", "")
+	defer tr.Finish()
+
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		http.Error(w, "must be signed in to import dashboards", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxDocumentSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxDocumentSize {
+		http.Error(w, "document is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	// Reject invalid documents here, so that they are reported as bad requests.
+	if _, err := portable.Unmarshal(body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	input := graphqlbackend.ImportInsightsDashboardsInput{Document: string(body)}
+	if onConflict := r.URL.Query().Get("onConflict"); onConflict != "" {
+		input.OnConflict = &onConflict
+	}
+	payload, err := h.resolver.ImportInsightsDashboards(ctx, &graphqlbackend.ImportInsightsDashboardsArgs{Input: input})
+	if err != nil {
+		var conflictErr *portable.ConflictError
+		if errors.As(err, &conflictErr) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := newImportResponse(ctx, payload)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func newImportResponse(ctx context.Context, payload graphqlbackend.ImportInsightsDashboardsPayloadResolver) (importResponse, error) {
+	dashboards, err := payload.Dashboards(ctx)
+	if err != nil {
+		return importResponse{}, err
+	}
+	resp := importResponse{
+		Dashboards:        make([]importedDashboard, 0, len(dashboards)),
+		SkippedDashboards: append([]string{}, payload.SkippedDashboards()...),
+		SkippedInsights:   append([]string{}, payload.SkippedInsights()...),
+	}
+	for _, dashboard := range dashboards {
+		resp.Dashboards = append(resp.Dashboards, importedDashboard{ID: dashboard.ID(), Title: dashboard.Title()})
+	}
+	return resp, nil
+}
@@ -0,0 +1,126 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/portable"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+type fakeResolver struct {
+	graphqlbackend.InsightsResolver
+
+	exportArgs *graphqlbackend.ExportInsightsDashboardsArgs
+	importArgs *graphqlbackend.ImportInsightsDashboardsArgs
+	importErr  error
+}
+
+func (r *fakeResolver) ExportInsightsDashboards(_ context.Context, args *graphqlbackend.ExportInsightsDashboardsArgs) (string, error) {
+	r.exportArgs = args
+	return "version: 1\n", nil
+}
+
+func (r *fakeResolver) ImportInsightsDashboards(_ context.Context, args *graphqlbackend.ImportInsightsDashboardsArgs) (graphqlbackend.ImportInsightsDashboardsPayloadResolver, error) {
+	r.importArgs = args
+	if r.importErr != nil {
+		return nil, r.importErr
+	}
+	return &fakePayload{}, nil
+}
+
+type fakePayload struct{}
+
+func (p *fakePayload) Dashboards(context.Context) ([]graphqlbackend.InsightsDashboardResolver, error) {
+	return nil, nil
+}
+
+func (p *fakePayload) SkippedDashboards() []string { return []string{"Existing"} }
+
+func (p *fakePayload) SkippedInsights() []string { return nil }
+
+const testDocument = `{
+  "version": 1,
+  "insights": [{
+    "id": "a",
+    "title": "A",
+    "presentationType": "LINE",
+    "series": [{"query": "test", "stepInterval": {"unit": "MONTH", "value": 1}}],
+    "grants": {}
+  }]
+}`
+
+func TestServeExport(t *testing.T) {
+	resolver := &fakeResolver{}
+	handler := NewDashboardsHandler(resolver)
+
+	req := httptest.NewRequest("GET", "/.api/insights/dashboards/export?id=a&id=b&format=yaml", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeExport(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected anonymous exports to be rejected, got %d", rec.Code)
+	}
+
+	req = req.WithContext(actor.WithActor(context.Background(), actor.FromUser(1)))
+	rec = httptest.NewRecorder()
+	handler.ServeExport(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/yaml" {
+		t.Errorf("unexpected content type %q", got)
+	}
+	want := &graphqlbackend.ExportInsightsDashboardsArgs{Ids: []graphql.ID{"a", "b"}, Format: "yaml"}
+	if diff := cmp.Diff(want, resolver.exportArgs); diff != "" {
+		t.Errorf("unexpected export args (-want +got):\n%s", diff)
+	}
+}
+
+func TestServeImport(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	t.Run("invalid document", func(t *testing.T) {
+		handler := NewDashboardsHandler(&fakeResolver{})
+		req := httptest.NewRequest("POST", "/.api/insights/dashboards/import", strings.NewReader(`{"version": 2}`)).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		handler.ServeImport(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected a bad request, got %d", rec.Code)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		handler := NewDashboardsHandler(&fakeResolver{importErr: &portable.ConflictError{Conflicts: []string{"insight a"}}})
+		req := httptest.NewRequest("POST", "/.api/insights/dashboards/import", strings.NewReader(testDocument)).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		handler.ServeImport(rec, req)
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("expected a conflict, got %d", rec.Code)
+		}
+	})
+
+	t.Run("imported", func(t *testing.T) {
+		resolver := &fakeResolver{}
+		handler := NewDashboardsHandler(resolver)
+		req := httptest.NewRequest("POST", "/.api/insights/dashboards/import?onConflict=skip", strings.NewReader(testDocument)).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		handler.ServeImport(rec, req)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body.String())
+		}
+		if got := resolver.importArgs.Input.OnConflict; got == nil || *got != "skip" {
+			t.Errorf("unexpected conflict strategy %v", got)
+		}
+		want := `{"dashboards":[],"skippedDashboards":["Existing"],"skippedInsights":[]}`
+		if got := strings.TrimSpace(rec.Body.String()); got != want {
+			t.Errorf("unexpected response %s", got)
+		}
+	})
+}
@@ -2,11 +2,13 @@ package insights
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"strconv"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/httpapi"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -57,6 +59,10 @@ func Init(ctx context.Context, postgres database.DB, _ conftypes.UnifiedWatchabl
 	}
 	enterpriseServices.InsightsResolver = resolvers.New(db, postgres)
 
+	dashboardsHandler := httpapi.NewDashboardsHandler(enterpriseServices.InsightsResolver)
+	enterpriseServices.InsightsDashboardsExport = http.HandlerFunc(dashboardsHandler.ServeExport)
+	enterpriseServices.InsightsDashboardsImport = http.HandlerFunc(dashboardsHandler.ServeImport)
+
 	return nil
 }
 
@@ -0,0 +1,236 @@
+// Package portable defines a serialization format for insights dashboards, so that dashboards can be
+// exported from one Sourcegraph instance and imported into another.
+//
+// A document describes dashboards, the insight views on them and the definitions of their series. It
+// refers to users and organizations by name rather than by ID, and it never contains recorded
+// points: imported series are backfilled on the importing instance.
+package portable
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// CurrentVersion is the version of the document format written by Marshal.
+const CurrentVersion = 1
+
+// Format is the encoding of a document.
+type Format string
+
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+// ParseFormat parses a case-insensitive format name. An empty name defaults to JSON.
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case "", JSON:
+		return JSON, nil
+	case YAML:
+		return YAML, nil
+	}
+	return "", errors.Newf("unsupported format %q", name)
+}
+
+type Document struct {
+	Version    int         `json:"version"`
+	Dashboards []Dashboard `json:"dashboards,omitempty"`
+	Insights   []Insight   `json:"insights,omitempty"`
+}
+
+type Dashboard struct {
+	Title string `json:"title"`
+	// Insights are the IDs of the insights in the document that are on the dashboard, in order.
+	Insights []string `json:"insights,omitempty"`
+	Grants   Grants   `json:"grants"`
+}
+
+// Grants are the users and organizations that can see a dashboard or insight, by username and
+// organization name.
+type Grants struct {
+	Users         []string `json:"users,omitempty"`
+	Organizations []string `json:"organizations,omitempty"`
+	Global        bool     `json:"global,omitempty"`
+}
+
+type Insight struct {
+	// ID is the unique ID of the insight on the instance it was exported from.
+	ID                  string                     `json:"id"`
+	Title               string                     `json:"title"`
+	Description         string                     `json:"description,omitempty"`
+	PresentationType    types.PresentationType     `json:"presentationType"`
+	Filters             Filters                    `json:"filters,omitempty"`
+	OtherThreshold      *float64                   `json:"otherThreshold,omitempty"`
+	SeriesSortMode      *types.SeriesSortMode      `json:"seriesSortMode,omitempty"`
+	SeriesSortDirection *types.SeriesSortDirection `json:"seriesSortDirection,omitempty"`
+	SeriesLimit         *int32                     `json:"seriesLimit,omitempty"`
+	Series              []Series                   `json:"series"`
+	Grants              Grants                     `json:"grants"`
+}
+
+type Filters struct {
+	IncludeRepoRegex *string  `json:"includeRepoRegex,omitempty"`
+	ExcludeRepoRegex *string  `json:"excludeRepoRegex,omitempty"`
+	SearchContexts   []string `json:"searchContexts,omitempty"`
+}
+
+type Series struct {
+	Query                      string       `json:"query"`
+	Label                      string       `json:"label,omitempty"`
+	Color                      string       `json:"color,omitempty"`
+	Repositories               []string     `json:"repositories,omitempty"`
+	StepInterval               StepInterval `json:"stepInterval"`
+	GeneratedFromCaptureGroups bool         `json:"generatedFromCaptureGroups,omitempty"`
+	GroupBy                    *string      `json:"groupBy,omitempty"`
+}
+
+type StepInterval struct {
+	Unit  string `json:"unit"`
+	Value int    `json:"value"`
+}
+
+// NewInsight converts an insight view and its series to their portable representation.
+func NewInsight(insight types.Insight, grants Grants) Insight {
+	series := make([]Series, 0, len(insight.Series))
+	for _, s := range insight.Series {
+		series = append(series, Series{
+			Query:                      s.Query,
+			Label:                      s.Label,
+			Color:                      s.LineColor,
+			Repositories:               s.Repositories,
+			StepInterval:               StepInterval{Unit: s.SampleIntervalUnit, Value: s.SampleIntervalValue},
+			GeneratedFromCaptureGroups: s.GeneratedFromCaptureGroups,
+			GroupBy:                    s.GroupBy,
+		})
+	}
+
+	var sortMode *types.SeriesSortMode
+	var sortDirection *types.SeriesSortDirection
+	if sortOptions := insight.SeriesOptions.SortOptions; sortOptions != nil {
+		sortMode = &sortOptions.Mode
+		sortDirection = &sortOptions.Direction
+	}
+
+	return Insight{
+		ID:               insight.UniqueID,
+		Title:            insight.Title,
+		Description:      insight.Description,
+		PresentationType: insight.PresentationType,
+		Filters: Filters{
+			IncludeRepoRegex: insight.Filters.IncludeRepoRegex,
+			ExcludeRepoRegex: insight.Filters.ExcludeRepoRegex,
+			SearchContexts:   insight.Filters.SearchContexts,
+		},
+		OtherThreshold:      insight.OtherThreshold,
+		SeriesSortMode:      sortMode,
+		SeriesSortDirection: sortDirection,
+		SeriesLimit:         insight.SeriesOptions.Limit,
+		Series:              series,
+		Grants:              grants,
+	}
+}
+
+// View returns the insight view to create for the insight, with the given unique ID.
+func (i Insight) View(uniqueID string) types.InsightView {
+	return types.InsightView{
+		Title:       i.Title,
+		Description: i.Description,
+		UniqueID:    uniqueID,
+		Filters: types.InsightViewFilters{
+			IncludeRepoRegex: i.Filters.IncludeRepoRegex,
+			ExcludeRepoRegex: i.Filters.ExcludeRepoRegex,
+			SearchContexts:   i.Filters.SearchContexts,
+		},
+		OtherThreshold:      i.OtherThreshold,
+		PresentationType:    i.PresentationType,
+		SeriesSortMode:      i.SeriesSortMode,
+		SeriesSortDirection: i.SeriesSortDirection,
+		SeriesLimit:         i.SeriesLimit,
+	}
+}
+
+// ConflictError is returned when importing a document whose insights or dashboards already exist.
+type ConflictError struct {
+	Conflicts []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("the document conflicts with existing insights and dashboards: %s", strings.Join(e.Conflicts, ", "))
+}
+
+// Marshal encodes a document in the given format.
+func Marshal(doc Document, format Format) ([]byte, error) {
+	switch format {
+	case JSON:
+		return json.MarshalIndent(doc, "", "  ")
+	case YAML:
+		return yaml.Marshal(doc)
+	}
+	return nil, errors.Newf("unsupported format %q", format)
+}
+
+// Unmarshal decodes and validates a document. JSON is a subset of YAML, so documents in either
+// format are accepted.
+func Unmarshal(data []byte) (Document, error) {
+	var doc Document
+	if err := yaml.UnmarshalStrict(data, &doc); err != nil {
+		return Document{}, errors.Wrap(err, "invalid document")
+	}
+	if err := doc.Validate(); err != nil {
+		return Document{}, err
+	}
+	return doc, nil
+}
+
+// Validate checks that a document is complete and that all dashboards refer to insights in the
+// document.
+func (d Document) Validate() error {
+	if d.Version != CurrentVersion {
+		return errors.Newf("unsupported document version %d, expected %d", d.Version, CurrentVersion)
+	}
+
+	insightIDs := make(map[string]struct{}, len(d.Insights))
+	for _, insight := range d.Insights {
+		if insight.ID == "" {
+			return errors.Newf("insight %q has no id", insight.Title)
+		}
+		if _, ok := insightIDs[insight.ID]; ok {
+			return errors.Newf("duplicate insight id %q", insight.ID)
+		}
+		insightIDs[insight.ID] = struct{}{}
+
+		if insight.PresentationType != types.Line && insight.PresentationType != types.Pie {
+			return errors.Newf("insight %q has unsupported presentation type %q", insight.ID, insight.PresentationType)
+		}
+		if len(insight.Series) == 0 {
+			return errors.Newf("insight %q has no series", insight.ID)
+		}
+		for _, series := range insight.Series {
+			if series.Query == "" {
+				return errors.Newf("insight %q has a series without a query", insight.ID)
+			}
+			if series.StepInterval.Unit == "" || series.StepInterval.Value <= 0 {
+				return errors.Newf("insight %q has a series without a step interval", insight.ID)
+			}
+		}
+	}
+
+	for _, dashboard := range d.Dashboards {
+		if dashboard.Title == "" {
+			return errors.New("dashboard has no title")
+		}
+		for _, id := range dashboard.Insights {
+			if _, ok := insightIDs[id]; !ok {
+				return errors.Newf("dashboard %q refers to insight %q, which is not in the document", dashboard.Title, id)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package portable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func testDocument() Document {
+	includeRepoRegex := "^github.com/sourcegraph/"
+	groupBy := "repo"
+	mode := types.ResultCount
+	direction := types.Desc
+	limit := int32(10)
+
+	return Document{
+		Version: CurrentVersion,
+		Dashboards: []Dashboard{{
+			Title:    "Migrations",
+			Insights: []string{"line", "pie"},
+			Grants:   Grants{Users: []string{"alice"}, Organizations: []string{"sourcegraph"}},
+		}},
+		Insights: []Insight{
+			NewInsight(types.Insight{
+				UniqueID:         "line",
+				Title:            "Migration progress",
+				Description:      "How far along we are",
+				PresentationType: types.Line,
+				Filters:          types.InsightViewFilters{IncludeRepoRegex: &includeRepoRegex},
+				SeriesOptions: types.SeriesDisplayOptions{
+					SortOptions: &types.SeriesSortOptions{Mode: mode, Direction: direction},
+					Limit:       &limit,
+				},
+				Series: []types.InsightViewSeries{
+					{
+						Query:               "React.createClass",
+						Label:               "old",
+						LineColor:           "red",
+						SampleIntervalUnit:  string(types.Month),
+						SampleIntervalValue: 1,
+					},
+					{
+						Query:               "count by repo",
+						Label:               "by repo",
+						Repositories:        []string{"github.com/sourcegraph/sourcegraph"},
+						SampleIntervalUnit:  string(types.Week),
+						SampleIntervalValue: 2,
+						GroupBy:             &groupBy,
+					},
+				},
+			}, Grants{Global: true}),
+			NewInsight(types.Insight{
+				UniqueID:         "pie",
+				Title:            "Languages",
+				PresentationType: types.Pie,
+				Series: []types.InsightViewSeries{{
+					Query:               "repo:^github.com/sourcegraph/sourcegraph$",
+					SampleIntervalUnit:  string(types.Month),
+					SampleIntervalValue: 1,
+				}},
+			}, Grants{Users: []string{"alice"}}),
+		},
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	want := testDocument()
+	for _, format := range []Format{JSON, YAML} {
+		t.Run(string(format), func(t *testing.T) {
+			data, err := Marshal(want, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Unmarshal(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("unexpected document (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInsightView(t *testing.T) {
+	insight := testDocument().Insights[0]
+	view := insight.View("new-id")
+
+	if view.UniqueID != "new-id" {
+		t.Errorf("expected the unique id to be remapped, got %q", view.UniqueID)
+	}
+	if view.SeriesSortMode == nil || *view.SeriesSortMode != types.ResultCount {
+		t.Errorf("expected the series sort mode to be kept, got %v", view.SeriesSortMode)
+	}
+	if view.Filters.IncludeRepoRegex == nil || *view.Filters.IncludeRepoRegex != "^github.com/sourcegraph/" {
+		t.Errorf("expected the filters to be kept, got %v", view.Filters)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(doc *Document)
+		err    string
+	}{
+		{
+			name:   "unsupported version",
+			modify: func(doc *Document) { doc.Version = 2 },
+			err:    "unsupported document version 2",
+		},
+		{
+			name:   "duplicate insight",
+			modify: func(doc *Document) { doc.Insights[1].ID = "line" },
+			err:    `duplicate insight id "line"`,
+		},
+		{
+			name:   "insight without series",
+			modify: func(doc *Document) { doc.Insights[1].Series = nil },
+			err:    `insight "pie" has no series`,
+		},
+		{
+			name:   "series without step interval",
+			modify: func(doc *Document) { doc.Insights[1].Series[0].StepInterval = StepInterval{} },
+			err:    `insight "pie" has a series without a step interval`,
+		},
+		{
+			name:   "unknown presentation type",
+			modify: func(doc *Document) { doc.Insights[1].PresentationType = "BAR" },
+			err:    `unsupported presentation type "BAR"`,
+		},
+		{
+			name:   "dangling insight reference",
+			modify: func(doc *Document) { doc.Dashboards[0].Insights = append(doc.Dashboards[0].Insights, "missing") },
+			err:    `refers to insight "missing"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := testDocument()
+			tc.modify(&doc)
+			err := doc.Validate()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestUnmarshalRejectsUnknownFields(t *testing.T) {
+	if _, err := Unmarshal([]byte("version: 1\npoints: []\n")); err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+}
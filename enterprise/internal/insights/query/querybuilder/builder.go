@@ -3,6 +3,7 @@ package querybuilder
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/grafana/regexp"
 
@@ -274,21 +275,54 @@ func AddFileFilter(query BasicQuery, file string) (BasicQuery, error) {
 	return addFilterSimple(query, searchquery.FieldFile, file)
 }
 
+// AddLanguageFilter adds a lang: filter for the given language to the query.
+func AddLanguageFilter(query BasicQuery, lang string) (BasicQuery, error) {
+	parameter := searchquery.Parameter{Field: searchquery.FieldLang, Value: lang}
+	if strings.ContainsAny(lang, " \t") {
+		parameter.Annotation.Labels = searchquery.Quoted
+	}
+	return addParameters(query, parameter)
+}
+
+// AddDirectoryFilter restricts the query to files under dir, which is a directory path with a
+// trailing slash. The directory "/" restricts the query to files at the root of a repository.
+func AddDirectoryFilter(query BasicQuery, dir string) (BasicQuery, error) {
+	value := fmt.Sprintf("(^%s)", regexp.QuoteMeta(dir))
+	if dir == "/" {
+		value = "(^[^/]+$)"
+	}
+	return addParameters(query, searchquery.Parameter{Field: searchquery.FieldFile, Value: value})
+}
+
+// AddDateFilter restricts the commits matched by the query to those authored after after and
+// before before.
+func AddDateFilter(query BasicQuery, after, before time.Time) (BasicQuery, error) {
+	return addParameters(query,
+		searchquery.Parameter{Field: searchquery.FieldAfter, Value: after.UTC().Format(time.RFC3339)},
+		searchquery.Parameter{Field: searchquery.FieldBefore, Value: before.UTC().Format(time.RFC3339)},
+	)
+}
+
 func addFilterSimple(query BasicQuery, field, value string) (BasicQuery, error) {
+	return addParameters(query, searchquery.Parameter{
+		Field:      field,
+		Value:      fmt.Sprintf("(^%s$)", regexp.QuoteMeta(value)),
+		Negated:    false,
+		Annotation: searchquery.Annotation{},
+	})
+}
+
+// addParameters appends parameters to every step of the query.
+func addParameters(query BasicQuery, parameters ...searchquery.Parameter) (BasicQuery, error) {
 	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
 	if err != nil {
 		return "", err
 	}
 
 	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
-		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+len(parameters))
 		modified = append(modified, basic.Parameters...)
-		modified = append(modified, searchquery.Parameter{
-			Field:      field,
-			Value:      fmt.Sprintf("(^%s$)", regexp.QuoteMeta(value)),
-			Negated:    false,
-			Annotation: searchquery.Annotation{},
-		})
+		modified = append(modified, parameters...)
 		return basic.MapParameters(modified)
 	})
 	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
@@ -2,6 +2,7 @@ package querybuilder
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hexops/autogold"
@@ -591,3 +592,70 @@ func Test_addFileFilter(t *testing.T) {
 		})
 	}
 }
+
+func Test_addLanguageFilter(t *testing.T) {
+	tests := []struct {
+		input string
+		lang  string
+		want  autogold.Value
+	}{
+		{
+			input: "myquery repo:supergreat",
+			lang:  "Go",
+			want:  autogold.Want("single word language", BasicQuery("repo:supergreat lang:Go myquery")),
+		},
+		{
+			input: "myquery",
+			lang:  "Common Lisp",
+			want:  autogold.Want("language with a space", BasicQuery(`lang:"Common Lisp" myquery`)),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddLanguageFilter(BasicQuery(test.input), test.lang)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
+func Test_addDirectoryFilter(t *testing.T) {
+	tests := []struct {
+		input string
+		dir   string
+		want  autogold.Value
+	}{
+		{
+			input: "myquery repo:supergreat",
+			dir:   "cmd/frontend/",
+			want:  autogold.Want("nested directory", BasicQuery("repo:supergreat file:(^cmd/frontend/) myquery")),
+		},
+		{
+			input: "(myquery repo:supergreat) or (big repo:asdf)",
+			dir:   "/",
+			want:  autogold.Want("compound query root directory", BasicQuery("(repo:supergreat file:(^[^/]+$) myquery OR repo:asdf file:(^[^/]+$) big)")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddDirectoryFilter(BasicQuery(test.input), test.dir)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
+func Test_addDateFilter(t *testing.T) {
+	after := time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC)
+	got, err := AddDateFilter(BasicQuery("type:commit fix"), after, after.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("date range", BasicQuery("type:commit after:2022-04-01T00:00:00Z before:2022-05-01T00:00:00Z fix")).Equal(t, got)
+}
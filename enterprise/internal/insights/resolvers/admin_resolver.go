@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -9,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -39,6 +41,60 @@ func (r *Resolver) UpdateInsightSeries(ctx context.Context, args *graphqlbackend
 	return &insightSeriesMetadataPayloadResolver{series: &series[0]}, nil
 }
 
+func (r *Resolver) RetryInsightSeriesBackfill(ctx context.Context, args *graphqlbackend.RetryInsightSeriesBackfillArgs) (graphqlbackend.InsightSeriesMetadataPayloadResolver, error) {
+	actr := actor.FromContext(ctx)
+	if err := backend.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+	if len(args.Input.Frames) == 0 {
+		return nil, errors.New("at least one frame is required")
+	}
+
+	series, err := r.dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: args.Input.SeriesId})
+	if err != nil {
+		return nil, err
+	}
+	if len(series) == 0 {
+		return nil, errors.Newf("unable to fetch series with series_id: %v", args.Input.SeriesId)
+	}
+	target := series[0]
+
+	frames := make([]time.Time, 0, len(args.Input.Frames))
+	for _, frame := range args.Input.Frames {
+		frames = append(frames, frame.Time)
+	}
+	var repoIDs []api.RepoID
+	if args.Input.Repositories != nil {
+		for _, id := range *args.Input.Repositories {
+			repoID, err := graphqlbackend.UnmarshalRepositoryID(id)
+			if err != nil {
+				return nil, err
+			}
+			repoIDs = append(repoIDs, repoID)
+		}
+	}
+
+	if err := r.backfillStore.ResetFrames(ctx, target.ID, frames, repoIDs); err != nil {
+		return nil, errors.Wrap(err, "ResetFrames")
+	}
+	// Existing points must be deleted, otherwise they would be aggregated with the points that are
+	// recorded again.
+	if err := r.baseInsightResolver.timeSeriesStore.DeletePointsAt(ctx, target.SeriesID, frames, repoIDs); err != nil {
+		return nil, err
+	}
+
+	if len(target.Repositories) > 0 {
+		if err := r.backfiller.ScopedBackfill(ctx, []types.InsightSeries{target}); err != nil {
+			return nil, errors.Wrap(err, "ScopedBackfill")
+		}
+	} else if err := r.dataSeriesStore.ResetBackfill(ctx, target); err != nil {
+		// Global series are backfilled by the historical enqueuer, which picks up series that
+		// haven't been backfilled.
+		return nil, errors.Wrap(err, "ResetBackfill")
+	}
+	return &insightSeriesMetadataPayloadResolver{series: &target}, nil
+}
+
 func (r *Resolver) InsightSeriesQueryStatus(ctx context.Context) ([]graphqlbackend.InsightSeriesQueryStatusResolver, error) {
 	actr := actor.FromContext(ctx)
 	if err := backend.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
@@ -30,6 +30,9 @@ const (
 const invalidQueryMsg = "Grouping is disabled because the search query is not valid."
 const fileUnsupportedFieldValueFmt = `Grouping by file is not available for searches with "%s:%s".`
 const authNotCommitDiffMsg = "Grouping by author is only available for diff and commit searches."
+const langUnsupportedFieldValueFmt = `Grouping by language is not available for searches with "%s:%s".`
+const dirUnsupportedFieldValueFmt = `Grouping by directory is not available for searches with "%s:%s".`
+const dateNotCommitDiffMsg = "Grouping by date is only available for diff and commit searches."
 const cgInvalidQueryMsg = "Grouping by capture group is only available for regexp searches that contain a capturing group."
 const cgMultipleQueryPatternMsg = "Grouping by capture group does not support search patterns with the following: and, or, negation."
 const cgUnsupportedSelectFmt = `Grouping by capture group is not available for searches with "%s:%s".`
@@ -110,7 +113,11 @@ func (r *searchAggregateResolver) Aggregations(ctx context.Context, args graphql
 		cappedAggregator.Add(amr.Key.Group, int32(amr.Count))
 	}
 
-	countingFunc, err := aggregation.GetCountFuncForMode(r.searchQuery, r.patternType, aggregationMode)
+	modeOptions := aggregation.ModeOptions{
+		DirectoryDepth: int(args.DirectoryDepth),
+		DateBucket:     types.SearchAggregationDateBucket(args.DateBucket),
+	}.WithDefaults()
+	countingFunc, err := aggregation.GetCountFuncForMode(r.searchQuery, r.patternType, aggregationMode, modeOptions)
 	if err != nil {
 		return &searchAggregationResultResolver{
 			resolver: newSearchAggregationNotAvailableResolver(
@@ -142,7 +149,7 @@ func (r *searchAggregateResolver) Aggregations(ctx context.Context, args graphql
 		return &searchAggregationResultResolver{resolver: newSearchAggregationNotAvailableResolver(failureReason, aggregationMode)}, nil
 	}
 
-	results := buildResults(cappedAggregator, int(args.Limit), aggregationMode, r.searchQuery, r.patternType)
+	results := buildResults(cappedAggregator, int(args.Limit), aggregationMode, modeOptions, r.searchQuery, r.patternType)
 
 	return &searchAggregationResultResolver{resolver: &searchAggregationModeResultResolver{
 		searchQuery:  r.searchQuery,
@@ -209,7 +216,7 @@ func (r *AggregationGroup) Query() (*string, error) {
 	return r.query, nil
 }
 
-func buildResults(aggregator aggregation.LimitedAggregator, limit int, mode types.SearchAggregationMode, originalQuery string, patternType string) aggregationResults {
+func buildResults(aggregator aggregation.LimitedAggregator, limit int, mode types.SearchAggregationMode, modeOptions aggregation.ModeOptions, originalQuery string, patternType string) aggregationResults {
 	sorted := aggregator.SortAggregate()
 	groups := make([]graphqlbackend.AggregationGroup, 0, limit)
 	otherResults := aggregator.OtherCounts().ResultCount
@@ -218,7 +225,7 @@ func buildResults(aggregator aggregation.LimitedAggregator, limit int, mode type
 	for i := 0; i < len(sorted); i++ {
 		if i < limit {
 			label := sorted[i].Label
-			drilldownQuery, err := buildDrilldownQuery(mode, originalQuery, label, patternType)
+			drilldownQuery, err := buildDrilldownQuery(mode, modeOptions, originalQuery, label, patternType)
 			if err != nil {
 				// for some reason we couldn't generate a new query, so fallback to the original
 				drilldownQuery = originalQuery
@@ -296,6 +303,9 @@ func getAggregateBy(mode types.SearchAggregationMode) canAggregateBy {
 		types.PATH_AGGREGATION_MODE:          canAggregateByPath,
 		types.AUTHOR_AGGREGATION_MODE:        canAggregateByAuthor,
 		types.CAPTURE_GROUP_AGGREGATION_MODE: canAggregateByCaptureGroup,
+		types.LANGUAGE_AGGREGATION_MODE:      canAggregateByLanguage,
+		types.DIRECTORY_AGGREGATION_MODE:     canAggregateByDirectory,
+		types.DATE_AGGREGATION_MODE:          canAggregateByDate,
 	}
 	canAggregateByFunc, ok := checkByMode[mode]
 	if !ok {
@@ -321,6 +331,20 @@ func canAggregateByRepo(searchQuery, patternType string) (bool, *notAvailableRea
 }
 
 func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, fileUnsupportedFieldValueFmt)
+}
+
+func canAggregateByLanguage(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, langUnsupportedFieldValueFmt)
+}
+
+func canAggregateByDirectory(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, dirUnsupportedFieldValueFmt)
+}
+
+// canAggregateByFile checks that a query returns file matches, so that the results can be grouped
+// by a property of their path. unsupportedFmt formats the reason given for queries that don't.
+func canAggregateByFile(searchQuery, patternType, unsupportedFmt string) (bool, *notAvailableReason, error) {
 	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
 	if err != nil {
 		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
@@ -331,7 +355,7 @@ func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableRea
 	for _, parameter := range parameters {
 		if parameter.Field == query.FieldSelect || parameter.Field == query.FieldType {
 			if strings.EqualFold(parameter.Value, "commit") || strings.EqualFold(parameter.Value, "diff") || strings.EqualFold(parameter.Value, "repo") {
-				reason := fmt.Sprintf(fileUnsupportedFieldValueFmt,
+				reason := fmt.Sprintf(unsupportedFmt,
 					parameter.Field, parameter.Value)
 				return false, &notAvailableReason{reason: reason, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
 			}
@@ -341,6 +365,16 @@ func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableRea
 }
 
 func canAggregateByAuthor(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByCommit(searchQuery, patternType, authNotCommitDiffMsg)
+}
+
+func canAggregateByDate(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByCommit(searchQuery, patternType, dateNotCommitDiffMsg)
+}
+
+// canAggregateByCommit checks that a query returns commit matches, so that the results can be
+// grouped by a property of their commit. unsupportedMsg is the reason given for queries that don't.
+func canAggregateByCommit(searchQuery, patternType, unsupportedMsg string) (bool, *notAvailableReason, error) {
 	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
 	if err != nil {
 		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
@@ -355,7 +389,7 @@ func canAggregateByAuthor(searchQuery, patternType string) (bool, *notAvailableR
 			}
 		}
 	}
-	return false, &notAvailableReason{reason: authNotCommitDiffMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
+	return false, &notAvailableReason{reason: unsupportedMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
 }
 
 func canAggregateByCaptureGroup(searchQuery, patternType string) (bool, *notAvailableReason, error) {
@@ -502,7 +536,7 @@ func (r *searchAggregationModeResultResolver) Mode() (string, error) {
 	return string(r.mode), nil
 }
 
-func buildDrilldownQuery(mode types.SearchAggregationMode, originalQuery string, drilldown string, patternType string) (string, error) {
+func buildDrilldownQuery(mode types.SearchAggregationMode, modeOptions aggregation.ModeOptions, originalQuery string, drilldown string, patternType string) (string, error) {
 	var modifierFunc func(querybuilder.BasicQuery, string) (querybuilder.BasicQuery, error)
 	switch mode {
 	case types.REPO_AGGREGATION_MODE:
@@ -511,6 +545,18 @@ func buildDrilldownQuery(mode types.SearchAggregationMode, originalQuery string,
 		modifierFunc = querybuilder.AddFileFilter
 	case types.AUTHOR_AGGREGATION_MODE:
 		modifierFunc = querybuilder.AddAuthorFilter
+	case types.LANGUAGE_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddLanguageFilter
+	case types.DIRECTORY_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddDirectoryFilter
+	case types.DATE_AGGREGATION_MODE:
+		modifierFunc = func(basicQuery querybuilder.BasicQuery, s string) (querybuilder.BasicQuery, error) {
+			after, before, err := aggregation.DateBucketRange(modeOptions.DateBucket, s)
+			if err != nil {
+				return "", err
+			}
+			return querybuilder.AddDateFilter(basicQuery, after, before)
+		}
 	case types.CAPTURE_GROUP_AGGREGATION_MODE:
 		searchType, err := client.SearchTypeFromString(patternType)
 		if err != nil {
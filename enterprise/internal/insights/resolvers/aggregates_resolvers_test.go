@@ -187,6 +187,72 @@ func Test_canAggregateByAuthor(t *testing.T) {
 	suite.Test_canAggregateBy()
 }
 
+func Test_canAggregateByDirectory(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with type:diff parameter",
+			query:        "insights type:diff",
+			reason:       fmt.Sprintf(dirUnsupportedFieldValueFmt, "type", "diff"),
+			canAggregate: false,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByDirectory,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
+func Test_canAggregateByLanguage(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for query with file parameter",
+			query:        "func file:cmd/",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with select:repo parameter",
+			query:        "repo:contains.path(README) select:repo",
+			reason:       fmt.Sprintf(langUnsupportedFieldValueFmt, "select", "repo"),
+			canAggregate: false,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByLanguage,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
+func Test_canAggregateByDate(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "cannot aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			reason:       dateNotCommitDiffMsg,
+			canAggregate: false,
+		},
+		{
+			name:         "can aggregate for query with type:diff parameter",
+			query:        "repo:contains.path(README) type:diff fix",
+			canAggregate: true,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByDate,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
 func Test_canAggregateByCaptureGroup(t *testing.T) {
 	testCases := []canAggregateTestCase{
 		{
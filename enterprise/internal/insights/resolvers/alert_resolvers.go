@@ -0,0 +1,167 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const insightSeriesAlertKind = "InsightSeriesAlert"
+
+const defaultAlertWindowDays = 7
+
+var _ graphqlbackend.InsightSeriesAlertResolver = &insightSeriesAlertResolver{}
+
+func (r *Resolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	actr := actor.FromContext(ctx)
+	if err := backend.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	series, err := r.getAlertSeries(ctx, args.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := r.alertStore.GetAlerts(ctx, store.GetAlertsArgs{SeriesID: series.ID})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.InsightSeriesAlertResolver, 0, len(alerts))
+	for _, alert := range alerts {
+		resolvers = append(resolvers, &insightSeriesAlertResolver{alert: alert, seriesID: series.SeriesID})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	actr := actor.FromContext(ctx)
+	if err := backend.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	input := args.Input
+	alert := types.InsightSeriesAlert{
+		Kind:       types.AlertKind(strings.ToLower(input.Kind)),
+		Direction:  types.AlertDirection(strings.ToLower(input.Direction)),
+		Value:      input.Value,
+		WindowDays: defaultAlertWindowDays,
+		ActionType: types.AlertActionType(strings.ToLower(input.ActionType)),
+		ActionURL:  input.ActionURL,
+		CreatedBy:  actr.UID,
+	}
+	if input.WindowDays != nil {
+		alert.WindowDays = int(*input.WindowDays)
+	}
+	if err := validateAlert(alert); err != nil {
+		return nil, err
+	}
+
+	series, err := r.getAlertSeries(ctx, input.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+	alert.SeriesID = series.ID
+
+	created, err := r.alertStore.CreateAlert(ctx, alert)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAlert")
+	}
+	return &insightSeriesAlertResolver{alert: created, seriesID: series.SeriesID}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	actr := actor.FromContext(ctx)
+	if err := backend.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the insight series alert id")
+	}
+	if err := r.alertStore.DeleteAlert(ctx, id); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) getAlertSeries(ctx context.Context, seriesID string) (*types.InsightSeries, error) {
+	series, err := r.dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: seriesID})
+	if err != nil {
+		return nil, err
+	}
+	if len(series) == 0 {
+		return nil, errors.Newf("unable to fetch series with series_id: %v", seriesID)
+	}
+	return &series[0], nil
+}
+
+func validateAlert(alert types.InsightSeriesAlert) error {
+	switch alert.Kind {
+	case types.ThresholdAlert:
+	case types.TrendAlert:
+		if alert.Value <= 0 {
+			return errors.New("trend alerts require a positive percentage")
+		}
+		if alert.WindowDays <= 0 {
+			return errors.New("trend alerts require a positive window")
+		}
+	default:
+		return errors.Newf("unsupported alert kind %q", alert.Kind)
+	}
+
+	switch alert.ActionType {
+	case types.EmailAlertAction:
+	case types.SlackWebhookAlertAction, types.WebhookAlertAction:
+		if alert.ActionURL == nil || *alert.ActionURL == "" {
+			return errors.Newf("%s alerts require a URL", alert.ActionType)
+		}
+	default:
+		return errors.Newf("unsupported alert action type %q", alert.ActionType)
+	}
+	return nil
+}
+
+type insightSeriesAlertResolver struct {
+	alert    *types.InsightSeriesAlert
+	seriesID string
+}
+
+func (r *insightSeriesAlertResolver) ID() graphql.ID {
+	return relay.MarshalID(insightSeriesAlertKind, r.alert.ID)
+}
+
+func (r *insightSeriesAlertResolver) SeriesId() string { return r.seriesID }
+
+func (r *insightSeriesAlertResolver) Kind() string { return strings.ToUpper(string(r.alert.Kind)) }
+
+func (r *insightSeriesAlertResolver) Direction() string {
+	return strings.ToUpper(string(r.alert.Direction))
+}
+
+func (r *insightSeriesAlertResolver) Value() float64 { return r.alert.Value }
+
+func (r *insightSeriesAlertResolver) WindowDays() int32 { return int32(r.alert.WindowDays) }
+
+func (r *insightSeriesAlertResolver) ActionType() string {
+	return strings.ToUpper(string(r.alert.ActionType))
+}
+
+func (r *insightSeriesAlertResolver) ActionURL() *string { return r.alert.ActionURL }
+
+func (r *insightSeriesAlertResolver) Triggered() bool { return r.alert.Triggered }
+
+func (r *insightSeriesAlertResolver) LastTriggeredAt() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.alert.LastTriggeredAt)
+}
+
+func (r *insightSeriesAlertResolver) LastValue() *float64 { return r.alert.LastValue }
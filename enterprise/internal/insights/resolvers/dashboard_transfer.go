@@ -0,0 +1,374 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/portable"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.ImportInsightsDashboardsPayloadResolver = &importInsightsDashboardsPayloadResolver{}
+
+// importConflictStrategy decides what happens when an imported insight or dashboard already exists.
+type importConflictStrategy string
+
+const (
+	// failOnConflict aborts the import if anything in the document already exists.
+	failOnConflict importConflictStrategy = "FAIL"
+	// skipOnConflict reuses existing insights and skips dashboards that already exist.
+	skipOnConflict importConflictStrategy = "SKIP"
+	// duplicateOnConflict creates new insights and dashboards alongside the existing ones.
+	duplicateOnConflict importConflictStrategy = "DUPLICATE"
+)
+
+func (r *Resolver) ExportInsightsDashboards(ctx context.Context, args *graphqlbackend.ExportInsightsDashboardsArgs) (string, error) {
+	format, err := portable.ParseFormat(args.Format)
+	if err != nil {
+		return "", err
+	}
+	if len(args.Ids) == 0 {
+		return "", errors.New("at least one dashboard is required to export")
+	}
+
+	dashboardIDs := make([]int, 0, len(args.Ids))
+	for _, id := range args.Ids {
+		dashboardID, err := unmarshalDashboardID(id)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to unmarshal dashboard id")
+		}
+		if dashboardID.isVirtualized() {
+			return "", errors.New("unable to export a virtualized dashboard")
+		}
+		dashboardIDs = append(dashboardIDs, int(dashboardID.Arg))
+	}
+
+	userIds, orgIds, err := getUserPermissions(ctx, r.postgresDB.Orgs())
+	if err != nil {
+		return "", errors.Wrap(err, "getUserPermissions")
+	}
+	dashboards, err := r.dashboardStore.GetDashboards(ctx, store.DashboardQueryArgs{ID: dashboardIDs, UserID: userIds, OrgID: orgIds})
+	if err != nil {
+		return "", errors.Wrap(err, "GetDashboards")
+	}
+	if len(dashboards) != len(dashboardIDs) {
+		return "", errors.New("dashboard not found")
+	}
+
+	doc := portable.Document{Version: portable.CurrentVersion}
+	exported := make(map[string]struct{})
+	for _, dashboard := range dashboards {
+		grants, err := r.exportGrants(ctx, dashboard.UserIdGrants, dashboard.OrgIdGrants, dashboard.GlobalGrant)
+		if err != nil {
+			return "", err
+		}
+		viewSeries, err := r.insightStore.GetAllOnDashboard(ctx, store.InsightsOnDashboardQueryArgs{DashboardID: dashboard.ID})
+		if err != nil {
+			return "", errors.Wrap(err, "GetAllOnDashboard")
+		}
+		insights := make(map[string]types.Insight)
+		for _, insight := range r.insightStore.GroupByView(ctx, viewSeries) {
+			insights[insight.UniqueID] = insight
+		}
+
+		exportedDashboard := portable.Dashboard{Title: dashboard.Title, Grants: grants}
+		onDashboard := make(map[string]struct{}, len(insights))
+		// Keep the order of the insights on the dashboard.
+		for _, series := range viewSeries {
+			if _, ok := onDashboard[series.UniqueID]; ok {
+				continue
+			}
+			onDashboard[series.UniqueID] = struct{}{}
+			exportedDashboard.Insights = append(exportedDashboard.Insights, series.UniqueID)
+
+			if _, ok := exported[series.UniqueID]; ok {
+				continue
+			}
+			exported[series.UniqueID] = struct{}{}
+			insight := insights[series.UniqueID]
+			insightGrants, err := r.exportViewGrants(ctx, insight.ViewID)
+			if err != nil {
+				return "", err
+			}
+			doc.Insights = append(doc.Insights, portable.NewInsight(insight, insightGrants))
+		}
+		doc.Dashboards = append(doc.Dashboards, exportedDashboard)
+	}
+
+	data, err := portable.Marshal(doc, format)
+	if err != nil {
+		return "", errors.Wrap(err, "Marshal")
+	}
+	return string(data), nil
+}
+
+func (r *Resolver) exportViewGrants(ctx context.Context, viewID int) (portable.Grants, error) {
+	viewGrants, err := r.insightStore.GetViewGrants(ctx, viewID)
+	if err != nil {
+		return portable.Grants{}, errors.Wrap(err, "GetViewGrants")
+	}
+	var userIDs, orgIDs []int64
+	var global bool
+	for _, grant := range viewGrants {
+		switch {
+		case grant.UserID != nil:
+			userIDs = append(userIDs, int64(*grant.UserID))
+		case grant.OrgID != nil:
+			orgIDs = append(orgIDs, int64(*grant.OrgID))
+		case grant.Global != nil && *grant.Global:
+			global = true
+		}
+	}
+	return r.exportGrants(ctx, userIDs, orgIDs, global)
+}
+
+// exportGrants replaces user and organization IDs with their names, which are stable across instances.
+func (r *Resolver) exportGrants(ctx context.Context, userIDs, orgIDs []int64, global bool) (portable.Grants, error) {
+	grants := portable.Grants{Global: global}
+	for _, id := range userIDs {
+		user, err := r.postgresDB.Users().GetByID(ctx, int32(id))
+		if err != nil {
+			return portable.Grants{}, errors.Wrapf(err, "unable to find user %d", id)
+		}
+		grants.Users = append(grants.Users, user.Username)
+	}
+	for _, id := range orgIDs {
+		org, err := r.postgresDB.Orgs().GetByID(ctx, int32(id))
+		if err != nil {
+			return portable.Grants{}, errors.Wrapf(err, "unable to find organization %d", id)
+		}
+		grants.Organizations = append(grants.Organizations, org.Name)
+	}
+	return grants, nil
+}
+
+func (r *Resolver) ImportInsightsDashboards(ctx context.Context, args *graphqlbackend.ImportInsightsDashboardsArgs) (_ graphqlbackend.ImportInsightsDashboardsPayloadResolver, err error) {
+	// Importing can create any number of insights, so it is not available in Limited Access Mode.
+	if err := licensing.Check(licensing.FeatureCodeInsights); err != nil {
+		return nil, errors.Wrap(err, "importing insights dashboards requires a license")
+	}
+
+	strategy := failOnConflict
+	if args.Input.OnConflict != nil {
+		strategy = importConflictStrategy(strings.ToUpper(*args.Input.OnConflict))
+	}
+	switch strategy {
+	case failOnConflict, skipOnConflict, duplicateOnConflict:
+	default:
+		return nil, errors.Newf("unsupported conflict strategy %q", strategy)
+	}
+
+	doc, err := portable.Unmarshal([]byte(args.Input.Document))
+	if err != nil {
+		return nil, err
+	}
+
+	uid := actor.FromContext(ctx).UID
+	userIds, orgIds, err := getUserPermissions(ctx, r.postgresDB.Orgs())
+	if err != nil {
+		return nil, errors.Wrap(err, "getUserPermissions")
+	}
+
+	// Plan the import before creating anything, so that conflicts are reported all at once.
+	var conflicts []string
+	uniqueIDs := make(map[string]string, len(doc.Insights))
+	reused := make(map[string]bool)
+	for _, insight := range doc.Insights {
+		existing, err := r.insightStore.Get(ctx, store.InsightQueryArgs{UniqueID: insight.ID, WithoutAuthorization: true})
+		if err != nil {
+			return nil, errors.Wrap(err, "Get")
+		}
+		if len(existing) == 0 {
+			uniqueIDs[insight.ID] = insight.ID
+			continue
+		}
+		switch strategy {
+		case failOnConflict:
+			conflicts = append(conflicts, "insight "+insight.ID)
+		case skipOnConflict:
+			visible, err := r.insightStore.Get(ctx, store.InsightQueryArgs{UniqueID: insight.ID, UserID: userIds, OrgID: orgIds})
+			if err != nil {
+				return nil, errors.Wrap(err, "Get")
+			}
+			if len(visible) > 0 {
+				uniqueIDs[insight.ID] = insight.ID
+				reused[insight.ID] = true
+				continue
+			}
+			uniqueIDs[insight.ID] = ksuid.New().String()
+		case duplicateOnConflict:
+			uniqueIDs[insight.ID] = ksuid.New().String()
+		}
+	}
+
+	existingTitles := make(map[string]struct{})
+	if strategy != duplicateOnConflict {
+		dashboards, err := r.dashboardStore.GetDashboards(ctx, store.DashboardQueryArgs{UserID: userIds, OrgID: orgIds})
+		if err != nil {
+			return nil, errors.Wrap(err, "GetDashboards")
+		}
+		for _, dashboard := range dashboards {
+			existingTitles[dashboard.Title] = struct{}{}
+		}
+	}
+	for _, dashboard := range doc.Dashboards {
+		if _, ok := existingTitles[dashboard.Title]; ok && strategy == failOnConflict {
+			conflicts = append(conflicts, "dashboard "+dashboard.Title)
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &portable.ConflictError{Conflicts: conflicts}
+	}
+
+	insightTx, err := r.insightStore.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = insightTx.Done(err) }()
+	dashboardTx := r.dashboardStore.With(insightTx)
+
+	payload := &importInsightsDashboardsPayloadResolver{baseInsightResolver: r.baseInsightResolver}
+	for _, insight := range doc.Insights {
+		if reused[insight.ID] {
+			payload.skippedInsights = append(payload.skippedInsights, insight.ID)
+			continue
+		}
+		grants, err := r.importGrants(ctx, insight.Grants, uid, userIds, orgIds)
+		if err != nil {
+			return nil, errors.Wrapf(err, "insight %q", insight.ID)
+		}
+		viewGrants := make([]store.InsightViewGrant, 0, len(grants))
+		for _, grant := range grants {
+			viewGrants = append(viewGrants, store.InsightViewGrant(grant))
+		}
+
+		view, err := insightTx.CreateView(ctx, insight.View(uniqueIDs[insight.ID]), viewGrants)
+		if err != nil {
+			return nil, errors.Wrap(err, "CreateView")
+		}
+		// Creating a view doesn't store its series display options.
+		if view.SeriesSortMode != nil || view.SeriesSortDirection != nil || view.SeriesLimit != nil {
+			if view, err = insightTx.UpdateView(ctx, view); err != nil {
+				return nil, errors.Wrap(err, "UpdateView")
+			}
+		}
+		for _, series := range insight.Series {
+			// Points are never imported: new series are backfilled like any other new series.
+			if _, err := createAndAttachSeries(ctx, insightTx, r.backfiller, r.insightEnqueuer, view, seriesInput(series)); err != nil {
+				return nil, errors.Wrapf(err, "insight %q", insight.ID)
+			}
+		}
+	}
+
+	for _, dashboard := range doc.Dashboards {
+		if _, ok := existingTitles[dashboard.Title]; ok {
+			payload.skippedDashboards = append(payload.skippedDashboards, dashboard.Title)
+			continue
+		}
+		grants, err := r.importGrants(ctx, dashboard.Grants, uid, userIds, orgIds)
+		if err != nil {
+			return nil, errors.Wrapf(err, "dashboard %q", dashboard.Title)
+		}
+		insightIDs := make([]string, 0, len(dashboard.Insights))
+		for _, id := range dashboard.Insights {
+			insightIDs = append(insightIDs, uniqueIDs[id])
+		}
+		created, err := dashboardTx.CreateDashboard(ctx, store.CreateDashboardArgs{
+			Dashboard: types.Dashboard{Title: dashboard.Title, InsightIDs: insightIDs, Save: true},
+			Grants:    grants,
+			UserID:    userIds,
+			OrgID:     orgIds,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "CreateDashboard")
+		}
+		if created != nil {
+			payload.dashboards = append(payload.dashboards, created)
+		}
+	}
+	return payload, nil
+}
+
+// importGrants resolves the users and organizations of imported grants by name. Imported content is
+// granted to the importing user if the document doesn't grant it to anyone.
+func (r *Resolver) importGrants(ctx context.Context, grants portable.Grants, uid int32, userIds, orgIds []int) ([]store.DashboardGrant, error) {
+	var result []store.DashboardGrant
+	for _, username := range grants.Users {
+		user, err := r.postgresDB.Users().GetByUsername(ctx, username)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to find user %q", username)
+		}
+		result = append(result, store.UserDashboardGrant(int(user.ID)))
+	}
+	for _, name := range grants.Organizations {
+		org, err := r.postgresDB.Orgs().GetByName(ctx, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to find organization %q", name)
+		}
+		result = append(result, store.OrgDashboardGrant(int(org.ID)))
+	}
+	if grants.Global {
+		result = append(result, store.GlobalDashboardGrant())
+	}
+
+	if len(result) == 0 {
+		if uid == 0 {
+			return nil, errors.New("anonymous users can only import globally granted content")
+		}
+		result = append(result, store.UserDashboardGrant(int(uid)))
+	}
+	if !hasPermissionForGrants(result, userIds, orgIds) {
+		return nil, errors.New("user does not have permission to grant access to the given users and organizations")
+	}
+	return result, nil
+}
+
+func seriesInput(series portable.Series) graphqlbackend.LineChartSearchInsightDataSeriesInput {
+	var generatedFromCaptureGroups *bool
+	if series.GeneratedFromCaptureGroups {
+		generatedFromCaptureGroups = &series.GeneratedFromCaptureGroups
+	}
+	return graphqlbackend.LineChartSearchInsightDataSeriesInput{
+		Query: series.Query,
+		TimeScope: graphqlbackend.TimeScopeInput{StepInterval: &graphqlbackend.TimeIntervalStepInput{
+			Unit:  series.StepInterval.Unit,
+			Value: int32(series.StepInterval.Value),
+		}},
+		RepositoryScope:            graphqlbackend.RepositoryScopeInput{Repositories: series.Repositories},
+		Options:                    graphqlbackend.LineChartDataSeriesOptionsInput{Label: &series.Label, LineColor: &series.Color},
+		GeneratedFromCaptureGroups: generatedFromCaptureGroups,
+		GroupBy:                    series.GroupBy,
+	}
+}
+
+type importInsightsDashboardsPayloadResolver struct {
+	dashboards        []*types.Dashboard
+	skippedDashboards []string
+	skippedInsights   []string
+
+	baseInsightResolver
+}
+
+func (i *importInsightsDashboardsPayloadResolver) Dashboards(ctx context.Context) ([]graphqlbackend.InsightsDashboardResolver, error) {
+	resolvers := make([]graphqlbackend.InsightsDashboardResolver, 0, len(i.dashboards))
+	for _, dashboard := range i.dashboards {
+		id := newRealDashboardID(int64(dashboard.ID))
+		resolvers = append(resolvers, &insightsDashboardResolver{dashboard: dashboard, id: &id, baseInsightResolver: i.baseInsightResolver})
+	}
+	return resolvers, nil
+}
+
+func (i *importInsightsDashboardsPayloadResolver) SkippedDashboards() []string {
+	return i.skippedDashboards
+}
+
+func (i *importInsightsDashboardsPayloadResolver) SkippedInsights() []string {
+	return i.skippedInsights
+}
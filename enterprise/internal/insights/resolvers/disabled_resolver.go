@@ -43,10 +43,34 @@ func (r *disabledResolver) RemoveInsightViewFromDashboard(ctx context.Context, a
 	return nil, errors.New(r.reason)
 }
 
+func (r *disabledResolver) ExportInsightsDashboards(ctx context.Context, args *graphqlbackend.ExportInsightsDashboardsArgs) (string, error) {
+	return "", errors.New(r.reason)
+}
+
+func (r *disabledResolver) ImportInsightsDashboards(ctx context.Context, args *graphqlbackend.ImportInsightsDashboardsArgs) (graphqlbackend.ImportInsightsDashboardsPayloadResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
 func (r *disabledResolver) UpdateInsightSeries(ctx context.Context, args *graphqlbackend.UpdateInsightSeriesArgs) (graphqlbackend.InsightSeriesMetadataPayloadResolver, error) {
 	return nil, errors.New(r.reason)
 }
 
+func (r *disabledResolver) RetryInsightSeriesBackfill(ctx context.Context, args *graphqlbackend.RetryInsightSeriesBackfillArgs) (graphqlbackend.InsightSeriesMetadataPayloadResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
+
 func (r *disabledResolver) InsightSeriesQueryStatus(ctx context.Context) ([]graphqlbackend.InsightSeriesQueryStatusResolver, error) {
 	return nil, errors.New(r.reason)
 }
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/grafana/regexp"
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/log"
@@ -18,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/search"
 	searchquery "github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
 	sctypes "github.com/sourcegraph/sourcegraph/internal/types"
@@ -100,6 +102,9 @@ func (r *insightSeriesResolver) Points(ctx context.Context, _ *graphqlbackend.In
 // will load regardless of the current user.
 type SearchContextLoader interface {
 	GetByName(ctx context.Context, name string) (*sctypes.SearchContext, error)
+	// GetRepositoryRevisions returns the repositories stored for a search
+	// context, which are the resolved repositories of a dynamic context.
+	GetRepositoryRevisions(ctx context.Context, searchContextID int64) ([]search.RepositoryRevisions, error)
 }
 
 type scLoader struct {
@@ -110,6 +115,10 @@ func (l *scLoader) GetByName(ctx context.Context, name string) (*sctypes.SearchC
 	return searchcontexts.ResolveSearchContextSpec(ctx, l.primary, name)
 }
 
+func (l *scLoader) GetRepositoryRevisions(ctx context.Context, searchContextID int64) ([]search.RepositoryRevisions, error) {
+	return searchcontexts.GetRepositoryRevisions(ctx, l.primary, searchContextID)
+}
+
 func unwrapSearchContexts(ctx context.Context, loader SearchContextLoader, rawContexts []string) ([]string, []string, error) {
 	var include []string
 	var exclude []string
@@ -119,6 +128,24 @@ func unwrapSearchContexts(ctx context.Context, loader SearchContextLoader, rawCo
 		if err != nil {
 			return nil, nil, err
 		}
+		if searchContext.Dynamic {
+			// The repositories of dynamic contexts can't be derived from
+			// their query, so we match the resolved ones by name.
+			repoRevs, err := loader.GetRepositoryRevisions(ctx, searchContext.ID)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to get repositories of search context: %s", rawContext)
+			}
+			names := make([]string, 0, len(repoRevs))
+			for _, repoRev := range repoRevs {
+				names = append(names, "^"+regexp.QuoteMeta(string(repoRev.Repo.Name))+"$")
+			}
+			if len(names) == 0 {
+				// Matches no repository name.
+				names = append(names, "$^")
+			}
+			include = append(include, searchquery.UnionRegExps(names))
+			continue
+		}
 		if searchContext.Query != "" {
 			var plan searchquery.Plan
 			plan, err := searchquery.Pipeline(
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	internalTypes "github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -384,14 +385,48 @@ func TestInsightViewDashboardConnections(t *testing.T) {
 	})
 }
 
+func TestFilterRepositoriesDynamicContext(t *testing.T) {
+	loader := &fakeSearchContextLoader{
+		mocks: map[string]*internalTypes.SearchContext{
+			"@dev/gomod": {ID: 1, Name: "gomod", Query: "repo:has.file(go.mod)", Dynamic: true},
+			"@dev/empty": {ID: 2, Name: "empty", Query: "repo:has.file(nope)", Dynamic: true},
+		},
+		repos: map[int64][]search.RepositoryRevisions{
+			1: {{Repo: internalTypes.MinimalRepo{Name: "github.com/sourcegraph/sourcegraph"}}},
+		},
+	}
+	repositories := []string{"github.com/sourcegraph/sourcegraph", "github.com/sourcegraph/sourcegraph-extensions", "gitlab.com/myrepo/repo"}
+
+	got, err := filterRepositories(context.Background(), types.InsightViewFilters{SearchContexts: []string{"@dev/gomod"}}, repositories, loader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"github.com/sourcegraph/sourcegraph"}, got); diff != "" {
+		t.Errorf("unexpected repositories (-want +got):\n%s", diff)
+	}
+
+	got, err = filterRepositories(context.Background(), types.InsightViewFilters{SearchContexts: []string{"@dev/empty"}}, repositories, loader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no repositories, got %v", got)
+	}
+}
+
 type fakeSearchContextLoader struct {
 	mocks map[string]*internalTypes.SearchContext
+	repos map[int64][]search.RepositoryRevisions
 }
 
 func (f *fakeSearchContextLoader) GetByName(ctx context.Context, name string) (*internalTypes.SearchContext, error) {
 	return f.mocks[name], nil
 }
 
+func (f *fakeSearchContextLoader) GetRepositoryRevisions(ctx context.Context, searchContextID int64) ([]search.RepositoryRevisions, error) {
+	return f.repos[searchContextID], nil
+}
+
 func TestRemoveClosePoints(t *testing.T) {
 	getPoint := func(month time.Month, day, hour, minute int) store.SeriesPoint {
 		return store.SeriesPoint{
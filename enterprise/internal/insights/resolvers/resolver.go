@@ -57,6 +57,8 @@ type Resolver struct {
 	timeSeriesStore      store.Interface
 	insightMetadataStore store.InsightMetadataStore
 	dataSeriesStore      store.DataSeriesStore
+	backfillStore        store.BackfillStore
+	alertStore           store.AlertStore
 	backfiller           *background.ScopedBackfiller
 	insightEnqueuer      *background.InsightEnqueuer
 
@@ -78,6 +80,8 @@ func newWithClock(db edb.InsightsDB, postgres database.DB, clock func() time.Tim
 		timeSeriesStore:      base.timeSeriesStore,
 		insightMetadataStore: base.insightStore,
 		dataSeriesStore:      base.insightStore,
+		backfillStore:        store.NewBackfillStore(db),
+		alertStore:           store.NewAlertStore(db),
 		backfiller:           background.NewScopedBackfiller(base.workerBaseStore, base.timeSeriesStore),
 		insightEnqueuer:      background.NewInsightEnqueuer(clock, base.workerBaseStore),
 	}
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+type AlertStore interface {
+	GetAlerts(ctx context.Context, args GetAlertsArgs) ([]*types.InsightSeriesAlert, error)
+	CreateAlert(ctx context.Context, alert types.InsightSeriesAlert) (*types.InsightSeriesAlert, error)
+	DeleteAlert(ctx context.Context, id int) error
+	SetAlertState(ctx context.Context, id int, triggered bool, value float64) error
+}
+
+var _ AlertStore = &DBAlertStore{}
+
+// DBAlertStore persists the alert rules of insight series.
+type DBAlertStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertStore returns a new DBAlertStore backed by the given Postgres db.
+func NewAlertStore(db edb.InsightsDB) *DBAlertStore {
+	return &DBAlertStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+func (s *DBAlertStore) With(other basestore.ShareableStore) *DBAlertStore {
+	return &DBAlertStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *DBAlertStore) Transact(ctx context.Context) (*DBAlertStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &DBAlertStore{Store: txBase, Now: s.Now}, err
+}
+
+type GetAlertsArgs struct {
+	ID       int
+	SeriesID int
+}
+
+func (s *DBAlertStore) GetAlerts(ctx context.Context, args GetAlertsArgs) ([]*types.InsightSeriesAlert, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.ID > 0 {
+		preds = append(preds, sqlf.Sprintf("id = %s", args.ID))
+	}
+	if args.SeriesID > 0 {
+		preds = append(preds, sqlf.Sprintf("series_id = %s", args.SeriesID))
+	}
+	return scanAlerts(s.Query(ctx, sqlf.Sprintf(getAlertsSql, sqlf.Join(preds, "AND"))))
+}
+
+const alertColumns = `id, series_id, kind, direction, value, window_days, action_type, action_url, created_by,
+	triggered, last_triggered_at, last_value, created_at`
+
+const getAlertsSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:GetAlerts
+SELECT ` + alertColumns + `
+FROM insight_series_alerts
+WHERE %s
+ORDER BY id
+`
+
+func (s *DBAlertStore) CreateAlert(ctx context.Context, alert types.InsightSeriesAlert) (*types.InsightSeriesAlert, error) {
+	q := sqlf.Sprintf(
+		createAlertSql,
+		alert.SeriesID,
+		alert.Kind,
+		alert.Direction,
+		alert.Value,
+		alert.WindowDays,
+		alert.ActionType,
+		alert.ActionURL,
+		alert.CreatedBy,
+		s.Now(),
+	)
+	alerts, err := scanAlerts(s.Query(ctx, q))
+	if err != nil || len(alerts) == 0 {
+		return nil, err
+	}
+	return alerts[0], nil
+}
+
+const createAlertSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:CreateAlert
+INSERT INTO insight_series_alerts (series_id, kind, direction, value, window_days, action_type, action_url, created_by, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING ` + alertColumns
+
+func (s *DBAlertStore) DeleteAlert(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteAlertSql, id))
+}
+
+const deleteAlertSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:DeleteAlert
+DELETE FROM insight_series_alerts WHERE id = %s
+`
+
+// SetAlertState records the outcome of evaluating an alert. The trigger time is only updated when
+// the alert starts to trigger.
+func (s *DBAlertStore) SetAlertState(ctx context.Context, id int, triggered bool, value float64) error {
+	return s.Exec(ctx, sqlf.Sprintf(setAlertStateSql, triggered, triggered, s.Now(), value, id))
+}
+
+const setAlertStateSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:SetAlertState
+UPDATE insight_series_alerts SET
+	triggered = %s,
+	last_triggered_at = CASE WHEN %s AND NOT triggered THEN %s ELSE last_triggered_at END,
+	last_value = %s
+WHERE id = %s
+`
+
+func scanAlerts(rows *sql.Rows, queryErr error) (_ []*types.InsightSeriesAlert, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []*types.InsightSeriesAlert
+	for rows.Next() {
+		var a types.InsightSeriesAlert
+		if err := rows.Scan(
+			&a.ID,
+			&a.SeriesID,
+			&a.Kind,
+			&a.Direction,
+			&a.Value,
+			&a.WindowDays,
+			&a.ActionType,
+			&a.ActionURL,
+			&a.CreatedBy,
+			&a.Triggered,
+			&a.LastTriggeredAt,
+			&a.LastValue,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, &a)
+	}
+	return results, nil
+}
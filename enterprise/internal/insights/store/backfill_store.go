@@ -0,0 +1,228 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RepoBackfill is the backfill progress of an insight series in a single repository.
+type RepoBackfill struct {
+	SeriesID        int
+	RepoID          api.RepoID
+	CompletedFrames []time.Time
+	FailedFrames    []time.Time
+	FrameCursor     *time.Time
+	Failures        int
+	LastError       *string
+	UpdatedAt       time.Time
+}
+
+// IsCompleted returns true if all of the given frames were completed.
+func (b *RepoBackfill) IsCompleted(frames ...time.Time) bool {
+	if b == nil {
+		return false
+	}
+	return ContainsFrames(b.CompletedFrames, frames...)
+}
+
+// ContainsFrames returns true if all of the given frames are in set.
+func ContainsFrames(set []time.Time, frames ...time.Time) bool {
+	for _, frame := range frames {
+		found := false
+		for _, t := range set {
+			if t.Equal(frame) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// RecordRepoBackfillArgs describes the progress a backfill made on a series in a single repository.
+type RecordRepoBackfillArgs struct {
+	SeriesID        int
+	RepoID          api.RepoID
+	CompletedFrames []time.Time
+	FailedFrames    []time.Time
+
+	// LastError is the error of the last failed frame, if any.
+	LastError error
+}
+
+type BackfillStore interface {
+	GetRepoBackfills(ctx context.Context, repoID api.RepoID, seriesIDs []int) (map[int]*RepoBackfill, error)
+	RecordRepoBackfill(ctx context.Context, args RecordRepoBackfillArgs) error
+	ResetFrames(ctx context.Context, seriesID int, frames []time.Time, repoIDs []api.RepoID) error
+}
+
+var _ BackfillStore = &DBBackfillStore{}
+
+// DBBackfillStore persists the progress of insight series backfills, so that an interrupted backfill
+// doesn't start over.
+type DBBackfillStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewBackfillStore returns a new DBBackfillStore backed by the given Postgres db.
+func NewBackfillStore(db edb.InsightsDB) *DBBackfillStore {
+	return &DBBackfillStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+func (s *DBBackfillStore) With(other basestore.ShareableStore) *DBBackfillStore {
+	return &DBBackfillStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *DBBackfillStore) Transact(ctx context.Context) (*DBBackfillStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &DBBackfillStore{Store: txBase, Now: s.Now}, err
+}
+
+// GetRepoBackfills returns the backfill progress in a repository of each of the given series, keyed by series ID.
+// Series that have no progress in the repository are not in the map.
+func (s *DBBackfillStore) GetRepoBackfills(ctx context.Context, repoID api.RepoID, seriesIDs []int) (map[int]*RepoBackfill, error) {
+	if len(seriesIDs) == 0 {
+		return map[int]*RepoBackfill{}, nil
+	}
+	q := sqlf.Sprintf(getRepoBackfillsSql, repoID, pq.Array(seriesIDs))
+	backfills, err := scanRepoBackfills(s.Query(ctx, q))
+	if err != nil {
+		return nil, err
+	}
+	bySeries := make(map[int]*RepoBackfill, len(backfills))
+	for _, b := range backfills {
+		bySeries[b.SeriesID] = b
+	}
+	return bySeries, nil
+}
+
+const getRepoBackfillsSql = `
+-- source: enterprise/internal/insights/store/backfill_store.go:GetRepoBackfills
+SELECT series_id, repo_id, array_to_json(completed_frames), array_to_json(failed_frames), frame_cursor, failures, last_error, updated_at
+FROM insight_series_repo_backfills
+WHERE repo_id = %s AND series_id = ANY(%s)
+`
+
+func scanRepoBackfills(rows *sql.Rows, queryErr error) (_ []*RepoBackfill, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []*RepoBackfill
+	for rows.Next() {
+		var b RepoBackfill
+		var completed, failed []byte
+		if err := rows.Scan(
+			&b.SeriesID,
+			&b.RepoID,
+			&completed,
+			&failed,
+			&b.FrameCursor,
+			&b.Failures,
+			&b.LastError,
+			&b.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(completed, &b.CompletedFrames); err != nil {
+			return nil, errors.Wrap(err, "completed_frames")
+		}
+		if err := json.Unmarshal(failed, &b.FailedFrames); err != nil {
+			return nil, errors.Wrap(err, "failed_frames")
+		}
+		results = append(results, &b)
+	}
+	return results, nil
+}
+
+// RecordRepoBackfill adds the frames of args to the progress of the series in the repository. Frames that
+// completed are no longer considered failed.
+func (s *DBBackfillStore) RecordRepoBackfill(ctx context.Context, args RecordRepoBackfillArgs) error {
+	if len(args.CompletedFrames) == 0 && len(args.FailedFrames) == 0 {
+		return nil
+	}
+
+	var cursor *time.Time
+	for _, frames := range [][]time.Time{args.CompletedFrames, args.FailedFrames} {
+		for i, frame := range frames {
+			if cursor == nil || frame.Before(*cursor) {
+				cursor = &frames[i]
+			}
+		}
+	}
+	var lastError *string
+	if args.LastError != nil {
+		msg := args.LastError.Error()
+		lastError = &msg
+	}
+
+	q := sqlf.Sprintf(
+		recordRepoBackfillSql,
+		args.SeriesID,
+		args.RepoID,
+		pq.Array(formatFrames(args.CompletedFrames)),
+		pq.Array(formatFrames(args.FailedFrames)),
+		cursor,
+		len(args.FailedFrames),
+		lastError,
+		s.Now(),
+	)
+	return s.Exec(ctx, q)
+}
+
+const recordRepoBackfillSql = `
+-- source: enterprise/internal/insights/store/backfill_store.go:RecordRepoBackfill
+INSERT INTO insight_series_repo_backfills AS b (series_id, repo_id, completed_frames, failed_frames, frame_cursor, failures, last_error, updated_at)
+VALUES (%s, %s, %s::timestamptz[], %s::timestamptz[], %s, %s, %s, %s)
+ON CONFLICT (series_id, repo_id) DO UPDATE SET
+	completed_frames = ARRAY(SELECT DISTINCT unnest(b.completed_frames || EXCLUDED.completed_frames) ORDER BY 1),
+	failed_frames = ARRAY(SELECT unnest(b.failed_frames || EXCLUDED.failed_frames) EXCEPT SELECT unnest(EXCLUDED.completed_frames) ORDER BY 1),
+	frame_cursor = LEAST(b.frame_cursor, EXCLUDED.frame_cursor),
+	failures = b.failures + EXCLUDED.failures,
+	last_error = COALESCE(EXCLUDED.last_error, b.last_error),
+	updated_at = EXCLUDED.updated_at
+`
+
+// ResetFrames removes the given frames from the progress of a series, so that they are backfilled again. If
+// repoIDs is empty, the frames are reset in all repositories.
+func (s *DBBackfillStore) ResetFrames(ctx context.Context, seriesID int, frames []time.Time, repoIDs []api.RepoID) error {
+	preds := []*sqlf.Query{sqlf.Sprintf("series_id = %s", seriesID)}
+	if len(repoIDs) > 0 {
+		preds = append(preds, sqlf.Sprintf("repo_id = ANY(%s)", pq.Array(repoIDs)))
+	}
+	formatted := pq.Array(formatFrames(frames))
+	q := sqlf.Sprintf(resetFramesSql, formatted, formatted, s.Now(), sqlf.Join(preds, "AND"))
+	return s.Exec(ctx, q)
+}
+
+const resetFramesSql = `
+-- source: enterprise/internal/insights/store/backfill_store.go:ResetFrames
+UPDATE insight_series_repo_backfills SET
+	completed_frames = ARRAY(SELECT unnest(completed_frames) EXCEPT SELECT unnest(%s::timestamptz[]) ORDER BY 1),
+	failed_frames = ARRAY(SELECT unnest(failed_frames) EXCEPT SELECT unnest(%s::timestamptz[]) ORDER BY 1),
+	updated_at = %s
+WHERE %s
+`
+
+func formatFrames(frames []time.Time) []string {
+	formatted := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		formatted = append(formatted, frame.UTC().Format(time.RFC3339Nano))
+	}
+	return formatted
+}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRepoBackfills(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	ctx := context.Background()
+	now := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := insightsDB.ExecContext(ctx, `INSERT INTO insight_series (id, series_id, query, created_at, oldest_historical_at, last_recorded_at,
+                            next_recording_after, last_snapshot_at, next_snapshot_after, deleted_at, generation_method)
+                            VALUES (1, 'series-id-1', 'query-1', $1, $1, $1, $1, $1, $1, null, 'search'),
+                                   (2, 'series-id-2', 'query-2', $1, $1, $1, $1, $1, $1, null, 'search');`, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewBackfillStore(insightsDB)
+	store.Now = func() time.Time { return now }
+
+	month := func(i int) time.Time { return now.AddDate(0, -i, 0) }
+	repoID := api.RepoID(5)
+
+	t.Run("no progress", func(t *testing.T) {
+		got, err := store.GetRepoBackfills(ctx, repoID, []int{1, 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 0 {
+			t.Errorf("expected no backfills, got %v", got)
+		}
+	})
+
+	t.Run("record progress", func(t *testing.T) {
+		err := store.RecordRepoBackfill(ctx, RecordRepoBackfillArgs{
+			SeriesID:        1,
+			RepoID:          repoID,
+			CompletedFrames: []time.Time{month(1), month(2)},
+			FailedFrames:    []time.Time{month(3)},
+			LastError:       errors.New("boom"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// A later attempt completes the failed frame.
+		err = store.RecordRepoBackfill(ctx, RecordRepoBackfillArgs{
+			SeriesID:        1,
+			RepoID:          repoID,
+			CompletedFrames: []time.Time{month(3)},
+			FailedFrames:    []time.Time{month(4)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := store.GetRepoBackfills(ctx, repoID, []int{1, 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 {
+			t.Fatalf("expected one backfill, got %d", len(got))
+		}
+		backfill := got[1]
+
+		cursor := month(4)
+		lastError := "boom"
+		want := &RepoBackfill{
+			SeriesID:        1,
+			RepoID:          repoID,
+			CompletedFrames: []time.Time{month(3), month(2), month(1)},
+			FailedFrames:    []time.Time{month(4)},
+			FrameCursor:     &cursor,
+			Failures:        2,
+			LastError:       &lastError,
+			UpdatedAt:       now,
+		}
+		if diff := cmp.Diff(want, backfill, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+			t.Errorf("unexpected backfill (-want +got):\n%s", diff)
+		}
+
+		if !backfill.IsCompleted(month(1), month(3)) {
+			t.Error("expected frames to be completed")
+		}
+		if backfill.IsCompleted(month(1), month(4)) {
+			t.Error("expected failed frame not to be completed")
+		}
+	})
+
+	t.Run("reset frames", func(t *testing.T) {
+		if err := store.ResetFrames(ctx, 1, []time.Time{month(1), month(4)}, nil); err != nil {
+			t.Fatal(err)
+		}
+		got, err := store.GetRepoBackfills(ctx, repoID, []int{1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		backfill := got[1]
+		if backfill.IsCompleted(month(1)) {
+			t.Error("expected reset frame not to be completed")
+		}
+		if !backfill.IsCompleted(month(2), month(3)) {
+			t.Error("expected other frames to be completed")
+		}
+		if len(backfill.FailedFrames) != 0 {
+			t.Errorf("expected no failed frames, got %v", backfill.FailedFrames)
+		}
+	})
+}
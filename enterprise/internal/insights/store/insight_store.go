@@ -697,6 +697,33 @@ INSERT INTO insight_view_grants (insight_view_id, org_id, user_id, global)
 VALUES %s;
 `
 
+// GetViewGrants returns the grants of an insight view.
+func (s *InsightStore) GetViewGrants(ctx context.Context, viewID int) ([]InsightViewGrant, error) {
+	return scanViewGrants(s.Query(ctx, sqlf.Sprintf(getViewGrantsSql, viewID)))
+}
+
+const getViewGrantsSql = `
+-- source: enterprise/internal/insights/store/insight_store.go:GetViewGrants
+SELECT user_id, org_id, global FROM insight_view_grants WHERE insight_view_id = %s ORDER BY id
+`
+
+func scanViewGrants(rows *sql.Rows, queryErr error) (_ []InsightViewGrant, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []InsightViewGrant
+	for rows.Next() {
+		var grant InsightViewGrant
+		if err := rows.Scan(&grant.UserID, &grant.OrgID, &grant.Global); err != nil {
+			return nil, err
+		}
+		results = append(results, grant)
+	}
+	return results, nil
+}
+
 // DeleteViewByUniqueID deletes an insight view (cascading to dependent child tables) given a unique ID. This operation
 // is idempotent and can be executed many times with only one effect or error.
 func (s *InsightStore) DeleteViewByUniqueID(ctx context.Context, uniqueID string) error {
@@ -797,6 +824,7 @@ type DataSeriesStore interface {
 	IncrementBackfillAttempts(ctx context.Context, series types.InsightSeries) error
 	GetScopedSearchSeriesNeedBackfill(ctx context.Context) ([]types.InsightSeries, error)
 	CompleteJustInTimeConversionAttempt(ctx context.Context, series types.InsightSeries) error
+	ResetBackfill(ctx context.Context, series types.InsightSeries) error
 }
 
 type InsightMetadataStore interface {
@@ -847,6 +875,11 @@ func (s *InsightStore) StampBackfill(ctx context.Context, series types.InsightSe
 	return series, nil
 }
 
+// ResetBackfill clears the backfill queued time for this series, so that the historical enqueuer picks it up again.
+func (s *InsightStore) ResetBackfill(ctx context.Context, series types.InsightSeries) error {
+	return s.Exec(ctx, sqlf.Sprintf(resetBackfillSql, series.ID))
+}
+
 func (s *InsightStore) SetSeriesEnabled(ctx context.Context, seriesId string, enabled bool) error {
 	var arg *sqlf.Query
 	if enabled {
@@ -954,6 +987,13 @@ SET deleted_at = %s
 WHERE series_id = %s;
 `
 
+const resetBackfillSql = `
+-- source: enterprise/internal/insights/store/insight_store.go:ResetBackfill
+UPDATE insight_series
+SET backfill_queued_at = NULL
+WHERE id = %s;
+`
+
 const stampBackfillSql = `
 -- source: enterprise/internal/insights/store/insight_store.go:StampRecording
 UPDATE insight_series
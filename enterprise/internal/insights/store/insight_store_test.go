@@ -1089,6 +1089,17 @@ func TestCreateGetView_WithGrants(t *testing.T) {
 		}
 	})
 
+	t.Run("view grants are returned", func(t *testing.T) {
+		got, err := store.GetViewGrants(ctx, view.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []InsightViewGrant{UserGrant(1), OrgGrant(5)}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected view grants (-want +got):\n%s", diff)
+		}
+	})
+
 	t.Run("org 1 cannot see the view", func(t *testing.T) {
 		got, err := store.Get(ctx, InsightQueryArgs{UniqueID: uniqueID, UserID: []int{3}, OrgID: []int{1}})
 		if err != nil {
@@ -33,6 +33,9 @@ type MockDataSeriesStore struct {
 	// object controlling the behavior of the method
 	// IncrementBackfillAttempts.
 	IncrementBackfillAttemptsFunc *DataSeriesStoreIncrementBackfillAttemptsFunc
+	// ResetBackfillFunc is an instance of a mock function object
+	// controlling the behavior of the method ResetBackfill.
+	ResetBackfillFunc *DataSeriesStoreResetBackfillFunc
 	// SetSeriesEnabledFunc is an instance of a mock function object
 	// controlling the behavior of the method SetSeriesEnabled.
 	SetSeriesEnabledFunc *DataSeriesStoreSetSeriesEnabledFunc
@@ -76,6 +79,11 @@ func NewMockDataSeriesStore() *MockDataSeriesStore {
 				return
 			},
 		},
+		ResetBackfillFunc: &DataSeriesStoreResetBackfillFunc{
+			defaultHook: func(context.Context, types.InsightSeries) (r0 error) {
+				return
+			},
+		},
 		SetSeriesEnabledFunc: &DataSeriesStoreSetSeriesEnabledFunc{
 			defaultHook: func(context.Context, string, bool) (r0 error) {
 				return
@@ -128,6 +136,11 @@ func NewStrictMockDataSeriesStore() *MockDataSeriesStore {
 				panic("unexpected invocation of MockDataSeriesStore.IncrementBackfillAttempts")
 			},
 		},
+		ResetBackfillFunc: &DataSeriesStoreResetBackfillFunc{
+			defaultHook: func(context.Context, types.InsightSeries) error {
+				panic("unexpected invocation of MockDataSeriesStore.ResetBackfill")
+			},
+		},
 		SetSeriesEnabledFunc: &DataSeriesStoreSetSeriesEnabledFunc{
 			defaultHook: func(context.Context, string, bool) error {
 				panic("unexpected invocation of MockDataSeriesStore.SetSeriesEnabled")
@@ -173,6 +186,9 @@ func NewMockDataSeriesStoreFrom(i DataSeriesStore) *MockDataSeriesStore {
 		IncrementBackfillAttemptsFunc: &DataSeriesStoreIncrementBackfillAttemptsFunc{
 			defaultHook: i.IncrementBackfillAttempts,
 		},
+		ResetBackfillFunc: &DataSeriesStoreResetBackfillFunc{
+			defaultHook: i.ResetBackfill,
+		},
 		SetSeriesEnabledFunc: &DataSeriesStoreSetSeriesEnabledFunc{
 			defaultHook: i.SetSeriesEnabled,
 		},
@@ -629,6 +645,112 @@ func (c DataSeriesStoreIncrementBackfillAttemptsFuncCall) Results() []interface{
 	return []interface{}{c.Result0}
 }
 
+// DataSeriesStoreResetBackfillFunc describes the behavior when the
+// ResetBackfill method of the parent MockDataSeriesStore instance is
+// invoked.
+type DataSeriesStoreResetBackfillFunc struct {
+	defaultHook func(context.Context, types.InsightSeries) error
+	hooks       []func(context.Context, types.InsightSeries) error
+	history     []DataSeriesStoreResetBackfillFuncCall
+	mutex       sync.Mutex
+}
+
+// ResetBackfill delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDataSeriesStore) ResetBackfill(v0 context.Context, v1 types.InsightSeries) error {
+	r0 := m.ResetBackfillFunc.nextHook()(v0, v1)
+	m.ResetBackfillFunc.appendCall(DataSeriesStoreResetBackfillFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ResetBackfill method
+// of the parent MockDataSeriesStore instance is invoked and the hook queue
+// is empty.
+func (f *DataSeriesStoreResetBackfillFunc) SetDefaultHook(hook func(context.Context, types.InsightSeries) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ResetBackfill method of the parent MockDataSeriesStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DataSeriesStoreResetBackfillFunc) PushHook(hook func(context.Context, types.InsightSeries) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DataSeriesStoreResetBackfillFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, types.InsightSeries) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DataSeriesStoreResetBackfillFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, types.InsightSeries) error {
+		return r0
+	})
+}
+
+func (f *DataSeriesStoreResetBackfillFunc) nextHook() func(context.Context, types.InsightSeries) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DataSeriesStoreResetBackfillFunc) appendCall(r0 DataSeriesStoreResetBackfillFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DataSeriesStoreResetBackfillFuncCall
+// objects describing the invocations of this function.
+func (f *DataSeriesStoreResetBackfillFunc) History() []DataSeriesStoreResetBackfillFuncCall {
+	f.mutex.Lock()
+	history := make([]DataSeriesStoreResetBackfillFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DataSeriesStoreResetBackfillFuncCall is an object that describes an
+// invocation of method ResetBackfill on an instance of MockDataSeriesStore.
+type DataSeriesStoreResetBackfillFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.InsightSeries
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DataSeriesStoreResetBackfillFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DataSeriesStoreResetBackfillFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DataSeriesStoreSetSeriesEnabledFunc describes the behavior when the
 // SetSeriesEnabled method of the parent MockDataSeriesStore instance is
 // invoked.
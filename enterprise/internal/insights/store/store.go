@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
@@ -183,6 +184,30 @@ const deleteForSeriesSnapshots = `
 DELETE FROM series_points_snapshots where series_id = %s;
 `
 
+// DeletePointsAt will hard delete the recorded data points of a series at the given times, so that
+// they can be recorded again. If repoIDs is empty, the points of all repositories are deleted.
+func (s *Store) DeletePointsAt(ctx context.Context, seriesId string, times []time.Time, repoIDs []api.RepoID) error {
+	if len(times) == 0 {
+		return nil
+	}
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("series_id = %s", seriesId),
+		sqlf.Sprintf("time = ANY(%s::timestamptz[])", pq.Array(formatFrames(times))),
+	}
+	if len(repoIDs) > 0 {
+		preds = append(preds, sqlf.Sprintf("repo_id = ANY(%s)", pq.Array(repoIDs)))
+	}
+	if err := s.Exec(ctx, sqlf.Sprintf(deletePointsAtSql, sqlf.Join(preds, "AND"))); err != nil {
+		return errors.Wrap(err, "DeletePointsAt")
+	}
+	return nil
+}
+
+const deletePointsAtSql = `
+-- source: enterprise/internal/insights/store/store.go:DeletePointsAt
+DELETE FROM series_points WHERE %s;
+`
+
 // Note: the inner query could return duplicate points on its own if we merely did a SUM(value) over
 // all desired repositories. By using the sub-query, we select the per-repository maximum (thus
 // eliminating duplicate points that might have been recorded in a given interval for a given repository)
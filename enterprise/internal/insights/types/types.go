@@ -161,6 +161,47 @@ type InsightSeriesStatus struct {
 	Completed  int
 }
 
+// InsightSeriesAlert is a rule on the values of an insight series that notifies someone when its
+// condition starts to hold.
+type InsightSeriesAlert struct {
+	ID         int
+	SeriesID   int
+	Kind       AlertKind
+	Direction  AlertDirection
+	Value      float64
+	WindowDays int
+	ActionType AlertActionType
+	ActionURL  *string
+	CreatedBy  int32
+
+	Triggered       bool
+	LastTriggeredAt *time.Time
+	LastValue       *float64
+	CreatedAt       time.Time
+}
+
+type AlertKind string
+
+const (
+	ThresholdAlert AlertKind = "threshold" // Compares the value of the series.
+	TrendAlert     AlertKind = "trend"     // Compares the percentage change of the series over a window.
+)
+
+type AlertDirection string
+
+const (
+	Above AlertDirection = "above"
+	Below AlertDirection = "below"
+)
+
+type AlertActionType string
+
+const (
+	EmailAlertAction        AlertActionType = "email"
+	SlackWebhookAlertAction AlertActionType = "slack_webhook"
+	WebhookAlertAction      AlertActionType = "webhook"
+)
+
 type PresentationType string
 
 const (
@@ -206,9 +247,20 @@ const (
 	PATH_AGGREGATION_MODE          SearchAggregationMode = "PATH"
 	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
 	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
+	LANGUAGE_AGGREGATION_MODE      SearchAggregationMode = "LANGUAGE"
+	DIRECTORY_AGGREGATION_MODE     SearchAggregationMode = "DIRECTORY"
+	DATE_AGGREGATION_MODE          SearchAggregationMode = "DATE"
 )
 
-var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE}
+var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, LANGUAGE_AGGREGATION_MODE, DIRECTORY_AGGREGATION_MODE, DATE_AGGREGATION_MODE}
+
+// SearchAggregationDateBucket is the period that DATE aggregations group commit dates by.
+type SearchAggregationDateBucket string
+
+const (
+	MONTH_DATE_BUCKET SearchAggregationDateBucket = "MONTH"
+	WEEK_DATE_BUCKET  SearchAggregationDateBucket = "WEEK"
+)
 
 type AggregationNotAvailableReasonType string
 
@@ -0,0 +1,78 @@
+package notebooks
+
+import "reflect"
+
+// DiffNotebookBlocks compares two versions of the blocks of a notebook, matching blocks by their ID.
+// The diff lists the blocks of newBlocks in order, followed by the removed blocks in their old order.
+//
+// Kept blocks are reported as moved when they are not part of the longest run of kept blocks that
+// stayed in the same relative order, so that moving one block doesn't mark every block in between
+// as moved.
+func DiffNotebookBlocks(oldBlocks, newBlocks NotebookBlocks) []NotebookBlockDiff {
+	oldByID := make(map[string]int, len(oldBlocks))
+	for i, block := range oldBlocks {
+		oldByID[block.ID] = i
+	}
+
+	// The old indexes of the kept blocks, in their new order.
+	var keptOldIndexes []int
+	for _, block := range newBlocks {
+		if oldIndex, ok := oldByID[block.ID]; ok {
+			keptOldIndexes = append(keptOldIndexes, oldIndex)
+		}
+	}
+	inOrder := longestIncreasingSubsequence(keptOldIndexes)
+
+	diff := make([]NotebookBlockDiff, 0, len(newBlocks))
+	kept := make(map[string]struct{}, len(keptOldIndexes))
+	for i := range newBlocks {
+		newBlock := &newBlocks[i]
+		oldIndex, ok := oldByID[newBlock.ID]
+		if !ok {
+			diff = append(diff, NotebookBlockDiff{Type: NotebookBlockAdded, BlockID: newBlock.ID, New: newBlock})
+			continue
+		}
+		kept[newBlock.ID] = struct{}{}
+
+		oldBlock := &oldBlocks[oldIndex]
+		diffType := NotebookBlockUnchanged
+		if !reflect.DeepEqual(*oldBlock, *newBlock) {
+			diffType = NotebookBlockModified
+		} else if _, ok := inOrder[oldIndex]; !ok {
+			diffType = NotebookBlockMoved
+		}
+		diff = append(diff, NotebookBlockDiff{Type: diffType, BlockID: newBlock.ID, Old: oldBlock, New: newBlock})
+	}
+
+	for i := range oldBlocks {
+		if _, ok := kept[oldBlocks[i].ID]; !ok {
+			diff = append(diff, NotebookBlockDiff{Type: NotebookBlockRemoved, BlockID: oldBlocks[i].ID, Old: &oldBlocks[i]})
+		}
+	}
+	return diff
+}
+
+// longestIncreasingSubsequence returns the values of a longest strictly increasing subsequence of
+// values. Notebooks have few blocks, so the quadratic algorithm is fine.
+func longestIncreasingSubsequence(values []int) map[int]struct{} {
+	lengths := make([]int, len(values))
+	previous := make([]int, len(values))
+	end := -1
+	for i := range values {
+		lengths[i], previous[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if values[j] < values[i] && lengths[j]+1 > lengths[i] {
+				lengths[i], previous[i] = lengths[j]+1, j
+			}
+		}
+		if end == -1 || lengths[i] > lengths[end] {
+			end = i
+		}
+	}
+
+	result := make(map[int]struct{}, len(values))
+	for i := end; i != -1; i = previous[i] {
+		result[values[i]] = struct{}{}
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package notebooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffNotebookBlocks(t *testing.T) {
+	block := func(id, text string) NotebookBlock {
+		return NotebookBlock{ID: id, Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{text}}
+	}
+	type change struct {
+		Type    NotebookBlockDiffType
+		BlockID string
+	}
+
+	testCases := []struct {
+		name      string
+		oldBlocks NotebookBlocks
+		newBlocks NotebookBlocks
+		want      []change
+	}{
+		{
+			name:      "unchanged",
+			oldBlocks: NotebookBlocks{block("1", "a"), block("2", "b")},
+			newBlocks: NotebookBlocks{block("1", "a"), block("2", "b")},
+			want:      []change{{NotebookBlockUnchanged, "1"}, {NotebookBlockUnchanged, "2"}},
+		},
+		{
+			name:      "added, modified and removed",
+			oldBlocks: NotebookBlocks{block("1", "a"), block("2", "b"), block("3", "c")},
+			newBlocks: NotebookBlocks{block("4", "d"), block("1", "a"), block("3", "c2")},
+			want: []change{
+				{NotebookBlockAdded, "4"},
+				{NotebookBlockUnchanged, "1"},
+				{NotebookBlockModified, "3"},
+				{NotebookBlockRemoved, "2"},
+			},
+		},
+		{
+			name:      "one block moved to the end",
+			oldBlocks: NotebookBlocks{block("1", "a"), block("2", "b"), block("3", "c")},
+			newBlocks: NotebookBlocks{block("2", "b"), block("3", "c"), block("1", "a")},
+			want: []change{
+				{NotebookBlockUnchanged, "2"},
+				{NotebookBlockUnchanged, "3"},
+				{NotebookBlockMoved, "1"},
+			},
+		},
+		{
+			name:      "all blocks removed",
+			oldBlocks: NotebookBlocks{block("1", "a")},
+			newBlocks: NotebookBlocks{},
+			want:      []change{{NotebookBlockRemoved, "1"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []change
+			for _, d := range DiffNotebookBlocks(tc.oldBlocks, tc.newBlocks) {
+				got = append(got, change{d.Type, d.BlockID})
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package notebooks
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/go-diff/diff"
+)
+
+// CheckPinnedLineRange compares the lines of a pinned block with a newer version of its file, given
+// the diff hunks of the file between the two commits. The line range follows the convention of the
+// GraphQL API: the start line is 0-indexed and inclusive, the end line is 0-indexed and exclusive.
+// A nil line range stands for the whole file.
+//
+// The returned range is where the pinned lines are in the newer version of the file. It covers the
+// lines of the range that were not edited, and is nil when none of them remain.
+func CheckPinnedLineRange(hunks []*diff.Hunk, lineRange *LineRange) (NotebookBlockPinState, *LineRange) {
+	if lineRange == nil {
+		if len(hunks) == 0 {
+			return NotebookBlockPinCurrent, nil
+		}
+		return NotebookBlockPinChanged, nil
+	}
+
+	var suggested *LineRange
+	edited := false
+	for line := lineRange.StartLine; line < lineRange.EndLine; line++ {
+		translated, ok := translateLine(hunks, int(line))
+		if !ok {
+			edited = true
+			continue
+		}
+		if suggested == nil {
+			suggested = &LineRange{StartLine: int32(translated), EndLine: int32(translated) + 1}
+		} else {
+			suggested.EndLine = int32(translated) + 1
+		}
+	}
+
+	if suggested == nil {
+		return NotebookBlockPinChanged, nil
+	}
+	// Lines added between the pinned lines make the range longer.
+	if edited || suggested.EndLine-suggested.StartLine != lineRange.EndLine-lineRange.StartLine {
+		return NotebookBlockPinChanged, suggested
+	}
+	if *suggested == *lineRange {
+		return NotebookBlockPinCurrent, suggested
+	}
+	return NotebookBlockPinMoved, suggested
+}
+
+// translateLine translates the given 0-indexed line through the hunks, the same way codenav's
+// GitTreeTranslator translates positions. It returns false if the line was edited or removed.
+func translateLine(hunks []*diff.Hunk, line int) (int, bool) {
+	// Translate to the 1-indexed lines of the diff.
+	line++
+
+	var hunk *diff.Hunk
+	for _, h := range hunks {
+		if int(h.OrigStartLine) > line {
+			break
+		}
+		hunk = h
+	}
+	if hunk == nil {
+		// No changes before this line
+		return line - 1, true
+	}
+
+	// The hunk ends before this line, so the line is shifted by the lines the hunk added or removed.
+	if line >= int(hunk.OrigStartLine+hunk.OrigLines) {
+		return line + int(hunk.NewStartLine+hunk.NewLines) - int(hunk.OrigStartLine+hunk.OrigLines) - 1, true
+	}
+
+	// Walk the hunk until the line, counting the lines of the source and target files.
+	sourceOffset := int(hunk.OrigStartLine)
+	targetOffset := int(hunk.NewStartLine)
+	for _, deltaLine := range strings.Split(string(hunk.Body), "\n") {
+		isAdded := strings.HasPrefix(deltaLine, "+")
+		isRemoved := strings.HasPrefix(deltaLine, "-")
+
+		if !isAdded {
+			sourceOffset++
+		}
+		if sourceOffset-1 == line {
+			if isAdded || isRemoved {
+				return 0, false
+			}
+			return targetOffset - 1, true
+		}
+		if !isRemoved {
+			targetOffset++
+		}
+	}
+
+	// The hunk body is malformed, it doesn't contain the line although the hunk covers it.
+	return 0, false
+}
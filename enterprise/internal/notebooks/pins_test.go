@@ -0,0 +1,105 @@
+package notebooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/go-diff/diff"
+)
+
+// pinsTestDiff changes a file of 20 lines l1...l20 by adding two lines after l2 and editing l15.
+const pinsTestDiff = `@@ -1,5 +1,7 @@
+ l1
+ l2
++a
++b
+ l3
+ l4
+ l5
+@@ -12,7 +14,7 @@
+ l12
+ l13
+ l14
+-l15
++x
+ l16
+ l17
+ l18
+`
+
+func TestCheckPinnedLineRange(t *testing.T) {
+	hunks, err := diff.ParseHunks([]byte(pinsTestDiff))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		hunks         []*diff.Hunk
+		lineRange     *LineRange
+		wantState     NotebookBlockPinState
+		wantSuggested *LineRange
+	}{
+		{
+			name:          "lines before the changes",
+			hunks:         hunks,
+			lineRange:     &LineRange{StartLine: 0, EndLine: 2},
+			wantState:     NotebookBlockPinCurrent,
+			wantSuggested: &LineRange{StartLine: 0, EndLine: 2},
+		},
+		{
+			name:          "lines after an addition",
+			hunks:         hunks,
+			lineRange:     &LineRange{StartLine: 2, EndLine: 5},
+			wantState:     NotebookBlockPinMoved,
+			wantSuggested: &LineRange{StartLine: 4, EndLine: 7},
+		},
+		{
+			name:          "lines around an addition",
+			hunks:         hunks,
+			lineRange:     &LineRange{StartLine: 1, EndLine: 3},
+			wantState:     NotebookBlockPinChanged,
+			wantSuggested: &LineRange{StartLine: 1, EndLine: 5},
+		},
+		{
+			name:          "lines around an edit",
+			hunks:         hunks,
+			lineRange:     &LineRange{StartLine: 13, EndLine: 16},
+			wantState:     NotebookBlockPinChanged,
+			wantSuggested: &LineRange{StartLine: 15, EndLine: 18},
+		},
+		{
+			name:      "edited line",
+			hunks:     hunks,
+			lineRange: &LineRange{StartLine: 14, EndLine: 15},
+			wantState: NotebookBlockPinChanged,
+		},
+		{
+			name:          "lines after all changes",
+			hunks:         hunks,
+			lineRange:     &LineRange{StartLine: 18, EndLine: 20},
+			wantState:     NotebookBlockPinMoved,
+			wantSuggested: &LineRange{StartLine: 20, EndLine: 22},
+		},
+		{
+			name:      "changed file",
+			hunks:     hunks,
+			wantState: NotebookBlockPinChanged,
+		},
+		{
+			name:      "unchanged file",
+			wantState: NotebookBlockPinCurrent,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, suggested := CheckPinnedLineRange(tc.hunks, tc.lineRange)
+			if state != tc.wantState {
+				t.Errorf("unexpected state, want %q got %q", tc.wantState, state)
+			}
+			if diff := cmp.Diff(tc.wantSuggested, suggested); diff != "" {
+				t.Errorf("unexpected suggested range (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
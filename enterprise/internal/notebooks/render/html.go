@@ -0,0 +1,207 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+)
+
+// htmlStyle styles the document and the code tables of the syntax highlighter, so that the document
+// doesn't depend on any other file.
+const htmlStyle = `
+body { max-width: 60rem; margin: 2rem auto; padding: 0 1rem; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; line-height: 1.5; color: #24292f; }
+a { color: #0b70db; }
+.block { margin: 1.5rem 0; }
+.block-header { font-weight: 600; margin-bottom: 0.5rem; }
+.block-header .detail { font-weight: normal; color: #57606a; }
+.query, .code-table, pre { font-family: SFMono-Regular, Consolas, Menlo, monospace; font-size: 0.85rem; }
+.query { display: block; padding: 0.5rem; background: #f6f8fa; border: 1px solid #d0d7de; border-radius: 4px; white-space: pre-wrap; }
+.result { margin: 0.75rem 0; }
+.code-table { width: 100%; border-collapse: collapse; border: 1px solid #d0d7de; margin-bottom: 0.5rem; }
+.code-table td { padding: 0 0.5rem; vertical-align: top; }
+.code-table td.line { width: 1%; text-align: right; color: #8c959f; user-select: none; }
+.code-table td.line::before { content: attr(data-line); }
+.code-table td.code { white-space: pre; }
+.compute-table { border-collapse: collapse; }
+.compute-table th, .compute-table td { border: 1px solid #d0d7de; padding: 0.25rem 0.75rem; }
+.compute-table td.count { text-align: right; }
+.note { color: #57606a; font-style: italic; }
+.error { padding: 0.5rem; color: #82071e; background: #ffebe9; border: 1px solid #ff818266; border-radius: 4px; }
+.hl-comment, .hl-typed-Comment { color: #6e7781; }
+.hl-keyword, .hl-storage, .hl-typed-Keyword { color: #cf222e; }
+.hl-string, .hl-typed-StringLiteral, .hl-typed-CharacterLiteral { color: #0a3069; }
+.hl-constant, .hl-typed-NumericLiteral, .hl-typed-BooleanLiteral { color: #0550ae; }
+.hl-entity, .hl-typed-IdentifierFunction, .hl-typed-IdentifierFunctionDefinition { color: #8250df; }
+.hl-support, .hl-typed-IdentifierType, .hl-typed-IdentifierBuiltinType { color: #953800; }
+`
+
+var htmlTemplate = template.Must(template.New("notebook").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>{{.Style}}</style>
+</head>
+<body>
+{{- if .Title}}
+<h1>{{.Title}}</h1>
+{{- end}}
+{{- range .Blocks}}
+<section class="block">
+{{- if .Markdown}}
+{{.Markdown}}
+{{- end}}
+{{- if .Header}}
+<div class="block-header">{{if .Header.URL}}<a href="{{.Header.URL}}">{{.Header.Label}}</a>{{else}}{{.Header.Label}}{{end}}{{if .Header.Detail}} <span class="detail">{{.Header.Detail}}</span>{{end}}</div>
+{{- end}}
+{{- if .Query}}
+<code class="query">{{.Query}}</code>
+{{- end}}
+{{- range .Results}}
+<div class="result">
+<div><a href="{{.URL}}">{{.Label}}</a></div>
+{{- range .Chunks}}
+<table class="code-table">{{range .}}{{.}}{{end}}</table>
+{{- end}}
+</div>
+{{- end}}
+{{- if .Code}}
+<table class="code-table">{{range .Code}}{{.}}{{end}}</table>
+{{- end}}
+{{- with .Table}}
+<table class="compute-table">
+<thead><tr><th>Value</th><th>Count</th></tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr><td>{{.Value}}</td><td class="count">{{.Count}}</td></tr>
+{{- end}}
+{{- if .OtherCount}}
+<tr><td class="note">Other</td><td class="count">{{.OtherCount}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .Note}}
+<p class="note">{{.Note}}</p>
+{{- end}}
+{{- if .Error}}
+<p class="error">{{.Error}}</p>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+type htmlDocument struct {
+	Title  string
+	Style  template.CSS
+	Blocks []htmlBlock
+}
+
+type htmlBlock struct {
+	Markdown template.HTML
+	Header   *htmlHeader
+	Query    string
+	Results  []htmlResult
+	Code     []template.HTML
+	Table    *Table
+	Note     string
+	Error    string
+}
+
+type htmlHeader struct {
+	Label  string
+	URL    string
+	Detail string
+}
+
+type htmlResult struct {
+	Label  string
+	URL    string
+	Chunks [][]template.HTML
+}
+
+func (r *Renderer) writeHTML(w io.Writer, doc *document) error {
+	data := htmlDocument{
+		Title:  doc.title,
+		Style:  template.CSS(htmlStyle),
+		Blocks: make([]htmlBlock, 0, len(doc.blocks)),
+	}
+	for _, rendered := range doc.blocks {
+		data.Blocks = append(data.Blocks, r.htmlBlock(rendered))
+	}
+	return htmlTemplate.Execute(w, data)
+}
+
+func (r *Renderer) htmlBlock(rendered renderedBlock) htmlBlock {
+	var out htmlBlock
+	block := rendered.block
+	switch {
+	case block.MarkdownInput != nil:
+		out.Markdown = template.HTML(r.backend.MarkdownHTML(rendered.markdown))
+
+	case block.QueryInput != nil:
+		out.Header = &htmlHeader{Label: "Search", URL: r.searchURL(rendered.query)}
+		out.Query = rendered.query
+		if results := rendered.searchResults; results != nil {
+			for _, result := range results.Results {
+				htmlResult := htmlResult{Label: result.Label, URL: r.absoluteURL(result.URL)}
+				for _, chunk := range result.Chunks {
+					htmlResult.Chunks = append(htmlResult.Chunks, plainHTMLRows(chunk.StartLine, chunk.Lines))
+				}
+				out.Results = append(out.Results, htmlResult)
+			}
+			if len(results.Results) == 0 {
+				out.Note = "No results."
+			} else if results.LimitHit {
+				out.Note = fmt.Sprintf("Showing the first %d results.", len(results.Results))
+			}
+		}
+
+	case block.FileInput != nil, block.SymbolInput != nil:
+		header := r.snippetHeader(rendered)
+		out.Header = &htmlHeader{Label: header.label, URL: header.url, Detail: header.detail}
+		if snippet := rendered.snippet; snippet != nil {
+			if snippet.HighlightedLines != nil {
+				for _, row := range snippet.HighlightedLines {
+					// The rows come from the syntax highlighter, which escapes the code.
+					out.Code = append(out.Code, template.HTML(row))
+				}
+			} else {
+				out.Code = plainHTMLRows(snippet.StartLine, snippet.Lines)
+			}
+		}
+
+	case block.ComputeInput != nil:
+		out.Header = &htmlHeader{Label: "Compute"}
+		out.Query = rendered.computeQuery
+		if rendered.table != nil {
+			if len(rendered.table.Rows) == 0 {
+				out.Note = "No results."
+			} else {
+				out.Table = rendered.table
+			}
+		}
+	}
+
+	if rendered.err != nil {
+		out.Error = rendered.err.Error()
+	}
+	return out
+}
+
+// plainHTMLRows returns rows with the same structure as the rows of the syntax highlighter, for
+// lines that aren't highlighted.
+func plainHTMLRows(startLine int32, lines []string) []template.HTML {
+	rows := make([]template.HTML, 0, len(lines))
+	for i, line := range lines {
+		rows = append(rows, template.HTML(fmt.Sprintf(
+			`<tr><td class="line" data-line="%d"></td><td class="code"><span>%s</span></td></tr>`,
+			int(startLine)+i+1,
+			html.EscapeString(line),
+		)))
+	}
+	return rows
+}
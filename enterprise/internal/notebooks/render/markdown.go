@@ -0,0 +1,136 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+func (r *Renderer) writeMarkdown(w io.Writer, doc *document) error {
+	var b strings.Builder
+	if doc.title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", escapeMarkdown(doc.title))
+	}
+	for _, block := range doc.blocks {
+		r.writeMarkdownBlock(&b, block)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (r *Renderer) writeMarkdownBlock(b *strings.Builder, rendered renderedBlock) {
+	block := rendered.block
+	switch {
+	case block.MarkdownInput != nil:
+		if text := strings.TrimSpace(rendered.markdown); text != "" {
+			b.WriteString(text)
+			b.WriteString("\n\n")
+		}
+		return
+
+	case block.QueryInput != nil:
+		fmt.Fprintf(b, "**Search** ([open](%s))\n\n", r.searchURL(rendered.query))
+		writeCodeBlock(b, "", []string{rendered.query})
+		if rendered.searchResults != nil {
+			r.writeMarkdownSearchResults(b, rendered.searchResults)
+		}
+
+	case block.FileInput != nil, block.SymbolInput != nil:
+		header := r.snippetHeader(rendered)
+		fmt.Fprintf(b, "**[%s](%s)**", escapeMarkdown(header.label), header.url)
+		if header.detail != "" {
+			fmt.Fprintf(b, " %s", escapeMarkdown(header.detail))
+		}
+		b.WriteString("\n\n")
+		if rendered.snippet != nil {
+			writeCodeBlock(b, language(rendered.snippet.FilePath), rendered.snippet.Lines)
+		}
+
+	case block.ComputeInput != nil:
+		b.WriteString("**Compute**\n\n")
+		if rendered.computeQuery != "" {
+			writeCodeBlock(b, "", []string{rendered.computeQuery})
+		}
+		if rendered.table != nil {
+			writeMarkdownTable(b, rendered.table)
+		}
+	}
+
+	if rendered.err != nil {
+		fmt.Fprintf(b, "> **Error:** %s\n\n", escapeMarkdown(oneLine(rendered.err.Error())))
+	}
+}
+
+func (r *Renderer) writeMarkdownSearchResults(b *strings.Builder, results *SearchResults) {
+	if len(results.Results) == 0 {
+		b.WriteString("_No results._\n\n")
+		return
+	}
+	for _, result := range results.Results {
+		fmt.Fprintf(b, "**[%s](%s)**\n\n", escapeMarkdown(result.Label), r.absoluteURL(result.URL))
+		for _, chunk := range result.Chunks {
+			writeCodeBlock(b, language(result.FilePath), chunk.Lines)
+		}
+	}
+	if results.LimitHit {
+		fmt.Fprintf(b, "_Showing the first %d results._\n\n", len(results.Results))
+	}
+}
+
+func writeMarkdownTable(b *strings.Builder, table *Table) {
+	if len(table.Rows) == 0 {
+		b.WriteString("_No results._\n\n")
+		return
+	}
+	b.WriteString("| Value | Count |\n| --- | ---: |\n")
+	for _, row := range table.Rows {
+		fmt.Fprintf(b, "| %s | %d |\n", escapeTableCell(row.Value), row.Count)
+	}
+	if table.OtherCount > 0 {
+		fmt.Fprintf(b, "| _Other_ | %d |\n", table.OtherCount)
+	}
+	b.WriteString("\n")
+}
+
+// writeCodeBlock writes a fenced code block, with a fence longer than any run of backticks in the
+// lines.
+func writeCodeBlock(b *strings.Builder, info string, lines []string) {
+	fence := "```"
+	for _, line := range lines {
+		for strings.Contains(line, fence) {
+			fence += "`"
+		}
+	}
+	b.WriteString(fence + info + "\n")
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString(fence + "\n\n")
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`#`, `\#`,
+	`|`, `\|`,
+)
+
+// escapeMarkdown escapes the characters of text that Markdown would otherwise interpret.
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+func escapeTableCell(text string) string {
+	return escapeMarkdown(oneLine(text))
+}
+
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,275 @@
+// Package render turns notebooks into standalone Markdown or HTML documents, so that they can be
+// checked into repositories or attached to incident reports. Query, file, symbol and compute blocks
+// are rendered with the results they show in the web app at the time of the export.
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultResultLimit is the number of search results rendered for each query block.
+const DefaultResultLimit = 20
+
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+)
+
+// ParseFormat parses the format of a rendered notebook. The empty string stands for Markdown.
+func ParseFormat(format string) (Format, error) {
+	switch strings.ToLower(format) {
+	case "", "md", string(Markdown):
+		return Markdown, nil
+	case string(HTML):
+		return HTML, nil
+	}
+	return "", errors.Errorf("unsupported notebook format %q, expected markdown or html", format)
+}
+
+// ContentType returns the MIME type of documents in the format.
+func (f Format) ContentType() string {
+	if f == HTML {
+		return "text/html; charset=utf-8"
+	}
+	return "text/markdown; charset=utf-8"
+}
+
+// Backend fetches the content shown by the blocks of a notebook, on behalf of the user rendering it.
+type Backend interface {
+	// Search runs a search query and returns at most limit results.
+	Search(ctx context.Context, query string, limit int) (*SearchResults, error)
+	// Compute runs a compute query and aggregates its results in a table.
+	Compute(ctx context.Context, query string) (*Table, error)
+	// File returns the lines of a file in the given range. A nil range stands for the whole file.
+	File(ctx context.Context, repositoryName, revision, filePath string, lineRange *notebooks.LineRange) (*Snippet, error)
+	// Symbol returns the lines around the definition of a symbol.
+	Symbol(ctx context.Context, input *notebooks.NotebookSymbolBlockInput, revision string) (*Snippet, error)
+	// MarkdownHTML renders Markdown into sanitized HTML.
+	MarkdownHTML(text string) string
+}
+
+// Snippet is a range of lines of a file.
+type Snippet struct {
+	RepositoryName string
+	Revision       string
+	FilePath       string
+	// StartLine is the 0-based line of the first line of the snippet.
+	StartLine int32
+	Lines     []string
+	// HighlightedLines are the HTML table rows of the syntax highlighted lines, or nil if the file
+	// couldn't be highlighted.
+	HighlightedLines []string
+}
+
+type SearchResults struct {
+	Results []SearchResult
+	// LimitHit is true if the query has more results than the ones returned.
+	LimitHit bool
+}
+
+// SearchResult is a single result of a query block, such as a file, a repository or a commit.
+type SearchResult struct {
+	Label string
+	// URL is the link to the result, relative to the external URL.
+	URL string
+	// FilePath is the path of the matched file, if any. It is used to guess the language of chunks.
+	FilePath string
+	Chunks   []Chunk
+}
+
+// Chunk is a range of matched lines.
+type Chunk struct {
+	// StartLine is the 0-based line of the first line of the chunk.
+	StartLine int32
+	Lines     []string
+}
+
+type Table struct {
+	Rows []TableRow
+	// OtherCount is the sum of the counts of the values that were left out of Rows.
+	OtherCount int
+}
+
+type TableRow struct {
+	Value string
+	Count int
+}
+
+type Renderer struct {
+	backend     Backend
+	externalURL string
+	resultLimit int
+}
+
+// NewRenderer returns a renderer that fetches the content of blocks from backend. Links to the
+// web app are made absolute with externalURL.
+func NewRenderer(backend Backend, externalURL string, resultLimit int) *Renderer {
+	return &Renderer{
+		backend:     backend,
+		externalURL: strings.TrimSuffix(externalURL, "/"),
+		resultLimit: resultLimit,
+	}
+}
+
+// Render writes the notebook to w in the given format. A block whose content can't be fetched is
+// rendered as an error message, so that a single missing repository doesn't fail the export.
+func (r *Renderer) Render(ctx context.Context, w io.Writer, notebook *notebooks.Notebook, format Format) error {
+	blocks := make([]renderedBlock, 0, len(notebook.Blocks))
+	for _, block := range notebook.Blocks {
+		rendered := r.renderBlock(ctx, block)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		blocks = append(blocks, rendered)
+	}
+
+	doc := &document{title: notebook.Title, blocks: blocks}
+	if format == HTML {
+		return r.writeHTML(w, doc)
+	}
+	return r.writeMarkdown(w, doc)
+}
+
+type document struct {
+	title  string
+	blocks []renderedBlock
+}
+
+// renderedBlock is a block along with the content it shows. Only the fields of the type of the
+// block are set, and err is set if its content couldn't be fetched.
+type renderedBlock struct {
+	block notebooks.NotebookBlock
+	err   error
+
+	markdown      string
+	query         string
+	searchResults *SearchResults
+	snippet       *Snippet
+	computeQuery  string
+	table         *Table
+}
+
+func (r *Renderer) renderBlock(ctx context.Context, block notebooks.NotebookBlock) renderedBlock {
+	rendered := renderedBlock{block: block}
+	var err error
+	switch {
+	case block.MarkdownInput != nil:
+		rendered.markdown = block.MarkdownInput.Text
+
+	case block.QueryInput != nil:
+		rendered.query = block.QueryInput.Text
+		rendered.searchResults, err = r.backend.Search(ctx, block.QueryInput.Text, r.resultLimit)
+
+	case block.FileInput != nil:
+		input := block.FileInput
+		rendered.snippet, err = r.backend.File(ctx, input.RepositoryName, blockRevision(block, input.Revision), input.FilePath, input.LineRange)
+
+	case block.SymbolInput != nil:
+		rendered.snippet, err = r.backend.Symbol(ctx, block.SymbolInput, blockRevision(block, block.SymbolInput.Revision))
+
+	case block.ComputeInput != nil:
+		rendered.computeQuery, err = parseComputeQuery(block.ComputeInput.Value)
+		if err == nil {
+			rendered.table, err = r.backend.Compute(ctx, rendered.computeQuery)
+		}
+
+	default:
+		err = errors.Errorf("unsupported block type %q", block.Type)
+	}
+	rendered.err = err
+	return rendered
+}
+
+// blockRevision returns the revision a file or symbol block shows: its pinned commit if it has one,
+// so that the export matches what readers of the notebook see.
+func blockRevision(block notebooks.NotebookBlock, revision *string) string {
+	if pinnedCommit := block.PinnedCommit(); pinnedCommit != "" {
+		return pinnedCommit
+	}
+	if revision != nil {
+		return *revision
+	}
+	return ""
+}
+
+// parseComputeQuery returns the query of a compute block, whose value is the JSON encoded state of
+// the compute block of the web app.
+func parseComputeQuery(value string) (string, error) {
+	var input struct {
+		ComputeQueries []string `json:"computeQueries"`
+	}
+	if err := json.Unmarshal([]byte(value), &input); err != nil {
+		return "", errors.Wrap(err, "invalid compute block value")
+	}
+	if len(input.ComputeQueries) == 0 || strings.TrimSpace(input.ComputeQueries[0]) == "" {
+		return "", errors.New("compute block has no query")
+	}
+	return input.ComputeQueries[0], nil
+}
+
+func (r *Renderer) absoluteURL(relativeURL string) string {
+	return r.externalURL + relativeURL
+}
+
+func (r *Renderer) searchURL(query string) string {
+	return r.absoluteURL("/search?q=" + url.QueryEscape(query))
+}
+
+type header struct {
+	label  string
+	url    string
+	detail string
+}
+
+// snippetHeader returns the title of a file or symbol block, which links to the lines it shows.
+func (r *Renderer) snippetHeader(rendered renderedBlock) header {
+	block := rendered.block
+	snippet := rendered.snippet
+	if snippet == nil {
+		// Link to the whole file if the lines couldn't be fetched.
+		switch {
+		case block.FileInput != nil:
+			snippet = &Snippet{RepositoryName: block.FileInput.RepositoryName, FilePath: block.FileInput.FilePath, Revision: blockRevision(block, block.FileInput.Revision)}
+		case block.SymbolInput != nil:
+			snippet = &Snippet{RepositoryName: block.SymbolInput.RepositoryName, FilePath: block.SymbolInput.FilePath, Revision: blockRevision(block, block.SymbolInput.Revision)}
+		}
+	}
+
+	location := snippet.RepositoryName + " › " + snippet.FilePath
+	if block.SymbolInput != nil {
+		return header{label: block.SymbolInput.SymbolName, url: r.snippetURL(snippet), detail: "in " + location}
+	}
+	return header{label: location, url: r.snippetURL(snippet)}
+}
+
+// snippetURL returns the link to the lines of a snippet in the web app.
+func (r *Renderer) snippetURL(snippet *Snippet) string {
+	u := "/" + snippet.RepositoryName
+	if snippet.Revision != "" {
+		u += "@" + snippet.Revision
+	}
+	u += "/-/blob/" + snippet.FilePath
+	if len(snippet.Lines) > 0 {
+		// The web app uses 1-based lines.
+		u += fmt.Sprintf("?L%d-%d", snippet.StartLine+1, snippet.StartLine+int32(len(snippet.Lines)))
+	}
+	return r.absoluteURL(u)
+}
+
+// language returns the name of the language of a file, as used in the info string of fenced code
+// blocks, or the empty string if it is unknown.
+func language(filePath string) string {
+	lang, _ := inventory.GetLanguageByFilename(filePath)
+	return strings.ReplaceAll(strings.ToLower(lang), " ", "-")
+}
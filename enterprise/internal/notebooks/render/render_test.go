@@ -0,0 +1,153 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeBackend struct {
+	searchLimit  int
+	fileRevision string
+}
+
+func (b *fakeBackend) Search(_ context.Context, query string, limit int) (*SearchResults, error) {
+	b.searchLimit = limit
+	return &SearchResults{
+		Results: []SearchResult{{
+			Label:    "github.com/sourcegraph/sourcegraph › main.go",
+			URL:      "/github.com/sourcegraph/sourcegraph/-/blob/main.go",
+			FilePath: "main.go",
+			Chunks:   []Chunk{{StartLine: 4, Lines: []string{"func main() {"}}},
+		}},
+		LimitHit: true,
+	}, nil
+}
+
+func (b *fakeBackend) Compute(_ context.Context, query string) (*Table, error) {
+	return &Table{Rows: []TableRow{{Value: "a|b", Count: 3}, {Value: "c", Count: 1}}, OtherCount: 2}, nil
+}
+
+func (b *fakeBackend) File(_ context.Context, repositoryName, revision, filePath string, lineRange *notebooks.LineRange) (*Snippet, error) {
+	b.fileRevision = revision
+	return &Snippet{
+		RepositoryName:   repositoryName,
+		Revision:         revision,
+		FilePath:         filePath,
+		StartLine:        lineRange.StartLine,
+		Lines:            []string{"x := 1", "y := \"```\""},
+		HighlightedLines: []string{`<tr><td class="line" data-line="2"></td><td class="code"><span class="hl-keyword">x</span></td></tr>`},
+	}, nil
+}
+
+func (b *fakeBackend) Symbol(_ context.Context, input *notebooks.NotebookSymbolBlockInput, revision string) (*Snippet, error) {
+	return nil, errors.New("symbol not found")
+}
+
+func (b *fakeBackend) MarkdownHTML(text string) string {
+	return "<p>" + text + "</p>"
+}
+
+func strPtr(s string) *string { return &s }
+
+var testNotebook = &notebooks.Notebook{
+	Title: "Architecture",
+	Blocks: notebooks.NotebookBlocks{
+		{ID: "1", Type: notebooks.NotebookMarkdownBlockType, MarkdownInput: &notebooks.NotebookMarkdownBlockInput{Text: "Some *text*"}},
+		{ID: "2", Type: notebooks.NotebookQueryBlockType, QueryInput: &notebooks.NotebookQueryBlockInput{Text: "func main"}},
+		{ID: "3", Type: notebooks.NotebookFileBlockType, FileInput: &notebooks.NotebookFileBlockInput{
+			RepositoryName: "github.com/sourcegraph/sourcegraph",
+			FilePath:       "a.go",
+			Revision:       strPtr("main"),
+			LineRange:      &notebooks.LineRange{StartLine: 1, EndLine: 3},
+			PinnedCommit:   strPtr("deadbeef"),
+		}},
+		{ID: "4", Type: notebooks.NotebookSymbolBlockType, SymbolInput: &notebooks.NotebookSymbolBlockInput{
+			RepositoryName: "github.com/sourcegraph/sourcegraph",
+			FilePath:       "b.go",
+			SymbolName:     "Handler",
+		}},
+		{ID: "5", Type: notebooks.NotebookComputeBlockType, ComputeInput: &notebooks.NotebookComputeBlockInput{Value: `{"computeQueries":["content:output(\\w+ -> $1)"]}`}},
+	},
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	backend := &fakeBackend{}
+	var buf bytes.Buffer
+	err := NewRenderer(backend, "https://sourcegraph.test/", 10).Render(context.Background(), &buf, testNotebook, Markdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "# Architecture\n\n" +
+		"Some *text*\n\n" +
+		"**Search** ([open](https://sourcegraph.test/search?q=func+main))\n\n" +
+		"```\nfunc main\n```\n\n" +
+		"**[github.com/sourcegraph/sourcegraph › main.go](https://sourcegraph.test/github.com/sourcegraph/sourcegraph/-/blob/main.go)**\n\n" +
+		"```go\nfunc main() {\n```\n\n" +
+		"_Showing the first 1 results._\n\n" +
+		"**[github.com/sourcegraph/sourcegraph › a.go](https://sourcegraph.test/github.com/sourcegraph/sourcegraph@deadbeef/-/blob/a.go?L2-3)**\n\n" +
+		"````go\nx := 1\ny := \"```\"\n````\n\n" +
+		"**[Handler](https://sourcegraph.test/github.com/sourcegraph/sourcegraph/-/blob/b.go)** in github.com/sourcegraph/sourcegraph › b.go\n\n" +
+		"> **Error:** symbol not found\n\n" +
+		"**Compute**\n\n" +
+		"```\ncontent:output(\\w+ -> $1)\n```\n\n" +
+		"| Value | Count |\n| --- | ---: |\n| a\\|b | 3 |\n| c | 1 |\n| _Other_ | 2 |\n\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("unexpected markdown (-want +got):\n%s", diff)
+	}
+
+	if backend.searchLimit != 10 {
+		t.Errorf("unexpected search limit, want 10, got %d", backend.searchLimit)
+	}
+	// Pinned blocks show their pinned commit.
+	if backend.fileRevision != "deadbeef" {
+		t.Errorf("unexpected file revision, want deadbeef, got %q", backend.fileRevision)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewRenderer(&fakeBackend{}, "https://sourcegraph.test", 10).Render(context.Background(), &buf, testNotebook, HTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+
+	for _, want := range []string{
+		"<title>Architecture</title>",
+		"<p>Some *text*</p>",
+		`<a href="https://sourcegraph.test/search?q=func&#43;main">Search</a>`,
+		`<td class="line" data-line="5"></td><td class="code"><span>func main() {</span></td>`,
+		`<span class="hl-keyword">x</span>`,
+		`<p class="error">symbol not found</p>`,
+		`<tr><td>a|b</td><td class="count">3</td></tr>`,
+		`<tr><td class="note">Other</td><td class="count">2</td></tr>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected HTML to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestParseComputeQuery(t *testing.T) {
+	if _, err := parseComputeQuery(`{"computeQueries":[]}`); err == nil {
+		t.Error("expected an error for a compute block without query")
+	}
+	if _, err := parseComputeQuery(`not json`); err == nil {
+		t.Error("expected an error for an invalid compute block value")
+	}
+	query, err := parseComputeQuery(`{"computeQueries":["repo:a content:output(.* -> x)"],"experimentalOptions":{}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "repo:a content:output(.* -> x)" {
+		t.Errorf("unexpected query %q", query)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 
@@ -20,6 +21,7 @@ import (
 
 var ErrNotebookNotFound = errors.New("notebook not found")
 var ErrNotebookStarNotFound = errors.New("notebook star not found")
+var ErrNotebookRevisionNotFound = errors.New("notebook revision not found")
 
 type NotebooksOrderByOption uint8
 
@@ -40,6 +42,11 @@ type ListNotebookStarsPageOptions struct {
 	After int64
 }
 
+type ListNotebookRevisionsPageOptions struct {
+	First int32
+	After int64
+}
+
 type ListNotebooksOptions struct {
 	Query             string
 	CreatorUserID     int32
@@ -81,6 +88,17 @@ type NotebooksStore interface {
 	DeleteNotebookStar(ctx context.Context, notebookID int64, userID int32) error
 	ListNotebookStars(ctx context.Context, pageOpts ListNotebookStarsPageOptions, notebookID int64) ([]*NotebookStar, error)
 	CountNotebookStars(ctx context.Context, notebookID int64) (int64, error)
+
+	GetNotebookRevision(ctx context.Context, notebookID int64, revisionID int64) (*NotebookRevision, error)
+	ListNotebookRevisions(ctx context.Context, pageOpts ListNotebookRevisionsPageOptions, notebookID int64) ([]*NotebookRevision, error)
+	CountNotebookRevisions(ctx context.Context, notebookID int64) (int64, error)
+	DiffNotebookRevisions(ctx context.Context, notebookID int64, fromRevisionID int64, toRevisionID int64) ([]NotebookBlockDiff, error)
+	RestoreNotebookRevision(ctx context.Context, notebookID int64, revisionID int64, userID int32) (*Notebook, error)
+
+	ListPinnedNotebookBlocksToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*PinnedNotebookBlock, error)
+	UpsertNotebookBlockPinCheck(ctx context.Context, check *NotebookBlockPinCheck) error
+	RecordNotebookBlockPinCheckFailure(ctx context.Context, pinned *PinnedNotebookBlock, failureMessage string) error
+	ListNotebookBlockPinChecks(ctx context.Context, notebookID int64) ([]*NotebookBlockPinCheck, error)
 }
 
 type notebooksStore struct {
@@ -337,12 +355,18 @@ INSERT INTO notebooks (title, blocks, public, creator_user_id, updater_user_id,
 RETURNING %s
 `
 
-func (s *notebooksStore) CreateNotebook(ctx context.Context, n *Notebook) (*Notebook, error) {
-	err := validateNotebookBlocks(n.Blocks)
+func (s *notebooksStore) CreateNotebook(ctx context.Context, n *Notebook) (_ *Notebook, err error) {
+	err = validateNotebookBlocks(n.Blocks)
 	if err != nil {
 		return nil, err
 	}
-	row := s.QueryRow(
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	row := tx.QueryRow(
 		ctx,
 		sqlf.Sprintf(
 			insertNotebookFmtStr,
@@ -356,7 +380,14 @@ func (s *notebooksStore) CreateNotebook(ctx context.Context, n *Notebook) (*Note
 			sqlf.Join(notebookColumns, ","),
 		),
 	)
-	return scanNotebook(row)
+	created, err := scanNotebook(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.createNotebookRevision(ctx, created, created.CreatorUserID, false); err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
 const deleteNotebookFmtStr = `DELETE FROM notebooks WHERE id = %d`
@@ -380,13 +411,33 @@ WHERE id = %d
 RETURNING %s
 `
 
+// UpdateNotebook updates the notebook and records a revision authored by the updater of the notebook.
+//
 // 🚨 SECURITY: The caller must ensure that the actor has permission to update the notebook.
 func (s *notebooksStore) UpdateNotebook(ctx context.Context, n *Notebook) (*Notebook, error) {
-	err := validateNotebookBlocks(n.Blocks)
+	return s.updateNotebook(ctx, n, true)
+}
+
+// updateNotebook updates the notebook and records a revision authored by the updater of the notebook.
+// Unless coalesce is true, the revision is never merged into the latest revision of the notebook.
+func (s *notebooksStore) updateNotebook(ctx context.Context, n *Notebook, coalesce bool) (_ *Notebook, err error) {
+	err = validateNotebookBlocks(n.Blocks)
 	if err != nil {
 		return nil, err
 	}
-	row := s.QueryRow(
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// The checks of blocks that were changed or removed don't apply anymore. This must run before
+	// the update, while the blocks of the notebook are the previous ones.
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteChangedNotebookBlockPinChecksFmtStr, n.ID, n.Blocks)); err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRow(
 		ctx,
 		sqlf.Sprintf(
 			updateNotebookFmtStr,
@@ -400,7 +451,14 @@ func (s *notebooksStore) UpdateNotebook(ctx context.Context, n *Notebook) (*Note
 			sqlf.Join(notebookColumns, ","),
 		),
 	)
-	return scanNotebook(row)
+	updated, err := scanNotebook(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.createNotebookRevision(ctx, updated, updated.UpdaterUserID, coalesce); err != nil {
+		return nil, err
+	}
+	return updated, nil
 }
 
 func scanNotebookStar(scanner dbutil.Scanner) (*NotebookStar, error) {
@@ -480,6 +538,365 @@ func (s *notebooksStore) CountNotebookStars(ctx context.Context, notebookID int6
 	return count, nil
 }
 
+var notebookRevisionColumns = []*sqlf.Query{
+	sqlf.Sprintf("notebook_revisions.id"),
+	sqlf.Sprintf("notebook_revisions.notebook_id"),
+	sqlf.Sprintf("notebook_revisions.title"),
+	sqlf.Sprintf("notebook_revisions.blocks"),
+	sqlf.Sprintf("notebook_revisions.author_user_id"),
+	sqlf.Sprintf("notebook_revisions.created_at"),
+}
+
+func scanNotebookRevision(scanner dbutil.Scanner) (*NotebookRevision, error) {
+	r := &NotebookRevision{}
+	err := scanner.Scan(
+		&r.ID,
+		&r.NotebookID,
+		&r.Title,
+		&r.Blocks,
+		&dbutil.NullInt32{N: &r.AuthorUserID},
+		&r.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// notebookRevisionCoalesceWindow is the time after the creation of a revision within which further saves
+// of the notebook by its author update it, so that editors saving on every change don't flood the history.
+// The window doesn't move with the saves, so an author who keeps editing still records a revision every
+// notebookRevisionCoalesceWindow.
+const notebookRevisionCoalesceWindow = 5 * time.Minute
+
+// maxNotebookRevisions is the number of most recent revisions kept per notebook.
+const maxNotebookRevisions = 100
+
+const latestNotebookRevisionFmtStr = `
+SELECT
+	id,
+	title = %s AND blocks = %s AS unchanged,
+	author_user_id IS NOT DISTINCT FROM %s AND created_at > now() - make_interval(secs => %s) AS recent_by_author
+FROM notebook_revisions
+WHERE notebook_id = %d
+ORDER BY id DESC
+LIMIT 1
+FOR UPDATE
+`
+
+const insertNotebookRevisionFmtStr = `
+INSERT INTO notebook_revisions (notebook_id, title, blocks, author_user_id) VALUES (%d, %s, %s, %s)
+`
+
+const coalesceNotebookRevisionFmtStr = `
+UPDATE notebook_revisions SET title = %s, blocks = %s WHERE id = %d
+`
+
+const pruneNotebookRevisionsFmtStr = `
+DELETE FROM notebook_revisions
+WHERE notebook_id = %d AND id < (
+	SELECT MIN(id) FROM (SELECT id FROM notebook_revisions WHERE notebook_id = %d ORDER BY id DESC LIMIT %d) AS kept
+)
+`
+
+// createNotebookRevision records the current content of the notebook as a revision. Saves that don't
+// change the content are not recorded, and only the latest maxNotebookRevisions revisions are kept. If
+// coalesce is true, saves by the author of the latest revision within notebookRevisionCoalesceWindow of
+// its creation update it rather than recording a new revision.
+func (s *notebooksStore) createNotebookRevision(ctx context.Context, n *Notebook, authorUserID int32, coalesce bool) error {
+	var (
+		latestID       int64
+		unchanged      bool
+		recentByAuthor bool
+	)
+	err := s.QueryRow(ctx, sqlf.Sprintf(
+		latestNotebookRevisionFmtStr,
+		n.Title,
+		n.Blocks,
+		nullInt32Column(authorUserID),
+		notebookRevisionCoalesceWindow.Seconds(),
+		n.ID,
+	)).Scan(&latestID, &unchanged, &recentByAuthor)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	hasLatest := err == nil
+
+	switch {
+	case hasLatest && unchanged:
+		return nil
+	case hasLatest && recentByAuthor && coalesce:
+		return s.Exec(ctx, sqlf.Sprintf(coalesceNotebookRevisionFmtStr, n.Title, n.Blocks, latestID))
+	}
+
+	if err := s.Exec(ctx, sqlf.Sprintf(insertNotebookRevisionFmtStr, n.ID, n.Title, n.Blocks, nullInt32Column(authorUserID))); err != nil {
+		return err
+	}
+	return s.Exec(ctx, sqlf.Sprintf(pruneNotebookRevisionsFmtStr, n.ID, n.ID, maxNotebookRevisions))
+}
+
+const getNotebookRevisionFmtStr = `
+SELECT %s
+FROM notebook_revisions
+WHERE notebook_id = %d AND id = %d
+`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) GetNotebookRevision(ctx context.Context, notebookID int64, revisionID int64) (*NotebookRevision, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(getNotebookRevisionFmtStr, sqlf.Join(notebookRevisionColumns, ","), notebookID, revisionID))
+	revision, err := scanNotebookRevision(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotebookRevisionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+const listNotebookRevisionsFmtStr = `
+SELECT %s
+FROM notebook_revisions
+WHERE notebook_id = %d
+ORDER BY id DESC
+LIMIT %d
+OFFSET %d
+`
+
+// ListNotebookRevisions lists the revisions of a notebook, newest first.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) ListNotebookRevisions(ctx context.Context, pageOpts ListNotebookRevisionsPageOptions, notebookID int64) ([]*NotebookRevision, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listNotebookRevisionsFmtStr, sqlf.Join(notebookRevisionColumns, ","), notebookID, pageOpts.First, pageOpts.After))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var revisions []*NotebookRevision
+	for rows.Next() {
+		revision, err := scanNotebookRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+const countNotebookRevisionsFmtStr = `SELECT COUNT(*) FROM notebook_revisions WHERE notebook_id = %d`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) CountNotebookRevisions(ctx context.Context, notebookID int64) (int64, error) {
+	var count int64
+	err := s.QueryRow(ctx, sqlf.Sprintf(countNotebookRevisionsFmtStr, notebookID)).Scan(&count)
+	if err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+// DiffNotebookRevisions returns the block-level changes from one revision of a notebook to another.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) DiffNotebookRevisions(ctx context.Context, notebookID int64, fromRevisionID int64, toRevisionID int64) ([]NotebookBlockDiff, error) {
+	from, err := s.GetNotebookRevision(ctx, notebookID, fromRevisionID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.GetNotebookRevision(ctx, notebookID, toRevisionID)
+	if err != nil {
+		return nil, err
+	}
+	return DiffNotebookBlocks(from.Blocks, to.Blocks), nil
+}
+
+// RestoreNotebookRevision restores the title and blocks of a notebook from one of its revisions. The
+// restore is always recorded as a new revision authored by userID, so it can be undone.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to update the notebook.
+func (s *notebooksStore) RestoreNotebookRevision(ctx context.Context, notebookID int64, revisionID int64, userID int32) (_ *Notebook, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	revision, err := tx.GetNotebookRevision(ctx, notebookID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	notebook, err := tx.GetNotebook(ctx, notebookID)
+	if err != nil {
+		return nil, err
+	}
+	notebook.Title = revision.Title
+	notebook.Blocks = revision.Blocks
+	notebook.UpdaterUserID = userID
+	return tx.updateNotebook(ctx, notebook, false)
+}
+
+const notebookBlockPinnedCommitExpr = `COALESCE(b.block->'fileInput'->>'pinnedCommit', b.block->'symbolInput'->>'pinnedCommit')`
+
+const listPinnedNotebookBlocksToCheckFmtStr = `
+SELECT notebooks.id, b.block
+FROM notebooks
+CROSS JOIN LATERAL jsonb_array_elements(notebooks.blocks) AS b(block)
+LEFT JOIN notebook_block_pin_checks checks ON checks.notebook_id = notebooks.id AND checks.block_id = b.block->>'id'
+WHERE
+	` + notebookBlockPinnedCommitExpr + ` IS NOT NULL
+	AND (checks.pinned_commit IS DISTINCT FROM ` + notebookBlockPinnedCommitExpr + ` OR GREATEST(checks.checked_at, checks.failed_at) < %s)
+ORDER BY CASE WHEN checks.pinned_commit = ` + notebookBlockPinnedCommitExpr + ` THEN GREATEST(checks.checked_at, checks.failed_at) END ASC NULLS FIRST, notebooks.id
+LIMIT %d
+`
+
+// ListPinnedNotebookBlocksToCheck lists the blocks pinned to a commit that were never checked, were
+// re-pinned to another commit since they were checked, or whose last check, successful or not, was
+// attempted before checkedBefore. Blocks are listed least recently attempted first.
+//
+// 🚨 SECURITY: The blocks of all notebooks are listed regardless of the actor, this must only be used
+// by background jobs.
+func (s *notebooksStore) ListPinnedNotebookBlocksToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*PinnedNotebookBlock, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listPinnedNotebookBlocksToCheckFmtStr, checkedBefore, limit))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var blocks []*PinnedNotebookBlock
+	for rows.Next() {
+		var (
+			pinned    PinnedNotebookBlock
+			blockJSON []byte
+		)
+		if err := rows.Scan(&pinned.NotebookID, &blockJSON); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(blockJSON, &pinned.Block); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, &pinned)
+	}
+	return blocks, rows.Err()
+}
+
+const upsertNotebookBlockPinCheckFmtStr = `
+INSERT INTO notebook_block_pin_checks (notebook_id, block_id, pinned_commit, head_commit, state, suggested_start_line, suggested_end_line, checked_at)
+VALUES (%d, %s, %s, %s, %s, %s, %s, now())
+ON CONFLICT (notebook_id, block_id) DO UPDATE SET
+	pinned_commit = EXCLUDED.pinned_commit,
+	head_commit = EXCLUDED.head_commit,
+	state = EXCLUDED.state,
+	suggested_start_line = EXCLUDED.suggested_start_line,
+	suggested_end_line = EXCLUDED.suggested_end_line,
+	checked_at = EXCLUDED.checked_at,
+	failure_message = NULL,
+	failed_at = NULL
+`
+
+// UpsertNotebookBlockPinCheck records the latest check of a pinned block, and clears its last failed check.
+func (s *notebooksStore) UpsertNotebookBlockPinCheck(ctx context.Context, check *NotebookBlockPinCheck) error {
+	var suggestedStartLine, suggestedEndLine *int32
+	if check.SuggestedLineRange != nil {
+		suggestedStartLine, suggestedEndLine = &check.SuggestedLineRange.StartLine, &check.SuggestedLineRange.EndLine
+	}
+	return s.Exec(ctx, sqlf.Sprintf(
+		upsertNotebookBlockPinCheckFmtStr,
+		check.NotebookID,
+		check.BlockID,
+		check.PinnedCommit,
+		check.HeadCommit,
+		check.State,
+		suggestedStartLine,
+		suggestedEndLine,
+	))
+}
+
+// The last successful check of the block is kept if the block is still pinned to the same commit.
+const upsertNotebookBlockPinCheckFailureFmtStr = `
+INSERT INTO notebook_block_pin_checks (notebook_id, block_id, pinned_commit, failure_message, failed_at)
+VALUES (%d, %s, %s, %s, now())
+ON CONFLICT (notebook_id, block_id) DO UPDATE SET
+	pinned_commit = EXCLUDED.pinned_commit,
+	head_commit = CASE WHEN notebook_block_pin_checks.pinned_commit = EXCLUDED.pinned_commit THEN notebook_block_pin_checks.head_commit END,
+	state = CASE WHEN notebook_block_pin_checks.pinned_commit = EXCLUDED.pinned_commit THEN notebook_block_pin_checks.state END,
+	suggested_start_line = CASE WHEN notebook_block_pin_checks.pinned_commit = EXCLUDED.pinned_commit THEN notebook_block_pin_checks.suggested_start_line END,
+	suggested_end_line = CASE WHEN notebook_block_pin_checks.pinned_commit = EXCLUDED.pinned_commit THEN notebook_block_pin_checks.suggested_end_line END,
+	checked_at = CASE WHEN notebook_block_pin_checks.pinned_commit = EXCLUDED.pinned_commit THEN notebook_block_pin_checks.checked_at END,
+	failure_message = EXCLUDED.failure_message,
+	failed_at = EXCLUDED.failed_at
+`
+
+// RecordNotebookBlockPinCheckFailure records that checking a pinned block failed, so that the block
+// moves to the back of the queue of blocks to check.
+func (s *notebooksStore) RecordNotebookBlockPinCheckFailure(ctx context.Context, pinned *PinnedNotebookBlock, failureMessage string) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		upsertNotebookBlockPinCheckFailureFmtStr,
+		pinned.NotebookID,
+		pinned.Block.ID,
+		pinned.Block.PinnedCommit(),
+		failureMessage,
+	))
+}
+
+const listNotebookBlockPinChecksFmtStr = `
+SELECT notebook_id, block_id, pinned_commit, head_commit, state, suggested_start_line, suggested_end_line, checked_at
+FROM notebook_block_pin_checks
+WHERE notebook_id = %d AND state IS NOT NULL
+ORDER BY block_id
+`
+
+const deleteChangedNotebookBlockPinChecksFmtStr = `
+DELETE FROM notebook_block_pin_checks checks
+WHERE checks.notebook_id = %d AND NOT EXISTS (
+	SELECT 1
+	FROM notebooks
+	CROSS JOIN LATERAL jsonb_array_elements(notebooks.blocks) AS old(block)
+	CROSS JOIN LATERAL jsonb_array_elements(%s::jsonb) AS new(block)
+	WHERE
+		notebooks.id = checks.notebook_id
+		AND old.block->>'id' = checks.block_id
+		AND new.block->>'id' = checks.block_id
+		AND new.block = old.block
+)
+`
+
+// ListNotebookBlockPinChecks lists the latest successful checks of the pinned blocks of a notebook.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) ListNotebookBlockPinChecks(ctx context.Context, notebookID int64) ([]*NotebookBlockPinCheck, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listNotebookBlockPinChecksFmtStr, notebookID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var checks []*NotebookBlockPinCheck
+	for rows.Next() {
+		var (
+			check                                NotebookBlockPinCheck
+			suggestedStartLine, suggestedEndLine *int32
+		)
+		err := rows.Scan(
+			&check.NotebookID,
+			&check.BlockID,
+			&check.PinnedCommit,
+			&check.HeadCommit,
+			&check.State,
+			&suggestedStartLine,
+			&suggestedEndLine,
+			&check.CheckedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if suggestedStartLine != nil && suggestedEndLine != nil {
+			check.SuggestedLineRange = &LineRange{StartLine: *suggestedStartLine, EndLine: *suggestedEndLine}
+		}
+		checks = append(checks, &check)
+	}
+	return checks, rows.Err()
+}
+
 func nullInt32Column(n int32) *int32 {
 	if n == 0 {
 		return nil
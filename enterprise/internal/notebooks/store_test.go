@@ -2,8 +2,10 @@ package notebooks
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -118,6 +120,319 @@ func TestUpdateNotebook(t *testing.T) {
 	}
 }
 
+func TestNotebookRevisions(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+	n := Notebooks(db)
+
+	creator, err := u.Create(ctx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	updater, err := u.Create(ctx, database.NewUser{Username: "u2", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	queryBlock := NotebookBlock{ID: "1", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{"repo:a b"}}
+	markdownBlock := NotebookBlock{ID: "2", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{"# Title"}}
+	notebook := notebookByUser(&Notebook{Title: "Notebook Title", Blocks: NotebookBlocks{queryBlock}, Public: true}, creator.ID)
+	createdNotebook, err := n.CreateNotebook(ctx, notebook)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createdNotebook.Title = "Notebook Title 1"
+	createdNotebook.Blocks = NotebookBlocks{markdownBlock}
+	createdNotebook.UpdaterUserID = updater.ID
+	if _, err := n.UpdateNotebook(ctx, createdNotebook); err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, err := n.ListNotebookRevisions(ctx, ListNotebookRevisionsPageOptions{First: 10}, createdNotebook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("wanted 2 revisions, got %d", len(revisions))
+	}
+	latest, first := revisions[0], revisions[1]
+	if first.Title != "Notebook Title" || first.AuthorUserID != creator.ID || !reflect.DeepEqual(first.Blocks, NotebookBlocks{queryBlock}) {
+		t.Fatalf("unexpected first revision %+v", first)
+	}
+	if latest.Title != "Notebook Title 1" || latest.AuthorUserID != updater.ID {
+		t.Fatalf("unexpected latest revision %+v", latest)
+	}
+
+	count, err := n.CountNotebookRevisions(ctx, createdNotebook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("wanted 2 revisions, got %d", count)
+	}
+
+	diff, err := n.DiffNotebookRevisions(ctx, createdNotebook.ID, first.ID, latest.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDiff := []NotebookBlockDiff{
+		{Type: NotebookBlockAdded, BlockID: "2", New: &markdownBlock},
+		{Type: NotebookBlockRemoved, BlockID: "1", Old: &queryBlock},
+	}
+	if !reflect.DeepEqual(wantDiff, diff) {
+		t.Fatalf("wanted %+v diff, got %+v", wantDiff, diff)
+	}
+
+	restored, err := n.RestoreNotebookRevision(ctx, createdNotebook.ID, first.ID, creator.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Title != "Notebook Title" || !reflect.DeepEqual(restored.Blocks, NotebookBlocks{queryBlock}) {
+		t.Fatalf("unexpected restored notebook %+v", restored)
+	}
+	count, err = n.CountNotebookRevisions(ctx, createdNotebook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Fatalf("wanted the restore to be recorded as a revision, got %d revisions", count)
+	}
+
+	_, err = n.GetNotebookRevision(ctx, createdNotebook.ID+1, first.ID)
+	if !errors.Is(err, ErrNotebookRevisionNotFound) {
+		t.Fatalf("want ErrNotebookRevisionNotFound error, got %+v", err)
+	}
+}
+
+func TestNotebookRevisionsHistorySize(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+	n := Notebooks(db)
+
+	creator, err := u.Create(ctx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	updater, err := u.Create(ctx, database.NewUser{Username: "u2", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	queryBlock := NotebookBlock{ID: "1", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{"repo:a b"}}
+	notebook := notebookByUser(&Notebook{Title: "Notebook Title", Blocks: NotebookBlocks{queryBlock}, Public: true}, creator.ID)
+	createdNotebook, err := n.CreateNotebook(ctx, notebook)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update := func(title string, userID int32) {
+		t.Helper()
+		createdNotebook.Title = title
+		createdNotebook.UpdaterUserID = userID
+		if _, err := n.UpdateNotebook(ctx, createdNotebook); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertRevisions := func(want int64, wantLatestTitle string) {
+		t.Helper()
+		count, err := n.CountNotebookRevisions(ctx, createdNotebook.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != want {
+			t.Fatalf("wanted %d revisions, got %d", want, count)
+		}
+		revisions, err := n.ListNotebookRevisions(ctx, ListNotebookRevisionsPageOptions{First: 1}, createdNotebook.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if revisions[0].Title != wantLatestTitle {
+			t.Fatalf("wanted latest revision title %q, got %q", wantLatestTitle, revisions[0].Title)
+		}
+	}
+
+	// Saving unchanged content doesn't record a revision.
+	update("Notebook Title", updater.ID)
+	assertRevisions(1, "Notebook Title")
+
+	// Saves by the same author in quick succession update the latest revision.
+	update("Notebook Title 1", creator.ID)
+	update("Notebook Title 2", creator.ID)
+	assertRevisions(1, "Notebook Title 2")
+
+	// Saves by other authors record new revisions.
+	update("Notebook Title 3", updater.ID)
+	assertRevisions(2, "Notebook Title 3")
+
+	// Restores always record a new revision, so the content before the restore isn't lost.
+	update("Notebook Title 3 edited", updater.ID)
+	assertRevisions(2, "Notebook Title 3 edited")
+	revisions, err := n.ListNotebookRevisions(ctx, ListNotebookRevisionsPageOptions{First: 2}, createdNotebook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.RestoreNotebookRevision(ctx, createdNotebook.ID, revisions[1].ID, updater.ID); err != nil {
+		t.Fatal(err)
+	}
+	assertRevisions(3, "Notebook Title 2")
+
+	// Only the most recent revisions are kept.
+	for i := 0; i < maxNotebookRevisions; i++ {
+		userID := creator.ID
+		if i%2 == 1 {
+			userID = updater.ID
+		}
+		update(fmt.Sprintf("Notebook Title %d", i+4), userID)
+	}
+	assertRevisions(maxNotebookRevisions, fmt.Sprintf("Notebook Title %d", maxNotebookRevisions+3))
+}
+
+func TestNotebookBlockPinChecks(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+	n := Notebooks(db)
+
+	user, err := u.Create(ctx, database.NewUser{Username: "u", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	pinnedCommit := "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+	pinnedBlock := NotebookBlock{ID: "2", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{
+		RepositoryName: "github.com/sourcegraph/sourcegraph",
+		FilePath:       "README.md",
+		LineRange:      &LineRange{StartLine: 1, EndLine: 3},
+		PinnedCommit:   &pinnedCommit,
+	}}
+	blocks := NotebookBlocks{
+		{ID: "1", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{RepositoryName: "github.com/sourcegraph/sourcegraph", FilePath: "README.md"}},
+		pinnedBlock,
+	}
+	notebook, err := n.CreateNotebook(ctx, notebookByUser(&Notebook{Title: "Notebook Title", Blocks: blocks, Public: true}, user.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pinned, err := n.ListPinnedNotebookBlocksToCheck(ctx, time.Now(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPinned := []*PinnedNotebookBlock{{NotebookID: notebook.ID, Block: pinnedBlock}}
+	if !reflect.DeepEqual(wantPinned, pinned) {
+		t.Fatalf("wanted %+v pinned blocks, got %+v", wantPinned, pinned)
+	}
+
+	// A failed check moves the block to the back of the queue until the failure is old enough.
+	if err := n.RecordNotebookBlockPinCheckFailure(ctx, pinned[0], "gitserver unavailable"); err != nil {
+		t.Fatal(err)
+	}
+	pinned, err = n.ListPinnedNotebookBlocksToCheck(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pinned) != 0 {
+		t.Fatalf("wanted no pinned blocks to check after a failure, got %+v", pinned)
+	}
+	pinned, err = n.ListPinnedNotebookBlocksToCheck(ctx, time.Now().Add(time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(wantPinned, pinned) {
+		t.Fatalf("wanted %+v pinned blocks, got %+v", wantPinned, pinned)
+	}
+	if checks, err := n.ListNotebookBlockPinChecks(ctx, notebook.ID); err != nil {
+		t.Fatal(err)
+	} else if len(checks) != 0 {
+		t.Fatalf("wanted no pin checks after a failure, got %+v", checks)
+	}
+
+	// A check recorded for a commit the block is no longer pinned to doesn't count.
+	if err := n.UpsertNotebookBlockPinCheck(ctx, &NotebookBlockPinCheck{
+		NotebookID:   notebook.ID,
+		BlockID:      "2",
+		PinnedCommit: "0000000000000000000000000000000000000000",
+		HeadCommit:   "cafebabecafebabecafebabecafebabecafebabe",
+		State:        NotebookBlockPinUnavailable,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	pinned, err = n.ListPinnedNotebookBlocksToCheck(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(wantPinned, pinned) {
+		t.Fatalf("wanted %+v pinned blocks, got %+v", wantPinned, pinned)
+	}
+
+	check := &NotebookBlockPinCheck{
+		NotebookID:         notebook.ID,
+		BlockID:            "2",
+		PinnedCommit:       pinnedCommit,
+		HeadCommit:         "cafebabecafebabecafebabecafebabecafebabe",
+		State:              NotebookBlockPinMoved,
+		SuggestedLineRange: &LineRange{StartLine: 5, EndLine: 7},
+	}
+	if err := n.UpsertNotebookBlockPinCheck(ctx, check); err != nil {
+		t.Fatal(err)
+	}
+
+	// The block was just checked, so it doesn't need to be checked again yet.
+	pinned, err = n.ListPinnedNotebookBlocksToCheck(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pinned) != 0 {
+		t.Fatalf("wanted no pinned blocks to check, got %+v", pinned)
+	}
+
+	checks, err := n.ListNotebookBlockPinChecks(ctx, notebook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checks) != 1 {
+		t.Fatalf("wanted 1 pin check, got %d", len(checks))
+	}
+	check.CheckedAt = checks[0].CheckedAt
+	if !reflect.DeepEqual(check, checks[0]) {
+		t.Fatalf("wanted %+v pin check, got %+v", check, checks[0])
+	}
+
+	// Saving the notebook keeps the checks of the blocks that didn't change.
+	if _, err := n.UpdateNotebook(ctx, notebook); err != nil {
+		t.Fatal(err)
+	}
+	checks, err = n.ListNotebookBlockPinChecks(ctx, notebook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checks) != 1 {
+		t.Fatalf("wanted 1 pin check after saving, got %d", len(checks))
+	}
+
+	// Changing a pinned block discards its check.
+	notebook.Blocks[1].FileInput.LineRange = &LineRange{StartLine: 5, EndLine: 7}
+	if _, err := n.UpdateNotebook(ctx, notebook); err != nil {
+		t.Fatal(err)
+	}
+	checks, err = n.ListNotebookBlockPinChecks(ctx, notebook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checks) != 0 {
+		t.Fatalf("wanted no pin checks after changing the block, got %+v", checks)
+	}
+}
+
 func TestDeleteNotebook(t *testing.T) {
 	t.Parallel()
 	logger := logtest.Scoped(t)
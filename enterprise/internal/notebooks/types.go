@@ -35,6 +35,9 @@ type NotebookFileBlockInput struct {
 	FilePath       string     `json:"filePath"`
 	Revision       *string    `json:"revision,omitempty"`
 	LineRange      *LineRange `json:"lineRange,omitempty"`
+
+	// PinnedCommit is the full SHA of the commit the block is pinned to, if any.
+	PinnedCommit *string `json:"pinnedCommit,omitempty"`
 }
 
 type NotebookSymbolBlockInput struct {
@@ -45,6 +48,9 @@ type NotebookSymbolBlockInput struct {
 	SymbolName          string  `json:"symbolName"`
 	SymbolContainerName string  `json:"symbolContainerName"`
 	SymbolKind          string  `json:"symbolKind"`
+
+	// PinnedCommit is the full SHA of the commit the block is pinned to, if any.
+	PinnedCommit *string `json:"pinnedCommit,omitempty"`
 }
 
 type NotebookComputeBlockInput struct {
@@ -63,6 +69,18 @@ type NotebookBlock struct {
 
 type NotebookBlocks []NotebookBlock
 
+// PinnedCommit returns the commit a file or symbol block is pinned to, or the empty string if the
+// block isn't pinned.
+func (b *NotebookBlock) PinnedCommit() string {
+	switch {
+	case b.FileInput != nil && b.FileInput.PinnedCommit != nil:
+		return *b.FileInput.PinnedCommit
+	case b.SymbolInput != nil && b.SymbolInput.PinnedCommit != nil:
+		return *b.SymbolInput.PinnedCommit
+	}
+	return ""
+}
+
 type Notebook struct {
 	ID              int64
 	Title           string
@@ -81,3 +99,70 @@ type NotebookStar struct {
 	UserID     int32
 	CreatedAt  time.Time
 }
+
+// NotebookRevision is a snapshot of the title and blocks of a notebook. A revision is recorded every
+// time a notebook is created, updated or restored.
+type NotebookRevision struct {
+	ID           int64
+	NotebookID   int64
+	Title        string
+	Blocks       NotebookBlocks
+	AuthorUserID int32
+	CreatedAt    time.Time
+}
+
+type NotebookBlockDiffType string
+
+const (
+	NotebookBlockAdded     NotebookBlockDiffType = "added"
+	NotebookBlockRemoved   NotebookBlockDiffType = "removed"
+	NotebookBlockModified  NotebookBlockDiffType = "modified"
+	NotebookBlockMoved     NotebookBlockDiffType = "moved"
+	NotebookBlockUnchanged NotebookBlockDiffType = "unchanged"
+)
+
+// NotebookBlockDiff describes how a single block changed between two revisions. Old is nil for added
+// blocks and New is nil for removed blocks.
+type NotebookBlockDiff struct {
+	Type    NotebookBlockDiffType
+	BlockID string
+	Old     *NotebookBlock
+	New     *NotebookBlock
+}
+
+// NotebookBlockPinState tells how the code shown by a pinned block compares with the default branch
+// of its repository.
+type NotebookBlockPinState string
+
+const (
+	// NotebookBlockPinCurrent means the pinned lines are unchanged and at the same position on the
+	// default branch.
+	NotebookBlockPinCurrent NotebookBlockPinState = "current"
+	// NotebookBlockPinMoved means the pinned lines are unchanged but at a different position on the
+	// default branch.
+	NotebookBlockPinMoved NotebookBlockPinState = "moved"
+	// NotebookBlockPinChanged means some of the pinned lines were edited or removed on the default
+	// branch.
+	NotebookBlockPinChanged NotebookBlockPinState = "changed"
+	// NotebookBlockPinUnavailable means the repository or the pinned commit doesn't exist anymore.
+	NotebookBlockPinUnavailable NotebookBlockPinState = "unavailable"
+)
+
+// NotebookBlockPinCheck is the result of comparing a pinned block with the default branch.
+type NotebookBlockPinCheck struct {
+	NotebookID   int64
+	BlockID      string
+	PinnedCommit string
+	HeadCommit   string
+	State        NotebookBlockPinState
+	// SuggestedLineRange is the range of the pinned lines on the default branch. It is nil when
+	// the block has no line range or none of its lines remain.
+	SuggestedLineRange *LineRange
+	CheckedAt          time.Time
+}
+
+// PinnedNotebookBlock is a block pinned to a commit, along with the notebook it belongs to.
+type PinnedNotebookBlock struct {
+	NotebookID int64
+	Block      NotebookBlock
+}
@@ -1,6 +1,9 @@
 package notebooks
 
-import "github.com/sourcegraph/sourcegraph/lib/errors"
+import (
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
 
 func validateNotebookBlock(block NotebookBlock) error {
 	if block.Type != NotebookQueryBlockType &&
@@ -27,6 +30,10 @@ func validateNotebookBlock(block NotebookBlock) error {
 		return errors.Errorf("symbol block line context cannot be negative, block id: %s", block.ID)
 	}
 
+	if pinnedCommit := block.PinnedCommit(); pinnedCommit != "" && !gitdomain.IsAbsoluteRevision(pinnedCommit) {
+		return errors.Errorf("block must be pinned to a full commit SHA, block id: %s", block.ID)
+	}
+
 	return nil
 }
 
@@ -5,6 +5,7 @@ import (
 )
 
 func TestNotebookBlocksValidation(t *testing.T) {
+	pinnedBranch := "main"
 	tests := []struct {
 		blocks  NotebookBlocks
 		wantErr string
@@ -23,6 +24,9 @@ func TestNotebookBlocksValidation(t *testing.T) {
 			{ID: "id1", SymbolInput: &NotebookSymbolBlockInput{LineContext: -10}, Type: NotebookSymbolBlockType},
 		}, wantErr: "symbol block line context cannot be negative, block id: id1"},
 		{blocks: NotebookBlocks{{ID: "id1", Type: NotebookComputeBlockType}}, wantErr: "invalid compute block with id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", FileInput: &NotebookFileBlockInput{PinnedCommit: &pinnedBranch}, Type: NotebookFileBlockType},
+		}, wantErr: "block must be pinned to a full commit SHA, block id: id1"},
 	}
 
 	for _, tt := range tests {
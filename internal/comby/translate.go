@@ -190,3 +190,26 @@ func StructuralPatToRegexpQuery(pattern string, shortcircuit bool) string {
 	}
 	return "(?:" + strings.Join(pieces, ")(?:.|\\s)*?(?:") + ")"
 }
+
+// StructuralPatToLiterals returns the strings and the regular expressions
+// that must appear in any content matching a comby pattern. Literals are split
+// on whitespace, since whitespace in a pattern matches any whitespace. The
+// regular expressions are those of regexp holes like :[x~re].
+//
+// Example:
+// "ParseInt(:[args]) if :[x~err\d]" -> ["ParseInt(", ")", "if"], ["err\d"]
+func StructuralPatToLiterals(pattern string) (literals, holeRegexps []string) {
+	for _, term := range parseTemplate([]byte(pattern)) {
+		switch v := term.(type) {
+		case Literal:
+			literals = append(literals, strings.Fields(v.String())...)
+		case Hole:
+			if matchRegexpPattern.MatchString(v.String()) {
+				holeRegexps = append(holeRegexps, matchRegexpPattern.ReplaceAllString(v.String(), `$2`))
+			}
+		default:
+			panic("Unreachable")
+		}
+	}
+	return literals, holeRegexps
+}
@@ -119,3 +119,44 @@ func TestStructuralPatToRegexpQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestStructuralPatToLiterals(t *testing.T) {
+	cases := []struct {
+		Name            string
+		Pattern         string
+		WantLiterals    []string
+		WantHoleRegexps []string
+	}{
+		{
+			Name:    "Just a hole",
+			Pattern: ":[1]",
+		},
+		{
+			Name:         "Literals are split on whitespace",
+			Pattern:      "ParseInt(:[stuff],    :[x])\n  if err ",
+			WantLiterals: []string{"ParseInt(", ",", ")", "if", "err"},
+		},
+		{
+			Name:            "Regex holes",
+			Pattern:         `foo(:[x~[a-z]+], :[y])`,
+			WantLiterals:    []string{"foo(", ",", ")"},
+			WantHoleRegexps: []string{"[a-z]+"},
+		},
+		{
+			Name:         "Array-like preserved",
+			Pattern:      `[:[x]]`,
+			WantLiterals: []string{"[", "]"},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			literals, holeRegexps := StructuralPatToLiterals(tt.Pattern)
+			if diff := cmp.Diff(tt.WantLiterals, literals); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tt.WantHoleRegexps, holeRegexps); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
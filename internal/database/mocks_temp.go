@@ -31453,9 +31453,25 @@ type MockSearchContextsStore struct {
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *SearchContextsStoreHandleFunc
+	// ListDynamicSearchContextsToRefreshFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// ListDynamicSearchContextsToRefresh.
+	ListDynamicSearchContextsToRefreshFunc *SearchContextsStoreListDynamicSearchContextsToRefreshFunc
+	// ListSearchContextMembershipEventsFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// ListSearchContextMembershipEvents.
+	ListSearchContextMembershipEventsFunc *SearchContextsStoreListSearchContextMembershipEventsFunc
 	// ListSearchContextsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListSearchContexts.
 	ListSearchContextsFunc *SearchContextsStoreListSearchContextsFunc
+	// MarkDynamicSearchContextRefreshAttemptedFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// MarkDynamicSearchContextRefreshAttempted.
+	MarkDynamicSearchContextRefreshAttemptedFunc *SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc
+	// SetDynamicSearchContextMembershipFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// SetDynamicSearchContextMembership.
+	SetDynamicSearchContextMembershipFunc *SearchContextsStoreSetDynamicSearchContextMembershipFunc
 	// SetSearchContextRepositoryRevisionsFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// SetSearchContextRepositoryRevisions.
@@ -31524,11 +31540,31 @@ func NewMockSearchContextsStore() *MockSearchContextsStore {
 				return
 			},
 		},
+		ListDynamicSearchContextsToRefreshFunc: &SearchContextsStoreListDynamicSearchContextsToRefreshFunc{
+			defaultHook: func(context.Context, time.Time, int32) (r0 []*types.SearchContext, r1 error) {
+				return
+			},
+		},
+		ListSearchContextMembershipEventsFunc: &SearchContextsStoreListSearchContextMembershipEventsFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 []*types.SearchContextMembershipEvent, r1 error) {
+				return
+			},
+		},
 		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
 			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) (r0 []*types.SearchContext, r1 error) {
 				return
 			},
 		},
+		MarkDynamicSearchContextRefreshAttemptedFunc: &SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
+		SetDynamicSearchContextMembershipFunc: &SearchContextsStoreSetDynamicSearchContextMembershipFunc{
+			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) (r0 []*types.SearchContextMembershipEvent, r1 error) {
+				return
+			},
+		},
 		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
 			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) (r0 error) {
 				return
@@ -31602,11 +31638,31 @@ func NewStrictMockSearchContextsStore() *MockSearchContextsStore {
 				panic("unexpected invocation of MockSearchContextsStore.Handle")
 			},
 		},
+		ListDynamicSearchContextsToRefreshFunc: &SearchContextsStoreListDynamicSearchContextsToRefreshFunc{
+			defaultHook: func(context.Context, time.Time, int32) ([]*types.SearchContext, error) {
+				panic("unexpected invocation of MockSearchContextsStore.ListDynamicSearchContextsToRefresh")
+			},
+		},
+		ListSearchContextMembershipEventsFunc: &SearchContextsStoreListSearchContextMembershipEventsFunc{
+			defaultHook: func(context.Context, int64, int64) ([]*types.SearchContextMembershipEvent, error) {
+				panic("unexpected invocation of MockSearchContextsStore.ListSearchContextMembershipEvents")
+			},
+		},
 		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
 			defaultHook: func(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error) {
 				panic("unexpected invocation of MockSearchContextsStore.ListSearchContexts")
 			},
 		},
+		MarkDynamicSearchContextRefreshAttemptedFunc: &SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockSearchContextsStore.MarkDynamicSearchContextRefreshAttempted")
+			},
+		},
+		SetDynamicSearchContextMembershipFunc: &SearchContextsStoreSetDynamicSearchContextMembershipFunc{
+			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) ([]*types.SearchContextMembershipEvent, error) {
+				panic("unexpected invocation of MockSearchContextsStore.SetDynamicSearchContextMembership")
+			},
+		},
 		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
 			defaultHook: func(context.Context, int64, []*types.SearchContextRepositoryRevisions) error {
 				panic("unexpected invocation of MockSearchContextsStore.SetSearchContextRepositoryRevisions")
@@ -31660,9 +31716,21 @@ func NewMockSearchContextsStoreFrom(i SearchContextsStore) *MockSearchContextsSt
 		HandleFunc: &SearchContextsStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
+		ListDynamicSearchContextsToRefreshFunc: &SearchContextsStoreListDynamicSearchContextsToRefreshFunc{
+			defaultHook: i.ListDynamicSearchContextsToRefresh,
+		},
+		ListSearchContextMembershipEventsFunc: &SearchContextsStoreListSearchContextMembershipEventsFunc{
+			defaultHook: i.ListSearchContextMembershipEvents,
+		},
 		ListSearchContextsFunc: &SearchContextsStoreListSearchContextsFunc{
 			defaultHook: i.ListSearchContexts,
 		},
+		MarkDynamicSearchContextRefreshAttemptedFunc: &SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc{
+			defaultHook: i.MarkDynamicSearchContextRefreshAttempted,
+		},
+		SetDynamicSearchContextMembershipFunc: &SearchContextsStoreSetDynamicSearchContextMembershipFunc{
+			defaultHook: i.SetDynamicSearchContextMembership,
+		},
 		SetSearchContextRepositoryRevisionsFunc: &SearchContextsStoreSetSearchContextRepositoryRevisionsFunc{
 			defaultHook: i.SetSearchContextRepositoryRevisions,
 		},
@@ -32765,6 +32833,237 @@ func (c SearchContextsStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// SearchContextsStoreListDynamicSearchContextsToRefreshFunc describes the
+// behavior when the ListDynamicSearchContextsToRefresh method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreListDynamicSearchContextsToRefreshFunc struct {
+	defaultHook func(context.Context, time.Time, int32) ([]*types.SearchContext, error)
+	hooks       []func(context.Context, time.Time, int32) ([]*types.SearchContext, error)
+	history     []SearchContextsStoreListDynamicSearchContextsToRefreshFuncCall
+	mutex       sync.Mutex
+}
+
+// ListDynamicSearchContextsToRefresh delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) ListDynamicSearchContextsToRefresh(v0 context.Context, v1 time.Time, v2 int32) ([]*types.SearchContext, error) {
+	r0, r1 := m.ListDynamicSearchContextsToRefreshFunc.nextHook()(v0, v1, v2)
+	m.ListDynamicSearchContextsToRefreshFunc.appendCall(SearchContextsStoreListDynamicSearchContextsToRefreshFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListDynamicSearchContextsToRefresh method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreListDynamicSearchContextsToRefreshFunc) SetDefaultHook(hook func(context.Context, time.Time, int32) ([]*types.SearchContext, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListDynamicSearchContextsToRefresh method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreListDynamicSearchContextsToRefreshFunc) PushHook(hook func(context.Context, time.Time, int32) ([]*types.SearchContext, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreListDynamicSearchContextsToRefreshFunc) SetDefaultReturn(r0 []*types.SearchContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time, int32) ([]*types.SearchContext, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreListDynamicSearchContextsToRefreshFunc) PushReturn(r0 []*types.SearchContext, r1 error) {
+	f.PushHook(func(context.Context, time.Time, int32) ([]*types.SearchContext, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreListDynamicSearchContextsToRefreshFunc) nextHook() func(context.Context, time.Time, int32) ([]*types.SearchContext, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreListDynamicSearchContextsToRefreshFunc) appendCall(r0 SearchContextsStoreListDynamicSearchContextsToRefreshFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreListDynamicSearchContextsToRefreshFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreListDynamicSearchContextsToRefreshFunc) History() []SearchContextsStoreListDynamicSearchContextsToRefreshFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreListDynamicSearchContextsToRefreshFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreListDynamicSearchContextsToRefreshFuncCall is an
+// object that describes an invocation of method
+// ListDynamicSearchContextsToRefresh on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreListDynamicSearchContextsToRefreshFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.SearchContext
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreListDynamicSearchContextsToRefreshFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreListDynamicSearchContextsToRefreshFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SearchContextsStoreListSearchContextMembershipEventsFunc describes the
+// behavior when the ListSearchContextMembershipEvents method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreListSearchContextMembershipEventsFunc struct {
+	defaultHook func(context.Context, int64, int64) ([]*types.SearchContextMembershipEvent, error)
+	hooks       []func(context.Context, int64, int64) ([]*types.SearchContextMembershipEvent, error)
+	history     []SearchContextsStoreListSearchContextMembershipEventsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListSearchContextMembershipEvents delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) ListSearchContextMembershipEvents(v0 context.Context, v1 int64, v2 int64) ([]*types.SearchContextMembershipEvent, error) {
+	r0, r1 := m.ListSearchContextMembershipEventsFunc.nextHook()(v0, v1, v2)
+	m.ListSearchContextMembershipEventsFunc.appendCall(SearchContextsStoreListSearchContextMembershipEventsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListSearchContextMembershipEvents method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreListSearchContextMembershipEventsFunc) SetDefaultHook(hook func(context.Context, int64, int64) ([]*types.SearchContextMembershipEvent, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListSearchContextMembershipEvents method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreListSearchContextMembershipEventsFunc) PushHook(hook func(context.Context, int64, int64) ([]*types.SearchContextMembershipEvent, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreListSearchContextMembershipEventsFunc) SetDefaultReturn(r0 []*types.SearchContextMembershipEvent, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) ([]*types.SearchContextMembershipEvent, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreListSearchContextMembershipEventsFunc) PushReturn(r0 []*types.SearchContextMembershipEvent, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) ([]*types.SearchContextMembershipEvent, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreListSearchContextMembershipEventsFunc) nextHook() func(context.Context, int64, int64) ([]*types.SearchContextMembershipEvent, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreListSearchContextMembershipEventsFunc) appendCall(r0 SearchContextsStoreListSearchContextMembershipEventsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreListSearchContextMembershipEventsFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreListSearchContextMembershipEventsFunc) History() []SearchContextsStoreListSearchContextMembershipEventsFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreListSearchContextMembershipEventsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreListSearchContextMembershipEventsFuncCall is an object
+// that describes an invocation of method ListSearchContextMembershipEvents
+// on an instance of MockSearchContextsStore.
+type SearchContextsStoreListSearchContextMembershipEventsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.SearchContextMembershipEvent
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreListSearchContextMembershipEventsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreListSearchContextMembershipEventsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SearchContextsStoreListSearchContextsFunc describes the behavior when the
 // ListSearchContexts method of the parent MockSearchContextsStore instance
 // is invoked.
@@ -32994,6 +33293,230 @@ func (c SearchContextsStoreSetSearchContextRepositoryRevisionsFuncCall) Results(
 	return []interface{}{c.Result0}
 }
 
+// SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc describes
+// the behavior when the MarkDynamicSearchContextRefreshAttempted method of the
+// parent MockSearchContextsStore instance is invoked.
+type SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFuncCall
+	mutex       sync.Mutex
+}
+
+// MarkDynamicSearchContextRefreshAttempted delegates to the next hook function
+// in the queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) MarkDynamicSearchContextRefreshAttempted(v0 context.Context, v1 int64) error {
+	r0 := m.MarkDynamicSearchContextRefreshAttemptedFunc.nextHook()(v0, v1)
+	m.MarkDynamicSearchContextRefreshAttemptedFunc.appendCall(SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// MarkDynamicSearchContextRefreshAttempted method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkDynamicSearchContextRefreshAttempted method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the given
+// values.
+func (f *SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc) appendCall(r0 SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFunc) History() []SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFuncCall is an
+// object that describes an invocation of method
+// MarkDynamicSearchContextRefreshAttempted on an instance of
+// MockSearchContextsStore.
+type SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this invocation.
+func (c SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreMarkDynamicSearchContextRefreshAttemptedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SearchContextsStoreSetDynamicSearchContextMembershipFunc describes the
+// behavior when the SetDynamicSearchContextMembership method of the parent
+// MockSearchContextsStore instance is invoked.
+type SearchContextsStoreSetDynamicSearchContextMembershipFunc struct {
+	defaultHook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) ([]*types.SearchContextMembershipEvent, error)
+	hooks       []func(context.Context, int64, []*types.SearchContextRepositoryRevisions) ([]*types.SearchContextMembershipEvent, error)
+	history     []SearchContextsStoreSetDynamicSearchContextMembershipFuncCall
+	mutex       sync.Mutex
+}
+
+// SetDynamicSearchContextMembership delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockSearchContextsStore) SetDynamicSearchContextMembership(v0 context.Context, v1 int64, v2 []*types.SearchContextRepositoryRevisions) ([]*types.SearchContextMembershipEvent, error) {
+	r0, r1 := m.SetDynamicSearchContextMembershipFunc.nextHook()(v0, v1, v2)
+	m.SetDynamicSearchContextMembershipFunc.appendCall(SearchContextsStoreSetDynamicSearchContextMembershipFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// SetDynamicSearchContextMembership method of the parent
+// MockSearchContextsStore instance is invoked and the hook queue is empty.
+func (f *SearchContextsStoreSetDynamicSearchContextMembershipFunc) SetDefaultHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) ([]*types.SearchContextMembershipEvent, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetDynamicSearchContextMembership method of the parent
+// MockSearchContextsStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *SearchContextsStoreSetDynamicSearchContextMembershipFunc) PushHook(hook func(context.Context, int64, []*types.SearchContextRepositoryRevisions) ([]*types.SearchContextMembershipEvent, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SearchContextsStoreSetDynamicSearchContextMembershipFunc) SetDefaultReturn(r0 []*types.SearchContextMembershipEvent, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions) ([]*types.SearchContextMembershipEvent, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SearchContextsStoreSetDynamicSearchContextMembershipFunc) PushReturn(r0 []*types.SearchContextMembershipEvent, r1 error) {
+	f.PushHook(func(context.Context, int64, []*types.SearchContextRepositoryRevisions) ([]*types.SearchContextMembershipEvent, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchContextsStoreSetDynamicSearchContextMembershipFunc) nextHook() func(context.Context, int64, []*types.SearchContextRepositoryRevisions) ([]*types.SearchContextMembershipEvent, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchContextsStoreSetDynamicSearchContextMembershipFunc) appendCall(r0 SearchContextsStoreSetDynamicSearchContextMembershipFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SearchContextsStoreSetDynamicSearchContextMembershipFuncCall objects
+// describing the invocations of this function.
+func (f *SearchContextsStoreSetDynamicSearchContextMembershipFunc) History() []SearchContextsStoreSetDynamicSearchContextMembershipFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchContextsStoreSetDynamicSearchContextMembershipFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchContextsStoreSetDynamicSearchContextMembershipFuncCall is an object
+// that describes an invocation of method SetDynamicSearchContextMembership
+// on an instance of MockSearchContextsStore.
+type SearchContextsStoreSetDynamicSearchContextMembershipFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*types.SearchContextRepositoryRevisions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.SearchContextMembershipEvent
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchContextsStoreSetDynamicSearchContextMembershipFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchContextsStoreSetDynamicSearchContextMembershipFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SearchContextsStoreTransactFunc describes the behavior when the Transact
 // method of the parent MockSearchContextsStore instance is invoked.
 type SearchContextsStoreTransactFunc struct {
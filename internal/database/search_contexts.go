@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
@@ -36,8 +37,12 @@ type SearchContextsStore interface {
 	GetSearchContext(context.Context, GetSearchContextOptions) (*types.SearchContext, error)
 	GetSearchContextRepositoryRevisions(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
 	ListSearchContexts(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)
+	ListDynamicSearchContextsToRefresh(context.Context, time.Time, int32) ([]*types.SearchContext, error)
+	ListSearchContextMembershipEvents(context.Context, int64, int64) ([]*types.SearchContextMembershipEvent, error)
+	MarkDynamicSearchContextRefreshAttempted(context.Context, int64) error
 	GetAllQueries(context.Context) ([]string, error)
 	SetSearchContextRepositoryRevisions(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
+	SetDynamicSearchContextMembership(context.Context, int64, []*types.SearchContextRepositoryRevisions) ([]*types.SearchContextMembershipEvent, error)
 	Transact(context.Context) (SearchContextsStore, error)
 	UpdateSearchContextWithRepositoryRevisions(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
 }
@@ -93,6 +98,8 @@ SELECT
   sc.namespace_org_id,
   sc.updated_at,
   sc.query,
+  sc.dynamic,
+  sc.membership_refreshed_at,
   u.username,
   o.name
 FROM search_contexts sc
@@ -309,8 +316,8 @@ func (s *searchContextsStore) DeleteSearchContext(ctx context.Context, searchCon
 
 const insertSearchContextFmtStr = `
 INSERT INTO search_contexts
-(name, description, public, namespace_user_id, namespace_org_id, query)
-VALUES (%s, %s, %s, %s, %s, %s)
+(name, description, public, namespace_user_id, namespace_org_id, query, dynamic)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
 `
 
 // 🚨 SECURITY: The caller must ensure that the actor is a site admin or has permission to create the search context.
@@ -340,6 +347,11 @@ SET
 	description = %s,
 	public = %s,
 	query = %s,
+	dynamic = %s,
+	-- Resolve the repositories of a dynamic context again, since its query
+	-- may have changed.
+	membership_refreshed_at = NULL,
+	membership_refresh_attempted_at = NULL,
 	updated_at = now()
 WHERE id = %d
 `
@@ -357,6 +369,12 @@ func (s *searchContextsStore) UpdateSearchContextWithRepositoryRevisions(ctx con
 		return nil, err
 	}
 
+	// The repositories of dynamic contexts are kept until the worker
+	// resolves them again, so that searches don't come up empty meanwhile.
+	if updatedSearchContext.Dynamic {
+		return updatedSearchContext, nil
+	}
+
 	err = tx.SetSearchContextRepositoryRevisions(ctx, updatedSearchContext.ID, repositoryRevisions)
 	if err != nil {
 		return nil, err
@@ -405,6 +423,7 @@ func createSearchContext(ctx context.Context, s SearchContextsStore, searchConte
 		nullInt32Column(searchContext.NamespaceUserID),
 		nullInt32Column(searchContext.NamespaceOrgID),
 		nullStringColumn(searchContext.Query),
+		searchContext.Dynamic,
 	)
 	_, err := s.Handle().ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 	if err != nil {
@@ -424,6 +443,7 @@ func updateSearchContext(ctx context.Context, s SearchContextsStore, searchConte
 		searchContext.Description,
 		searchContext.Public,
 		nullStringColumn(searchContext.Query),
+		searchContext.Dynamic,
 		searchContext.ID,
 	)
 	_, err := s.Handle().ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
@@ -461,6 +481,8 @@ func scanSearchContexts(rows *sql.Rows) ([]*types.SearchContext, error) {
 			&dbutil.NullInt32{N: &sc.NamespaceOrgID},
 			&sc.UpdatedAt,
 			&dbutil.NullString{S: &sc.Query},
+			&sc.Dynamic,
+			&dbutil.NullTime{Time: &sc.MembershipRefreshedAt},
 			&dbutil.NullString{S: &sc.NamespaceUserName},
 			&dbutil.NullString{S: &sc.NamespaceOrgName},
 		)
@@ -601,7 +623,133 @@ func (s *searchContextsStore) GetAllQueries(ctx context.Context) (qs []string, _
 		return nil, errors.New("GetAllQueries can only be accessed by an internal actor")
 	}
 
-	q := sqlf.Sprintf(`SELECT array_agg(query) FROM search_contexts WHERE query IS NOT NULL`)
+	// The repositories of dynamic contexts are stored in search_context_repos,
+	// so their queries are not needed to find revisions to index.
+	q := sqlf.Sprintf(`SELECT array_agg(query) FROM search_contexts WHERE query IS NOT NULL AND NOT dynamic`)
 
 	return qs, s.QueryRow(ctx, q).Scan(pq.Array(&qs))
 }
+
+// ListDynamicSearchContextsToRefresh returns at most limit dynamic search
+// contexts whose repositories were not attempted to be resolved since
+// refreshedBefore, least recently attempted first. Contexts whose resolution
+// keeps failing therefore don't starve the other ones.
+func (s *searchContextsStore) ListDynamicSearchContextsToRefresh(ctx context.Context, refreshedBefore time.Time, limit int32) ([]*types.SearchContext, error) {
+	if a := actor.FromContext(ctx); !a.IsInternal() {
+		return nil, errors.New("ListDynamicSearchContextsToRefresh can only be accessed by an internal actor")
+	}
+
+	cond := sqlf.Sprintf("sc.dynamic AND (sc.membership_refresh_attempted_at IS NULL OR sc.membership_refresh_attempted_at < %s)", refreshedBefore)
+	orderBy := sqlf.Sprintf("sc.membership_refresh_attempted_at ASC NULLS FIRST, sc.id ASC")
+	return s.listSearchContexts(ctx, cond, orderBy, limit, 0)
+}
+
+// MarkDynamicSearchContextRefreshAttempted records that the repositories of a
+// dynamic search context were attempted to be resolved, so that it moves to
+// the back of the refresh queue even if resolving them failed.
+func (s *searchContextsStore) MarkDynamicSearchContextRefreshAttempted(ctx context.Context, searchContextID int64) error {
+	if a := actor.FromContext(ctx); !a.IsInternal() {
+		return errors.New("MarkDynamicSearchContextRefreshAttempted can only be accessed by an internal actor")
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf("UPDATE search_contexts SET membership_refresh_attempted_at = now() WHERE id = %d", searchContextID))
+}
+
+const insertSearchContextMembershipEventsFmtStr = `
+INSERT INTO search_context_membership_events (search_context_id, repo_id, kind)
+VALUES %s
+RETURNING id, search_context_id, repo_id, kind, created_at
+`
+
+// SetDynamicSearchContextMembership replaces the repositories of a dynamic
+// search context with the resolved repositoryRevisions, and records which
+// repositories were added and removed. It returns the recorded events.
+func (s *searchContextsStore) SetDynamicSearchContextMembership(ctx context.Context, searchContextID int64, repositoryRevisions []*types.SearchContextRepositoryRevisions) (events []*types.SearchContextMembershipEvent, err error) {
+	if a := actor.FromContext(ctx); !a.IsInternal() {
+		return nil, errors.New("SetDynamicSearchContextMembership can only be accessed by an internal actor")
+	}
+
+	txBase, err := s.Store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx := &searchContextsStore{Store: txBase, logger: s.logger}
+	defer func() { err = tx.Done(err) }()
+
+	previous, err := basestore.NewSliceScanner(basestore.ScanAny[api.RepoID])(tx.Query(ctx, sqlf.Sprintf(
+		"SELECT DISTINCT repo_id FROM search_context_repos WHERE search_context_id = %d",
+		searchContextID,
+	)))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.SetSearchContextRepositoryRevisions(ctx, searchContextID, repositoryRevisions); err != nil {
+		return nil, err
+	}
+
+	removed := make(map[api.RepoID]struct{}, len(previous))
+	for _, id := range previous {
+		removed[id] = struct{}{}
+	}
+	var values []*sqlf.Query
+	for _, repoRev := range repositoryRevisions {
+		if _, ok := removed[repoRev.Repo.ID]; ok {
+			delete(removed, repoRev.Repo.ID)
+			continue
+		}
+		values = append(values, sqlf.Sprintf("(%s, %s, %s)", searchContextID, repoRev.Repo.ID, types.SearchContextMembershipEventAdded))
+	}
+	for id := range removed {
+		values = append(values, sqlf.Sprintf("(%s, %s, %s)", searchContextID, id, types.SearchContextMembershipEventRemoved))
+	}
+
+	if len(values) > 0 {
+		rows, err := tx.Query(ctx, sqlf.Sprintf(insertSearchContextMembershipEventsFmtStr, sqlf.Join(values, ",")))
+		if err != nil {
+			return nil, err
+		}
+		events, err = scanSearchContextMembershipEvents(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Exec(ctx, sqlf.Sprintf("UPDATE search_contexts SET membership_refreshed_at = now(), membership_refresh_attempted_at = now() WHERE id = %d", searchContextID))
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+const listSearchContextMembershipEventsFmtStr = `
+SELECT id, search_context_id, repo_id, kind, created_at
+FROM search_context_membership_events
+WHERE search_context_id = %d AND id > %d
+ORDER BY id ASC
+`
+
+// ListSearchContextMembershipEvents returns the membership events of a
+// dynamic search context with an ID greater than afterID, oldest first.
+// Consumers can pass the ID of the last event they have seen to only get new
+// events.
+func (s *searchContextsStore) ListSearchContextMembershipEvents(ctx context.Context, searchContextID, afterID int64) ([]*types.SearchContextMembershipEvent, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listSearchContextMembershipEventsFmtStr, searchContextID, afterID))
+	if err != nil {
+		return nil, err
+	}
+	return scanSearchContextMembershipEvents(rows)
+}
+
+func scanSearchContextMembershipEvents(rows *sql.Rows) (events []*types.SearchContextMembershipEvent, err error) {
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var e types.SearchContextMembershipEvent
+		if err := rows.Scan(&e.ID, &e.SearchContextID, &e.RepoID, &e.Kind, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, &e)
+	}
+	return events, nil
+}
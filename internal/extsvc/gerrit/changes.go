@@ -0,0 +1,244 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangeStatus is the status of a Gerrit change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Change is a change of the Gerrit REST API, see
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-info.
+type Change struct {
+	ID              string               `json:"id"`
+	Project         string               `json:"project"`
+	Branch          string               `json:"branch"`
+	Topic           string               `json:"topic,omitempty"`
+	ChangeID        string               `json:"change_id"`
+	Subject         string               `json:"subject"`
+	Status          ChangeStatus         `json:"status"`
+	Created         Timestamp            `json:"created"`
+	Updated         Timestamp            `json:"updated"`
+	WorkInProgress  bool                 `json:"work_in_progress,omitempty"`
+	Number          int                  `json:"_number"`
+	Owner           Account              `json:"owner"`
+	Labels          map[string]LabelInfo `json:"labels,omitempty"`
+	CurrentRevision string               `json:"current_revision,omitempty"`
+	Revisions       map[string]Revision  `json:"revisions,omitempty"`
+}
+
+// LabelInfo is the state of a label of a change. With detailed labels, All
+// has the votes of all reviewers that can vote on the label.
+type LabelInfo struct {
+	Optional    bool           `json:"optional,omitempty"`
+	Approved    *Account       `json:"approved,omitempty"`
+	Rejected    *Account       `json:"rejected,omitempty"`
+	Recommended *Account       `json:"recommended,omitempty"`
+	Disliked    *Account       `json:"disliked,omitempty"`
+	All         []ApprovalInfo `json:"all,omitempty"`
+}
+
+// ApprovalInfo is the vote of a reviewer on a label.
+type ApprovalInfo struct {
+	Account
+	Value int `json:"value"`
+}
+
+// Revision is a patch set of a change.
+type Revision struct {
+	Number int        `json:"_number"`
+	Ref    string     `json:"ref"`
+	Commit CommitInfo `json:"commit"`
+}
+
+// CommitInfo is the commit of a patch set.
+type CommitInfo struct {
+	Parents []ParentCommit `json:"parents"`
+	Subject string         `json:"subject"`
+	Message string         `json:"message"`
+}
+
+// ParentCommit is a parent of the commit of a patch set.
+type ParentCommit struct {
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+}
+
+// timestampLayout is the layout of timestamps in the Gerrit REST API, which
+// are always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a time in the format of the Gerrit REST API.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// ChangeIdentifier returns the identifier of the change with the given number
+// in the given project, to be used in the URLs of the changes API.
+func ChangeIdentifier(project string, number int) string {
+	return project + "~" + strconv.Itoa(number)
+}
+
+// Identifier returns the identifier of the change, see ChangeIdentifier.
+func (c *Change) Identifier() string {
+	return ChangeIdentifier(c.Project, c.Number)
+}
+
+// ChangeIdentifierForBranch returns the identifier of the change with the
+// given Change-Id on the given branch of the given project.
+func ChangeIdentifierForBranch(project, branch, changeID string) string {
+	return project + "~" + strings.TrimPrefix(branch, "refs/heads/") + "~" + changeID
+}
+
+// changeOptions are the options of the changes that the client returns. They
+// include the current revision and the votes of all reviewers, which are
+// needed to compute the state of a change.
+var changeOptions = []string{"DETAILED_LABELS", "DETAILED_ACCOUNTS", "CURRENT_REVISION", "CURRENT_COMMIT"}
+
+// GetChange returns the change with the given identifier, see
+// ChangeIdentifier.
+func (c *Client) GetChange(ctx context.Context, id string) (*Change, error) {
+	req, err := http.NewRequest("GET", changeURL(id, "")+"?o="+strings.Join(changeOptions, "&o="), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err := c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// AbandonChange abandons the change with the given identifier.
+func (c *Client) AbandonChange(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "/abandon", struct{}{})
+}
+
+// RestoreChange restores the abandoned change with the given identifier.
+func (c *Client) RestoreChange(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "/restore", struct{}{})
+}
+
+// SubmitChange submits the change with the given identifier, which merges it
+// into its branch.
+func (c *Client) SubmitChange(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "/submit", struct{}{})
+}
+
+// SetWorkInProgress marks the change with the given identifier as work in
+// progress.
+func (c *Client) SetWorkInProgress(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "/wip", struct{}{})
+}
+
+// SetReadyForReview marks the change with the given identifier as ready for
+// review.
+func (c *Client) SetReadyForReview(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "/ready", struct{}{})
+}
+
+// MoveChange moves the change with the given identifier to another branch.
+func (c *Client) MoveChange(ctx context.Context, id, branch string) error {
+	return c.postChange(ctx, id, "/move", struct {
+		DestinationBranch string `json:"destination_branch"`
+	}{DestinationBranch: branch})
+}
+
+// ReviewInput is a review of the current revision of a change.
+type ReviewInput struct {
+	Message string `json:"message,omitempty"`
+}
+
+// SetReview posts a review of the current revision of the change with the
+// given identifier.
+func (c *Client) SetReview(ctx context.Context, id string, review ReviewInput) error {
+	return c.postChange(ctx, id, "/revisions/current/review", review)
+}
+
+// SetCommitMessage creates a new patch set of the change with the given
+// identifier that has the given commit message. The message must keep the
+// Change-Id footer of the change.
+func (c *Client) SetCommitMessage(ctx context.Context, id, message string) error {
+	req, err := newJSONRequest("PUT", changeURL(id, "/message"), struct {
+		Message string `json:"message"`
+	}{Message: message})
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// GetAuthenticatedAccount returns the account the client is authenticated as.
+func (c *Client) GetAuthenticatedAccount(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if _, err := c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (c *Client) postChange(ctx context.Context, id, action string, body any) error {
+	req, err := newJSONRequest("POST", changeURL(id, action), body)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// changeURL returns the URL of the endpoint of the changes API of the change
+// with the given identifier. The identifier is escaped, since project names
+// can contain slashes.
+func changeURL(id, endpoint string) string {
+	return "a/changes/" + url.PathEscape(id) + endpoint
+}
+
+func newJSONRequest(method, urlStr string, body any) (*http.Request, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, urlStr, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	return req, nil
+}
@@ -10,8 +10,10 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -52,6 +54,38 @@ func NewClient(urn string, config *schema.GerritConnection, httpClient httpcli.D
 	}, nil
 }
 
+// Authenticator returns the basic authentication credentials of the client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return &auth.BasicAuth{Username: c.Config.Username, Password: c.Config.Password}
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTP client and rate limiter as the current Client, except authenticated
+// with the given authenticator. Gerrit only supports basic authentication
+// with an HTTP password.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	var basic *auth.BasicAuth
+	switch a := a.(type) {
+	case *auth.BasicAuth:
+		basic = a
+	case *auth.BasicAuthWithSSH:
+		basic = &a.BasicAuth
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Gerrit clients: %T", a)
+	}
+
+	config := *c.Config
+	config.Username = basic.Username
+	config.Password = basic.Password
+
+	return &Client{
+		httpClient: c.httpClient,
+		Config:     &config,
+		URL:        c.URL,
+		rateLimit:  c.rateLimit,
+	}, nil
+}
+
 type ListAccountsResponse []Account
 
 func (c *Client) ListAccountsByEmail(ctx context.Context, email string) (ListAccountsResponse, error) {
@@ -166,7 +200,6 @@ func (c *Client) ListProjects(ctx context.Context, opts ListProjectsArgs) (proje
 	return &respCodeProjects, nextPage, nil
 }
 
-// nolint:unparam
 func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
 	req.URL = c.URL.ResolveReference(req.URL)
 
@@ -199,6 +232,12 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 		}
 	}
 
+	// Some endpoints, such as setting the commit message of a change, respond
+	// without content.
+	if result == nil {
+		return resp, nil
+	}
+
 	// The first 4 characters of the Gerrit API responses need to be stripped, see: https://gerrit-review.googlesource.com/Documentation/rest-api.html#output .
 	if len(bs) < 4 {
 		return nil, &httpError{
@@ -186,6 +186,23 @@ type PullRequest struct {
 	UpdatedAt      time.Time
 }
 
+// Issue is a GitHub issue.
+type Issue struct {
+	RepoWithOwner string `json:"-"`
+	ID            string
+	Title         string
+	Body          string
+	State         string
+	URL           string
+	Number        int64
+	Author        Actor
+	Assignees     []Actor
+	Labels        struct{ Nodes []Label }
+	TimelineItems []TimelineItem
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
 // AssignedEvent represents an 'assigned' event on a PullRequest.
 type AssignedEvent struct {
 	Actor     Actor
@@ -929,6 +946,272 @@ func (c *V4Client) GetOpenPullRequestByRefs(ctx context.Context, owner, name, ba
 	return &pr, nil
 }
 
+// This fragment was formatted using the "prettify" button in the GitHub API explorer:
+// https://developer.github.com/v4/explorer/
+const issueFragments = prCommonFragments + `
+fragment issueTimelineItems on IssueTimelineItems {
+  ... on AssignedEvent {
+    actor {
+      ...actor
+    }
+    assignee {
+      ...actor
+    }
+    createdAt
+  }
+  ... on ClosedEvent {
+    actor {
+      ...actor
+    }
+    createdAt
+    url
+  }
+  ... on ReopenedEvent {
+    actor {
+      ...actor
+    }
+    createdAt
+  }
+  ... on UnassignedEvent {
+    actor {
+      ...actor
+    }
+    assignee {
+      ...actor
+    }
+    createdAt
+  }
+}
+
+fragment issue on Issue {
+  id
+  title
+  body
+  state
+  url
+  number
+  createdAt
+  updatedAt
+  author {
+    ...actor
+  }
+  assignees(first: 100) {
+    nodes {
+      ...actor
+    }
+  }
+  labels(first: 100) {
+    nodes {
+      ...label
+    }
+  }
+  timelineItems(last: 250, itemTypes: [ASSIGNED_EVENT, CLOSED_EVENT, REOPENED_EVENT, UNASSIGNED_EVENT]) {
+    nodes {
+      __typename
+      ...issueTimelineItems
+    }
+  }
+}
+`
+
+// issueResult is the shape of an issue as returned by the issue fragment.
+type issueResult struct {
+	Issue
+	Assignees     struct{ Nodes []Actor }
+	TimelineItems struct{ Nodes []TimelineItem }
+}
+
+func (r *issueResult) toIssue() Issue {
+	issue := r.Issue
+	issue.Assignees = r.Assignees.Nodes
+	issue.TimelineItems = r.TimelineItems.Nodes
+	return issue
+}
+
+// LoadIssue loads an Issue from Github. Only the last 250 timeline items of
+// the issue are loaded, which is plenty to track its state.
+func (c *V4Client) LoadIssue(ctx context.Context, issue *Issue) error {
+	owner, repo, err := SplitRepositoryNameWithOwner(issue.RepoWithOwner)
+	if err != nil {
+		return err
+	}
+
+	q := issueFragments + `
+query($owner: String!, $name: String!, $number: Int!) {
+	repository(owner: $owner, name: $name) {
+		issue(number: $number) { ...issue }
+	}
+}`
+
+	var result struct {
+		Repository struct {
+			Issue issueResult
+		}
+	}
+
+	err = c.requestGraphQL(ctx, q, map[string]any{"owner": owner, "name": repo, "number": issue.Number}, &result)
+	if err != nil {
+		var errs graphqlErrors
+		if errors.As(err, &errs) {
+			for _, err := range errs {
+				if err.Type == graphqlErrTypeNotFound && len(err.Path) >= 1 {
+					if repoPath, ok := err.Path[0].(string); !ok || repoPath != "repository" {
+						continue
+					}
+					if len(err.Path) == 1 {
+						return ErrRepoNotFound
+					}
+					if issuePath, ok := err.Path[1].(string); !ok || issuePath != "issue" {
+						continue
+					}
+					return ErrIssueNotFound(issue.Number)
+				}
+			}
+		}
+		return err
+	}
+
+	repoWithOwner := issue.RepoWithOwner
+	*issue = result.Repository.Issue.toIssue()
+	issue.RepoWithOwner = repoWithOwner
+
+	return nil
+}
+
+type CreateIssueInput struct {
+	// The Node ID of the repository.
+	RepositoryID string `json:"repositoryId"`
+	// The title of the issue.
+	Title string `json:"title"`
+	// The body of the issue (optional).
+	Body string `json:"body"`
+}
+
+// CreateIssue creates an Issue on Github.
+func (c *V4Client) CreateIssue(ctx context.Context, in *CreateIssueInput) (*Issue, error) {
+	q := issueFragments + `
+mutation CreateIssue($input: CreateIssueInput!) {
+  createIssue(input: $input) {
+    issue {
+      ... issue
+    }
+  }
+}`
+
+	var result struct {
+		CreateIssue struct {
+			Issue issueResult `json:"issue"`
+		} `json:"createIssue"`
+	}
+
+	err := c.requestGraphQL(ctx, q, map[string]any{"input": in}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := result.CreateIssue.Issue.toIssue()
+	return &issue, nil
+}
+
+type UpdateIssueInput struct {
+	// The Node ID of the issue.
+	ID string `json:"id"`
+	// The title of the issue.
+	Title string `json:"title"`
+	// The body of the issue (optional).
+	Body string `json:"body"`
+}
+
+// UpdateIssue updates an Issue on Github.
+func (c *V4Client) UpdateIssue(ctx context.Context, in *UpdateIssueInput) (*Issue, error) {
+	q := issueFragments + `
+mutation UpdateIssue($input: UpdateIssueInput!) {
+  updateIssue(input: $input) {
+    issue {
+      ... issue
+    }
+  }
+}`
+
+	var result struct {
+		UpdateIssue struct {
+			Issue issueResult `json:"issue"`
+		} `json:"updateIssue"`
+	}
+
+	err := c.requestGraphQL(ctx, q, map[string]any{"input": in}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := result.UpdateIssue.Issue.toIssue()
+	return &issue, nil
+}
+
+// CloseIssue closes the Issue on Github.
+func (c *V4Client) CloseIssue(ctx context.Context, issue *Issue) error {
+	q := issueFragments + `
+mutation CloseIssue($input: CloseIssueInput!) {
+  closeIssue(input: $input) {
+    issue {
+      ... issue
+    }
+  }
+}`
+
+	var result struct {
+		CloseIssue struct {
+			Issue issueResult `json:"issue"`
+		} `json:"closeIssue"`
+	}
+
+	input := map[string]any{"input": struct {
+		ID string `json:"issueId"`
+	}{ID: issue.ID}}
+	err := c.requestGraphQL(ctx, q, input, &result)
+	if err != nil {
+		return err
+	}
+
+	repoWithOwner := issue.RepoWithOwner
+	*issue = result.CloseIssue.Issue.toIssue()
+	issue.RepoWithOwner = repoWithOwner
+
+	return nil
+}
+
+// ReopenIssue reopens the Issue on Github.
+func (c *V4Client) ReopenIssue(ctx context.Context, issue *Issue) error {
+	q := issueFragments + `
+mutation ReopenIssue($input: ReopenIssueInput!) {
+  reopenIssue(input: $input) {
+    issue {
+      ... issue
+    }
+  }
+}`
+
+	var result struct {
+		ReopenIssue struct {
+			Issue issueResult `json:"issue"`
+		} `json:"reopenIssue"`
+	}
+
+	input := map[string]any{"input": struct {
+		ID string `json:"issueId"`
+	}{ID: issue.ID}}
+	err := c.requestGraphQL(ctx, q, input, &result)
+	if err != nil {
+		return err
+	}
+
+	repoWithOwner := issue.RepoWithOwner
+	*issue = result.ReopenIssue.Issue.toIssue()
+	issue.RepoWithOwner = repoWithOwner
+
+	return nil
+}
+
 const createPullRequestCommentMutation = `
 mutation CreatePullRequestComment($input: AddCommentInput!) {
   addComment(input: $input) {
@@ -1601,7 +1884,7 @@ func (e OrgNotFoundError) NotFound() bool { return true }
 // IsNotFound reports whether err is a GitHub API error of type NOT_FOUND, the equivalent cached
 // response error, or HTTP 404.
 func IsNotFound(err error) bool {
-	if errors.HasType(err, &RepoNotFoundError{}) || errors.HasType(err, &OrgNotFoundError{}) || errors.HasType(err, ErrPullRequestNotFound(0)) ||
+	if errors.HasType(err, &RepoNotFoundError{}) || errors.HasType(err, &OrgNotFoundError{}) || errors.HasType(err, ErrPullRequestNotFound(0)) || errors.HasType(err, ErrIssueNotFound(0)) ||
 		HTTPErrorCode(err) == http.StatusNotFound {
 		return true
 	}
@@ -1671,6 +1954,13 @@ func (e ErrPullRequestNotFound) Error() string {
 	return fmt.Sprintf("GitHub pull request not found: %d", e)
 }
 
+// ErrIssueNotFound is when the requested GitHub Issue doesn't exist.
+type ErrIssueNotFound int
+
+func (e ErrIssueNotFound) Error() string {
+	return fmt.Sprintf("GitHub issue not found: %d", e)
+}
+
 // ErrRepoArchived is returned when a mutation is performed on an archived
 // repo.
 type ErrRepoArchived struct{}
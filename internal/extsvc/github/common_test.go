@@ -1,11 +1,20 @@
 package github
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
 
 func TestSplitRepositoryNameWithOwner(t *testing.T) {
@@ -59,3 +68,200 @@ func repoListsAreEqual(a []*Repository, b []*Repository) bool {
 	}
 	return true
 }
+
+// testIssueJSON is an issue as returned by the issue fragment.
+const testIssueJSON = `{
+  "id": "I_kwDOEZ3Gcc5TYtHS",
+  "title": "Upgrade the logging library",
+  "body": "The logging library needs to be upgraded to v2.",
+  "state": "OPEN",
+  "url": "https://github.com/sourcegraph/automation-testing/issues/42",
+  "number": 42,
+  "createdAt": "2022-10-11T14:03:52Z",
+  "updatedAt": "2022-10-12T09:21:08Z",
+  "author": {"avatarUrl": "https://avatars.githubusercontent.com/u/1", "login": "alice", "url": "https://github.com/alice"},
+  "assignees": {"nodes": [{"avatarUrl": "https://avatars.githubusercontent.com/u/2", "login": "bob", "url": "https://github.com/bob"}]},
+  "labels": {"nodes": [{"id": "LA_kwDOEZ3Gcc7_Ffxj", "color": "d73a4a", "description": "Something isn't working", "name": "bug"}]},
+  "timelineItems": {"nodes": [
+    {
+      "__typename": "AssignedEvent",
+      "actor": {"avatarUrl": "https://avatars.githubusercontent.com/u/1", "login": "alice", "url": "https://github.com/alice"},
+      "assignee": {"avatarUrl": "https://avatars.githubusercontent.com/u/2", "login": "bob", "url": "https://github.com/bob"},
+      "createdAt": "2022-10-11T14:05:00Z"
+    },
+    {
+      "__typename": "ClosedEvent",
+      "actor": {"avatarUrl": "https://avatars.githubusercontent.com/u/2", "login": "bob", "url": "https://github.com/bob"},
+      "createdAt": "2022-10-12T09:00:00Z",
+      "url": "https://github.com/sourcegraph/automation-testing/issues/42#event-1"
+    },
+    {
+      "__typename": "ReopenedEvent",
+      "actor": {"avatarUrl": "https://avatars.githubusercontent.com/u/1", "login": "alice", "url": "https://github.com/alice"},
+      "createdAt": "2022-10-12T09:21:08Z"
+    }
+  ]}
+}`
+
+// testIssue returns the issue of testIssueJSON.
+func testIssue() *Issue {
+	alice := Actor{AvatarURL: "https://avatars.githubusercontent.com/u/1", Login: "alice", URL: "https://github.com/alice"}
+	bob := Actor{AvatarURL: "https://avatars.githubusercontent.com/u/2", Login: "bob", URL: "https://github.com/bob"}
+
+	issue := &Issue{
+		ID:        "I_kwDOEZ3Gcc5TYtHS",
+		Title:     "Upgrade the logging library",
+		Body:      "The logging library needs to be upgraded to v2.",
+		State:     "OPEN",
+		URL:       "https://github.com/sourcegraph/automation-testing/issues/42",
+		Number:    42,
+		Author:    alice,
+		Assignees: []Actor{bob},
+		TimelineItems: []TimelineItem{
+			{Type: "AssignedEvent", Item: &AssignedEvent{Actor: alice, Assignee: bob, CreatedAt: time.Date(2022, 10, 11, 14, 5, 0, 0, time.UTC)}},
+			{Type: "ClosedEvent", Item: &ClosedEvent{Actor: bob, CreatedAt: time.Date(2022, 10, 12, 9, 0, 0, 0, time.UTC), URL: "https://github.com/sourcegraph/automation-testing/issues/42#event-1"}},
+			{Type: "ReopenedEvent", Item: &ReopenedEvent{Actor: alice, CreatedAt: time.Date(2022, 10, 12, 9, 21, 8, 0, time.UTC)}},
+		},
+		CreatedAt: time.Date(2022, 10, 11, 14, 3, 52, 0, time.UTC),
+		UpdatedAt: time.Date(2022, 10, 12, 9, 21, 8, 0, time.UTC),
+	}
+	issue.Labels.Nodes = []Label{{ID: "LA_kwDOEZ3Gcc7_Ffxj", Color: "d73a4a", Description: "Something isn't working", Name: "bug"}}
+	return issue
+}
+
+// newIssueTestClient returns a client that answers GraphQL requests with the
+// given response body, and records the variables of the last request.
+func newIssueTestClient(t *testing.T, responseBody string) (*V4Client, *map[string]any) {
+	t.Helper()
+
+	var vars map[string]any
+	doer := httpcli.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		vars = body.Variables
+
+		return &http.Response{
+			Request:    req,
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(responseBody)),
+		}, nil
+	})
+
+	apiURL := &url.URL{Scheme: "https", Host: "example.com", Path: "/"}
+	return NewV4Client("Test", apiURL, nil, doer), &vars
+}
+
+func TestIssueResult(t *testing.T) {
+	// The assignees and timeline items of the issue fragment are connections,
+	// so issueResult shadows the fields of Issue to unmarshal their nodes.
+	var result issueResult
+	require.NoError(t, json.Unmarshal([]byte(testIssueJSON), &result))
+	assert.Equal(t, *testIssue(), result.toIssue())
+}
+
+func TestV4Client_LoadIssue(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cli, vars := newIssueTestClient(t, `{"data": {"repository": {"issue": `+testIssueJSON+`}}}`)
+
+		issue := &Issue{RepoWithOwner: "sourcegraph/automation-testing", Number: 42}
+		require.NoError(t, cli.LoadIssue(context.Background(), issue))
+
+		want := testIssue()
+		want.RepoWithOwner = "sourcegraph/automation-testing"
+		assert.Equal(t, want, issue)
+		assert.Equal(t, map[string]any{"owner": "sourcegraph", "name": "automation-testing", "number": float64(42)}, *vars)
+	})
+
+	for name, tc := range map[string]struct {
+		path []string
+		want error
+	}{
+		"repository not found": {path: []string{"repository"}, want: ErrRepoNotFound},
+		"issue not found":      {path: []string{"repository", "issue"}, want: ErrIssueNotFound(42)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path, err := json.Marshal(tc.path)
+			require.NoError(t, err)
+			cli, _ := newIssueTestClient(t, fmt.Sprintf(`{"data": null, "errors": [{"type": "NOT_FOUND", "path": %s, "message": "Could not resolve to an Issue with the number of 42."}]}`, path))
+
+			err = cli.LoadIssue(context.Background(), &Issue{RepoWithOwner: "sourcegraph/automation-testing", Number: 42})
+			assert.Equal(t, tc.want, err)
+		})
+	}
+
+	t.Run("invalid repository name", func(t *testing.T) {
+		cli, _ := newIssueTestClient(t, "")
+		assert.Error(t, cli.LoadIssue(context.Background(), &Issue{RepoWithOwner: "automation-testing", Number: 42}))
+	})
+}
+
+func TestV4Client_CreateIssue(t *testing.T) {
+	cli, vars := newIssueTestClient(t, `{"data": {"createIssue": {"issue": `+testIssueJSON+`}}}`)
+
+	issue, err := cli.CreateIssue(context.Background(), &CreateIssueInput{
+		RepositoryID: "MDEwOlJlcG9zaXRvcnkyMjExNDc1MTM=",
+		Title:        "Upgrade the logging library",
+		Body:         "The logging library needs to be upgraded to v2.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testIssue(), issue)
+	assert.Equal(t, map[string]any{"input": map[string]any{
+		"repositoryId": "MDEwOlJlcG9zaXRvcnkyMjExNDc1MTM=",
+		"title":        "Upgrade the logging library",
+		"body":         "The logging library needs to be upgraded to v2.",
+	}}, *vars)
+}
+
+func TestV4Client_UpdateIssue(t *testing.T) {
+	cli, vars := newIssueTestClient(t, `{"data": {"updateIssue": {"issue": `+testIssueJSON+`}}}`)
+
+	issue, err := cli.UpdateIssue(context.Background(), &UpdateIssueInput{
+		ID:    "I_kwDOEZ3Gcc5TYtHS",
+		Title: "Upgrade the logging library",
+		Body:  "The logging library needs to be upgraded to v2.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testIssue(), issue)
+	assert.Equal(t, map[string]any{"input": map[string]any{
+		"id":    "I_kwDOEZ3Gcc5TYtHS",
+		"title": "Upgrade the logging library",
+		"body":  "The logging library needs to be upgraded to v2.",
+	}}, *vars)
+}
+
+func TestV4Client_CloseAndReopenIssue(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mutation string
+		run      func(*V4Client, *Issue) error
+	}{
+		"close": {
+			mutation: "closeIssue",
+			run: func(cli *V4Client, issue *Issue) error {
+				return cli.CloseIssue(context.Background(), issue)
+			},
+		},
+		"reopen": {
+			mutation: "reopenIssue",
+			run: func(cli *V4Client, issue *Issue) error {
+				return cli.ReopenIssue(context.Background(), issue)
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cli, vars := newIssueTestClient(t, fmt.Sprintf(`{"data": {%q: {"issue": %s}}}`, tc.mutation, testIssueJSON))
+
+			issue := &Issue{RepoWithOwner: "sourcegraph/automation-testing", ID: "I_kwDOEZ3Gcc5TYtHS"}
+			require.NoError(t, tc.run(cli, issue))
+
+			// The repository isn't part of the issue fragment, so it is kept.
+			want := testIssue()
+			want.RepoWithOwner = "sourcegraph/automation-testing"
+			assert.Equal(t, want, issue)
+			assert.Equal(t, map[string]any{"input": map[string]any{"issueId": "I_kwDOEZ3Gcc5TYtHS"}}, *vars)
+		})
+	}
+}
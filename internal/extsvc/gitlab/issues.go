@@ -0,0 +1,147 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type IssueState string
+
+const (
+	IssueStateOpened IssueState = "opened"
+	IssueStateClosed IssueState = "closed"
+)
+
+type Issue struct {
+	ID          ID         `json:"id"`
+	IID         ID         `json:"iid"`
+	ProjectID   ID         `json:"project_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       IssueState `json:"state"`
+	CreatedAt   Time       `json:"created_at"`
+	UpdatedAt   Time       `json:"updated_at"`
+	ClosedAt    *Time      `json:"closed_at"`
+	Labels      []string   `json:"labels"`
+	WebURL      string     `json:"web_url"`
+	Author      User       `json:"author"`
+	Assignees   []User     `json:"assignees"`
+
+	// ResourceStateEvents are retrieved with a separate REST API request, see
+	// GetIssueResourceStateEvents.
+	ResourceStateEvents []*ResourceStateEvent
+}
+
+func (c *Client) GetIssue(ctx context.Context, project *Project, iid ID) (*Issue, error) {
+	if MockGetIssue != nil {
+		return MockGetIssue(c, ctx, project, iid)
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/issues/%d", project.ID, iid), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to get an issue")
+	}
+
+	resp := &Issue{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		var e HTTPError
+		if errors.As(err, &e) && e.Code() == http.StatusNotFound {
+			if strings.Contains(e.Message(), "Project Not Found") {
+				err = ErrProjectNotFound
+			} else {
+				err = ErrIssueNotFound
+			}
+		}
+		return nil, errors.Wrap(err, "sending request to get an issue")
+	}
+
+	return resp, nil
+}
+
+type CreateIssueOpts struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+func (c *Client) CreateIssue(ctx context.Context, project *Project, opts CreateIssueOpts) (*Issue, error) {
+	if MockCreateIssue != nil {
+		return MockCreateIssue(c, ctx, project, opts)
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/issues", project.ID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to create an issue")
+	}
+
+	resp := &Issue{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		if aerr := c.convertToArchivedError(ctx, err, project); aerr != nil {
+			return nil, aerr
+		}
+		return nil, errors.Wrap(err, "sending request to create an issue")
+	}
+
+	return resp, nil
+}
+
+type UpdateIssueOpts struct {
+	Title       string                `json:"title,omitempty"`
+	Description string                `json:"description,omitempty"`
+	StateEvent  UpdateIssueStateEvent `json:"state_event,omitempty"`
+}
+
+type UpdateIssueStateEvent string
+
+const (
+	UpdateIssueStateEventClose  UpdateIssueStateEvent = "close"
+	UpdateIssueStateEventReopen UpdateIssueStateEvent = "reopen"
+
+	// Like merge requests, issues are closed and reopened through the update
+	// API. Leaving the state event empty updates the issue without changing
+	// its state.
+	UpdateIssueStateEventUnchanged UpdateIssueStateEvent = ""
+)
+
+func (c *Client) UpdateIssue(ctx context.Context, project *Project, issue *Issue, opts UpdateIssueOpts) (*Issue, error) {
+	if MockUpdateIssue != nil {
+		return MockUpdateIssue(c, ctx, project, issue, opts)
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/issues/%d", project.ID, issue.IID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to update an issue")
+	}
+
+	resp := &Issue{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		if aerr := c.convertToArchivedError(ctx, err, project); aerr != nil {
+			return nil, aerr
+		}
+		return nil, errors.Wrap(err, "sending request to update an issue")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,266 @@
+package gitlab
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const testIssueResponseBody = `{
+  "id": 1001,
+  "iid": 42,
+  "project_id": 7,
+  "title": "Upgrade the logging library",
+  "description": "The logging library needs to be upgraded to v2.",
+  "state": "opened",
+  "created_at": "2022-10-11T14:03:52.000Z",
+  "updated_at": "2022-10-12T09:21:08.000Z",
+  "closed_at": null,
+  "labels": ["bug"],
+  "web_url": "https://gitlab.com/sourcegraph/automation-testing/-/issues/42",
+  "author": {"id": 1, "name": "Alice", "username": "alice"},
+  "assignees": [{"id": 2, "name": "Bob", "username": "bob"}]
+}`
+
+func testIssue() *Issue {
+	return &Issue{
+		ID:          1001,
+		IID:         42,
+		ProjectID:   7,
+		Title:       "Upgrade the logging library",
+		Description: "The logging library needs to be upgraded to v2.",
+		State:       IssueStateOpened,
+		CreatedAt:   Time{time.Date(2022, 10, 11, 14, 3, 52, 0, time.UTC)},
+		UpdatedAt:   Time{time.Date(2022, 10, 12, 9, 21, 8, 0, time.UTC)},
+		Labels:      []string{"bug"},
+		WebURL:      "https://gitlab.com/sourcegraph/automation-testing/-/issues/42",
+		Author:      User{ID: 1, Name: "Alice", Username: "alice"},
+		Assignees:   []User{{ID: 2, Name: "Bob", Username: "bob"}},
+	}
+}
+
+// mockHTTPRequestRecorder responds with the given response body and records
+// the method, path and body of the last request.
+type mockHTTPRequestRecorder struct {
+	responseBody string
+
+	method, path, body string
+}
+
+func (s *mockHTTPRequestRecorder) Do(req *http.Request) (*http.Response, error) {
+	s.method = req.Method
+	s.path = req.URL.Path
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		s.body = string(body)
+	}
+
+	return &http.Response{
+		Request:    req,
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(s.responseBody)),
+	}, nil
+}
+
+func TestGetIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{ProjectCommon: ProjectCommon{ID: 7}}
+
+	t.Run("project not found", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			statusCode:   http.StatusNotFound,
+			responseBody: `{"message":"404 Project Not Found"}`,
+		}
+
+		issue, err := client.GetIssue(ctx, project, 42)
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if !errors.Is(err, ErrProjectNotFound) {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("issue not found", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			statusCode:   http.StatusNotFound,
+			responseBody: `{"message":"404 Not found"}`,
+		}
+
+		issue, err := client.GetIssue(ctx, project, 42)
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if !errors.Is(err, ErrIssueNotFound) {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `this is not valid JSON`,
+		}
+
+		issue, err := client.GetIssue(ctx, project, 42)
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		mock := &mockHTTPRequestRecorder{responseBody: testIssueResponseBody}
+		client.httpClient = mock
+
+		issue, err := client.GetIssue(ctx, project, 42)
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		if diff := cmp.Diff(testIssue(), issue); diff != "" {
+			t.Errorf("unexpected issue: %s", diff)
+		}
+		if mock.method != "GET" || mock.path != "/projects/7/issues/42" {
+			t.Errorf("unexpected request: %s %s", mock.method, mock.path)
+		}
+	})
+}
+
+func TestCreateIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{ProjectCommon: ProjectCommon{ID: 7}}
+	opts := CreateIssueOpts{
+		Title:       "Upgrade the logging library",
+		Description: "The logging library needs to be upgraded to v2.",
+	}
+
+	t.Run("archived project", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = httpcli.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			// Creating the issue is forbidden, since the project is archived.
+			status, body := http.StatusForbidden, `{"message":"403 Forbidden"}`
+			if req.Method == "GET" {
+				status, body = http.StatusOK, `{"id":7,"path_with_namespace":"sourcegraph/automation-testing","archived":true}`
+			}
+			return &http.Response{
+				Request:    req,
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		})
+
+		issue, err := client.CreateIssue(ctx, project, opts)
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if !errcode.IsArchived(err) {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusInternalServerError}
+
+		issue, err := client.CreateIssue(ctx, project, opts)
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		mock := &mockHTTPRequestRecorder{responseBody: testIssueResponseBody}
+		client.httpClient = mock
+
+		issue, err := client.CreateIssue(ctx, project, opts)
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		if diff := cmp.Diff(testIssue(), issue); diff != "" {
+			t.Errorf("unexpected issue: %s", diff)
+		}
+		if mock.method != "POST" || mock.path != "/projects/7/issues" {
+			t.Errorf("unexpected request: %s %s", mock.method, mock.path)
+		}
+		if want := `{"title":"Upgrade the logging library","description":"The logging library needs to be upgraded to v2."}`; mock.body != want {
+			t.Errorf("unexpected request body: have %s; want %s", mock.body, want)
+		}
+	})
+}
+
+func TestUpdateIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{ProjectCommon: ProjectCommon{ID: 7}}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusInternalServerError}
+
+		issue, err := client.UpdateIssue(ctx, project, testIssue(), UpdateIssueOpts{Title: "Upgrade the logging library"})
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		opts UpdateIssueOpts
+		want string
+	}{
+		"update": {
+			opts: UpdateIssueOpts{Title: "Upgrade the logging library", StateEvent: UpdateIssueStateEventUnchanged},
+			want: `{"title":"Upgrade the logging library"}`,
+		},
+		"close": {
+			opts: UpdateIssueOpts{StateEvent: UpdateIssueStateEventClose},
+			want: `{"state_event":"close"}`,
+		},
+		"reopen": {
+			opts: UpdateIssueOpts{StateEvent: UpdateIssueStateEventReopen},
+			want: `{"state_event":"reopen"}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t)
+			mock := &mockHTTPRequestRecorder{responseBody: testIssueResponseBody}
+			client.httpClient = mock
+
+			issue, err := client.UpdateIssue(ctx, project, testIssue(), tc.opts)
+			if err != nil {
+				t.Fatalf("unexpected non-nil error: %+v", err)
+			}
+			if diff := cmp.Diff(testIssue(), issue); diff != "" {
+				t.Errorf("unexpected issue: %s", diff)
+			}
+			if mock.method != "PUT" || mock.path != "/projects/7/issues/42" {
+				t.Errorf("unexpected request: %s %s", mock.method, mock.path)
+			}
+			if mock.body != tc.want {
+				t.Errorf("unexpected request body: have %s; want %s", mock.body, tc.want)
+			}
+		})
+	}
+}
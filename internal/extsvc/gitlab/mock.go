@@ -29,6 +29,19 @@ var MockGetMergeRequest func(c *Client, ctx context.Context, project *Project, i
 // Client.GetMergeRequestResourceStateEvents
 var MockGetMergeRequestResourceStateEvents func(c *Client, ctx context.Context, project *Project, iid ID) func() ([]*ResourceStateEvent, error)
 
+// MockGetIssue, if non-nil, will be called instead of Client.GetIssue
+var MockGetIssue func(c *Client, ctx context.Context, project *Project, iid ID) (*Issue, error)
+
+// MockCreateIssue, if non-nil, will be called instead of Client.CreateIssue
+var MockCreateIssue func(c *Client, ctx context.Context, project *Project, opts CreateIssueOpts) (*Issue, error)
+
+// MockUpdateIssue, if non-nil, will be called instead of Client.UpdateIssue
+var MockUpdateIssue func(c *Client, ctx context.Context, project *Project, issue *Issue, opts UpdateIssueOpts) (*Issue, error)
+
+// MockGetIssueResourceStateEvents, if non-nil, will be called instead of
+// Client.GetIssueResourceStateEvents
+var MockGetIssueResourceStateEvents func(c *Client, ctx context.Context, project *Project, iid ID) func() ([]*ResourceStateEvent, error)
+
 // MockGetMergeRequestNotes, if non-nil, will be called instead of
 // Client.GetMergeRequestNotes
 var MockGetMergeRequestNotes func(c *Client, ctx context.Context, project *Project, iid ID) func() ([]*Note, error)
@@ -19,7 +19,22 @@ func (c *Client) GetMergeRequestResourceStateEvents(ctx context.Context, project
 		return MockGetMergeRequestResourceStateEvents(c, ctx, project, iid)
 	}
 
-	baseURL := fmt.Sprintf("projects/%d/merge_requests/%d/resource_state_events", project.ID, iid)
+	return c.getResourceStateEvents(ctx, fmt.Sprintf("projects/%d/merge_requests/%d/resource_state_events", project.ID, iid))
+}
+
+// GetIssueResourceStateEvents retrieves the events for the given issue. As the
+// events are paginated, a function is returned that may be invoked to return the
+// next page of results. An empty slice and a nil error indicates that all pages
+// have been returned.
+func (c *Client) GetIssueResourceStateEvents(ctx context.Context, project *Project, iid ID) func() ([]*ResourceStateEvent, error) {
+	if MockGetIssueResourceStateEvents != nil {
+		return MockGetIssueResourceStateEvents(c, ctx, project, iid)
+	}
+
+	return c.getResourceStateEvents(ctx, fmt.Sprintf("projects/%d/issues/%d/resource_state_events", project.ID, iid))
+}
+
+func (c *Client) getResourceStateEvents(ctx context.Context, baseURL string) func() ([]*ResourceStateEvent, error) {
 	currentPage := "1"
 	return func() ([]*ResourceStateEvent, error) {
 		page := []*ResourceStateEvent{}
@@ -253,6 +253,10 @@ type CreateCommitFromPatchRequest struct {
 	// Push specifies whether the target ref will be pushed to the code host: if
 	// nil, no push will be attempted, if non-nil, a push will be attempted.
 	Push *PushConfig
+	// PushRef is the ref that the commit is pushed to on the code host, if it
+	// differs from TargetRef. Gerrit, for example, creates and updates changes
+	// from pushes to refs/for/<branch>.
+	PushRef *string
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
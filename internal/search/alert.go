@@ -61,6 +61,15 @@ const (
 	// query. May be a number or string representing something approximate,
 	// like "500+".
 	ResultCount AnnotationName = "ResultCount"
+
+	// Rules communicates the comma-separated names of the rules that
+	// generated a query, like "lang-patterns,and-patterns".
+	Rules AnnotationName = "Rules"
+
+	// ResultRange communicates the indexes of the results a query added to
+	// the results of the search, as "start:end" with end excluded, like
+	// "12:20".
+	ResultRange AnnotationName = "ResultRange"
 )
 
 func (q *QueryDescription) QueryString() string {
@@ -74,6 +83,8 @@ func (q *QueryDescription) QueryString() string {
 			return q.Query + " patternType:literal"
 		case query.SearchTypeStructural:
 			return q.Query + " patternType:structural"
+		case query.SearchTypeFuzzy:
+			return q.Query + " patternType:fuzzy"
 		case query.SearchTypeLucky:
 			return q.Query
 		default:
@@ -93,6 +93,11 @@ func (s *searchClient) Plan(
 		if err != nil {
 			return "", err
 		}
+		if sc.Dynamic {
+			// The repositories of dynamic contexts are resolved ahead of
+			// time, so we keep the context: filter.
+			return "", nil
+		}
 		tr.LazyPrintf("substitute query %s for context %s", sc.Query, context)
 		return sc.Query, nil
 	})
@@ -175,6 +180,8 @@ func SearchTypeFromString(patternType string) (query.SearchType, error) {
 		return query.SearchTypeLucky, nil
 	case "keyword":
 		return query.SearchTypeKeyword, nil
+	case "fuzzy":
+		return query.SearchTypeFuzzy, nil
 	default:
 		return -1, errors.Errorf("unrecognized patternType %q", patternType)
 	}
@@ -225,6 +232,8 @@ func overrideSearchType(input string, searchType query.SearchType) query.SearchT
 			searchType = query.SearchTypeLucky
 		case "keyword":
 			searchType = query.SearchTypeKeyword
+		case "fuzzy":
+			searchType = query.SearchTypeFuzzy
 		}
 	})
 	return searchType
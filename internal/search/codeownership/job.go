@@ -6,6 +6,7 @@ import (
 
 	otlog "github.com/opentracing/opentracing-go/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
@@ -43,7 +44,7 @@ func (s *codeownershipJob) Run(ctx context.Context, clients job.RuntimeClients,
 
 	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
 		var err error
-		event.Results, err = applyCodeOwnershipFiltering(ctx, clients.Gitserver, &rules, s.includeOwners, s.excludeOwners, event.Results)
+		event.Results, err = applyCodeOwnershipFiltering(ctx, clients.Gitserver, clients.DB.Repos(), &rules, s.includeOwners, s.excludeOwners, event.Results)
 		if err != nil {
 			mu.Lock()
 			errs = errors.Append(errs, err)
@@ -89,6 +90,7 @@ func (s *codeownershipJob) MapChildren(fn job.MapFunc) job.Job {
 func applyCodeOwnershipFiltering(
 	ctx context.Context,
 	gitserver gitserver.Client,
+	repos database.RepoStore,
 	rules *RulesCache,
 	includeOwners,
 	excludeOwners []string,
@@ -105,7 +107,7 @@ matchesLoop:
 			continue
 		}
 
-		ruleset, err := rules.GetFromCacheOrFetch(ctx, gitserver, mm.Repo.Name, mm.CommitID)
+		ruleset, err := rules.GetFromCacheOrFetch(ctx, gitserver, repos, mm.Repo, mm.CommitID)
 		if err != nil {
 			errs = errors.Append(errs, err)
 		}
@@ -9,8 +9,10 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 func Test_applyCodeOwnershipFiltering(t *testing.T) {
@@ -103,7 +105,10 @@ func Test_applyCodeOwnershipFiltering(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
+			repos := database.NewMockRepoStore()
+			repos.GetFunc.SetDefaultReturn(&types.Repo{ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeGitHub}}, nil)
 			db := database.NewMockDB()
+			db.ReposFunc.SetDefaultReturn(repos)
 			rules := NewRulesCache()
 
 			gitserver.Mocks.ReadFile = func(_ api.CommitID, file string) ([]byte, error) {
@@ -115,7 +120,7 @@ func Test_applyCodeOwnershipFiltering(t *testing.T) {
 			}
 			t.Cleanup(func() { gitserver.Mocks.ReadFile = nil })
 
-			matches, _ := applyCodeOwnershipFiltering(ctx, gitserver.NewClient(db), &rules, tt.args.includeOwners, tt.args.excludeOwners, tt.args.matches)
+			matches, _ := applyCodeOwnershipFiltering(ctx, gitserver.NewClient(db), db.Repos(), &rules, tt.args.includeOwners, tt.args.excludeOwners, tt.args.matches)
 
 			tt.want.Equal(t, matches)
 		})
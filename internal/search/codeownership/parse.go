@@ -0,0 +1,166 @@
+package codeownership
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/hmarr/codeowners"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// sectionHeaderRegexp matches a GitLab section header, e.g. "[Docs]",
+// "^[Optional docs]" or "[Docs][2] @docs-team". The optional "^" marks a
+// section that doesn't require approval, and the optional number is the
+// required approval count. Neither changes who owns a file. Anything after
+// the header is the list of default owners for the section.
+var sectionHeaderRegexp = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(?:\s+(.*))?$`)
+
+// ParseRuleset parses the content of a CODEOWNERS file. The syntax accepted
+// depends on the code host the file was read from, identified by its
+// extsvc service type:
+//
+//   - GitLab files can be split into sections, which can declare default
+//     owners for the rules that don't list any.
+//   - Bitbucket files can refer to groups as "@@group".
+//
+// Like the code hosts themselves, lines that can't be parsed are skipped
+// rather than invalidating the whole file.
+func ParseRuleset(r io.Reader, serviceType string) (Ruleset, error) {
+	var (
+		ruleset Ruleset
+		current *section
+	)
+	sectionsByName := map[string]*section{}
+	getSection := func(name string) *section {
+		key := strings.ToLower(name)
+		if s, ok := sectionsByName[key]; ok {
+			return s
+		}
+		ruleset.sections = append(ruleset.sections, &section{name: name})
+		s := ruleset.sections[len(ruleset.sections)-1]
+		sectionsByName[key] = s
+		return s
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Ignore blank lines and comments
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		if serviceType == extsvc.TypeGitLab {
+			if match := sectionHeaderRegexp.FindStringSubmatch(line); match != nil {
+				current = getSection(match[1])
+				current.defaultOwners = parseOwners(splitFields(match[2]))
+				continue
+			}
+		}
+
+		fields := splitFields(line)
+		rule, ok := parsePattern(fields[0])
+		if !ok {
+			continue
+		}
+		rule.Owners = parseOwners(fields[1:])
+
+		if current == nil {
+			current = getSection("")
+		}
+		if len(fields) == 1 {
+			rule.Owners = current.defaultOwners
+		}
+		current.rules = append(current.rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return Ruleset{}, err
+	}
+
+	return ruleset, nil
+}
+
+// splitFields splits a line on whitespace that isn't escaped with a
+// backslash, dropping a trailing comment. Escapes are kept, since they are
+// part of the pattern syntax.
+func splitFields(line string) []string {
+	var (
+		fields  []string
+		current strings.Builder
+		escaped bool
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			fields = append(fields, current.String())
+			current.Reset()
+		}
+	}
+	for _, ch := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '#' && current.Len() == 0:
+			flush()
+			return fields
+		case ch == ' ' || ch == '\t':
+			flush()
+			continue
+		}
+		current.WriteRune(ch)
+	}
+	flush()
+	return fields
+}
+
+// parsePattern uses hmarr/codeowners to compile a single pattern, so that we
+// match paths exactly like it does. The returned rule has no owners.
+func parsePattern(pattern string) (codeowners.Rule, bool) {
+	rules, err := codeowners.ParseFile(strings.NewReader(pattern))
+	if err != nil || len(rules) != 1 {
+		return codeowners.Rule{}, false
+	}
+	return rules[0], true
+}
+
+// parseOwners converts owner fields to owners, skipping the fields that
+// aren't valid owners. We accept a wider range of handles than
+// hmarr/codeowners, since GitLab usernames can contain dots and GitLab
+// groups can be nested.
+func parseOwners(fields []string) Owners {
+	owners := make(Owners, 0, len(fields))
+	for _, field := range fields {
+		if owner, ok := parseOwner(field); ok {
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
+
+var handleRegexp = regexp.MustCompile(`^[A-Za-z0-9_.\-]+(/[A-Za-z0-9_.\-]+)*$`)
+
+func parseOwner(field string) (codeowners.Owner, bool) {
+	switch {
+	case strings.HasPrefix(field, "@@"):
+		// Bitbucket group
+		group := strings.TrimPrefix(field, "@@")
+		return codeowners.Owner{Value: group, Type: codeowners.TeamOwner}, handleRegexp.MatchString(group)
+	case strings.HasPrefix(field, "@"):
+		handle := strings.TrimPrefix(field, "@")
+		if !handleRegexp.MatchString(handle) {
+			return codeowners.Owner{}, false
+		}
+		if strings.Contains(handle, "/") {
+			return codeowners.Owner{Value: handle, Type: codeowners.TeamOwner}, true
+		}
+		return codeowners.Owner{Value: handle, Type: codeowners.UsernameOwner}, true
+	case strings.Count(field, "@") == 1 && !strings.HasSuffix(field, "@"):
+		return codeowners.Owner{Value: field, Type: codeowners.EmailOwner}, true
+	}
+	return codeowners.Owner{}, false
+}
@@ -0,0 +1,75 @@
+package codeownership
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func TestParseRuleset(t *testing.T) {
+	match := func(t *testing.T, file, serviceType, path string) []string {
+		t.Helper()
+		ruleset, err := ParseRuleset(strings.NewReader(file), serviceType)
+		require.NoError(t, err)
+		owners, err := ruleset.Match(path)
+		require.NoError(t, err)
+		var values []string
+		for _, o := range owners {
+			values = append(values, o.Type+":"+o.String())
+		}
+		return values
+	}
+
+	t.Run("GitHub", func(t *testing.T) {
+		file := `# Comment
+* @global-owner
+*.go @gopher test@example.com # Go code
+/docs/ @octo-org/docs
+/broken/ @@@invalid[
+`
+		autogold.Want("last matching rule wins", []string{"username:@gopher", "email:test@example.com"}).Equal(t, match(t, file, extsvc.TypeGitHub, "cmd/main.go"))
+		autogold.Want("team owner", []string{"team:@octo-org/docs"}).Equal(t, match(t, file, extsvc.TypeGitHub, "docs/README.md"))
+		autogold.Want("fallback rule", []string{"username:@global-owner"}).Equal(t, match(t, file, extsvc.TypeGitHub, "README.md"))
+		autogold.Want("sections are not supported", []string{"username:@global-owner"}).Equal(t, match(t, "* @global-owner\n[Docs] @docs\n", extsvc.TypeGitHub, "README.md"))
+	})
+
+	t.Run("GitLab sections", func(t *testing.T) {
+		file := `* @default-owner
+
+[Docs] @docs-team
+*.md
+/internal/*.md @internal.writer
+
+^[Frontend][2] @frontend/web/reviewers
+*.md @frontend/web/maintainers
+
+[docs]
+/guides/ @guide-writer
+`
+		autogold.Want("owners of each section are combined", []string{
+			"username:@default-owner",
+			"username:@internal.writer",
+			"team:@frontend/web/maintainers",
+		}).Equal(t, match(t, file, extsvc.TypeGitLab, "internal/README.md"))
+		autogold.Want("section default owners", []string{
+			"username:@default-owner",
+			"username:@docs-team",
+			"team:@frontend/web/maintainers",
+		}).Equal(t, match(t, file, extsvc.TypeGitLab, "README.md"))
+		autogold.Want("sections are case insensitive", []string{
+			"username:@default-owner",
+			"username:@guide-writer",
+			"team:@frontend/web/maintainers",
+		}).Equal(t, match(t, file, extsvc.TypeGitLab, "guides/intro.md"))
+	})
+
+	t.Run("Bitbucket groups", func(t *testing.T) {
+		file := `* @@platform-team @alice
+`
+		autogold.Want("group owner", []string{"team:@platform-team", "username:@alice"}).Equal(t, match(t, file, extsvc.TypeBitbucketServer, "main.go"))
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"sync"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 type RulesKey struct {
@@ -23,15 +25,15 @@ func NewRulesCache() RulesCache {
 	return RulesCache{rules: make(map[RulesKey]Ruleset)}
 }
 
-func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) (Ruleset, error) {
+func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, gitserver gitserver.Client, repos database.RepoStore, repo types.MinimalRepo, commitID api.CommitID) (Ruleset, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	key := RulesKey{repoName, commitID}
+	key := RulesKey{repo.Name, commitID}
 	ruleset, ok := c.rules[key]
 	var err error
 	if !ok {
-		ruleset, err = NewRuleset(ctx, gitserver, repoName, commitID)
+		ruleset, err = fetchRuleset(ctx, gitserver, repos, repo, commitID)
 		if err != nil {
 			emptyRuleset := Ruleset{}
 			c.rules[key] = emptyRuleset
@@ -42,3 +44,13 @@ func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, gitserver gitserve
 
 	return ruleset, nil
 }
+
+// fetchRuleset looks up the code host of repo, since where the CODEOWNERS
+// file lives and its syntax depend on it.
+func fetchRuleset(ctx context.Context, gitserver gitserver.Client, repos database.RepoStore, repo types.MinimalRepo, commitID api.CommitID) (Ruleset, error) {
+	r, err := repos.Get(ctx, repo.ID)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	return NewRuleset(ctx, gitserver, repo.Name, commitID, r.ExternalRepo.ServiceType)
+}
@@ -8,39 +8,71 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 )
 
 type Ruleset struct {
-	codeownersRuleset codeowners.Ruleset
+	sections []*section
 }
 type Owners = []codeowners.Owner
 
+// section is a group of rules of a CODEOWNERS file. Only GitLab supports
+// named sections, files from other code hosts have a single unnamed section.
+// The owners of a path are the union of the owners of the last matching rule
+// in each section.
+type section struct {
+	name          string
+	defaultOwners Owners
+	rules         codeowners.Ruleset
+}
+
 func (r *Ruleset) Match(path string) (Owners, error) {
-	if r.codeownersRuleset == nil {
-		return Owners{}, nil
-	}
+	var owners Owners
+	for _, s := range r.sections {
+		rule, err := s.rules.Match(path)
+		if err != nil {
+			return Owners{}, err
+		}
 
-	rule, err := r.codeownersRuleset.Match(path)
-	if err != nil {
-		return Owners{}, err
+		if rule == nil {
+			continue
+		}
+
+		if owners == nil {
+			// We directly return the codeowners.Owner struct to avoid creating
+			// unnecessary copies when a single section matches. We also found
+			// that the longest list of owners is less than 50.
+			// c.f. https://github.com/sourcegraph/sourcegraph/pull/39250#discussion_r927942090
+			owners = rule.Owners
+			continue
+		}
+		owners = appendNewOwners(owners, rule.Owners)
 	}
 
-	if rule == nil {
+	if owners == nil {
 		return Owners{}, nil
 	}
+	return owners, nil
+}
 
-	// We directly return the codeowners.Owner struct to avoid creating
-	// unnecessary copies. We also found that the longest list of owners
-	// is less than 50.
-	// c.f. https://github.com/sourcegraph/sourcegraph/pull/39250#discussion_r927942090
-	return rule.Owners, nil
+// appendNewOwners appends the owners in src that aren't in dst. dst is
+// copied rather than appended to, since it may belong to a rule.
+func appendNewOwners(dst, src Owners) Owners {
+	merged := make(Owners, len(dst), len(dst)+len(src))
+	copy(merged, dst)
+	for _, o := range src {
+		if !containsOwner(merged, o.String()) {
+			merged = append(merged, o)
+		}
+	}
+	return merged
 }
 
-func NewRuleset(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) (Ruleset, error) {
+func NewRuleset(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID, serviceType string) (Ruleset, error) {
 	ruleset := Ruleset{}
 
-	content, err := loadOwnershipFile(ctx, gitserver, repoName, commitID)
+	content, err := loadOwnershipFile(ctx, gitserver, repoName, commitID, serviceType)
 	if err != nil {
 		return ruleset, err
 	}
@@ -48,18 +80,26 @@ func NewRuleset(ctx context.Context, gitserver gitserver.Client, repoName api.Re
 		return ruleset, nil
 	}
 
-	codeownersRuleset, err := codeowners.ParseFile(bytes.NewReader(content))
-	if err != nil {
-		return ruleset, err
-	}
-
-	ruleset.codeownersRuleset = codeownersRuleset
+	return ParseRuleset(bytes.NewReader(content), serviceType)
+}
 
-	return ruleset, nil
+// ownershipFilePaths returns the paths code hosts look for a CODEOWNERS file
+// in, in the order they look for them. Only the first file found is used.
+func ownershipFilePaths(serviceType string) []string {
+	switch serviceType {
+	case extsvc.TypeGitHub:
+		return []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+	case extsvc.TypeGitLab:
+		return []string{"CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud:
+		return []string{".bitbucket/CODEOWNERS", "CODEOWNERS"}
+	default:
+		return []string{"CODEOWNERS", ".github/CODEOWNERS", ".gitlab/CODEOWNERS", ".bitbucket/CODEOWNERS", "docs/CODEOWNERS"}
+	}
 }
 
-func loadOwnershipFile(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) ([]byte, error) {
-	for _, path := range []string{"CODEOWNERS", ".github/CODEOWNERS", ".gitlab/CODEOWNERS", "docs/CODEOWNERS"} {
+func loadOwnershipFile(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID, serviceType string) ([]byte, error) {
+	for _, path := range ownershipFilePaths(serviceType) {
 		content, err := gitserver.ReadFile(
 			ctx,
 			repoName,
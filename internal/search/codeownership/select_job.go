@@ -0,0 +1,128 @@
+package codeownership
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hmarr/codeowners"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewSelectOwners returns a job that replaces the file matches streamed by
+// child with the code owners of those files. Each owner is only sent once.
+func NewSelectOwners(child job.Job) job.Job {
+	return &selectOwnersJob{
+		child: child,
+	}
+}
+
+type selectOwnersJob struct {
+	child job.Job
+}
+
+func (s *selectOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu    sync.Mutex
+		errs  error
+		dedup = result.NewDeduper()
+	)
+
+	rules := NewRulesCache()
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		owners, err := getCodeOwners(ctx, clients.Gitserver, clients.DB.Repos(), &rules, event.Results)
+
+		mu.Lock()
+		if err != nil {
+			errs = errors.Append(errs, err)
+		}
+		selected := owners[:0]
+		for _, owner := range owners {
+			if dedup.Seen(owner) {
+				continue
+			}
+			dedup.Add(owner)
+			selected = append(selected, owner)
+		}
+		mu.Unlock()
+
+		event.Results = selected
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (s *selectOwnersJob) Name() string {
+	return "SelectOwnersJob"
+}
+
+func (s *selectOwnersJob) Fields(job.Verbosity) []otlog.Field { return nil }
+
+func (s *selectOwnersJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *selectOwnersJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
+
+// getCodeOwners returns an OwnerMatch for each owner of the files in
+// matches. The returned owners are not deduplicated across matches.
+func getCodeOwners(
+	ctx context.Context,
+	gitserver gitserver.Client,
+	repos database.RepoStore,
+	rules *RulesCache,
+	matches []result.Match) ([]result.Match, error) {
+	var errs error
+
+	var owners []result.Match
+	for _, m := range matches {
+		// Code ownership is currently only implemented for files.
+		mm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		ruleset, err := rules.GetFromCacheOrFetch(ctx, gitserver, repos, mm.Repo, mm.CommitID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+		}
+
+		fileOwners, err := ruleset.Match(mm.File.Path)
+		if err != nil {
+			errs = errors.Append(errs, err)
+		}
+
+		for _, o := range fileOwners {
+			owners = append(owners, toOwnerMatch(o))
+		}
+	}
+
+	return owners, errs
+}
+
+func toOwnerMatch(o codeowners.Owner) *result.OwnerMatch {
+	if o.Type == codeowners.EmailOwner {
+		return &result.OwnerMatch{Email: o.Value}
+	}
+	return &result.OwnerMatch{Handle: o.Value}
+}
@@ -0,0 +1,87 @@
+package codeownership
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func Test_getCodeOwners(t *testing.T) {
+	tests := []struct {
+		name        string
+		matches     []result.Match
+		repoContent map[string]string
+		want        autogold.Value
+	}{
+		{
+			name: "no owners without a code owners file",
+			matches: []result.Match{
+				&result.FileMatch{
+					File: result.File{
+						Path: "README.md",
+					},
+				},
+			},
+			want: autogold.Want("no owners", []result.Match(nil)),
+		},
+		{
+			name: "selects handle and email owners",
+			matches: []result.Match{
+				&result.FileMatch{
+					File: result.File{
+						Path: "README.md",
+					},
+				},
+				&result.FileMatch{
+					File: result.File{
+						Path: "package.json",
+					},
+				},
+				&result.RepoMatch{
+					Name: "github.com/sourcegraph/sourcegraph",
+				},
+			},
+			repoContent: map[string]string{
+				"CODEOWNERS": "README.md @sqs @octo-org/octocats\n*.json test@example.com\n",
+			},
+			want: autogold.Want("owners of file matches", []result.Match{
+				&result.OwnerMatch{Handle: "sqs"},
+				&result.OwnerMatch{Handle: "octo-org/octocats"},
+				&result.OwnerMatch{Email: "test@example.com"},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := database.NewMockRepoStore()
+			repos.GetFunc.SetDefaultReturn(&types.Repo{ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeGitHub}}, nil)
+			db := database.NewMockDB()
+			db.ReposFunc.SetDefaultReturn(repos)
+			rules := NewRulesCache()
+
+			gitserver.Mocks.ReadFile = func(_ api.CommitID, file string) ([]byte, error) {
+				content, ok := tt.repoContent[file]
+				if !ok {
+					return nil, errors.New("file does not exist")
+				}
+				return []byte(content), nil
+			}
+			t.Cleanup(func() { gitserver.Mocks.ReadFile = nil })
+
+			owners, _ := getCodeOwners(ctx, gitserver.NewClient(db), db.Repos(), &rules, tt.matches)
+
+			tt.want.Equal(t, owners)
+		})
+	}
+}
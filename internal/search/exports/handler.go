@@ -0,0 +1,187 @@
+package exports
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamclient "github.com/sourcegraph/sourcegraph/internal/search/streaming/client"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// progressInterval is how often the progress of a running export is
+// written to the database.
+const progressInterval = 5 * time.Second
+
+// handler runs the search of an export and uploads its results.
+type handler struct {
+	db           database.DB
+	store        *Store
+	uploadStore  uploadstore.Store
+	searchClient client.SearchClient
+}
+
+var _ workerutil.Handler = &handler{}
+
+func (h *handler) Handle(ctx context.Context, logger log.Logger, record workerutil.Record) (err error) {
+	defer func() {
+		if err != nil {
+			logger.Error("searchExports.Handle", log.Error(err))
+		}
+	}()
+
+	e, ok := record.(*Export)
+	if !ok {
+		return errors.Errorf("unexpected record type %T", record)
+	}
+
+	// SECURITY: search as the user who created the export, so that it only
+	// contains results from repositories the user can read.
+	ctx = actor.WithActor(ctx, actor.FromUser(e.UserID))
+
+	inputs, err := h.searchClient.Plan(ctx, "V3", e.PatternType, e.Query, search.Streaming, e.Settings, envvar.SourcegraphDotComMode())
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+	withCountAll(inputs)
+
+	f, err := os.CreateTemp("", fmt.Sprintf("search-export-%d-*.%s", e.ID, e.Format))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	if err := h.search(ctx, e, inputs, f); err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	key := uploadKey(e)
+	size, err := h.uploadStore.Upload(ctx, key, f)
+	if err != nil {
+		return errors.Wrap(err, "upload export")
+	}
+	return h.store.SetUpload(ctx, e.ID, key, size)
+}
+
+// search runs the search of e and writes its results to w. The progress of
+// the search is periodically written to the database.
+func (h *handler) search(ctx context.Context, e *Export, inputs *search.Inputs, w io.Writer) error {
+	var (
+		mu       sync.Mutex
+		writeErr error
+		progress = &streamclient.ProgressAggregator{
+			Start:     time.Now(),
+			Limit:     inputs.MaxResults(),
+			RepoNamer: streamclient.RepoNamer(ctx, h.db),
+		}
+	)
+
+	mw := newMatchWriter(e.Format, w)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		progress.Update(event)
+		if writeErr != nil {
+			return
+		}
+		for _, m := range event.Results {
+			if _, writeErr = mw.Write(m); writeErr != nil {
+				return
+			}
+		}
+	})
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			mu.Lock()
+			var current *streamapi.Progress
+			if progress.Dirty {
+				p := progress.Current()
+				current = &p
+			}
+			mu.Unlock()
+
+			if current != nil {
+				// Progress is informational, so a failed update doesn't
+				// fail the export.
+				_ = h.store.UpdateProgress(ctx, e.ID, *current)
+			}
+		}
+	}()
+
+	_, err := h.searchClient.Execute(ctx, stream, inputs)
+	close(done)
+	wg.Wait()
+	if err != nil {
+		return errors.Wrap(err, "execute search")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if writeErr != nil {
+		return errors.Wrap(writeErr, "write export")
+	}
+	if err := mw.Close(); err != nil {
+		return errors.Wrap(err, "write export")
+	}
+	return h.store.UpdateProgress(ctx, e.ID, progress.Final())
+}
+
+// withCountAll sets count:all on the queries of inputs that don't set a count,
+// so that exports contain every result rather than the default number of
+// results of a search. The count is set on the parsed queries, so that it
+// applies to every operand of an or-expression.
+func withCountAll(inputs *search.Inputs) {
+	plan := make(query.Plan, 0, len(inputs.Plan))
+	for _, b := range inputs.Plan {
+		if b.ToParseTree().Count() == nil {
+			parameters := make([]query.Parameter, 0, len(b.Parameters)+1)
+			parameters = append(parameters, b.Parameters...)
+			parameters = append(parameters, query.Parameter{Field: query.FieldCount, Value: strconv.Itoa(query.CountAll)})
+			b = b.MapParameters(parameters)
+		}
+		plan = append(plan, b)
+	}
+	inputs.Plan = plan
+	inputs.Query = plan.ToQ()
+}
+
+func uploadKey(e *Export) string {
+	return fmt.Sprintf("search-exports/%d.%s", e.ID, e.Format)
+}
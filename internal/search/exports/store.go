@@ -0,0 +1,253 @@
+package exports
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Format is the file format of an export.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// ParseFormat returns the format named s, or an error if s is not a
+// supported format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatCSV, FormatJSONL:
+		return f, nil
+	default:
+		return "", errors.Errorf("unsupported export format %q: must be csv or jsonl", s)
+	}
+}
+
+// ContentType returns the MIME type of files in format f.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+// Export is a search query whose results are written to a file in the upload
+// store by the export worker.
+type Export struct {
+	ID          int
+	UserID      int32
+	Query       string
+	PatternType *string
+	Format      Format
+
+	// Settings are the final settings of the user when the export was
+	// created.
+	Settings *schema.Settings
+
+	// Progress is the latest progress of the search. It is nil until the
+	// worker starts running the search.
+	Progress *streamapi.Progress
+
+	// UploadKey and UploadSize are set once the export file is uploaded.
+	UploadKey  *string
+	UploadSize *int64
+
+	CreatedAt      time.Time
+	State          string
+	FailureMessage *string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int
+	NumFailures    int
+}
+
+// RecordID implements workerutil.Record.
+func (e *Export) RecordID() int {
+	return e.ID
+}
+
+// Done returns true if the export finished, successfully or not.
+func (e *Export) Done() bool {
+	return e.State == "completed" || e.State == "failed" || e.State == "errored"
+}
+
+// Columns are the columns of search_exports read by scanExport.
+var Columns = []*sqlf.Query{
+	sqlf.Sprintf("search_exports.id"),
+	sqlf.Sprintf("search_exports.user_id"),
+	sqlf.Sprintf("search_exports.query"),
+	sqlf.Sprintf("search_exports.pattern_type"),
+	sqlf.Sprintf("search_exports.format"),
+	sqlf.Sprintf("search_exports.settings"),
+	sqlf.Sprintf("search_exports.progress"),
+	sqlf.Sprintf("search_exports.upload_key"),
+	sqlf.Sprintf("search_exports.upload_size"),
+	sqlf.Sprintf("search_exports.created_at"),
+	sqlf.Sprintf("search_exports.state"),
+	sqlf.Sprintf("search_exports.failure_message"),
+	sqlf.Sprintf("search_exports.started_at"),
+	sqlf.Sprintf("search_exports.finished_at"),
+	sqlf.Sprintf("search_exports.process_after"),
+	sqlf.Sprintf("search_exports.num_resets"),
+	sqlf.Sprintf("search_exports.num_failures"),
+}
+
+func scanExport(s dbutil.Scanner) (*Export, error) {
+	var (
+		e        Export
+		settings []byte
+		progress []byte
+	)
+	if err := s.Scan(
+		&e.ID,
+		&e.UserID,
+		&e.Query,
+		&e.PatternType,
+		&e.Format,
+		&settings,
+		&progress,
+		&e.UploadKey,
+		&e.UploadSize,
+		&e.CreatedAt,
+		&e.State,
+		&e.FailureMessage,
+		&e.StartedAt,
+		&e.FinishedAt,
+		&e.ProcessAfter,
+		&e.NumResets,
+		&e.NumFailures,
+	); err != nil {
+		return nil, err
+	}
+	e.Settings = &schema.Settings{}
+	if err := json.Unmarshal(settings, e.Settings); err != nil {
+		return nil, errors.Wrap(err, "unmarshal settings")
+	}
+	if progress != nil {
+		e.Progress = &streamapi.Progress{}
+		if err := json.Unmarshal(progress, e.Progress); err != nil {
+			return nil, errors.Wrap(err, "unmarshal progress")
+		}
+	}
+	return &e, nil
+}
+
+var (
+	scanExports     = basestore.NewSliceScanner(scanExport)
+	scanFirstExport = basestore.NewFirstScanner(scanExport)
+)
+
+// ErrNotFound is returned by Store.GetByID if there is no export with the
+// given ID.
+var ErrNotFound = errors.New("search export not found")
+
+// Store reads and writes search exports.
+type Store struct {
+	*basestore.Store
+}
+
+// NewStore returns a store backed by the given database handle.
+func NewStore(handle basestore.TransactableHandle) *Store {
+	return &Store{Store: basestore.NewWithHandle(handle)}
+}
+
+// Create enqueues a new export of the results of query for the given user,
+// whose search is planned with the given final settings of the user.
+func (s *Store) Create(ctx context.Context, userID int32, query string, patternType *string, format Format, settings *schema.Settings) (*Export, error) {
+	rawSettings, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlf.Sprintf(createExportFmtstr, userID, query, patternType, string(format), rawSettings, sqlf.Join(Columns, ", "))
+	e, _, err := scanFirstExport(s.Query(ctx, q))
+	return e, err
+}
+
+const createExportFmtstr = `
+-- source: internal/search/exports/store.go:Create
+INSERT INTO search_exports (user_id, query, pattern_type, format, settings)
+VALUES (%s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// GetByID returns the export with the given ID.
+func (s *Store) GetByID(ctx context.Context, id int) (*Export, error) {
+	q := sqlf.Sprintf(getExportFmtstr, sqlf.Join(Columns, ", "), id)
+	e, ok, err := scanFirstExport(s.Query(ctx, q))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return e, nil
+}
+
+const getExportFmtstr = `
+-- source: internal/search/exports/store.go:GetByID
+SELECT %s FROM search_exports WHERE id = %s
+`
+
+// ListByUser returns the exports of a user, most recent first.
+func (s *Store) ListByUser(ctx context.Context, userID int32) ([]*Export, error) {
+	q := sqlf.Sprintf(listExportsFmtstr, sqlf.Join(Columns, ", "), userID)
+	return scanExports(s.Query(ctx, q))
+}
+
+const listExportsFmtstr = `
+-- source: internal/search/exports/store.go:ListByUser
+SELECT %s FROM search_exports WHERE user_id = %s ORDER BY id DESC
+`
+
+// UpdateProgress records the latest search progress of an export.
+func (s *Store) UpdateProgress(ctx context.Context, id int, progress streamapi.Progress) error {
+	raw, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	return s.Exec(ctx, sqlf.Sprintf(updateProgressFmtstr, raw, id))
+}
+
+const updateProgressFmtstr = `
+-- source: internal/search/exports/store.go:UpdateProgress
+UPDATE search_exports SET progress = %s WHERE id = %s
+`
+
+// SetUpload records the key and size of the uploaded export file.
+func (s *Store) SetUpload(ctx context.Context, id int, key string, size int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(setUploadFmtstr, key, size, id))
+}
+
+const setUploadFmtstr = `
+-- source: internal/search/exports/store.go:SetUpload
+UPDATE search_exports SET upload_key = %s, upload_size = %s WHERE id = %s
+`
+
+// DeleteFinishedBefore deletes the exports that finished before the given
+// time, and returns the upload keys of the ones that had an export file.
+func (s *Store) DeleteFinishedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	return basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(deleteFinishedBeforeFmtstr, before)))
+}
+
+const deleteFinishedBeforeFmtstr = `
+-- source: internal/search/exports/store.go:DeleteFinishedBefore
+WITH deleted AS (
+	DELETE FROM search_exports
+	WHERE
+		state IN ('completed', 'failed', 'errored')
+		AND finished_at < %s
+	RETURNING upload_key
+)
+SELECT upload_key FROM deleted WHERE upload_key IS NOT NULL
+`
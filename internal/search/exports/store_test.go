@@ -0,0 +1,80 @@
+package exports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := NewStore(db.Handle())
+
+	user, err := db.Users().Create(ctx, database.NewUser{Username: "test"})
+	require.NoError(t, err)
+
+	patternType := "regexp"
+	settings := &schema.Settings{SearchDefaultPatternType: "regexp"}
+	e, err := store.Create(ctx, user.ID, "foo.*bar", &patternType, FormatCSV, settings)
+	require.NoError(t, err)
+	require.Equal(t, "queued", e.State)
+	require.Equal(t, FormatCSV, e.Format)
+	require.Nil(t, e.Progress)
+
+	progress := streamapi.Progress{MatchCount: 12, DurationMs: 34, Skipped: []streamapi.Skipped{}}
+	require.NoError(t, store.UpdateProgress(ctx, e.ID, progress))
+	require.NoError(t, store.SetUpload(ctx, e.ID, "search-exports/1.csv", 56))
+
+	got, err := store.GetByID(ctx, e.ID)
+	require.NoError(t, err)
+	require.Equal(t, &progress, got.Progress)
+	require.Equal(t, "search-exports/1.csv", *got.UploadKey)
+	require.Equal(t, int64(56), *got.UploadSize)
+	require.Equal(t, "foo.*bar", got.Query)
+	require.Equal(t, patternType, *got.PatternType)
+	require.Equal(t, settings, got.Settings)
+
+	other, err := store.Create(ctx, user.ID, "baz", nil, FormatJSONL, &schema.Settings{})
+	require.NoError(t, err)
+
+	list, err := store.ListByUser(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, other.ID, list[0].ID)
+
+	_, err = store.GetByID(ctx, other.ID+100)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	t.Run("DeleteFinishedBefore", func(t *testing.T) {
+		// Only finished exports are deleted, and only the keys of exports
+		// with a file are returned.
+		require.NoError(t, store.Exec(ctx, sqlf.Sprintf(
+			"UPDATE search_exports SET state = 'completed', finished_at = %s WHERE id = %s",
+			time.Now().Add(-48*time.Hour), e.ID,
+		)))
+
+		keys, err := store.DeleteFinishedBefore(ctx, time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []string{"search-exports/1.csv"}, keys)
+
+		list, err := store.ListByUser(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, other.ID, list[0].ID)
+	})
+}
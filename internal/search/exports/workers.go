@@ -0,0 +1,100 @@
+package exports
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	workerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// NewWorker returns a worker that runs queued search exports.
+func NewWorker(ctx context.Context, logger log.Logger, db database.DB, uploadStore uploadstore.Store, workerStore workerstore.Store, metrics workerutil.WorkerMetrics) *workerutil.Worker {
+	h := &handler{
+		db:           db,
+		store:        NewStore(db.Handle()),
+		uploadStore:  uploadStore,
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+	}
+	options := workerutil.WorkerOptions{
+		Name:              "search_exports_worker",
+		NumHandlers:       2,
+		Interval:          5 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           metrics,
+	}
+	return dbworker.NewWorker(ctx, workerStore, h, options)
+}
+
+// NewResetter returns a resetter that requeues exports whose worker stopped
+// sending heartbeats.
+func NewResetter(ctx context.Context, logger log.Logger, workerStore workerstore.Store, metrics dbworker.ResetterMetrics) *dbworker.Resetter {
+	options := dbworker.ResetterOptions{
+		Name:     "search_exports_resetter",
+		Interval: 1 * time.Minute,
+		Metrics:  metrics,
+	}
+	return dbworker.NewResetter(logger, workerStore, options)
+}
+
+// CreateWorkerStore returns the dbworker store of the search_exports queue.
+func CreateWorkerStore(logger log.Logger, dbHandle basestore.TransactableHandle) workerstore.Store {
+	return workerstore.New(logger, dbHandle, workerstore.Options{
+		Name:              "search_exports_worker_store",
+		TableName:         "search_exports",
+		ColumnExpressions: Columns,
+		Scan:              workerstore.BuildWorkerScan(scanExport),
+		OrderByExpression: sqlf.Sprintf("search_exports.id"),
+		StalledMaxAge:     time.Minute,
+		MaxNumResets:      3,
+		MaxNumRetries:     1,
+		RetryAfter:        time.Minute,
+	})
+}
+
+// NewJanitor returns a background routine that deletes the exports, and their
+// files, that finished more than retention ago.
+func NewJanitor(ctx context.Context, logger log.Logger, store *Store, uploadStore uploadstore.Store, retention time.Duration, observationContext *observation.Context) goroutine.BackgroundRoutine {
+	redMetrics := metrics.NewREDMetrics(
+		observationContext.Registerer,
+		"search_exports_janitor",
+		metrics.WithCountHelp("Total number of search exports janitor executions"),
+	)
+	operation := observationContext.Operation(observation.Op{
+		Name:    "SearchExports.Janitor.Run",
+		Metrics: redMetrics,
+	})
+	return goroutine.NewPeriodicGoroutineWithMetrics(ctx, 1*time.Hour, goroutine.NewHandlerWithErrorMessage(
+		"search_exports_janitor",
+		func(ctx context.Context) error {
+			return deleteExpiredExports(ctx, logger, store, uploadStore, time.Now().Add(-retention))
+		},
+	), operation)
+}
+
+func deleteExpiredExports(ctx context.Context, logger log.Logger, store *Store, uploadStore uploadstore.Store, before time.Time) error {
+	keys, err := store.DeleteFinishedBefore(ctx, before)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		// Files we fail to delete here are eventually removed by the TTL
+		// of the bucket.
+		if err := uploadStore.Delete(ctx, key); err != nil {
+			logger.Warn("failed to delete search export file", log.String("key", key), log.Error(err))
+		}
+	}
+	return nil
+}
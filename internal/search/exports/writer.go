@@ -0,0 +1,153 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Row is a single exported match. File matches without line matches are
+// exported as one row of type "file", file matches with line matches as one
+// row of type "line" per matched line, and commit and diff matches as one row
+// of type "commit". Other matches, such as repository matches, are not
+// exported.
+type Row struct {
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+	Commit     string `json:"commit"`
+	Path       string `json:"path,omitempty"`
+
+	// LineNumber is 1-based. Preview is the content of the matched line.
+	LineNumber int    `json:"lineNumber,omitempty"`
+	Preview    string `json:"preview,omitempty"`
+
+	Author  string     `json:"author,omitempty"`
+	Date    *time.Time `json:"date,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+// csvHeader is the first record of a CSV export, in the order written by
+// csvRecord.
+var csvHeader = []string{"type", "repository", "commit", "path", "line_number", "preview", "author", "date", "message"}
+
+func (r Row) csvRecord() []string {
+	var lineNumber, date string
+	if r.LineNumber > 0 {
+		lineNumber = strconv.Itoa(r.LineNumber)
+	}
+	if r.Date != nil {
+		date = r.Date.UTC().Format(time.RFC3339)
+	}
+	return []string{r.Type, r.Repository, r.Commit, r.Path, lineNumber, r.Preview, r.Author, date, r.Message}
+}
+
+// Rows returns the rows that m is exported as.
+func Rows(m result.Match) []Row {
+	switch v := m.(type) {
+	case *result.FileMatch:
+		base := Row{
+			Type:       "file",
+			Repository: string(v.Repo.Name),
+			Commit:     string(v.CommitID),
+			Path:       v.Path,
+		}
+		lineMatches := v.ChunkMatches.AsLineMatches()
+		if len(lineMatches) == 0 {
+			return []Row{base}
+		}
+		rows := make([]Row, 0, len(lineMatches))
+		for _, lm := range lineMatches {
+			row := base
+			row.Type = "line"
+			row.LineNumber = int(lm.LineNumber) + 1
+			row.Preview = lm.Preview
+			rows = append(rows, row)
+		}
+		return rows
+	case *result.CommitMatch:
+		date := v.Commit.Author.Date
+		return []Row{{
+			Type:       "commit",
+			Repository: string(v.Repo.Name),
+			Commit:     string(v.Commit.ID),
+			Author:     v.Commit.Author.Name,
+			Date:       &date,
+			Message:    string(v.Commit.Message),
+		}}
+	default:
+		return nil
+	}
+}
+
+// matchWriter writes search matches to a file in an export format.
+type matchWriter interface {
+	// Write writes the rows of m. It returns the number of rows written.
+	Write(m result.Match) (int, error)
+
+	// Close flushes any buffered rows. It does not close the underlying
+	// writer.
+	Close() error
+}
+
+func newMatchWriter(format Format, w io.Writer) matchWriter {
+	if format == FormatCSV {
+		return &csvWriter{w: csv.NewWriter(w)}
+	}
+	return &jsonlWriter{enc: json.NewEncoder(w)}
+}
+
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (c *csvWriter) Write(m result.Match) (int, error) {
+	if !c.headerWritten {
+		if err := c.w.Write(csvHeader); err != nil {
+			return 0, err
+		}
+		c.headerWritten = true
+	}
+	rows := Rows(m)
+	for i, row := range rows {
+		if err := c.w.Write(row.csvRecord()); err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
+func (c *csvWriter) Close() error {
+	if !c.headerWritten {
+		// Write the header of empty exports, so that they are still
+		// valid CSV files with the expected columns.
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonlWriter) Write(m result.Match) (int, error) {
+	rows := Rows(m)
+	for i, row := range rows {
+		// Encode terminates each value with a newline.
+		if err := j.enc.Encode(row); err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
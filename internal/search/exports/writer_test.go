@@ -0,0 +1,126 @@
+package exports
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func testMatches() []result.Match {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	return []result.Match{
+		&result.FileMatch{
+			File: result.File{Repo: repo, CommitID: "abc", Path: "README.md"},
+		},
+		&result.FileMatch{
+			File: result.File{Repo: repo, CommitID: "abc", Path: "main.go"},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "func main() {\n\tfmt.Println(\"hello, world\")",
+				ContentStart: result.Location{Line: 4},
+				Ranges: result.Ranges{
+					{Start: result.Location{Line: 4, Column: 5}, End: result.Location{Line: 4, Column: 9}},
+					{Start: result.Location{Line: 5, Column: 14}, End: result.Location{Line: 5, Column: 19}},
+				},
+			}},
+		},
+		&result.CommitMatch{
+			Repo: repo,
+			Commit: gitdomain.Commit{
+				ID:      "def",
+				Author:  gitdomain.Signature{Name: "Alice", Date: time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)},
+				Message: "Add main, with a \"quoted\" word",
+			},
+		},
+		&result.RepoMatch{Name: repo.Name, ID: repo.ID},
+	}
+}
+
+func TestRows(t *testing.T) {
+	var rows []Row
+	for _, m := range testMatches() {
+		rows = append(rows, Rows(m)...)
+	}
+
+	date := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, []Row{
+		{Type: "file", Repository: "github.com/sourcegraph/sourcegraph", Commit: "abc", Path: "README.md"},
+		{Type: "line", Repository: "github.com/sourcegraph/sourcegraph", Commit: "abc", Path: "main.go", LineNumber: 5, Preview: "func main() {"},
+		{Type: "line", Repository: "github.com/sourcegraph/sourcegraph", Commit: "abc", Path: "main.go", LineNumber: 6, Preview: "\tfmt.Println(\"hello, world\")"},
+		{Type: "commit", Repository: "github.com/sourcegraph/sourcegraph", Commit: "def", Author: "Alice", Date: &date, Message: "Add main, with a \"quoted\" word"},
+	}, rows)
+}
+
+func TestMatchWriter(t *testing.T) {
+	write := func(t *testing.T, format Format, matches []result.Match) string {
+		t.Helper()
+		var buf bytes.Buffer
+		w := newMatchWriter(format, &buf)
+		for _, m := range matches {
+			_, err := w.Write(m)
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		return buf.String()
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		want := `type,repository,commit,path,line_number,preview,author,date,message
+file,github.com/sourcegraph/sourcegraph,abc,README.md,,,,,
+line,github.com/sourcegraph/sourcegraph,abc,main.go,5,func main() {,,,
+line,github.com/sourcegraph/sourcegraph,abc,main.go,6,"	fmt.Println(""hello, world"")",,,
+commit,github.com/sourcegraph/sourcegraph,def,,,,Alice,2022-08-01T12:00:00Z,"Add main, with a ""quoted"" word"
+`
+		require.Equal(t, want, write(t, FormatCSV, testMatches()))
+	})
+
+	t.Run("empty csv", func(t *testing.T) {
+		require.Equal(t, "type,repository,commit,path,line_number,preview,author,date,message\n", write(t, FormatCSV, nil))
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		want := `{"type":"file","repository":"github.com/sourcegraph/sourcegraph","commit":"abc","path":"README.md"}
+{"type":"line","repository":"github.com/sourcegraph/sourcegraph","commit":"abc","path":"main.go","lineNumber":5,"preview":"func main() {"}
+{"type":"line","repository":"github.com/sourcegraph/sourcegraph","commit":"abc","path":"main.go","lineNumber":6,"preview":"\tfmt.Println(\"hello, world\")"}
+{"type":"commit","repository":"github.com/sourcegraph/sourcegraph","commit":"def","author":"Alice","date":"2022-08-01T12:00:00Z","message":"Add main, with a \"quoted\" word"}
+`
+		require.Equal(t, want, write(t, FormatJSONL, testMatches()))
+	})
+}
+
+func TestWithCountAll(t *testing.T) {
+	cases := []struct {
+		query      string
+		want       []string
+		maxResults int
+	}{
+		{query: "foo", want: []string{"count:99999999 foo"}, maxResults: 99999999},
+		{query: "repo:foo bar", want: []string{"repo:foo count:99999999 bar"}, maxResults: 99999999},
+		{query: "foo count:100", want: []string{"count:100 foo"}, maxResults: 100},
+		{query: "foo or bar", want: []string{"count:99999999 (foo OR bar)"}, maxResults: 99999999},
+		{query: "(foo count:10) or bar", want: []string{"count:10 foo", "count:99999999 bar"}, maxResults: 99999999},
+	}
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			plan, err := query.Pipeline(query.Init(tc.query, query.SearchTypeLiteral))
+			require.NoError(t, err)
+
+			inputs := &search.Inputs{Plan: plan, Query: plan.ToQ()}
+			withCountAll(inputs)
+
+			var have []string
+			for _, b := range inputs.Plan {
+				have = append(have, b.StringHuman())
+			}
+			require.Equal(t, tc.want, have)
+			require.Equal(t, tc.maxResults, inputs.Query.MaxResults(30))
+		})
+	}
+}
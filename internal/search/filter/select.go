@@ -10,6 +10,7 @@ const (
 	Commit     = "commit"
 	Content    = "content"
 	File       = "file"
+	Owner      = "owner"
 	Repository = "repo"
 	Symbol     = "symbol"
 )
@@ -44,6 +45,7 @@ var validSelectors = object{
 		"directory": nil,
 		"path":      nil,
 	},
+	Owner:      nil,
 	Repository: nil,
 	Symbol: object{
 		/* cf. SymbolKind https://microsoft.github.io/language-server-protocol/specification */
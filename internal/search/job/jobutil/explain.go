@@ -0,0 +1,148 @@
+package jobutil
+
+import (
+	"context"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+)
+
+// ExplainNode describes a job of a search plan without running it.
+type ExplainNode struct {
+	Name   string
+	Fields []otlog.Field
+
+	// Estimate describes the repositories searched by this job and its
+	// descendants. It is nil if the job doesn't search a resolved set of
+	// repositories, for example if it is a global Zoekt search.
+	Estimate *Estimate
+
+	Children []*ExplainNode
+}
+
+// Estimate describes how a job fans out to repositories and search backends.
+type Estimate struct {
+	// ResolvedRepos is the number of repositories the query resolved to.
+	ResolvedRepos int
+
+	// IndexedRepos and UnindexedRepos split ResolvedRepos by whether Zoekt
+	// or searcher will search them.
+	IndexedRepos   int
+	UnindexedRepos int
+
+	// Pages is the number of pages of repositories. Child jobs run once per
+	// page.
+	Pages int
+
+	// ZoektCalls and SearcherCalls are the number of requests that will be
+	// made to Zoekt and searcher.
+	ZoektCalls    int
+	SearcherCalls int
+}
+
+func (e *Estimate) add(o *Estimate) {
+	e.ResolvedRepos += o.ResolvedRepos
+	e.IndexedRepos += o.IndexedRepos
+	e.UnindexedRepos += o.UnindexedRepos
+	e.Pages += o.Pages
+	e.ZoektCalls += o.ZoektCalls
+	e.SearcherCalls += o.SearcherCalls
+}
+
+// Explain returns a description of the job tree j, annotated with estimates of
+// the work it will do. Explain resolves the repositories that j searches, but
+// doesn't run any search.
+func Explain(ctx context.Context, clients job.RuntimeClients, j job.Describer) (*ExplainNode, error) {
+	node := &ExplainNode{
+		Name:   j.Name(),
+		Fields: j.Fields(job.VerbosityBasic),
+	}
+
+	for _, child := range j.Children() {
+		childNode, err := Explain(ctx, clients, child)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, childNode)
+	}
+
+	if pager, ok := j.(*repoPagerJob); ok {
+		estimate, err := pager.estimate(ctx, clients)
+		if err != nil {
+			return nil, err
+		}
+		node.Estimate = estimate
+		return node, nil
+	}
+
+	// Other jobs sum the estimates of their descendants, so that each node
+	// shows the work done by its whole subtree.
+	for _, child := range node.Children {
+		if child.Estimate == nil {
+			continue
+		}
+		if node.Estimate == nil {
+			node.Estimate = &Estimate{}
+		}
+		node.Estimate.add(child.Estimate)
+	}
+	return node, nil
+}
+
+// estimate resolves and partitions the repositories of the pager the same way
+// Run does, and counts the backend requests its child would make.
+func (p *repoPagerJob) estimate(ctx context.Context, clients job.RuntimeClients) (*Estimate, error) {
+	var zoektJobs, searcherJobs int
+	job.Visit(p.child, func(d job.Describer) {
+		switch d.(type) {
+		case *zoekt.RepoSubsetTextSearchJob, *zoekt.SymbolSearchJob:
+			zoektJobs++
+		case *searcher.TextSearchJob, *searcher.SymbolSearchJob:
+			searcherJobs++
+		}
+	})
+
+	var e Estimate
+	repoResolver := repos.NewResolver(clients.Logger, clients.DB, clients.SearcherURLs, clients.Zoekt)
+	err := repoResolver.Paginate(ctx, p.repoOpts, func(page *repos.Resolved) error {
+		indexed, unindexed, err := zoekt.PartitionRepos(
+			ctx,
+			clients.Logger,
+			page.RepoRevs,
+			clients.Zoekt,
+			search.TextRequest,
+			p.repoOpts.UseIndex,
+			p.containsRefGlobs,
+		)
+		if err != nil {
+			return err
+		}
+
+		e.Pages++
+		e.ResolvedRepos += len(page.RepoRevs)
+		if indexed != nil && len(indexed.RepoRevs) > 0 {
+			e.IndexedRepos += len(indexed.RepoRevs)
+			// Zoekt searches all indexed repos of a page in one request.
+			e.ZoektCalls += zoektJobs
+		}
+		e.UnindexedRepos += len(unindexed)
+		for _, repoRevs := range unindexed {
+			// Searcher is called once per revision of each repo.
+			revs := len(repoRevs.Revs)
+			if revs == 0 {
+				revs = 1
+			}
+			e.SearcherCalls += searcherJobs * revs
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
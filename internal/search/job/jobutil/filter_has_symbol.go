@@ -0,0 +1,304 @@
+package jobutil
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// symbolSubqueryLimit is the maximum number of files with matching symbols
+// that a has.symbol() subquery collects.
+const symbolSubqueryLimit = 10000
+
+// NewHasSymbolFilterJob creates a filter job to post-filter results for the
+// file:has.symbol() and repo:has.symbol() predicates.
+//
+// Each predicate is evaluated by a symbol search subquery, which runs over the
+// same repositories as the original query (and the same files, for
+// file:has.symbol()). Subqueries are built with NewBasicJob, so they search
+// with Zoekt for indexed repositories and with searcher for the others. Once
+// all subqueries are done, the child runs and only the results in a file (or
+// repository) that defines a matching symbol for every predicate are sent.
+func NewHasSymbolFilterJob(inputs *search.Inputs, b query.Basic, fileSymbols, repoSymbols []query.SymbolFilter, child job.Job) (job.Job, error) {
+	newSubqueries := func(filters []query.SymbolFilter, scope map[string]struct{}) ([]job.Job, error) {
+		subqueries := make([]job.Job, 0, len(filters))
+		for _, f := range filters {
+			subInputs := *inputs
+			subInputs.PatternType = query.SearchTypeRegex
+			subquery, err := NewBasicJob(&subInputs, symbolSubquery(b, f, scope))
+			if err != nil {
+				return nil, err
+			}
+			subqueries = append(subqueries, subquery)
+		}
+		return subqueries, nil
+	}
+
+	fileSubqueries, err := newSubqueries(fileSymbols, fileScopeFields)
+	if err != nil {
+		return nil, err
+	}
+	repoSubqueries, err := newSubqueries(repoSymbols, repoScopeFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hasSymbolFilterJob{
+		fileSymbols:    fileSymbols,
+		repoSymbols:    repoSymbols,
+		fileSubqueries: fileSubqueries,
+		repoSubqueries: repoSubqueries,
+		child:          child,
+	}, nil
+}
+
+// repoScopeFields are the fields of the original query that are kept in a
+// repo:has.symbol() subquery.
+var repoScopeFields = map[string]struct{}{
+	query.FieldRepo:       {},
+	query.FieldRev:        {},
+	query.FieldContext:    {},
+	query.FieldFork:       {},
+	query.FieldArchived:   {},
+	query.FieldVisibility: {},
+	query.FieldIndex:      {},
+	query.FieldCase:       {},
+	query.FieldTimeout:    {},
+}
+
+// fileScopeFields are the fields of the original query that are kept in a
+// file:has.symbol() subquery.
+var fileScopeFields = func() map[string]struct{} {
+	fields := map[string]struct{}{
+		query.FieldFile: {},
+		query.FieldLang: {},
+	}
+	for field := range repoScopeFields {
+		fields[field] = struct{}{}
+	}
+	return fields
+}()
+
+// symbolSubquery returns a symbol search for the symbols matching f, scoped
+// by the fields of b that are in scope.
+func symbolSubquery(b query.Basic, f query.SymbolFilter, scope map[string]struct{}) query.Basic {
+	var params []query.Parameter
+	for _, p := range b.Parameters {
+		if _, ok := scope[p.Field]; !ok {
+			continue
+		}
+		if p.Annotation.Labels.IsSet(query.IsPredicate) {
+			// Keep predicates that narrow down repositories, but drop
+			// the ones that need post-filtering, including this one.
+			name, _ := query.ParseAsPredicate(p.Value)
+			if p.Field != query.FieldRepo || name == "has.symbol" {
+				continue
+			}
+		}
+		params = append(params, p)
+	}
+
+	selectPath := filter.Symbol
+	if f.Kind != "" {
+		selectPath += "." + f.Kind
+	}
+
+	params = append(params,
+		query.Parameter{Field: query.FieldType, Value: "symbol"},
+		query.Parameter{Field: query.FieldSelect, Value: selectPath},
+		query.Parameter{Field: query.FieldCount, Value: strconv.Itoa(symbolSubqueryLimit)},
+	)
+
+	return query.Basic{
+		Parameters: params,
+		Pattern: query.Pattern{
+			Value:      f.Pattern,
+			Annotation: query.Annotation{Labels: query.Regexp},
+		},
+	}
+}
+
+type hasSymbolFilterJob struct {
+	fileSymbols []query.SymbolFilter
+	repoSymbols []query.SymbolFilter
+
+	// One subquery per predicate, in the same order as the filters above.
+	fileSubqueries []job.Job
+	repoSubqueries []job.Job
+
+	child job.Job
+}
+
+type symbolFileKey struct {
+	repo api.RepoID
+	path string
+}
+
+// symbolSubqueryResult is the set of files and repositories that define a
+// symbol matched by a subquery.
+type symbolSubqueryResult struct {
+	files map[symbolFileKey]struct{}
+	repos map[api.RepoID]struct{}
+}
+
+func (j *hasSymbolFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	fileResults := make([]symbolSubqueryResult, len(j.fileSubqueries))
+	repoResults := make([]symbolSubqueryResult, len(j.repoSubqueries))
+
+	g, gctx := errgroup.WithContext(ctx)
+	runSubqueries := func(subqueries []job.Job, results []symbolSubqueryResult) {
+		for i, subquery := range subqueries {
+			i, subquery := i, subquery
+			g.Go(func() error {
+				res, limitHit, err := runSymbolSubquery(gctx, clients, subquery)
+				if limitHit {
+					// Results may be missing because the subquery didn't
+					// see every matching symbol.
+					stream.Send(streaming.SearchEvent{Stats: streaming.Stats{IsLimitHit: true}})
+				}
+				results[i] = res
+				return err
+			})
+		}
+	}
+	runSubqueries(j.fileSubqueries, fileResults)
+	runSubqueries(j.repoSubqueries, repoResults)
+	if err := g.Wait(); err != nil {
+		return nil, errors.Wrap(err, "has.symbol subquery")
+	}
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		filtered := event.Results[:0]
+		for _, m := range event.Results {
+			if matchesSymbolResults(m, fileResults, repoResults) {
+				filtered = append(filtered, m)
+			}
+		}
+		event.Results = filtered
+		stream.Send(event)
+	})
+
+	return j.child.Run(ctx, clients, filteredStream)
+}
+
+func runSymbolSubquery(ctx context.Context, clients job.RuntimeClients, subquery job.Job) (symbolSubqueryResult, bool, error) {
+	agg := streaming.NewAggregatingStream()
+	_, err := subquery.Run(ctx, clients, agg)
+	if err != nil {
+		return symbolSubqueryResult{}, false, err
+	}
+
+	res := symbolSubqueryResult{
+		files: make(map[symbolFileKey]struct{}),
+		repos: make(map[api.RepoID]struct{}),
+	}
+	for _, m := range agg.Results {
+		fm, ok := m.(*result.FileMatch)
+		if !ok || len(fm.Symbols) == 0 {
+			continue
+		}
+		res.files[symbolFileKey{repo: fm.Repo.ID, path: fm.Path}] = struct{}{}
+		res.repos[fm.Repo.ID] = struct{}{}
+	}
+	return res, agg.Stats.IsLimitHit, nil
+}
+
+// matchesSymbolResults returns true if m is in a repository found by every
+// repo:has.symbol() subquery, and in a file found by every file:has.symbol()
+// subquery. Only file matches can satisfy file:has.symbol().
+func matchesSymbolResults(m result.Match, fileResults, repoResults []symbolSubqueryResult) bool {
+	repoID := m.RepoName().ID
+	for _, res := range repoResults {
+		if _, ok := res.repos[repoID]; !ok {
+			return false
+		}
+	}
+
+	if len(fileResults) == 0 {
+		return true
+	}
+	fm, ok := m.(*result.FileMatch)
+	if !ok {
+		return false
+	}
+	key := symbolFileKey{repo: repoID, path: fm.Path}
+	for _, res := range fileResults {
+		if _, ok := res.files[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *hasSymbolFilterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	cp.fileSubqueries = make([]job.Job, len(j.fileSubqueries))
+	for i := range j.fileSubqueries {
+		cp.fileSubqueries[i] = job.Map(j.fileSubqueries[i], fn)
+	}
+	cp.repoSubqueries = make([]job.Job, len(j.repoSubqueries))
+	for i := range j.repoSubqueries {
+		cp.repoSubqueries[i] = job.Map(j.repoSubqueries[i], fn)
+	}
+	return &cp
+}
+
+func (j *hasSymbolFilterJob) Children() []job.Describer {
+	res := make([]job.Describer, 0, 1+len(j.fileSubqueries)+len(j.repoSubqueries))
+	res = append(res, j.child)
+	for _, subquery := range j.fileSubqueries {
+		res = append(res, subquery)
+	}
+	for _, subquery := range j.repoSubqueries {
+		res = append(res, subquery)
+	}
+	return res
+}
+
+func (j *hasSymbolFilterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("fileSymbols", symbolFilterStrings(j.fileSymbols)),
+			trace.Strings("repoSymbols", symbolFilterStrings(j.repoSymbols)),
+		)
+	}
+	return res
+}
+
+func (j *hasSymbolFilterJob) Name() string {
+	return "HasSymbolFilterJob"
+}
+
+func symbolFilterStrings(filters []query.SymbolFilter) []string {
+	res := make([]string, 0, len(filters))
+	for _, f := range filters {
+		var b strings.Builder
+		b.WriteString(f.Pattern)
+		if f.Kind != "" {
+			b.WriteString(" kind:")
+			b.WriteString(f.Kind)
+		}
+		res = append(res, b.String())
+	}
+	return res
+}
@@ -0,0 +1,104 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSymbolSubquery(t *testing.T) {
+	test := func(input string, scope map[string]struct{}, f query.SymbolFilter) string {
+		plan, err := query.Pipeline(query.Init(input, query.SearchTypeRegex))
+		require.NoError(t, err)
+		return symbolSubquery(plan[0], f, scope).StringHuman()
+	}
+
+	autogold.Want("file scope keeps file filters", `repo:^github\.com/foo file:\.go$ type:symbol select:symbol.struct count:10000 /Handler$/`).
+		Equal(t, test(`repo:^github\.com/foo file:\.go$ file:has.symbol(Handler$ kind:struct) ServeHTTP`, fileScopeFields, query.SymbolFilter{Pattern: "Handler$", Kind: "struct"}))
+
+	autogold.Want("repo scope drops file filters", `repo:foo repo:has.path(go.mod) type:symbol select:symbol count:10000 /ServeHTTP/`).
+		Equal(t, test(`repo:foo repo:has.path(go.mod) repo:has.symbol(ServeHTTP) file:\.go$ lang:go count:5 bar`, repoScopeFields, query.SymbolFilter{Pattern: "ServeHTTP"}))
+}
+
+func TestHasSymbolFilterJob(t *testing.T) {
+	repoA := types.MinimalRepo{ID: 1, Name: "a"}
+	repoB := types.MinimalRepo{ID: 2, Name: "b"}
+
+	fileMatch := func(repo types.MinimalRepo, path string, symbols ...string) *result.FileMatch {
+		fm := &result.FileMatch{File: result.File{Repo: repo, Path: path}}
+		for _, name := range symbols {
+			fm.Symbols = append(fm.Symbols, &result.SymbolMatch{Symbol: result.Symbol{Name: name}})
+		}
+		return fm
+	}
+
+	mockSearch := func(matches ...result.Match) *mockjob.MockJob {
+		j := mockjob.NewMockJob()
+		j.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: matches})
+			return nil, nil
+		})
+		return j
+	}
+
+	child := func() job.Job {
+		return mockSearch(
+			fileMatch(repoA, "handler.go"),
+			fileMatch(repoA, "main.go"),
+			fileMatch(repoB, "handler.go"),
+			&result.RepoMatch{Name: repoA.Name, ID: repoA.ID},
+			&result.RepoMatch{Name: repoB.Name, ID: repoB.ID},
+		)
+	}
+
+	run := func(t *testing.T, j job.Job) []string {
+		var got []string
+		_, err := j.Run(context.Background(), job.RuntimeClients{}, streaming.StreamFunc(func(ev streaming.SearchEvent) {
+			for _, m := range ev.Results {
+				got = append(got, string(m.RepoName().Name)+":"+m.Key().Path)
+			}
+		}))
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Run("file:has.symbol", func(t *testing.T) {
+		j := &hasSymbolFilterJob{
+			fileSubqueries: []job.Job{mockSearch(
+				fileMatch(repoA, "handler.go", "Handler"),
+				fileMatch(repoB, "handler.go", "Handler"),
+			)},
+			child: child(),
+		}
+		require.Equal(t, []string{"a:handler.go", "b:handler.go"}, run(t, j))
+	})
+
+	t.Run("every file predicate must match", func(t *testing.T) {
+		j := &hasSymbolFilterJob{
+			fileSubqueries: []job.Job{
+				mockSearch(fileMatch(repoA, "handler.go", "Handler"), fileMatch(repoB, "handler.go", "Handler")),
+				mockSearch(fileMatch(repoB, "handler.go", "ServeHTTP")),
+			},
+			child: child(),
+		}
+		require.Equal(t, []string{"b:handler.go"}, run(t, j))
+	})
+
+	t.Run("repo:has.symbol", func(t *testing.T) {
+		j := &hasSymbolFilterJob{
+			repoSubqueries: []job.Job{mockSearch(fileMatch(repoA, "other.go", "Handler"))},
+			child:          child(),
+		}
+		require.Equal(t, []string{"a:handler.go", "a:main.go", "a:"}, run(t, j))
+	})
+}
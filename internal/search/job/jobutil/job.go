@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/limits"
 	"github.com/sourcegraph/sourcegraph/internal/search/lucky"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/ranking"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
@@ -43,7 +44,7 @@ func NewPlanJob(inputs *search.Inputs, plan query.Plan) (job.Job, error) {
 		return NewBasicJob(inputs, b)
 	}
 	if inputs.PatternType == query.SearchTypeLucky || inputs.Features.AbLuckySearch {
-		jobTree = lucky.NewFeelingLuckySearchJob(jobTree, newJob, plan)
+		jobTree = lucky.NewFeelingLuckySearchJob(jobTree, newJob, plan, lucky.ConfiguredRuleSet())
 	} else if inputs.PatternType == query.SearchTypeKeyword && len(plan) == 1 {
 		newJobTree, err := keyword.NewKeywordSearchJob(plan[0], newJob)
 		if err != nil {
@@ -98,14 +99,16 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 			selector:       selector,
 		}
 
-		if resultTypes.Has(result.TypeFile | result.TypePath) {
+		// Zoekt can't match fuzzy patterns. Searcher searches indexed repos
+		// for them instead, see NewFlatJob.
+		if resultTypes.Has(result.TypeFile|result.TypePath) && !b.IsFuzzy() {
 			// Create Global Text Search jobs.
 			if repoUniverseSearch {
 				job, err := builder.newZoektGlobalSearch(search.TextRequest)
 				if err != nil {
 					return nil, err
 				}
-				addJob(job)
+				addJob(builder.withRelevanceRanking(job))
 			}
 
 			if !skipRepoSubsetSearch && runZoektOverRepos {
@@ -113,11 +116,11 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 				if err != nil {
 					return nil, err
 				}
-				addJob(&repoPagerJob{
+				addJob(builder.withRelevanceRanking(&repoPagerJob{
 					child:            &reposPartialJob{job},
 					repoOpts:         repoOptions,
 					containsRefGlobs: query.ContainsRefGlobs(b.ToParseTree()),
-				})
+				}))
 			}
 		}
 
@@ -182,16 +185,37 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.symbol() and repo:has.symbol() post-filter
+		fileSymbols, repoSymbols := b.FileHasSymbol(), b.RepoHasSymbol()
+		if len(fileSymbols) > 0 || len(repoSymbols) > 0 {
+			var err error
+			basicJob, err = NewHasSymbolFilterJob(inputs, b, fileSymbols, repoSymbols, basicJob)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	{ // Apply code ownership post-search filter
 		if includeOwners, excludeOwners := b.FileHasOwner(); inputs.Features.CodeOwnershipFilters == true && (len(includeOwners) > 0 || len(excludeOwners) > 0) {
 			basicJob = codeownershipjob.New(basicJob, includeOwners, excludeOwners)
 		}
 	}
 
+	selectOwners := false
 	{ // Apply selectors
 		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
-			basicJob = NewSelectJob(sp, basicJob)
+			if sp.Root() == filter.Owner {
+				if !inputs.Features.CodeOwnershipFilters {
+					return nil, errors.New("select:owner is not available because the code-ownership feature is disabled.")
+				}
+				// Owners are selected after subrepo permissions are
+				// checked, since they are resolved from file paths.
+				selectOwners = true
+			} else {
+				basicJob = NewSelectJob(sp, basicJob)
+			}
 		}
 	}
 
@@ -202,6 +226,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply code ownership selector
+		if selectOwners {
+			basicJob = codeownershipjob.NewSelectOwners(basicJob)
+		}
+	}
+
 	{ // Apply limit
 		maxResults := b.ToParseTree().MaxResults(inputs.DefaultLimit())
 		basicJob = NewLimitJob(maxResults, basicJob)
@@ -311,13 +341,24 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 		if resultTypes.Has(result.TypeFile | result.TypePath) {
 			// Create Text Search jobs over repo set.
 			if !skipRepoSubsetSearch {
-				searcherJob := &searcher.TextSearchJob{
+				var searcherJob job.Job = &searcher.TextSearchJob{
 					PatternInfo:     patternInfo,
 					Indexed:         false,
 					UseFullDeadline: useFullDeadline,
 					Features:        *searchInputs.Features,
 				}
 
+				if f.ToBasic().IsFuzzy() {
+					// Searcher also verifies the candidate files that
+					// Zoekt finds for fuzzy patterns in indexed repos.
+					searcherJob = NewParallelJob(searcherJob, &searcher.TextSearchJob{
+						PatternInfo:     patternInfo,
+						Indexed:         true,
+						UseFullDeadline: useFullDeadline,
+						Features:        *searchInputs.Features,
+					})
+				}
+
 				addJob(&repoPagerJob{
 					child:            &reposPartialJob{searcherJob},
 					repoOpts:         repoOptions,
@@ -377,6 +418,7 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 					query.FieldRepoHasCommitAfter: {},
 					query.FieldPatternType:        {},
 					query.FieldSelect:             {},
+					query.FieldSort:               {},
 				}
 
 				// Don't run a repo search if the search contains fields that aren't on the allowlist.
@@ -538,6 +580,8 @@ func toTextPatternInfo(b query.Basic, resultTypes result.Types, p search.Protoco
 		// Values dependent on pattern atom.
 		IsRegExp:        isRegexp,
 		IsStructuralPat: b.IsStructural(),
+		IsFuzzy:         b.IsFuzzy(),
+		MaxEdits:        b.MaxEdits(),
 		IsCaseSensitive: b.IsCaseSensitive(),
 		FileMatchLimit:  int32(count),
 		Pattern:         b.PatternString(),
@@ -562,6 +606,9 @@ func computeResultTypes(types []string, b query.Basic, searchType query.SearchTy
 	var rts result.Types
 	if searchType == query.SearchTypeStructural && !b.IsEmptyPattern() {
 		rts = result.TypeStructural
+	} else if b.IsFuzzy() {
+		// Fuzzy patterns only match file contents.
+		rts = result.TypeFile
 	} else {
 		if len(types) == 0 {
 			rts = result.TypeFile | result.TypePath | result.TypeRepo
@@ -717,8 +764,17 @@ func (b *jobBuilder) newZoektSearch(typ search.IndexedRequestType) (job.Job, err
 	return nil, errors.Errorf("attempt to create unrecognized zoekt search with value %v", typ)
 }
 
+// withRelevanceRanking wraps j in a job that reorders its file matches by
+// relevance if the query contains sort:relevance.
+func (b *jobBuilder) withRelevanceRanking(j job.Job) job.Job {
+	if b.query.FindValue(query.FieldSort) != query.SortRelevance {
+		return j
+	}
+	return ranking.NewRelevanceJob(j, ranking.OptionsFromConfig(conf.Get().SiteConfiguration))
+}
+
 func jobMode(b query.Basic, repoOptions search.RepoOptions, resultTypes result.Types, st query.SearchType, onSourcegraphDotCom bool) (repoUniverseSearch, skipRepoSubsetSearch, runZoektOverRepos bool) {
-	isGlobalSearch := isGlobal(repoOptions) && st != query.SearchTypeStructural
+	isGlobalSearch := isGlobal(repoOptions) && st != query.SearchTypeStructural && st != query.SearchTypeFuzzy
 
 	hasGlobalSearchResultType := resultTypes.Has(result.TypeFile | result.TypePath | result.TypeSymbol)
 	isIndexedSearch := b.Index() != query.No
@@ -667,6 +667,29 @@ func TestNewPlanJob(t *testing.T) {
 	}
 }
 
+func TestNewPlanJob_SelectOwner(t *testing.T) {
+	plan, err := query.Pipeline(query.Init("file:README select:owner", query.SearchTypeLiteral))
+	require.NoError(t, err)
+
+	inputs := &search.Inputs{
+		UserSettings: &schema.Settings{},
+		PatternType:  query.SearchTypeLiteral,
+		Protocol:     search.Streaming,
+		Features:     &search.Features{},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := NewPlanJob(inputs, plan)
+		require.ErrorContains(t, err, "code-ownership feature is disabled")
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		inputs.Features.CodeOwnershipFilters = true
+		_, err := NewPlanJob(inputs, plan)
+		require.NoError(t, err)
+	})
+}
+
 func TestToEvaluateJob(t *testing.T) {
 	test := func(input string, protocol search.Protocol) string {
 		q, _ := query.ParseLiteral(input)
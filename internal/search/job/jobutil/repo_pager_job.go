@@ -61,7 +61,11 @@ func setRepos(j job.Job, indexed *zoekt.IndexedRepoRevs, unindexed []*search.Rep
 			return &cp
 		case *searcher.TextSearchJob:
 			cp := *v
-			cp.Repos = unindexed
+			if v.Indexed {
+				cp.Repos = indexedRepoRevs(indexed)
+			} else {
+				cp.Repos = unindexed
+			}
 			return &cp
 		case *zoekt.SymbolSearchJob:
 			cp := *v
@@ -77,6 +81,19 @@ func setRepos(j job.Job, indexed *zoekt.IndexedRepoRevs, unindexed []*search.Rep
 	})
 }
 
+// indexedRepoRevs returns the repository revisions of indexed, for searcher
+// jobs that query Zoekt themselves.
+func indexedRepoRevs(indexed *zoekt.IndexedRepoRevs) []*search.RepositoryRevisions {
+	if indexed == nil {
+		return nil
+	}
+	repoRevs := make([]*search.RepositoryRevisions, 0, len(indexed.RepoRevs))
+	for _, repoRev := range indexed.RepoRevs {
+		repoRevs = append(repoRevs, repoRev)
+	}
+	return repoRevs
+}
+
 func (p *repoPagerJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
 	_, ctx, stream, finish := job.StartSpan(ctx, stream, p)
 	defer func() { finish(alert, err) }()
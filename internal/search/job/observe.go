@@ -2,6 +2,8 @@ package job
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/opentracing/opentracing-go/log"
 	"go.uber.org/atomic"
@@ -19,7 +21,16 @@ func StartSpan(ctx context.Context, stream streaming.Sender, job Job) (*trace.Tr
 
 	observingStream := newObservingStream(tr, stream)
 
+	analysis := analysisFromContext(ctx)
+	if analysis != nil {
+		analysis = analysis.newChild(job)
+		ctx = context.WithValue(ctx, analysisKey{}, analysis)
+	}
+
 	return tr, ctx, observingStream, func(alert *search.Alert, err error) {
+		if analysis != nil {
+			analysis.finish(observingStream.totalEvents.Load(), err)
+		}
 		tr.SetError(err)
 		if alert != nil {
 			tr.TagFields(log.String("alert", alert.Title))
@@ -50,3 +61,71 @@ func (o *observingStream) Send(event streaming.SearchEvent) {
 	}
 	o.parent.Send(event)
 }
+
+type analysisKey struct{}
+
+// Analysis records how the jobs of a search ran. Every job that calls
+// StartSpan with a context returned by WithAnalysis adds a node to the tree,
+// under the node of the closest ancestor job that did the same. A job that
+// runs several times, like the children of a repo pager, has a node for each
+// run.
+type Analysis struct {
+	Name   string
+	Fields []log.Field
+
+	// Duration is the wall time of the job, from StartSpan until its finish
+	// func was called.
+	Duration time.Duration
+
+	// Results is the number of results the job sent.
+	Results int64
+
+	Err error
+
+	start    time.Time
+	mu       sync.Mutex
+	children []*Analysis
+}
+
+// WithAnalysis returns a context that records an Analysis of the jobs run with
+// it. The returned Analysis is a root without a name, whose children are the
+// top-level jobs. It is complete once those jobs have returned.
+func WithAnalysis(ctx context.Context) (context.Context, *Analysis) {
+	root := &Analysis{start: time.Now()}
+	return context.WithValue(ctx, analysisKey{}, root), root
+}
+
+func analysisFromContext(ctx context.Context) *Analysis {
+	a, _ := ctx.Value(analysisKey{}).(*Analysis)
+	return a
+}
+
+func (a *Analysis) newChild(job Job) *Analysis {
+	child := &Analysis{
+		Name:   job.Name(),
+		Fields: job.Fields(VerbosityBasic),
+		start:  time.Now(),
+	}
+	a.mu.Lock()
+	a.children = append(a.children, child)
+	a.mu.Unlock()
+	return child
+}
+
+func (a *Analysis) finish(results int64, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.Duration = time.Since(a.start)
+	a.Results = results
+	a.Err = err
+}
+
+// Children returns the analyses of the jobs run by this job, in the order
+// they started.
+func (a *Analysis) Children() []*Analysis {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	children := make([]*Analysis, len(a.children))
+	copy(children, a.children)
+	return children
+}
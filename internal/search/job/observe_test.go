@@ -0,0 +1,90 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// testJob sends one result per match and then runs its children in order.
+type testJob struct {
+	name     string
+	matches  int
+	children []Job
+}
+
+func (j *testJob) Run(ctx context.Context, clients RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	for i := 0; i < j.matches; i++ {
+		stream.Send(streaming.SearchEvent{Results: result.Matches{&result.RepoMatch{}}})
+	}
+	for _, child := range j.children {
+		if _, err := child.Run(ctx, clients, stream); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (j *testJob) Name() string                   { return j.name }
+func (j *testJob) Fields(Verbosity) []otlog.Field { return []otlog.Field{otlog.String("name", j.name)} }
+func (j *testJob) Children() []Describer {
+	res := make([]Describer, 0, len(j.children))
+	for _, child := range j.children {
+		res = append(res, child)
+	}
+	return res
+}
+func (j *testJob) MapChildren(MapFunc) Job { return j }
+
+func TestWithAnalysis(t *testing.T) {
+	j := &testJob{
+		name: "parent",
+		children: []Job{
+			&testJob{name: "a", matches: 2},
+			&testJob{name: "b", matches: 1, children: []Job{&testJob{name: "c", matches: 3}}},
+		},
+	}
+
+	ctx, analysis := WithAnalysis(context.Background())
+	_, err := j.Run(ctx, RuntimeClients{}, streaming.StreamFunc(func(streaming.SearchEvent) {}))
+	require.NoError(t, err)
+
+	type node struct {
+		name     string
+		results  int64
+		children []node
+	}
+	var toNode func(*Analysis) node
+	toNode = func(a *Analysis) node {
+		n := node{name: a.Name, results: a.Results}
+		for _, child := range a.Children() {
+			n.children = append(n.children, toNode(child))
+		}
+		return n
+	}
+
+	require.Equal(t, node{children: []node{{
+		name:    "parent",
+		results: 6,
+		children: []node{
+			{name: "a", results: 2},
+			{name: "b", results: 4, children: []node{{name: "c", results: 3}}},
+		},
+	}}}, toNode(analysis))
+}
+
+func TestStartSpanWithoutAnalysis(t *testing.T) {
+	// Jobs run without WithAnalysis must not record anything.
+	_, ctx, _, finish := StartSpan(context.Background(), streaming.StreamFunc(func(streaming.SearchEvent) {}), &testJob{name: "a"})
+	finish(nil, nil)
+	require.Nil(t, analysisFromContext(ctx))
+}
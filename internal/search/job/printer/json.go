@@ -30,11 +30,7 @@ type node struct {
 }
 
 func (n node) params() map[string]interface{} {
-	m := make(map[string]interface{})
-	enc := jsonFieldEncoder{&m}
-	for _, field := range n.tags {
-		field.Marshal(enc)
-	}
+	m := FieldsMap(n.tags)
 	seenJobNames := map[string]int{}
 	for _, child := range n.children {
 		key := child.name
@@ -75,6 +71,17 @@ func toNode(j job.Describer, v job.Verbosity) node {
 	}
 }
 
+// FieldsMap returns the values of fields keyed by their names, in a form
+// that can be encoded as JSON.
+func FieldsMap(fields []otlog.Field) map[string]interface{} {
+	m := make(map[string]interface{}, len(fields))
+	enc := jsonFieldEncoder{&m}
+	for _, field := range fields {
+		field.Marshal(enc)
+	}
+	return m
+}
+
 type jsonFieldEncoder struct {
 	m *map[string]interface{}
 }
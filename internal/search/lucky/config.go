@@ -0,0 +1,212 @@
+package lucky
+
+import (
+	"sync"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/log"
+	"go.uber.org/atomic"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func init() {
+	conf.ContributeValidator(func(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+		if _, err := RuleSetFromConfig(c.SiteConfig()); err != nil {
+			problems = append(problems, conf.NewSiteProblem(err.Error()))
+		}
+		return problems
+	})
+}
+
+// RuleSet is the ordered sets of narrowing and widening rules that lucky
+// search applies to the queries of a plan. See NewGenerator for how they are
+// combined.
+type RuleSet struct {
+	narrow []rule
+	widen  []rule
+}
+
+// DefaultRuleSet is the rule set of lucky search when none is configured.
+var DefaultRuleSet = &RuleSet{narrow: rulesNarrow, widen: rulesWiden}
+
+var (
+	configuredRuleSetOnce sync.Once
+	configuredRuleSet     atomic.Value
+)
+
+// ConfiguredRuleSet returns the rule set of the site configuration. It is
+// compiled again when the site configuration changes. If the configured rules
+// are invalid, it returns DefaultRuleSet: the site configuration validator
+// reports the problem to site admins, and lucky search keeps working.
+func ConfiguredRuleSet() *RuleSet {
+	configuredRuleSetOnce.Do(func() {
+		logger := log.Scoped("luckySearch", "lucky search rules of the site configuration")
+		conf.Watch(func() {
+			rules, err := RuleSetFromConfig(conf.Get().SiteConfiguration)
+			if err != nil {
+				logger.Error("invalid lucky search rules in site configuration, using the default rules", log.Error(err))
+				rules = DefaultRuleSet
+			}
+			configuredRuleSet.Store(rules)
+		})
+	})
+	return configuredRuleSet.Load().(*RuleSet)
+}
+
+// RuleSetFromConfig returns the rule set of the experimentalFeatures.luckySearch
+// site configuration. Rules are referred to by name, so that they can be
+// disabled or reordered, and site-specific rewrite rules are defined there.
+func RuleSetFromConfig(c schema.SiteConfiguration) (*RuleSet, error) {
+	if c.ExperimentalFeatures == nil || c.ExperimentalFeatures.LuckySearch == nil {
+		return DefaultRuleSet, nil
+	}
+	config := c.ExperimentalFeatures.LuckySearch
+
+	rulesByName := make(map[string]rule, len(rulesNarrow)+len(rulesWiden)+len(config.RewriteRules))
+	for _, r := range append(append([]rule{}, rulesNarrow...), rulesWiden...) {
+		rulesByName[r.name] = r
+	}
+
+	var rewriteRuleNames []string
+	for _, ruleConfig := range config.RewriteRules {
+		r, err := newRewriteRule(ruleConfig)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := rulesByName[r.name]; ok {
+			return nil, errors.Errorf("lucky search rule %q is defined more than once", r.name)
+		}
+		rulesByName[r.name] = r
+		rewriteRuleNames = append(rewriteRuleNames, r.name)
+	}
+
+	lookup := func(names []string) ([]rule, error) {
+		rules := make([]rule, 0, len(names))
+		for _, name := range names {
+			r, ok := rulesByName[name]
+			if !ok {
+				return nil, errors.Errorf("unknown lucky search rule %q", name)
+			}
+			rules = append(rules, r)
+		}
+		return rules, nil
+	}
+
+	// Rewrite rules are specific to a site, so they are tried before the
+	// built-in rules unless the order is configured.
+	narrowNames := config.NarrowRules
+	if narrowNames == nil {
+		narrowNames = append(rewriteRuleNames, ruleNames(rulesNarrow)...)
+	}
+	widenNames := config.WidenRules
+	if widenNames == nil {
+		widenNames = ruleNames(rulesWiden)
+	}
+
+	narrow, err := lookup(narrowNames)
+	if err != nil {
+		return nil, err
+	}
+	widen, err := lookup(widenNames)
+	if err != nil {
+		return nil, err
+	}
+	return &RuleSet{narrow: narrow, widen: widen}, nil
+}
+
+func ruleNames(rules []rule) []string {
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+// newRewriteRule returns the rule of a rewrite rule of the site configuration.
+func newRewriteRule(c *schema.LuckySearchRewriteRule) (rule, error) {
+	if c.Name == "" {
+		return rule{}, errors.New("lucky search rewrite rules must have a name")
+	}
+	// The pattern must match a whole search pattern.
+	re, err := regexp.Compile(`^(?:` + c.Pattern + `)$`)
+	if err != nil {
+		return rule{}, errors.Wrapf(err, "invalid pattern of lucky search rule %q", c.Name)
+	}
+	if _, err := query.Parse(c.Query, query.SearchTypeStandard); err != nil {
+		return rule{}, errors.Wrapf(err, "invalid query of lucky search rule %q", c.Name)
+	}
+
+	description := c.Description
+	if description == "" {
+		description = c.Name
+	}
+	return rule{
+		name:        c.Name,
+		description: description,
+		transform:   []transform{rewritePatterns(re, c.Query)},
+	}, nil
+}
+
+// rewritePatterns returns a transformation that replaces the patterns matching
+// re with the terms of template, where $1 or ${name} expand to the groups of
+// re. For example, with re `(?i)jira-(\d+)` and template `type:commit
+// message:JIRA-$1`, the query `jira-123` becomes `type:commit message:JIRA-123`.
+func rewritePatterns(re *regexp.Regexp, template string) transform {
+	return func(b query.Basic) *query.Basic {
+		rawParseTree, err := query.Parse(query.StringHuman(b.ToParseTree()), query.SearchTypeStandard)
+		if err != nil {
+			return nil
+		}
+
+		rewrites := []query.Node{}
+		changed := false
+		newParseTree := query.MapPattern(rawParseTree, func(value string, negated bool, annotation query.Annotation) query.Node {
+			pattern := query.Pattern{
+				Value:      value,
+				Negated:    negated,
+				Annotation: annotation,
+			}
+			if negated || annotation.Labels.IsSet(query.Regexp) {
+				return pattern
+			}
+
+			match := re.FindStringSubmatchIndex(value)
+			if match == nil {
+				return pattern
+			}
+			nodes, err := query.Parse(string(re.ExpandString(nil, template, value, match)), query.SearchTypeStandard)
+			if err != nil {
+				return pattern
+			}
+
+			changed = true
+			// Like patternsToCodeHostFilters, collect the terms and
+			// delete the pattern, so that parameters aren't created
+			// in concat nodes.
+			rewrites = append(rewrites, nodes...)
+			return nil
+		})
+
+		if !changed {
+			return nil
+		}
+
+		newParseTree = query.NewOperator(append(newParseTree, rewrites...), query.And)
+		newNodes, err := query.Sequence(query.For(query.SearchTypeStandard))(newParseTree)
+		if err != nil {
+			return nil
+		}
+
+		newBasic, err := query.ToBasicQuery(newNodes)
+		if err != nil {
+			return nil
+		}
+
+		return &newBasic
+	}
+}
@@ -0,0 +1,107 @@
+package lucky
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func Test_rewritePatterns(t *testing.T) {
+	r, err := newRewriteRule(&schema.LuckySearchRewriteRule{
+		Name:    "jira-issues",
+		Pattern: `(?i)jira-(?P<id>\d+)`,
+		Query:   `repo:^github\.com/acme/ type:commit message:JIRA-${id}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "jira-issues", r.description)
+
+	test := func(input string) string {
+		return apply(input, r.transform)
+	}
+
+	autogold.Want("pattern", autogold.Raw(`{
+  "Input": "jira-123",
+  "Query": "repo:^github\\.com/acme/ type:commit message:JIRA-123"
+}`)).Equal(t, autogold.Raw(test(`jira-123`)))
+	autogold.Want("pattern with other patterns and parameters", autogold.Raw(`{
+  "Input": "lang:go JIRA-42 fix",
+  "Query": "lang:go repo:^github\\.com/acme/ type:commit message:JIRA-42 fix"
+}`)).Equal(t, autogold.Raw(test(`lang:go JIRA-42 fix`)))
+	autogold.Want("partial match", autogold.Raw(`{
+  "Input": "myjira-123",
+  "Query": "DOES NOT APPLY"
+}`)).Equal(t, autogold.Raw(test(`myjira-123`)))
+	autogold.Want("negated pattern", autogold.Raw(`{
+  "Input": "NOT jira-123",
+  "Query": "DOES NOT APPLY"
+}`)).Equal(t, autogold.Raw(test(`NOT jira-123`)))
+}
+
+func TestRuleSetFromConfig(t *testing.T) {
+	config := func(luckySearch *schema.LuckySearch) schema.SiteConfiguration {
+		return schema.SiteConfiguration{ExperimentalFeatures: &schema.ExperimentalFeatures{LuckySearch: luckySearch}}
+	}
+	jiraRule := &schema.LuckySearchRewriteRule{Name: "jira-issues", Pattern: `jira-(\d+)`, Query: `type:commit message:JIRA-$1`}
+
+	t.Run("default", func(t *testing.T) {
+		rules, err := RuleSetFromConfig(schema.SiteConfiguration{})
+		require.NoError(t, err)
+		require.Equal(t, DefaultRuleSet, rules)
+	})
+
+	t.Run("rewrite rules come first by default", func(t *testing.T) {
+		rules, err := RuleSetFromConfig(config(&schema.LuckySearch{RewriteRules: []*schema.LuckySearchRewriteRule{jiraRule}}))
+		require.NoError(t, err)
+		want := []string{"jira-issues", "unquote-patterns", "type-patterns", "lang-patterns", "symbol-patterns", "code-host-filters"}
+		if diff := cmp.Diff(want, ruleNames(rules.narrow)); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff([]string{"regexp-patterns", "and-patterns"}, ruleNames(rules.widen)); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("enable and order rules", func(t *testing.T) {
+		rules, err := RuleSetFromConfig(config(&schema.LuckySearch{
+			NarrowRules:  []string{"lang-patterns", "jira-issues"},
+			WidenRules:   []string{},
+			RewriteRules: []*schema.LuckySearchRewriteRule{jiraRule},
+		}))
+		require.NoError(t, err)
+		if diff := cmp.Diff([]string{"lang-patterns", "jira-issues"}, ruleNames(rules.narrow)); diff != "" {
+			t.Error(diff)
+		}
+		require.Empty(t, rules.widen)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		config *schema.LuckySearch
+		want   string
+	}{
+		{
+			name:   "unknown rule",
+			config: &schema.LuckySearch{WidenRules: []string{"or-patterns"}},
+			want:   `unknown lucky search rule "or-patterns"`,
+		},
+		{
+			name:   "duplicate rule",
+			config: &schema.LuckySearch{RewriteRules: []*schema.LuckySearchRewriteRule{{Name: "lang-patterns", Query: "lang:go"}}},
+			want:   `lucky search rule "lang-patterns" is defined more than once`,
+		},
+		{
+			name:   "invalid pattern",
+			config: &schema.LuckySearch{RewriteRules: []*schema.LuckySearchRewriteRule{{Name: "broken", Pattern: "(", Query: "lang:go"}}},
+			want:   `invalid pattern of lucky search rule "broken"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := RuleSetFromConfig(config(tc.config))
+			require.ErrorContains(t, err, tc.want)
+		})
+	}
+}
@@ -3,8 +3,10 @@ package lucky
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/opentracing/opentracing-go/log"
+	"go.uber.org/atomic"
 
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	alertobserver "github.com/sourcegraph/sourcegraph/internal/search/alert"
@@ -18,6 +20,7 @@ import (
 // autoQuery is an automatically generated query with associated data (e.g., description).
 type autoQuery struct {
 	description string
+	rules       []string // the names of the rules that generated the query.
 	query       query.Basic
 }
 
@@ -31,11 +34,12 @@ type newJob func(query.Basic) (job.Job, error)
 // that apply various rules, transforming the original input plan into various
 // queries that alter its interpretation (e.g., search literally for quotes or
 // not, attempt to search the pattern as a regexp, and so on). There is no
-// random choice when applying rules.
-func NewFeelingLuckySearchJob(initialJob job.Job, newJob newJob, plan query.Plan) *FeelingLuckySearchJob {
+// random choice when applying rules. The rules are those of rules, see
+// ConfiguredRuleSet.
+func NewFeelingLuckySearchJob(initialJob job.Job, newJob newJob, plan query.Plan, rules *RuleSet) *FeelingLuckySearchJob {
 	generators := make([]next, 0, len(plan))
 	for _, b := range plan {
-		generators = append(generators, NewGenerator(b, rulesNarrow, rulesWiden))
+		generators = append(generators, NewGenerator(b, rules.narrow, rules.widen))
 	}
 
 	newGeneratedJob := func(autoQ *autoQuery) job.Job {
@@ -76,7 +80,11 @@ func (f *FeelingLuckySearchJob) Run(ctx context.Context, clients job.RuntimeClie
 	_, ctx, parentStream, finish := job.StartSpan(ctx, parentStream, f)
 	defer func() { finish(alert, err) }()
 
-	dedupingStream := streaming.NewDedupingStream(parentStream)
+	// Count the matches sent after deduplication, which are the ones in the
+	// result list of the client, to locate the results of each generated
+	// query in it.
+	sent := &matchCountingStream{parent: parentStream}
+	dedupingStream := streaming.NewDedupingStream(sent)
 	// Count stream results to know whether to run generated queries
 	stream := streaming.NewResultCountingStream(dedupingStream)
 
@@ -117,7 +125,9 @@ func (f *FeelingLuckySearchJob) Run(ctx context.Context, clients job.RuntimeClie
 				// Generated an invalid job with this query, just continue.
 				continue
 			}
+			start := sent.Count()
 			alert, err = j.Run(ctx, clients, stream)
+			annotateResultRange(err, start, sent.Count())
 			if stream.Count()-initialResultSetSize >= RESULT_THRESHOLD {
 				// We've sent additional results up to the maximum bound. Let's stop here.
 				var lErr *alertobserver.ErrLuckyQueries
@@ -152,6 +162,36 @@ func (f *FeelingLuckySearchJob) Run(ctx context.Context, clients job.RuntimeClie
 	return maxAlerter.Alert, errs
 }
 
+// annotateResultRange records the indexes of the results a generated query
+// added in the proposed query of its notification, so that the client can
+// attribute clicks on its results to the rules that generated it.
+func annotateResultRange(err error, start, end int) {
+	var lErr *alertobserver.ErrLuckyQueries
+	if !errors.As(err, &lErr) {
+		return
+	}
+	for _, q := range lErr.ProposedQueries {
+		q.Annotations[search.ResultRange] = fmt.Sprintf("%d:%d", start, end)
+	}
+}
+
+// matchCountingStream counts the matches sent on a stream. Unlike
+// streaming.NewResultCountingStream, it counts matches rather than the
+// results within them.
+type matchCountingStream struct {
+	parent streaming.Sender
+	count  atomic.Int64
+}
+
+func (s *matchCountingStream) Send(event streaming.SearchEvent) {
+	s.count.Add(int64(len(event.Results)))
+	s.parent.Send(event)
+}
+
+func (s *matchCountingStream) Count() int {
+	return int(s.count.Load())
+}
+
 func (f *FeelingLuckySearchJob) Name() string {
 	return "FeelingLuckySearchJob"
 }
@@ -237,6 +277,7 @@ func (n *notifier) New(count int) error {
 			Description: n.description,
 			Annotations: map[search.AnnotationName]string{
 				search.ResultCount: resultCountString,
+				search.Rules:       strings.Join(n.rules, ","),
 			},
 			Query:       query.StringHuman(n.query.ToParseTree()),
 			PatternType: query.SearchTypeLucky,
@@ -129,3 +129,38 @@ func TestNewFeelingLuckySearchJob_ResultCount(t *testing.T) {
 		require.Equal(t, RESULT_THRESHOLD, len(sent))
 	})
 }
+
+func TestNewFeelingLuckySearchJob_ResultRange(t *testing.T) {
+	sendPaths := func(paths ...string) *mockjob.MockJob {
+		j := mockjob.NewMockJob()
+		j.RunFunc.SetDefaultHook(func(ctx context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			for _, path := range paths {
+				s.Send(streaming.SearchEvent{Results: []result.Match{&result.FileMatch{File: result.File{Path: path}}}})
+			}
+			return nil, nil
+		})
+		return j
+	}
+
+	autoQ := &autoQuery{description: "mock", rules: []string{"mock-rule"}, query: query.Basic{}}
+	j := FeelingLuckySearchJob{
+		initialJob: sendPaths("a", "b"),
+		generators: []next{func() (*autoQuery, next) { return autoQ, nil }},
+		newGeneratedJob: func(autoQ *autoQuery) job.Job {
+			return &generatedSearchJob{
+				Child:           sendPaths("b", "c", "d"),
+				NewNotification: (&notifier{autoQuery: autoQ}).New,
+			}
+		},
+	}
+
+	_, err := j.Run(context.Background(), job.RuntimeClients{}, streaming.StreamFunc(func(streaming.SearchEvent) {}))
+	var lErr *alertobserver.ErrLuckyQueries
+	require.ErrorAs(t, err, &lErr)
+	require.Len(t, lErr.ProposedQueries, 1)
+
+	// The duplicate result "b" isn't in the result list, so the generated
+	// query added the third and fourth results.
+	require.Equal(t, "2:4", lErr.ProposedQueries[0].Annotations[search.ResultRange])
+	require.Equal(t, "mock-rule", lErr.ProposedQueries[0].Annotations[search.Rules])
+}
@@ -14,8 +14,10 @@ import (
 // rule represents a transformation function on a Basic query. Transformation
 // cannot fail: either they apply in sequence and produce a valid, non-nil,
 // Basic query, or they do not apply, in which case they return nil. See the
-// `unquotePatterns` rule for an example.
+// `unquotePatterns` rule for an example. The name of a rule identifies it in
+// the site configuration and in usage statistics.
 type rule struct {
+	name        string
 	description string
 	transform   []transform
 }
@@ -24,22 +26,27 @@ type transform func(query.Basic) *query.Basic
 
 var rulesNarrow = []rule{
 	{
+		name:        "unquote-patterns",
 		description: "unquote patterns",
 		transform:   []transform{unquotePatterns},
 	},
 	{
+		name:        "type-patterns",
 		description: "apply search type for pattern",
 		transform:   []transform{typePatterns},
 	},
 	{
+		name:        "lang-patterns",
 		description: "apply language filter for pattern",
 		transform:   []transform{langPatterns},
 	},
 	{
+		name:        "symbol-patterns",
 		description: "apply symbol select for pattern",
 		transform:   []transform{symbolPatterns},
 	},
 	{
+		name:        "code-host-filters",
 		description: "expand URL to filters",
 		transform:   []transform{patternsToCodeHostFilters},
 	},
@@ -47,10 +54,12 @@ var rulesNarrow = []rule{
 
 var rulesWiden = []rule{
 	{
+		name:        "regexp-patterns",
 		description: "patterns as regular expressions",
 		transform:   []transform{regexpPatterns},
 	},
 	{
+		name:        "and-patterns",
 		description: "AND patterns together",
 		transform:   []transform{unorderedPatterns},
 	},
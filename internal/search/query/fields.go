@@ -33,6 +33,22 @@ const (
 	FieldTimeout   = "timeout"
 	FieldCombyRule = "rule"
 	FieldSelect    = "select"
+	FieldSort      = "sort"
+	FieldMaxEdits  = "maxedits"
+)
+
+// Values of the `sort:` field.
+const (
+	// SortRelevance ranks file results by signals like file recency, repo
+	// stars and symbol definitions rather than the order of the backend.
+	SortRelevance = "relevance"
+)
+
+// Bounds of the `maxedits:` field, which sets the number of edits fuzzy
+// patterns tolerate.
+const (
+	DefaultMaxEdits = 1
+	MaxMaxEdits     = 3
 )
 
 var allFields = map[string]struct{}{
@@ -71,6 +87,8 @@ var allFields = map[string]struct{}{
 	FieldRev:                empty,
 	"revision":              empty,
 	FieldSelect:             empty,
+	FieldSort:               empty,
+	FieldMaxEdits:           empty,
 }
 
 var aliases = map[string]string{
@@ -1073,7 +1073,7 @@ func (p *parser) parseAnd() ([]Node, error) {
 	switch p.leafParser {
 	case SearchTypeRegex:
 		left, err = p.parseLeaves(Regexp)
-	case SearchTypeLiteral, SearchTypeStructural:
+	case SearchTypeLiteral, SearchTypeStructural, SearchTypeFuzzy:
 		left, err = p.parseLeaves(Literal)
 	case SearchTypeStandard, SearchTypeLucky:
 		left, err = p.parseLeaves(Literal | Standard)
@@ -1171,7 +1171,7 @@ func Parse(in string, searchType SearchType) ([]Node, error) {
 			nodes = hoistedNodes
 		}
 	}
-	if searchType == SearchTypeLiteral || searchType == SearchTypeStandard {
+	if searchType == SearchTypeLiteral || searchType == SearchTypeStandard || searchType == SearchTypeFuzzy {
 		err = validatePureLiteralPattern(nodes, parser.balanced == 0)
 		if err != nil {
 			return nil, err
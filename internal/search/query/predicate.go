@@ -5,6 +5,7 @@ import (
 
 	"github.com/grafana/regexp"
 
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -33,6 +34,7 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"contains.commit.after": func() Predicate { return &RepoContainsCommitAfterPredicate{} },
 		"has.commit.after":      func() Predicate { return &RepoContainsCommitAfterPredicate{} },
 		"has.description":       func() Predicate { return &RepoHasDescriptionPredicate{} },
+		"has.symbol":            func() Predicate { return &RepoHasSymbolPredicate{} },
 		"has.tag":               func() Predicate { return &RepoHasTagPredicate{} },
 		"has":                   func() Predicate { return &RepoHasKVPPredicate{} },
 	},
@@ -40,6 +42,7 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
 		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
+		"has.symbol":       func() Predicate { return &FileHasSymbolPredicate{} },
 	},
 }
 
@@ -306,3 +309,79 @@ func (f *FileHasOwnerPredicate) ParseParams(params string) error {
 
 func (f FileHasOwnerPredicate) Field() string { return FieldFile }
 func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
+
+/* file:has.symbol(pattern) */
+
+// FileHasSymbolPredicate represents the `file:has.symbol()` predicate, which
+// filters to files that define a symbol matching a name pattern and,
+// optionally, a kind. For example: `file:has.symbol(Handler$ kind:struct)`.
+type FileHasSymbolPredicate struct {
+	Pattern string
+	Kind    string
+}
+
+func (f *FileHasSymbolPredicate) ParseParams(params string) (err error) {
+	f.Pattern, f.Kind, err = parseSymbolPredicateParams("file:has.symbol", params)
+	return err
+}
+
+func (f FileHasSymbolPredicate) Field() string { return FieldFile }
+func (f FileHasSymbolPredicate) Name() string  { return "has.symbol" }
+
+/* repo:has.symbol(pattern) */
+
+// RepoHasSymbolPredicate represents the `repo:has.symbol()` predicate, which
+// filters to repos that define a symbol matching a name pattern and,
+// optionally, a kind. For example: `repo:has.symbol(ServeHTTP kind:method)`.
+type RepoHasSymbolPredicate struct {
+	Pattern string
+	Kind    string
+}
+
+func (f *RepoHasSymbolPredicate) ParseParams(params string) (err error) {
+	f.Pattern, f.Kind, err = parseSymbolPredicateParams("repo:has.symbol", params)
+	return err
+}
+
+func (f RepoHasSymbolPredicate) Field() string { return FieldRepo }
+func (f RepoHasSymbolPredicate) Name() string  { return "has.symbol" }
+
+// parseSymbolPredicateParams parses the arguments of the symbol predicates: a
+// regular expression for the symbol name, and an optional `kind:` option
+// which accepts the symbol kinds that are valid for `select:symbol.<kind>`.
+func parseSymbolPredicateParams(predicate, params string) (pattern, kind string, err error) {
+	for _, arg := range strings.Fields(params) {
+		if value, ok := cutPrefixFold(arg, "kind:"); ok {
+			if kind != "" {
+				return "", "", errors.New("cannot specify kind multiple times")
+			}
+			kind = strings.ToLower(value)
+			if _, err := filter.SelectPathFromString(filter.Symbol + "." + kind); err != nil || kind == "" {
+				return "", "", errors.Errorf("%s predicate has invalid `kind` argument %q", predicate, value)
+			}
+			continue
+		}
+
+		if pattern != "" {
+			return "", "", errors.Errorf("%s predicate accepts a single symbol name pattern", predicate)
+		}
+		if _, err := regexp.Compile(arg); err != nil {
+			return "", "", errors.Errorf("%s argument: %w", predicate, err)
+		}
+		pattern = arg
+	}
+
+	if pattern == "" {
+		return "", "", errors.Errorf("%s argument should contain a symbol name pattern", predicate)
+	}
+	return pattern, kind, nil
+}
+
+// cutPrefixFold is like strings.CutPrefix, but matches the prefix
+// case-insensitively.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -168,3 +168,61 @@ func TestFileHasOwnerPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestSymbolPredicates(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *FileHasSymbolPredicate
+		}
+
+		valid := []test{
+			{`name`, `Handler`, &FileHasSymbolPredicate{Pattern: "Handler"}},
+			{`regexp`, `^New.*Handler$`, &FileHasSymbolPredicate{Pattern: "^New.*Handler$"}},
+			{`kind`, `Handler$ kind:struct`, &FileHasSymbolPredicate{Pattern: "Handler$", Kind: "struct"}},
+			{`kind first`, `KIND:Enum-Member Red`, &FileHasSymbolPredicate{Pattern: "Red", Kind: "enum-member"}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasSymbolPredicate{}
+				err := p.ParseParams(tc.params)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+
+				r := &RepoHasSymbolPredicate{}
+				if err := r.ParseParams(tc.params); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if r.Pattern != tc.expected.Pattern || r.Kind != tc.expected.Kind {
+					t.Fatalf("expected %#v, got %#v", tc.expected, r)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, nil},
+			{`only kind`, `kind:struct`, nil},
+			{`invalid kind`, `Handler kind:structure`, nil},
+			{`multiple kinds`, `Handler kind:struct kind:class`, nil},
+			{`multiple patterns`, `Handler ServeHTTP`, nil},
+			{`catch invalid regexp`, `([)`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasSymbolPredicate{}
+				err := p.ParseParams(tc.params)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
@@ -15,6 +15,36 @@ func TestPipelineStructural(t *testing.T) {
 	autogold.Want("contains(...) spans newlines", `"repo:contains.path(\nfoo\n)"`).Equal(t, test("repo:contains.path(\nfoo\n)"))
 }
 
+func TestPipelineFuzzy(t *testing.T) {
+	cases := []struct {
+		input        string
+		wantPattern  string
+		wantMaxEdits int
+	}{
+		{input: "hander", wantPattern: "hander", wantMaxEdits: 1},
+		{input: "repo:foo http hander maxedits:2", wantPattern: "http hander", wantMaxEdits: 2},
+		{input: `content:"foo.Bar("`, wantPattern: "foo.Bar(", wantMaxEdits: 1},
+	}
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			plan, err := Pipeline(Init(c.input, SearchTypeFuzzy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			b := plan[0]
+			if !b.IsFuzzy() || b.IsLiteral() {
+				t.Fatalf("expected a fuzzy pattern, got %s", b)
+			}
+			if got := b.PatternString(); got != c.wantPattern {
+				t.Errorf("got pattern %q, want %q", got, c.wantPattern)
+			}
+			if got := b.MaxEdits(); got != c.wantMaxEdits {
+				t.Errorf("got maxedits %d, want %d", got, c.wantMaxEdits)
+			}
+		})
+	}
+}
+
 func TestSubstituteSearchContexts(t *testing.T) {
 	test := func(input string, verbose bool) string {
 		lookup := func(string) (string, error) {
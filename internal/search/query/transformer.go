@@ -2,6 +2,7 @@ package query
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/grafana/regexp"
@@ -41,11 +42,14 @@ func LowercaseFieldNames(nodes []Node) []Node {
 	})
 }
 
+// CountAll is the count that count:all is substituted with.
+const CountAll = 99999999
+
 // SubstituteCountAll replaces count:all with count:99999999.
 func SubstituteCountAll(nodes []Node) []Node {
 	return MapParameter(nodes, func(field, value string, negated bool, annotation Annotation) Node {
 		if field == FieldCount && strings.ToLower(value) == "all" {
-			return Parameter{Field: field, Value: "99999999", Negated: negated, Annotation: annotation}
+			return Parameter{Field: field, Value: strconv.Itoa(CountAll), Negated: negated, Annotation: annotation}
 		}
 		return Parameter{Field: field, Value: value, Negated: negated, Annotation: annotation}
 	})
@@ -515,13 +519,20 @@ func conjunction(left, right Basic) Basic {
 // pattern node, just not in any of the parameters.
 //
 // For example, the query
-//   repo:a (file:b OR file:c)
+//
+//	repo:a (file:b OR file:c)
+//
 // is transformed to
-//   (repo:a file:b) OR (repo:a file:c)
+//
+//	(repo:a file:b) OR (repo:a file:c)
+//
 // but the query
-//   (repo:a OR repo:b) (b OR c)
+//
+//	(repo:a OR repo:b) (b OR c)
+//
 // is transformed to
-//   (repo:a (b OR c)) OR (repo:b (b OR c))
+//
+//	(repo:a (b OR c)) OR (repo:b (b OR c))
 func BuildPlan(query []Node) Plan {
 	return distribute([]Basic{}, query)
 }
@@ -824,6 +835,20 @@ func labelStructural(nodes []Node) []Node {
 	})
 }
 
+// labelFuzzy converts Literal labels to Fuzzy labels. Like structural queries,
+// fuzzy queries are parsed the same as literal queries.
+func labelFuzzy(nodes []Node) []Node {
+	return MapPattern(nodes, func(value string, negated bool, annotation Annotation) Node {
+		annotation.Labels.Unset(Literal)
+		annotation.Labels.Set(Fuzzy)
+		return Pattern{
+			Value:      value,
+			Negated:    negated,
+			Annotation: annotation,
+		}
+	})
+}
+
 // ellipsesForHoles substitutes ellipses ... for :[_] holes in structural search queries.
 func ellipsesForHoles(nodes []Node) []Node {
 	return MapPattern(nodes, func(value string, negated bool, annotation Annotation) Node {
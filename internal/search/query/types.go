@@ -36,6 +36,7 @@ const (
 	SearchTypeLucky
 	SearchTypeStandard
 	SearchTypeKeyword
+	SearchTypeFuzzy
 )
 
 func (s SearchType) String() string {
@@ -52,6 +53,8 @@ func (s SearchType) String() string {
 		return "lucky"
 	case SearchTypeKeyword:
 		return "keyword"
+	case SearchTypeFuzzy:
+		return "fuzzy"
 	default:
 		return fmt.Sprintf("unknown{%d}", s)
 	}
@@ -292,6 +295,10 @@ func (b Basic) IsStructural() bool {
 	return b.HasPatternLabel(Structural)
 }
 
+func (b Basic) IsFuzzy() bool {
+	return b.HasPatternLabel(Fuzzy)
+}
+
 // PatternString returns the simple string pattern of a basic query. It assumes
 // there is only on pattern atom.
 func (b Basic) PatternString() string {
@@ -443,6 +450,27 @@ func (p Parameters) FileHasOwner() (include, exclude []string) {
 	return include, exclude
 }
 
+// SymbolFilter represents the arguments of a file:has.symbol() or
+// repo:has.symbol() predicate.
+type SymbolFilter struct {
+	Pattern string
+	Kind    string // optional
+}
+
+func (p Parameters) FileHasSymbol() (res []SymbolFilter) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasSymbolPredicate, _ bool) {
+		res = append(res, SymbolFilter{Pattern: pred.Pattern, Kind: pred.Kind})
+	})
+	return res
+}
+
+func (p Parameters) RepoHasSymbol() (res []SymbolFilter) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasSymbolPredicate, _ bool) {
+		res = append(res, SymbolFilter{Pattern: pred.Pattern, Kind: pred.Kind})
+	})
+	return res
+}
+
 // Exists returns whether a parameter exists in the query (whether negated or not).
 func (p Parameters) Exists(field string) bool {
 	found := false
@@ -484,6 +512,20 @@ func (p Parameters) Count() (count *int) {
 	return count
 }
 
+// MaxEdits returns the number of edits fuzzy patterns tolerate, set by the
+// `maxedits:` field.
+func (p Parameters) MaxEdits() int {
+	maxEdits := DefaultMaxEdits
+	VisitField(toNodes(p), FieldMaxEdits, func(value string, _ bool, _ Annotation) {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			panic(fmt.Sprintf("Value %q for maxedits cannot be parsed as an int", value))
+		}
+		maxEdits = n
+	})
+	return maxEdits
+}
+
 // GetTimeout returns the time.Duration value from the `timeout:` field.
 func (p Parameters) GetTimeout() *time.Duration {
 	var timeout *time.Duration
@@ -216,6 +216,21 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return err
 	}
 
+	isValidSort := func() error {
+		if value != SortRelevance {
+			return errors.Errorf("invalid value %q for field %q. Valid values are: %s", value, field, SortRelevance)
+		}
+		return nil
+	}
+
+	isValidMaxEdits := func() error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > MaxMaxEdits {
+			return errors.Errorf("invalid value %q for field %q. Valid values are 1 to %d", value, field, MaxMaxEdits)
+		}
+		return nil
+	}
+
 	isValidGitDate := func() error {
 		_, err := ParseGitDate(value, time.Now)
 		return err
@@ -292,6 +307,12 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
+	case
+		FieldSort:
+		return satisfies(isSingular, isNotNegated, isValidSort)
+	case
+		FieldMaxEdits:
+		return satisfies(isSingular, isNotNegated, isValidMaxEdits)
 	default:
 		return isUnrecognizedField()
 	}
@@ -376,6 +397,50 @@ func validateTypeStructural(nodes []Node) error {
 	return nil
 }
 
+// validateFuzzy checks that maxedits: is only used with fuzzy patterns, that
+// fuzzy patterns only search file contents, and that they are long enough to
+// be meaningful: a pattern no longer than the number of edits would match any
+// text.
+func validateFuzzy(nodes []Node) error {
+	maxEdits, seenMaxEdits := DefaultMaxEdits, false
+	VisitField(nodes, FieldMaxEdits, func(value string, _ bool, _ Annotation) {
+		seenMaxEdits = true
+		// Invariant: the value is validated by validateField.
+		maxEdits, _ = strconv.Atoi(value)
+	})
+
+	seenFuzzy := false
+	var err error
+	VisitPattern(nodes, func(value string, negated bool, annotation Annotation) {
+		if err != nil || !annotation.Labels.IsSet(Fuzzy) {
+			return
+		}
+		seenFuzzy = true
+		if negated {
+			err = errors.New("the query contains a negated search pattern. Fuzzy search does not support negated search patterns")
+			return
+		}
+		if len(value) <= maxEdits {
+			err = errors.Errorf("the fuzzy search pattern %q must be longer than the number of allowed edits (maxedits:%d)", value, maxEdits)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if seenFuzzy {
+		VisitField(nodes, FieldType, func(value string, _ bool, _ Annotation) {
+			if err == nil && value != "file" {
+				err = errors.Errorf("this fuzzy search query specifies `type:%s` and is not supported. Fuzzy search only applies to searching file contents", value)
+			}
+		})
+		return err
+	}
+	if seenMaxEdits {
+		return errors.New("the field maxedits: only applies to fuzzy search patterns. Add patterntype:fuzzy to the query")
+	}
+	return nil
+}
+
 func validateRefGlobs(nodes []Node) error {
 	if !ContainsRefGlobs(nodes) {
 		return nil
@@ -496,6 +561,7 @@ func validate(nodes []Node) error {
 		validateRepoHasFile,
 		validateCommitParameters,
 		validateTypeStructural,
+		validateFuzzy,
 		validateRefGlobs,
 	)
 }
@@ -99,6 +99,14 @@ func TestValidation(t *testing.T) {
 			input: "type:symbol select:symbol.timelime",
 			want:  `invalid field "timelime" on select path "symbol.timelime"`,
 		},
+		{
+			input: "foo sort:stars",
+			want:  `invalid value "stars" for field "sort". Valid values are: relevance`,
+		},
+		{
+			input: "foo -sort:relevance",
+			want:  `field "sort" does not support negation`,
+		},
 		{
 			input:      "nice try type:repo",
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents",
@@ -109,6 +117,31 @@ func TestValidation(t *testing.T) {
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents and is not currently supported for diff searches",
 			searchType: SearchTypeStructural,
 		},
+		{
+			input:      "-content:handler",
+			want:       "the query contains a negated search pattern. Fuzzy search does not support negated search patterns",
+			searchType: SearchTypeFuzzy,
+		},
+		{
+			input:      "ab maxedits:2",
+			want:       `the fuzzy search pattern "ab" must be longer than the number of allowed edits (maxedits:2)`,
+			searchType: SearchTypeFuzzy,
+		},
+		{
+			input:      "handler maxedits:4",
+			want:       `invalid value "4" for field "maxedits". Valid values are 1 to 3`,
+			searchType: SearchTypeFuzzy,
+		},
+		{
+			input:      "handler type:commit",
+			want:       "this fuzzy search query specifies `type:commit` and is not supported. Fuzzy search only applies to searching file contents",
+			searchType: SearchTypeFuzzy,
+		},
+		{
+			input:      "handler maxedits:2",
+			want:       "the field maxedits: only applies to fuzzy search patterns. Add patterntype:fuzzy to the query",
+			searchType: SearchTypeLiteral,
+		},
 	}
 	for _, c := range cases {
 		t.Run("validate and/or query", func(t *testing.T) {
@@ -0,0 +1,424 @@
+package ranking
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/search/symbol"
+)
+
+// NewRelevanceJob returns a job that reorders the file matches of child by
+// their relevance score. Since results are streamed, matches are buffered in
+// windows of opts.Window matches and each window is sorted separately. All
+// other events are passed through as they arrive.
+func NewRelevanceJob(child job.Job, opts Options) job.Job {
+	if opts.Window <= 0 {
+		opts.Window = DefaultOptions.Window
+	}
+	return &relevanceJob{child: child, opts: opts}
+}
+
+type relevanceJob struct {
+	child job.Job
+	opts  Options
+}
+
+func (j *relevanceJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	signals := newSignalsFetcher(clients)
+
+	var (
+		mu     sync.Mutex
+		window []*result.FileMatch
+	)
+
+	// Windows are ranked one at a time in a separate goroutine, in the order
+	// they were filled, so that fetching signals doesn't block the child from
+	// sending more results. At most maxPendingWindows filled windows wait to be
+	// ranked. Past that, sending results blocks until a window is ranked, so
+	// that a slow ranking slows down the child rather than buffering all of its
+	// results.
+	pending := make(chan []*result.FileMatch, maxPendingWindows)
+	ranked := make(chan struct{})
+	go func() {
+		defer close(ranked)
+		for matches := range pending {
+			stream.Send(streaming.SearchEvent{Results: j.rank(ctx, signals, matches)})
+		}
+	}()
+
+	// enqueue hands the current window off to the ranking goroutine. It must
+	// be called with mu held, so that windows are ranked in the order they
+	// were filled.
+	enqueue := func() {
+		if len(window) == 0 {
+			return
+		}
+		pending <- window
+		window = nil
+	}
+
+	alert, err = j.child.Run(ctx, clients, streaming.StreamFunc(func(event streaming.SearchEvent) {
+		passthrough := event.Results[:0]
+		mu.Lock()
+		for _, m := range event.Results {
+			if fm, ok := m.(*result.FileMatch); ok {
+				window = append(window, fm)
+			} else {
+				passthrough = append(passthrough, m)
+			}
+		}
+		if len(window) >= j.opts.Window {
+			enqueue()
+		}
+		mu.Unlock()
+
+		event.Results = passthrough
+		stream.Send(event)
+	}))
+
+	mu.Lock()
+	enqueue()
+	mu.Unlock()
+	close(pending)
+	<-ranked
+
+	return alert, err
+}
+
+// maxPendingWindows bounds the number of filled windows waiting to be ranked.
+const maxPendingWindows = 1
+
+// maxSignalsConcurrency bounds the number of file matches whose signals are
+// fetched concurrently.
+const maxSignalsConcurrency = 8
+
+// rank returns the matches sorted by descending score. Matches with the same
+// score keep the order in which the backend returned them.
+func (j *relevanceJob) rank(ctx context.Context, fetcher *signalsFetcher, matches []*result.FileMatch) []result.Match {
+	if ctx.Err() != nil {
+		// The search is over, so there is no point in fetching signals.
+		return sortByScore(matches, make([]float64, len(matches)))
+	}
+
+	w := j.opts.Weights
+	if w.Recency != 0 && !fetcher.reserveRecencyLookups(len(matches)) {
+		// Recency is left out of the scores of the whole window rather than
+		// of only some of its matches, so that the window is ranked
+		// consistently.
+		w.Recency = 0
+	}
+
+	var definitions map[*result.FileMatch]bool
+	if w.SymbolDefinition != 0 {
+		definitions = fetcher.symbolDefinitions(ctx, matches)
+	}
+
+	scores := make([]float64, len(matches))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxSignalsConcurrency)
+	for i, fm := range matches {
+		i, fm := i, fm
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			signals := fetcher.Signals(ctx, fm, w)
+			signals.SymbolDefinition = definitions[fm]
+			scores[i] = w.Score(signals)
+		}()
+	}
+	wg.Wait()
+
+	return sortByScore(matches, scores)
+}
+
+func sortByScore(matches []*result.FileMatch, scores []float64) []result.Match {
+	idx := make([]int, len(matches))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return scores[idx[a]] > scores[idx[b]]
+	})
+
+	ranked := make([]result.Match, 0, len(matches))
+	for _, i := range idx {
+		ranked = append(ranked, matches[i])
+	}
+	return ranked
+}
+
+func (j *relevanceJob) Name() string {
+	return "RelevanceJob"
+}
+
+func (j *relevanceJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		res = append(res,
+			otlog.Float64("weights.recency", j.opts.Weights.Recency),
+			otlog.Float64("weights.repoStars", j.opts.Weights.RepoStars),
+			otlog.Float64("weights.symbolDefinition", j.opts.Weights.SymbolDefinition),
+			otlog.Float64("weights.testPath", j.opts.Weights.TestPath),
+			otlog.Float64("weights.vendorPath", j.opts.Weights.VendorPath),
+		)
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			otlog.Int("window", j.opts.Window),
+		)
+	}
+	return res
+}
+
+func (j *relevanceJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *relevanceJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// signalsFetcher looks up the signals of file matches. Signals are best
+// effort: a signal that can't be fetched doesn't contribute to the score
+// rather than failing the search.
+type signalsFetcher struct {
+	clients job.RuntimeClients
+	now     func() time.Time
+
+	mu                 sync.Mutex
+	stars              map[api.RepoID]int
+	recencyLookupsLeft int
+}
+
+// maxRecencyLookups bounds the number of gitserver calls a search makes to
+// look up the recency of file matches.
+const maxRecencyLookups = 1000
+
+func newSignalsFetcher(clients job.RuntimeClients) *signalsFetcher {
+	return &signalsFetcher{
+		clients:            clients,
+		now:                time.Now,
+		stars:              make(map[api.RepoID]int),
+		recencyLookupsLeft: maxRecencyLookups,
+	}
+}
+
+// reserveRecencyLookups returns true if the recency of n more file matches can
+// be looked up, and counts them against maxRecencyLookups.
+func (f *signalsFetcher) reserveRecencyLookups(n int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if n > f.recencyLookupsLeft {
+		return false
+	}
+	f.recencyLookupsLeft -= n
+	return true
+}
+
+// Signals returns the signals of fm, except SymbolDefinition, which is
+// fetched for a whole window at once by symbolDefinitions. Signals with a
+// weight of zero don't contribute to the score, so they aren't fetched.
+func (f *signalsFetcher) Signals(ctx context.Context, fm *result.FileMatch, w Weights) (s Signals) {
+	if w.Recency != 0 {
+		s.Recency = f.recency(ctx, fm)
+	}
+	if w.RepoStars != 0 {
+		s.RepoStars = RepoStarsSignal(f.repoStars(ctx, fm.Repo.ID))
+	}
+	if w.TestPath != 0 {
+		s.TestPath = IsTestPath(fm.Path)
+	}
+	if w.VendorPath != 0 {
+		s.VendorPath = IsVendorPath(fm.Path)
+	}
+	return s
+}
+
+// recency returns the recency signal of the last commit that modified the
+// file of fm.
+func (f *signalsFetcher) recency(ctx context.Context, fm *result.FileMatch) float64 {
+	commits, err := f.clients.Gitserver.Commits(ctx, fm.Repo.Name, gitserver.CommitsOptions{
+		Range: string(fm.CommitID),
+		Path:  fm.Path,
+		N:     1,
+	}, authz.DefaultSubRepoPermsChecker)
+	if err != nil || len(commits) == 0 {
+		return 0
+	}
+
+	date := commits[0].Author.Date
+	if commits[0].Committer != nil {
+		date = commits[0].Committer.Date
+	}
+	return RecencySignal(f.now().Sub(date))
+}
+
+func (f *signalsFetcher) repoStars(ctx context.Context, id api.RepoID) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if stars, ok := f.stars[id]; ok {
+		return stars
+	}
+
+	repos, err := f.clients.DB.Repos().Metadata(ctx, id)
+	if err != nil {
+		// Don't cache failures, so the next window can try again.
+		return 0
+	}
+	stars := 0
+	if len(repos) > 0 {
+		stars = repos[0].Stars
+	}
+	f.stars[id] = stars
+	return stars
+}
+
+// maxSymbolsPerFile bounds the number of symbols fetched per file match.
+const maxSymbolsPerFile = 500
+
+// maxFilesPerSymbolsRequest bounds the number of files whose symbols are
+// fetched with a single request.
+const maxFilesPerSymbolsRequest = 50
+
+// symbolDefinitions returns the file matches for which one of the matches
+// overlaps the name of a symbol defined in the file. The symbols of the files
+// of the same repository and commit are fetched together.
+func (f *signalsFetcher) symbolDefinitions(ctx context.Context, matches []*result.FileMatch) map[*result.FileMatch]bool {
+	type repoCommit struct {
+		repo   api.RepoID
+		commit api.CommitID
+	}
+
+	var (
+		keys   []repoCommit
+		groups = make(map[repoCommit][]*result.FileMatch)
+	)
+	for _, fm := range matches {
+		if len(fm.ChunkMatches) == 0 {
+			continue
+		}
+		key := repoCommit{repo: fm.Repo.ID, commit: fm.CommitID}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], fm)
+	}
+
+	var (
+		mu          sync.Mutex
+		definitions = make(map[*result.FileMatch]bool)
+		wg          sync.WaitGroup
+		sem         = make(chan struct{}, maxSignalsConcurrency)
+	)
+	for _, key := range keys {
+		group := groups[key]
+		for len(group) > 0 {
+			batch := group
+			if len(batch) > maxFilesPerSymbolsRequest {
+				batch = batch[:maxFilesPerSymbolsRequest]
+			}
+			group = group[len(batch):]
+
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				for fm, defines := range f.definesMatchedSymbols(ctx, batch) {
+					mu.Lock()
+					definitions[fm] = defines
+					mu.Unlock()
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	return definitions
+}
+
+// definesMatchedSymbols returns, for each of matches, whether one of its
+// matches overlaps the name of a symbol defined in the file. All matches must
+// be in the same repository and commit.
+func (f *signalsFetcher) definesMatchedSymbols(ctx context.Context, matches []*result.FileMatch) map[*result.FileMatch]bool {
+	paths := make([]string, 0, len(matches))
+	for _, fm := range matches {
+		paths = append(paths, regexp.QuoteMeta(fm.Path))
+	}
+
+	var (
+		first           = matches[0]
+		query           = ""
+		limit           = int32(maxSymbolsPerFile * len(matches))
+		includePatterns = []string{"^(?:" + strings.Join(paths, "|") + ")$"}
+	)
+	symbols, err := symbol.Compute(ctx, authz.DefaultSubRepoPermsChecker, first.Repo, first.CommitID, first.InputRev, &query, &limit, &includePatterns)
+	if err != nil {
+		return nil
+	}
+
+	byPath := make(map[string][]result.Symbol)
+	for _, s := range symbols {
+		byPath[s.File.Path] = append(byPath[s.File.Path], s.Symbol)
+	}
+
+	definitions := make(map[*result.FileMatch]bool, len(matches))
+	for _, fm := range matches {
+		for _, sym := range byPath[fm.Path] {
+			if overlapsSymbol(fm.ChunkMatches, sym) {
+				definitions[fm] = true
+				break
+			}
+		}
+	}
+	return definitions
+}
+
+// overlapsSymbol returns true if any range in chunks overlaps the name of sym
+// at its definition.
+func overlapsSymbol(chunks result.ChunkMatches, sym result.Symbol) bool {
+	symRange := sym.Range()
+	for _, cm := range chunks {
+		for _, rr := range cm.Ranges {
+			if rr.Start.Line != symRange.Start.Line {
+				continue
+			}
+			end := rr.End.Column
+			if rr.End.Line > rr.Start.Line {
+				// The match continues past the end of the line.
+				end = symRange.End.Character
+			}
+			if rr.Start.Column < symRange.End.Character && end > symRange.Start.Character {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package ranking
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+func TestRelevanceJob(t *testing.T) {
+	child := mockjob.NewMockJob()
+	child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		for _, path := range []string{"a_test.go", "b.go", "c.go", "d_test.go", "e.go"} {
+			s.Send(streaming.SearchEvent{Results: result.Matches{&result.FileMatch{File: result.File{Path: path}}}})
+		}
+		s.Send(streaming.SearchEvent{Results: result.Matches{&result.RepoMatch{Name: "repo"}}})
+		return nil, nil
+	})
+
+	// Only the test path signal has a weight, so no other signals are
+	// fetched. Fetching them would fail with the empty runtime clients.
+	j := NewRelevanceJob(child, Options{Weights: Weights{TestPath: 1}, Window: 2})
+
+	var (
+		mu    sync.Mutex
+		paths []string
+		repos int
+	)
+	_, err := j.Run(context.Background(), job.RuntimeClients{}, streaming.StreamFunc(func(e streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, m := range e.Results {
+			switch v := m.(type) {
+			case *result.FileMatch:
+				paths = append(paths, v.Path)
+			case *result.RepoMatch:
+				repos++
+			}
+		}
+	}))
+	require.NoError(t, err)
+
+	// Windows are ranked separately and sent in order.
+	require.Equal(t, []string{"b.go", "a_test.go", "c.go", "d_test.go", "e.go"}, paths)
+	require.Equal(t, 1, repos)
+}
+
+func TestRelevanceJobBackpressure(t *testing.T) {
+	var sent int32
+	child := mockjob.NewMockJob()
+	child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		for i := 0; i < 10; i++ {
+			s.Send(streaming.SearchEvent{Results: result.Matches{&result.FileMatch{File: result.File{Path: fmt.Sprintf("%d.go", i)}}}})
+			atomic.AddInt32(&sent, 1)
+		}
+		return nil, nil
+	})
+
+	j := NewRelevanceJob(child, Options{Weights: Weights{TestPath: 1}, Window: 1})
+
+	release := make(chan struct{})
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	done := make(chan error)
+	go func() {
+		_, err := j.Run(context.Background(), job.RuntimeClients{}, streaming.StreamFunc(func(e streaming.SearchEvent) {
+			if len(e.Results) == 0 {
+				return
+			}
+			// Block the first ranked window until released.
+			<-release
+			mu.Lock()
+			defer mu.Unlock()
+			paths = append(paths, e.Results[0].(*result.FileMatch).Path)
+		}))
+		done <- err
+	}()
+
+	// One window is being sent and another one waits to be ranked, so the
+	// child is blocked on its third result.
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&sent) == 2 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, int32(2), atomic.LoadInt32(&sent))
+
+	close(release)
+	require.NoError(t, <-done)
+	require.Len(t, paths, 10)
+}
@@ -0,0 +1,139 @@
+// Package ranking reorders file results by relevance signals that the search
+// backends don't take into account, like how recently a file was modified or
+// how popular its repository is. It is used by queries with sort:relevance.
+package ranking
+
+import (
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Weights configures how much each signal contributes to the score of a file
+// result. Penalties are subtracted from the score.
+type Weights struct {
+	Recency          float64
+	RepoStars        float64
+	SymbolDefinition float64
+	TestPath         float64
+	VendorPath       float64
+}
+
+// Options configures the relevance ranking of a search.
+type Options struct {
+	Weights Weights
+
+	// Window is the number of file results that are buffered and sorted
+	// together before they are streamed.
+	Window int
+}
+
+// DefaultOptions are the options used when none are set in the site
+// configuration. They match the defaults of the site configuration schema.
+var DefaultOptions = Options{
+	Weights: Weights{
+		Recency:          1,
+		RepoStars:        1,
+		SymbolDefinition: 2,
+		TestPath:         1,
+		VendorPath:       2,
+	},
+	Window: 100,
+}
+
+// OptionsFromConfig returns the ranking options in the site configuration,
+// falling back to DefaultOptions for the ones that aren't set.
+func OptionsFromConfig(c schema.SiteConfiguration) Options {
+	opts := DefaultOptions
+	if c.ExperimentalFeatures == nil || c.ExperimentalFeatures.Ranking == nil {
+		return opts
+	}
+	ranking := c.ExperimentalFeatures.Ranking
+
+	if ranking.RelevanceWindow != nil && *ranking.RelevanceWindow > 0 {
+		opts.Window = *ranking.RelevanceWindow
+	}
+
+	if w := ranking.RelevanceWeights; w != nil {
+		set := func(dst *float64, src *float64) {
+			if src != nil {
+				*dst = *src
+			}
+		}
+		set(&opts.Weights.Recency, w.Recency)
+		set(&opts.Weights.RepoStars, w.RepoStars)
+		set(&opts.Weights.SymbolDefinition, w.SymbolDefinition)
+		set(&opts.Weights.TestPath, w.TestPath)
+		set(&opts.Weights.VendorPath, w.VendorPath)
+	}
+	return opts
+}
+
+// Signals are the properties of a file result used to score it. Numeric
+// signals are normalized to [0, 1].
+type Signals struct {
+	Recency          float64
+	RepoStars        float64
+	SymbolDefinition bool
+	TestPath         bool
+	VendorPath       bool
+}
+
+// Score returns the weighted sum of the signals.
+func (w Weights) Score(s Signals) float64 {
+	score := w.Recency*s.Recency + w.RepoStars*s.RepoStars
+	if s.SymbolDefinition {
+		score += w.SymbolDefinition
+	}
+	if s.TestPath {
+		score -= w.TestPath
+	}
+	if s.VendorPath {
+		score -= w.VendorPath
+	}
+	return score
+}
+
+// recencyHalfLife is the age at which a file gets half of the recency
+// signal of a file modified just now.
+const recencyHalfLife = 90 * 24 * time.Hour
+
+// RecencySignal normalizes the age of the last modification of a file. It
+// decays exponentially from 1 for a file modified now.
+func RecencySignal(age time.Duration) float64 {
+	if age < 0 {
+		age = 0
+	}
+	return math.Exp2(-float64(age) / float64(recencyHalfLife))
+}
+
+// maxStarsLog10 is the order of magnitude of stars at which a repository gets
+// the full stars signal.
+const maxStarsLog10 = 5
+
+// RepoStarsSignal normalizes the star count of a repository on a log scale,
+// so that a handful of very popular repositories don't flatten out the
+// difference between all others.
+func RepoStarsSignal(stars int) float64 {
+	if stars <= 0 {
+		return 0
+	}
+	return math.Min(1, math.Log10(float64(stars)+1)/maxStarsLog10)
+}
+
+var (
+	testPathRegexp   = regexp.MustCompile(`(^|/)(tests?|__tests__|spec|testdata)/|(_test|\.test|\.spec|_spec)\.[^/]+$|(^|/)test_[^/]+\.py$`)
+	vendorPathRegexp = regexp.MustCompile(`(^|/)(vendor|node_modules|third_party|thirdparty|bower_components)/|\.min\.(js|css)$`)
+)
+
+// IsTestPath returns true if path looks like a test file or test fixture.
+func IsTestPath(path string) bool {
+	return testPathRegexp.MatchString(path)
+}
+
+// IsVendorPath returns true if path looks like vendored or minified code.
+func IsVendorPath(path string) bool {
+	return vendorPathRegexp.MatchString(path)
+}
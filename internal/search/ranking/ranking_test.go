@@ -0,0 +1,132 @@
+package ranking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestOptionsFromConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		require.Equal(t, DefaultOptions, OptionsFromConfig(schema.SiteConfiguration{}))
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		window := 20
+		recency := 3.0
+		vendor := 0.0
+		opts := OptionsFromConfig(schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				Ranking: &schema.Ranking{
+					RelevanceWindow: &window,
+					RelevanceWeights: &schema.RelevanceWeights{
+						Recency:    &recency,
+						VendorPath: &vendor,
+					},
+				},
+			},
+		})
+
+		want := DefaultOptions
+		want.Window = 20
+		want.Weights.Recency = 3
+		want.Weights.VendorPath = 0
+		require.Equal(t, want, opts)
+	})
+}
+
+func TestScore(t *testing.T) {
+	w := DefaultOptions.Weights
+
+	require.Equal(t, 0.0, w.Score(Signals{}))
+	require.Equal(t, 1.5, w.Score(Signals{Recency: 1, RepoStars: 0.5}))
+	require.Equal(t, 2.0, w.Score(Signals{SymbolDefinition: true}))
+	require.Equal(t, -3.0, w.Score(Signals{TestPath: true, VendorPath: true}))
+}
+
+func TestRecencySignal(t *testing.T) {
+	require.Equal(t, 1.0, RecencySignal(0))
+	require.Equal(t, 1.0, RecencySignal(-time.Hour))
+	require.InDelta(t, 0.5, RecencySignal(recencyHalfLife), 1e-9)
+	require.InDelta(t, 0.25, RecencySignal(2*recencyHalfLife), 1e-9)
+}
+
+func TestRepoStarsSignal(t *testing.T) {
+	require.Equal(t, 0.0, RepoStarsSignal(0))
+	require.InDelta(t, 0.2, RepoStarsSignal(9), 1e-9)
+	require.Equal(t, 1.0, RepoStarsSignal(99999))
+	require.Equal(t, 1.0, RepoStarsSignal(1000000))
+}
+
+func TestPathHeuristics(t *testing.T) {
+	cases := []struct {
+		path   string
+		test   bool
+		vendor bool
+	}{
+		{path: "main.go"},
+		{path: "internal/latest/contest.go"},
+		{path: "main_test.go", test: true},
+		{path: "src/app.test.ts", test: true},
+		{path: "src/app.spec.js", test: true},
+		{path: "lib/foo_spec.rb", test: true},
+		{path: "tests/conftest.py", test: true},
+		{path: "pkg/test_utils.py", test: true},
+		{path: "internal/testdata/input.txt", test: true},
+		{path: "src/__tests__/app.js", test: true},
+		{path: "vendor/github.com/foo/bar.go", vendor: true},
+		{path: "web/node_modules/react/index.js", vendor: true},
+		{path: "third_party/zlib/zlib.h", vendor: true},
+		{path: "static/jquery.min.js", vendor: true},
+		{path: "vendor/foo/foo_test.go", test: true, vendor: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			require.Equal(t, tc.test, IsTestPath(tc.path), "IsTestPath")
+			require.Equal(t, tc.vendor, IsVendorPath(tc.path), "IsVendorPath")
+		})
+	}
+}
+
+func TestOverlapsSymbol(t *testing.T) {
+	// func Foo() on line 3 (1-based), name starting at column 5.
+	sym := result.Symbol{Name: "Foo", Line: 3, Character: 5}
+
+	chunk := func(startLine, startCol, endLine, endCol int) result.ChunkMatches {
+		return result.ChunkMatches{{
+			Ranges: result.Ranges{{
+				Start: result.Location{Line: startLine, Column: startCol},
+				End:   result.Location{Line: endLine, Column: endCol},
+			}},
+		}}
+	}
+
+	require.True(t, overlapsSymbol(chunk(2, 5, 2, 8), sym), "exact match")
+	require.True(t, overlapsSymbol(chunk(2, 6, 2, 7), sym), "inside name")
+	require.True(t, overlapsSymbol(chunk(2, 0, 2, 6), sym), "overlaps start")
+	require.True(t, overlapsSymbol(chunk(2, 7, 3, 2), sym), "multiline")
+	require.False(t, overlapsSymbol(chunk(2, 0, 2, 4), sym), "func keyword")
+	require.False(t, overlapsSymbol(chunk(2, 8, 2, 10), sym), "after name")
+	require.False(t, overlapsSymbol(chunk(5, 5, 5, 8), sym), "other line")
+}
+
+func TestSortByScore(t *testing.T) {
+	matches := []*result.FileMatch{
+		{File: result.File{Path: "a"}},
+		{File: result.File{Path: "b"}},
+		{File: result.File{Path: "c"}},
+		{File: result.File{Path: "d"}},
+	}
+	ranked := sortByScore(matches, []float64{0, 2, 0, 1})
+
+	var paths []string
+	for _, m := range ranked {
+		paths = append(paths, m.(*result.FileMatch).Path)
+	}
+	require.Equal(t, []string{"b", "d", "a", "c"}, paths)
+}
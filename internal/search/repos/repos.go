@@ -170,8 +170,10 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 	}
 
 	// Filter by search context repository revisions only if this search context doesn't have
-	// a query, which replaces the context:foo term at query parsing time.
-	if searchContext.Query == "" {
+	// a query, which replaces the context:foo term at query parsing time. The
+	// repositories of dynamic search contexts are resolved from their query
+	// ahead of time and stored like those of contexts without a query.
+	if searchContext.Query == "" || searchContext.Dynamic {
 		options.SearchContextID = searchContext.ID
 		options.UserID = searchContext.NamespaceUserID
 		options.OrgID = searchContext.NamespaceOrgID
@@ -215,7 +217,7 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 	}
 
 	var searchContextRepositoryRevisions map[api.RepoID]RepoRevSpecs
-	if !searchcontexts.IsAutoDefinedSearchContext(searchContext) && searchContext.Query == "" {
+	if !searchcontexts.IsAutoDefinedSearchContext(searchContext) && (searchContext.Query == "" || searchContext.Dynamic) {
 		scRepoRevs, err := searchcontexts.GetRepositoryRevisions(ctx, r.db, searchContext.ID)
 		if err != nil {
 			return Resolved{}, err
@@ -172,6 +172,14 @@ func TestKeyEquality(t *testing.T) {
 		match1:   &CommitMatch{Commit: gitdomain.Commit{ID: "test1"}},
 		match2:   &CommitMatch{Commit: gitdomain.Commit{ID: "test2"}},
 		areEqual: false,
+	}, {
+		match1:   &OwnerMatch{Handle: "sqs"},
+		match2:   &OwnerMatch{Handle: "sqs"},
+		areEqual: true,
+	}, {
+		match1:   &OwnerMatch{Handle: "sqs"},
+		match2:   &OwnerMatch{Email: "sqs@sourcegraph.com"},
+		areEqual: false,
 	}}
 
 	for _, tc := range cases {
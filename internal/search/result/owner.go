@@ -0,0 +1,60 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is a code owner of one or more files matched by a search. It is
+// produced by `select:owner`. An owner is identified by either a handle or an
+// email, and owner matches are deduplicated across repositories.
+type OwnerMatch struct {
+	// Handle is the username or team name of the owner, without the leading
+	// "@". It is empty if the owner is identified by an email.
+	Handle string
+
+	// Email is the email of the owner. It is empty if the owner is
+	// identified by a handle.
+	Email string
+}
+
+// Identifier returns the string used to refer to this owner in a CODEOWNERS
+// file, e.g. "@octo-org/octocats" or "test@example.com".
+func (o *OwnerMatch) Identifier() string {
+	if o.Email != "" {
+		return o.Email
+	}
+	return "@" + o.Handle
+}
+
+// RepoName returns an empty repo, since owners are not associated with a
+// single repository.
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	return types.MinimalRepo{}
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Owner:
+		return o
+	}
+	return nil
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Owner:    o.Identifier(),
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
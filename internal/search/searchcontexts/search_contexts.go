@@ -190,8 +190,15 @@ func validateSearchContextRepositoryRevisions(repositoryRevisions []*types.Searc
 // be converted to an efficient database lookup when determing which revisions
 // to index in RepoRevs. We don't want to run a search to determine which revisions
 // we need to index. That would be brittle, recursive and possibly impossible.
-func validateSearchContextQuery(contextQuery string) error {
+//
+// Dynamic search contexts are the exception: their repositories are resolved
+// with a search by a background worker, so their queries may also contain repo
+// predicates.
+func validateSearchContextQuery(contextQuery string, dynamic bool) error {
 	if contextQuery == "" {
+		if dynamic {
+			return errors.New("dynamic search contexts must have a query")
+		}
 		return nil
 	}
 
@@ -207,8 +214,10 @@ func validateSearchContextQuery(contextQuery string) error {
 		switch field {
 		case query.FieldRepo:
 			if a.Labels.IsSet(query.IsPredicate) {
-				errs = errors.Append(errs,
-					errors.Errorf("unsupported repo field predicate in search context query: %q", value))
+				if !dynamic {
+					errs = errors.Append(errs,
+						errors.Errorf("unsupported repo field predicate in search context query: %q", value))
+				}
 				return
 			}
 
@@ -302,7 +311,7 @@ func CreateSearchContextWithRepositoryRevisions(
 		return nil, err
 	}
 
-	err = validateSearchContextQuery(searchContext.Query)
+	err = validateSearchContextQuery(searchContext.Query, searchContext.Dynamic)
 	if err != nil {
 		return nil, err
 	}
@@ -348,7 +357,7 @@ func UpdateSearchContextWithRepositoryRevisions(ctx context.Context, db database
 		return nil, err
 	}
 
-	err = validateSearchContextQuery(searchContext.Query)
+	err = validateSearchContextQuery(searchContext.Query, searchContext.Dynamic)
 	if err != nil {
 		return nil, err
 	}
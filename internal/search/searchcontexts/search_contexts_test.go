@@ -470,6 +470,23 @@ func TestCreatingSearchContexts(t *testing.T) {
 			},
 			wantErr: fmt.Sprintf("revision %q exceeds maximum allowed length (255)", tooLongRevision),
 		},
+		{
+			name:          "cannot create search context with repo predicate",
+			searchContext: &types.SearchContext{Name: "predicate", Query: "repo:has.file(go.mod)"},
+			userID:        user1.ID,
+			wantErr:       "unsupported repo field predicate in search context query",
+		},
+		{
+			name:          "can create dynamic search context with repo predicate",
+			searchContext: &types.SearchContext{Name: "dynamic", Query: "repo:has.file(go.mod)", Dynamic: true},
+			userID:        user1.ID,
+		},
+		{
+			name:          "cannot create dynamic search context without query",
+			searchContext: &types.SearchContext{Name: "dynamic-empty", Dynamic: true},
+			userID:        user1.ID,
+			wantErr:       "dynamic search contexts must have a query",
+		},
 	}
 
 	for _, tt := range tests {
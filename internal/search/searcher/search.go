@@ -188,9 +188,9 @@ func (s *TextSearchJob) searchFilesInRepo(
 		return false, err
 	}
 
-	// Structural and hybrid search both speak to zoekt so need the endpoints.
+	// Structural, fuzzy and hybrid search speak to zoekt so need the endpoints.
 	var indexerEndpoints []string
-	if info.IsStructuralPat || s.Features.HybridSearch {
+	if info.IsStructuralPat || info.IsFuzzy || s.Features.HybridSearch {
 		indexerEndpoints, err = search.Indexers().Map.Endpoints()
 		if err != nil {
 			return false, err
@@ -185,6 +185,8 @@ type TextPatternInfo struct {
 	IsNegated       bool
 	IsRegExp        bool
 	IsStructuralPat bool
+	IsFuzzy         bool
+	MaxEdits        int
 	CombyRule       string
 	IsWordMatch     bool
 	IsCaseSensitive bool
@@ -222,6 +224,9 @@ func (p *TextPatternInfo) Fields() []otlog.Field {
 	if p.IsStructuralPat {
 		add(otlog.Bool("isStructural", p.IsStructuralPat))
 	}
+	if p.IsFuzzy {
+		add(otlog.Bool("isFuzzy", p.IsFuzzy), otlog.Int("maxEdits", p.MaxEdits))
+	}
 	if p.CombyRule != "" {
 		add(otlog.String("combyRule", p.CombyRule))
 	}
@@ -268,6 +273,9 @@ func (p *TextPatternInfo) String() string {
 			args = append(args, "comby")
 		}
 	}
+	if p.IsFuzzy {
+		args = append(args, fmt.Sprintf("fuzzy:%d", p.MaxEdits))
+	}
 	if p.IsWordMatch {
 		args = append(args, "word")
 	}
@@ -325,7 +333,7 @@ type Features struct {
 
 	// CodeOwnershipFilters when true will add the code ownership post-search
 	// filter and allow users to search by code owners using the has.owner
-	// predicate.
+	// predicate, and to find the owners of matching files with select:owner.
 	CodeOwnershipFilters bool `json:"code-ownership"`
 
 	// When true lucky search runs by default. Adding for A/B testing in
@@ -1604,6 +1604,33 @@ type SearchContext struct {
 	// Query is the Sourcegraph query that defines this search context
 	// e.g. repo:^github\.com/org rev:bar archive:no f:sub/dir
 	Query string
+
+	// Dynamic is true if the repositories of this search context are resolved
+	// from Query by a background worker, instead of when searching. This
+	// allows Query to use repo predicates like repo:has.file(go.mod).
+	Dynamic bool
+	// MembershipRefreshedAt is when the repositories of a dynamic search
+	// context were last resolved. It is zero if they never were.
+	MembershipRefreshedAt time.Time
+}
+
+// SearchContextMembershipEventKind is the kind of a
+// SearchContextMembershipEvent.
+type SearchContextMembershipEventKind string
+
+const (
+	SearchContextMembershipEventAdded   SearchContextMembershipEventKind = "added"
+	SearchContextMembershipEventRemoved SearchContextMembershipEventKind = "removed"
+)
+
+// SearchContextMembershipEvent records that a repository was added to or
+// removed from a dynamic search context.
+type SearchContextMembershipEvent struct {
+	ID              int64
+	SearchContextID int64
+	RepoID          api.RepoID
+	Kind            SearchContextMembershipEventKind
+	CreatedAt       time.Time
 }
 
 // SearchContextRepositoryRevisions is a simple wrapper for a repository and its revisions
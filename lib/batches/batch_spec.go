@@ -36,15 +36,49 @@ type BatchSpec struct {
 	Steps             []Step                   `json:"steps,omitempty" yaml:"steps"`
 	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
+	CreateIssues      []CreateIssue            `json:"createIssues,omitempty" yaml:"createIssues,omitempty"`
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
 }
 
 type ChangesetTemplate struct {
-	Title     string                       `json:"title,omitempty" yaml:"title"`
-	Body      string                       `json:"body,omitempty" yaml:"body"`
-	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
-	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
-	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Title       string                       `json:"title,omitempty" yaml:"title"`
+	Body        string                       `json:"body,omitempty" yaml:"body"`
+	Branch      string                       `json:"branch,omitempty" yaml:"branch"`
+	Commit      ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
+	Published   *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Rollout     *Rollout                     `json:"rollout,omitempty" yaml:"rollout,omitempty"`
+	AutoRefresh bool                         `json:"autoRefresh,omitempty" yaml:"autoRefresh,omitempty"`
+}
+
+// Rollout describes the stages in which the changesets of a batch change are
+// published.
+type Rollout struct {
+	WaitFor RolloutWaitFor `json:"waitFor,omitempty" yaml:"waitFor"`
+	Stages  []RolloutStage `json:"stages,omitempty" yaml:"stages"`
+}
+
+// RolloutWaitFor is the state that all changesets of a rollout stage need to
+// reach before the changesets of the next stage are published. Closed and
+// deleted changesets never reach it, so they don't hold back the next stage.
+type RolloutWaitFor string
+
+const (
+	RolloutWaitForMerged   RolloutWaitFor = "merged"
+	RolloutWaitForApproved RolloutWaitFor = "approved"
+)
+
+// WaitForOrDefault returns the WaitFor of the rollout, defaulting to
+// RolloutWaitForMerged.
+func (r *Rollout) WaitForOrDefault() RolloutWaitFor {
+	if r.WaitFor == "" {
+		return RolloutWaitForMerged
+	}
+	return r.WaitFor
+}
+
+type RolloutStage struct {
+	Repositories              []string `json:"repositories,omitempty" yaml:"repositories"`
+	RepositoriesMatchingQuery string   `json:"repositoriesMatchingQuery,omitempty" yaml:"repositoriesMatchingQuery"`
 }
 
 type GitCommitAuthor struct {
@@ -58,8 +92,18 @@ type ExpandedGitCommitDescription struct {
 }
 
 type ImportChangeset struct {
-	Repository  string `json:"repository" yaml:"repository"`
-	ExternalIDs []any  `json:"externalIDs" yaml:"externalIDs"`
+	Repository  string        `json:"repository" yaml:"repository"`
+	ExternalIDs []any         `json:"externalIDs" yaml:"externalIDs"`
+	Kind        ChangesetKind `json:"kind,omitempty" yaml:"kind,omitempty"`
+}
+
+// CreateIssue describes issues that are created in the given repositories, one
+// per repository, for changes that can't be made automatically.
+type CreateIssue struct {
+	Repositories []string `json:"repositories" yaml:"repositories"`
+	Title        string   `json:"title" yaml:"title"`
+	Body         string   `json:"body,omitempty" yaml:"body,omitempty"`
+	Published    *bool    `json:"published,omitempty" yaml:"published,omitempty"`
 }
 
 type WorkspaceConfiguration struct {
@@ -209,6 +209,106 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("rollout stages", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  rollout:
+    waitFor: approved
+    stages:
+      - repositories: [github.com/foo/lib]
+      - repositoriesMatchingQuery: repo:^github.com/foo/
+`
+
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &Rollout{
+			WaitFor: RolloutWaitForApproved,
+			Stages: []RolloutStage{
+				{Repositories: []string{"github.com/foo/lib"}},
+				{RepositoriesMatchingQuery: "repo:^github.com/foo/"},
+			},
+		}
+		assert.Equal(t, want, batchSpec.ChangesetTemplate.Rollout)
+	})
+
+	t.Run("auto refresh", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  autoRefresh: true
+`
+
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, batchSpec.ChangesetTemplate.AutoRefresh)
+	})
+
+	t.Run("rollout stage with repositories and query", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  rollout:
+    waitFor: deployed
+    stages:
+      - repositories: [github.com/foo/lib]
+        repositoriesMatchingQuery: repo:^github.com/foo/
+`
+
+		_, err := ParseBatchSpec([]byte(spec))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+		assert.Contains(t, err.Error(), "changesetTemplate.rollout.waitFor")
+		assert.Contains(t, err.Error(), "changesetTemplate.rollout.stages.0: Must validate one and only one schema (oneOf)")
+	})
+}
+
+func TestRollout_WaitForOrDefault(t *testing.T) {
+	assert.Equal(t, RolloutWaitForMerged, (&Rollout{}).WaitForOrDefault())
+	assert.Equal(t, RolloutWaitForApproved, (&Rollout{WaitFor: RolloutWaitForApproved}).WaitForOrDefault())
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
@@ -67,6 +67,10 @@ type ChangesetSpec struct {
 	// changeset and the rest of these fields are empty.
 	ExternalID string `json:"externalID,omitempty"`
 
+	// Kind is the kind of changeset the description is for. It is empty for
+	// pull requests.
+	Kind ChangesetKind `json:"kind,omitempty"`
+
 	BaseRev string `json:"baseRev,omitempty"`
 	BaseRef string `json:"baseRef,omitempty"`
 
@@ -94,6 +98,7 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 	v := struct {
 		BaseRepository string                 `json:"baseRepository,omitempty"`
 		ExternalID     string                 `json:"externalID,omitempty"`
+		Kind           ChangesetKind          `json:"kind,omitempty"`
 		BaseRev        string                 `json:"baseRev,omitempty"`
 		BaseRef        string                 `json:"baseRef,omitempty"`
 		HeadRepository string                 `json:"headRepository,omitempty"`
@@ -105,6 +110,7 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
+		Kind:           c.Kind,
 		BaseRev:        c.BaseRev,
 		BaseRef:        c.BaseRef,
 		HeadRepository: c.HeadRepository,
@@ -131,6 +137,9 @@ func (d *ChangesetSpec) Type() ChangesetSpecDescriptionType {
 	if d.ExternalID != "" {
 		return ChangesetSpecDescriptionTypeExisting
 	}
+	if d.Kind == ChangesetKindIssue {
+		return ChangesetSpecDescriptionTypeIssue
+	}
 	return ChangesetSpecDescriptionTypeBranch
 }
 
@@ -146,6 +155,12 @@ func (d *ChangesetSpec) IsBranch() bool {
 	return d.Type() == ChangesetSpecDescriptionTypeBranch
 }
 
+// IsIssue returns whether the description is of type
+// ChangesetSpecDescriptionTypeIssue.
+func (d *ChangesetSpec) IsIssue() bool {
+	return d.Type() == ChangesetSpecDescriptionTypeIssue
+}
+
 // ChangesetSpecDescriptionType tells the consumer what the type of a
 // ChangesetSpecDescription is without having to look into the description.
 // Useful in the GraphQL when a HiddenChangesetSpec is returned.
@@ -155,6 +170,17 @@ type ChangesetSpecDescriptionType string
 const (
 	ChangesetSpecDescriptionTypeExisting ChangesetSpecDescriptionType = "EXISTING"
 	ChangesetSpecDescriptionTypeBranch   ChangesetSpecDescriptionType = "BRANCH"
+	ChangesetSpecDescriptionTypeIssue    ChangesetSpecDescriptionType = "ISSUE"
+)
+
+// ChangesetKind is the kind of changeset on the code host that a
+// ChangesetSpec describes. The empty kind is a pull request.
+type ChangesetKind string
+
+// Valid ChangesetKinds
+const (
+	ChangesetKindPullRequest ChangesetKind = ""
+	ChangesetKindIssue       ChangesetKind = "issue"
 )
 
 // ErrNoCommits is returned by (*ChangesetSpecDescription).Diff if the
@@ -186,6 +186,75 @@ func BuildImportChangesetSpecs(ctx context.Context, importChangesets []ImportCha
 			specs = append(specs, &ChangesetSpec{
 				BaseRepository: repoID,
 				ExternalID:     extID,
+				Kind:           ic.Kind,
+			})
+		}
+	}
+
+	return specs, errs
+}
+
+// BuildIssueChangesetSpecs builds the changeset specs for the issues that are
+// created in the repositories listed in createIssues. The title and body of
+// every issue are rendered as templates in the context of its repository.
+func BuildIssueChangesetSpecs(ctx context.Context, attrs *template.BatchChangeAttributes, createIssues []CreateIssue, repoFetcher RepoFetcher) (specs []*ChangesetSpec, errs error) {
+	if len(createIssues) == 0 {
+		return nil, nil
+	}
+
+	var repoNames []string
+	for _, ci := range createIssues {
+		repoNames = append(repoNames, ci.Repositories...)
+	}
+
+	repoNameIDs, err := repoFetcher(ctx, repoNames)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(repoNames))
+	for _, ci := range createIssues {
+		for _, repoName := range ci.Repositories {
+			if _, ok := seen[repoName]; ok {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("repository %q is listed in createIssues more than once", repoName)))
+				continue
+			}
+			seen[repoName] = struct{}{}
+
+			repoID, ok := repoNameIDs[repoName]
+			if !ok {
+				errs = errors.Append(errs, errors.Newf("repository %q not found", repoName))
+				continue
+			}
+
+			tmplCtx := &template.ChangesetTemplateContext{
+				BatchChangeAttributes: *attrs,
+				Repository:            template.Repository{Name: repoName},
+			}
+
+			title, err := template.RenderChangesetTemplateField("title", ci.Title, tmplCtx)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				continue
+			}
+
+			body, err := template.RenderChangesetTemplateField("body", ci.Body, tmplCtx)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				continue
+			}
+
+			var published any = nil
+			if ci.Published != nil {
+				published = *ci.Published
+			}
+
+			specs = append(specs, &ChangesetSpec{
+				BaseRepository: repoID,
+				Kind:           ChangesetKindIssue,
+				Title:          title,
+				Body:           body,
+				Published:      PublishedValue{Val: published},
 			})
 		}
 	}
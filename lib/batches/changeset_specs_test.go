@@ -1,6 +1,7 @@
 package batches
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -167,6 +168,107 @@ func TestCreateChangesetSpecs(t *testing.T) {
 	}
 }
 
+func TestBuildIssueChangesetSpecs(t *testing.T) {
+	attrs := &template.BatchChangeAttributes{Name: "the-name", Description: "The description"}
+	repoFetcher := func(ctx context.Context, names []string) (map[string]string, error) {
+		ids := map[string]string{}
+		for _, name := range names {
+			if name != "github.com/sourcegraph/unknown" {
+				ids[name] = name + "-id"
+			}
+		}
+		return ids, nil
+	}
+	published := true
+
+	tests := []struct {
+		name         string
+		createIssues []CreateIssue
+		want         []*ChangesetSpec
+		wantErr      string
+	}{
+		{
+			name: "templated title and body",
+			createIssues: []CreateIssue{{
+				Repositories: []string{"github.com/sourcegraph/src-cli", "github.com/sourcegraph/sourcegraph"},
+				Title:        "Migrate ${{ repository.name }}",
+				Body:         "Part of ${{ batch_change.name }}",
+				Published:    &published,
+			}},
+			want: []*ChangesetSpec{
+				{
+					BaseRepository: "github.com/sourcegraph/src-cli-id",
+					Kind:           ChangesetKindIssue,
+					Title:          "Migrate github.com/sourcegraph/src-cli",
+					Body:           "Part of the-name",
+					Published:      PublishedValue{Val: true},
+				},
+				{
+					BaseRepository: "github.com/sourcegraph/sourcegraph-id",
+					Kind:           ChangesetKindIssue,
+					Title:          "Migrate github.com/sourcegraph/sourcegraph",
+					Body:           "Part of the-name",
+					Published:      PublishedValue{Val: true},
+				},
+			},
+		},
+		{
+			name: "published omitted",
+			createIssues: []CreateIssue{{
+				Repositories: []string{"github.com/sourcegraph/src-cli"},
+				Title:        "Migrate",
+			}},
+			want: []*ChangesetSpec{
+				{
+					BaseRepository: "github.com/sourcegraph/src-cli-id",
+					Kind:           ChangesetKindIssue,
+					Title:          "Migrate",
+					Published:      PublishedValue{Val: nil},
+				},
+			},
+		},
+		{
+			name: "repository listed twice",
+			createIssues: []CreateIssue{
+				{Repositories: []string{"github.com/sourcegraph/src-cli"}, Title: "One"},
+				{Repositories: []string{"github.com/sourcegraph/src-cli"}, Title: "Two"},
+			},
+			wantErr: "repository \"github.com/sourcegraph/src-cli\" is listed in createIssues more than once",
+		},
+		{
+			name: "unknown repository",
+			createIssues: []CreateIssue{
+				{Repositories: []string{"github.com/sourcegraph/unknown"}, Title: "One"},
+			},
+			wantErr: "repository \"github.com/sourcegraph/unknown\" not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			have, err := BuildIssueChangesetSpecs(context.Background(), attrs, tt.createIssues, repoFetcher)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("wrong error. want=%q, got=%v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !cmp.Equal(tt.want, have) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(tt.want, have))
+			}
+			for _, spec := range have {
+				if !spec.IsIssue() {
+					t.Errorf("spec is not an issue: %+v", spec)
+				}
+			}
+		})
+	}
+}
+
 func TestGroupFileDiffs(t *testing.T) {
 	diff1 := `diff --git 1/1.txt 1/1.txt
 new file mode 100644
@@ -302,6 +302,44 @@ const BatchSpecJSON = `{
               ]
             },
             "examples": [120, "120"]
+          },
+          "kind": {
+            "type": "string",
+            "description": "The kind of the changesets to import. Omit it to import pull requests (or merge requests), or set it to issue to import GitHub or GitLab issues.",
+            "enum": ["issue"]
+          }
+        }
+      }
+    },
+    "createIssues": {
+      "type": ["array", "null"],
+      "description": "Create issues on code hosts for changes that can't be made automatically. The issues are tracked like the other changesets of the batch change.",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["repositories", "title"],
+        "properties": {
+          "repositories": {
+            "type": "array",
+            "description": "The names of the repositories, as configured on your Sourcegraph instance, in which an issue is created. Only GitHub and GitLab repositories are supported.",
+            "minItems": 1,
+            "uniqueItems": true,
+            "items": {
+              "type": "string"
+            },
+            "examples": [["github.com/foo/bar"]]
+          },
+          "title": {
+            "type": "string",
+            "description": "The title of the issues. It can contain template variables, such as ${{ repository.name }}."
+          },
+          "body": {
+            "type": "string",
+            "description": "The body of the issues. It can contain template variables, such as ${{ repository.name }}."
+          },
+          "published": {
+            "type": "boolean",
+            "description": "Whether to publish the issues. If omitted, the issues can be published from the UI."
           }
         }
       }
@@ -395,6 +433,63 @@ const BatchSpecJSON = `{
               }
             }
           ]
+        },
+        "rollout": {
+          "type": "object",
+          "description": "Publishes the changesets in stages. The changesets of a stage are only published once all changesets of the earlier stages have reached the state given in waitFor. Changesets in repositories that don't match any stage are published after the last stage.",
+          "additionalProperties": false,
+          "required": ["stages"],
+          "properties": {
+            "waitFor": {
+              "type": "string",
+              "description": "The state that all changesets of a stage need to reach before the changesets of the next stage are published. ` + "`" + `approved` + "`" + ` includes merged changesets and changesets that have been approved. Closed and deleted changesets never reach either state, so they don't hold back the next stage.",
+              "enum": ["merged", "approved"],
+              "default": "merged"
+            },
+            "stages": {
+              "type": "array",
+              "description": "The stages of the rollout, in order. If a repository matches more than one stage, the earliest stage is used.",
+              "minItems": 1,
+              "items": {
+                "title": "RolloutStage",
+                "type": "object",
+                "oneOf": [
+                  {
+                    "type": "object",
+                    "additionalProperties": false,
+                    "required": ["repositories"],
+                    "properties": {
+                      "repositories": {
+                        "type": "array",
+                        "description": "The names of the repositories (as they are known to Sourcegraph) in this stage.",
+                        "items": {
+                          "type": "string"
+                        },
+                        "examples": [["github.com/foo/bar"]]
+                      }
+                    }
+                  },
+                  {
+                    "type": "object",
+                    "additionalProperties": false,
+                    "required": ["repositoriesMatchingQuery"],
+                    "properties": {
+                      "repositoriesMatchingQuery": {
+                        "type": "string",
+                        "description": "A Sourcegraph search query that matches the repositories in this stage.",
+                        "examples": ["repo:^github.com/foo/ file:go.mod"]
+                      }
+                    }
+                  }
+                ]
+              }
+            }
+          }
+        },
+        "autoRefresh": {
+          "type": "boolean",
+          "description": "Whether to refresh the changesets when their base branch moves. The steps are re-executed against the new commit of the base branch and the changeset branch is force-pushed. Only supported for batch changes that are executed server-side.",
+          "default": false
         }
       }
     }
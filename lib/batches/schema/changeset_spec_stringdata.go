@@ -22,11 +22,40 @@ const ChangesetSpecJSON = `{
           "type": "string",
           "description": "The ID that uniquely identifies the existing changeset on the code host",
           "examples": ["3912", "12"]
+        },
+        "kind": {
+          "type": "string",
+          "description": "The kind of the existing changeset on the code host. Omit it for pull requests.",
+          "enum": ["issue"]
         }
       },
       "required": ["baseRepository", "externalID"],
       "additionalProperties": false
     },
+    {
+      "title": "IssueChangesetSpec",
+      "type": "object",
+      "properties": {
+        "baseRepository": {
+          "type": "string",
+          "description": "The GraphQL ID of the repository in which the issue is created.",
+          "examples": ["UmVwb3NpdG9yeTo5Cg=="]
+        },
+        "kind": {
+          "type": "string",
+          "description": "The kind of changeset to create on the code host.",
+          "enum": ["issue"]
+        },
+        "title": { "type": "string", "description": "The title of the issue on the code host." },
+        "body": { "type": "string", "description": "The body (description) of the issue on the code host." },
+        "published": {
+          "oneOf": [{ "type": "boolean" }, { "type": "null" }],
+          "description": "Whether to publish the issue. An unpublished issue can be previewed on Sourcegraph by any person who can view the batch change, but it isn't created on the code host."
+        }
+      },
+      "required": ["baseRepository", "kind", "title"],
+      "additionalProperties": false
+    },
     {
       "title": "BranchChangesetSpec",
       "type": "object",
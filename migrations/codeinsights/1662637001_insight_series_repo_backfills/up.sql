@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS insight_series_repo_backfills (
+    series_id INT NOT NULL REFERENCES insight_series (id) ON DELETE CASCADE,
+    repo_id INT NOT NULL,
+    completed_frames TIMESTAMPTZ[] NOT NULL DEFAULT '{}',
+    failed_frames TIMESTAMPTZ[] NOT NULL DEFAULT '{}',
+    frame_cursor TIMESTAMPTZ,
+    failures INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (series_id, repo_id)
+);
+
+COMMENT ON TABLE insight_series_repo_backfills IS 'Backfill progress of an insight series in a single repository, so that an interrupted backfill resumes where it stopped.';
+COMMENT ON COLUMN insight_series_repo_backfills.completed_frames IS 'Recording times of the frames whose data was queued or recorded. These frames are skipped when the backfill resumes.';
+COMMENT ON COLUMN insight_series_repo_backfills.failed_frames IS 'Recording times of the frames that failed and will be retried when the backfill resumes.';
+COMMENT ON COLUMN insight_series_repo_backfills.frame_cursor IS 'The oldest recording time handled so far. Frames are backfilled from newest to oldest.';
+COMMENT ON COLUMN insight_series_repo_backfills.failures IS 'The total number of frame failures in this repository.';
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS insight_series_alerts (
+    id SERIAL PRIMARY KEY,
+    series_id INT NOT NULL REFERENCES insight_series (id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    direction TEXT NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    window_days INT NOT NULL DEFAULT 7,
+    action_type TEXT NOT NULL,
+    action_url TEXT,
+    created_by INT NOT NULL,
+    triggered BOOLEAN NOT NULL DEFAULT FALSE,
+    last_triggered_at TIMESTAMPTZ,
+    last_value DOUBLE PRECISION,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT insight_series_alerts_kind_check CHECK (kind IN ('threshold', 'trend')),
+    CONSTRAINT insight_series_alerts_direction_check CHECK (direction IN ('above', 'below')),
+    CONSTRAINT insight_series_alerts_action_type_check CHECK (action_type IN ('email', 'slack_webhook', 'webhook'))
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alerts_series_id_idx ON insight_series_alerts (series_id);
+
+COMMENT ON TABLE insight_series_alerts IS 'Alert rules on the values of an insight series, evaluated after each recording of the series.';
+COMMENT ON COLUMN insight_series_alerts.kind IS 'threshold alerts compare the value of the series to value. trend alerts compare the percentage change of the series over window_days to value.';
+COMMENT ON COLUMN insight_series_alerts.direction IS 'Whether the alert triggers when the value (or change) goes above or below value.';
+COMMENT ON COLUMN insight_series_alerts.action_url IS 'The URL of the Slack webhook or webhook to notify. Email alerts are sent to the user that created the alert.';
+COMMENT ON COLUMN insight_series_alerts.created_by IS 'The ID of the user in the frontend database that created the alert.';
+COMMENT ON COLUMN insight_series_alerts.triggered IS 'Whether the condition of the alert held at the last evaluation. Notifications are only sent when the condition starts to hold.';
@@ -0,0 +1,4 @@
+ALTER TABLE cm_trigger_jobs DROP CONSTRAINT IF EXISTS content_results_is_array;
+ALTER TABLE cm_trigger_jobs DROP COLUMN IF EXISTS content_results;
+
+DROP TABLE IF EXISTS cm_last_content_matches;
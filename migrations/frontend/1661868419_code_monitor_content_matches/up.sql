@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS cm_last_content_matches (
+    monitor_id BIGINT NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    repo_id INTEGER NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    match_hashes text[] NOT NULL,
+    PRIMARY KEY (monitor_id, repo_id)
+);
+
+COMMENT ON TABLE cm_last_content_matches
+    IS 'The content matches seen on the last run of a content-match code monitor for the given repo';
+COMMENT ON COLUMN cm_last_content_matches.match_hashes
+    IS 'Hashes of the matched file paths and lines. Matches not in this set on the next run are reported as new';
+
+ALTER TABLE cm_trigger_jobs ADD COLUMN IF NOT EXISTS content_results jsonb;
+
+ALTER TABLE cm_trigger_jobs DROP CONSTRAINT IF EXISTS content_results_is_array;
+ALTER TABLE cm_trigger_jobs ADD CONSTRAINT content_results_is_array CHECK (jsonb_typeof(content_results) = 'array');
+
+COMMENT ON COLUMN cm_trigger_jobs.content_results
+    IS 'The new content matches of a content-match code monitor, including the matched lines. They are deleted along with the trigger job';
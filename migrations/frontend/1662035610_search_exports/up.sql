@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS search_exports (
+    id SERIAL PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    query text NOT NULL,
+    pattern_type text,
+    format text NOT NULL,
+    settings jsonb NOT NULL,
+    progress jsonb,
+    upload_key text,
+    upload_size bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+
+    state text DEFAULT 'queued'::text NOT NULL,
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer DEFAULT 0 NOT NULL,
+    num_failures integer DEFAULT 0 NOT NULL,
+    execution_logs json[],
+    last_heartbeat_at timestamp with time zone,
+    worker_hostname text DEFAULT ''::text NOT NULL,
+    cancel boolean DEFAULT false NOT NULL,
+
+    CONSTRAINT search_exports_format_valid CHECK (format = ANY (ARRAY['csv'::text, 'jsonl'::text]))
+);
+
+CREATE INDEX IF NOT EXISTS search_exports_state_idx ON search_exports USING btree (state);
+CREATE INDEX IF NOT EXISTS search_exports_user_id_idx ON search_exports USING btree (user_id);
+
+COMMENT ON TABLE search_exports IS 'Search queries whose results are exported to a file in the upload store by a background worker.';
+COMMENT ON COLUMN search_exports.progress IS 'The latest search progress of the export, in the format of the streaming API progress event.';
+COMMENT ON COLUMN search_exports.upload_key IS 'The key of the exported file in the upload store. Set once the export completed.';
+COMMENT ON COLUMN search_exports.settings IS 'The final settings of the user when the export was created, which the search of the export is planned with.';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS search_context_membership_events;
+
+ALTER TABLE search_contexts DROP COLUMN IF EXISTS membership_refresh_attempted_at;
+ALTER TABLE search_contexts DROP COLUMN IF EXISTS membership_refreshed_at;
+ALTER TABLE search_contexts DROP COLUMN IF EXISTS dynamic;
@@ -0,0 +1,21 @@
+ALTER TABLE search_contexts ADD COLUMN IF NOT EXISTS dynamic boolean DEFAULT false NOT NULL;
+ALTER TABLE search_contexts ADD COLUMN IF NOT EXISTS membership_refreshed_at timestamp with time zone;
+ALTER TABLE search_contexts ADD COLUMN IF NOT EXISTS membership_refresh_attempted_at timestamp with time zone;
+
+COMMENT ON COLUMN search_contexts.dynamic IS 'Whether the repositories of the search context are resolved from its query by a background worker, and stored in search_context_repos.';
+COMMENT ON COLUMN search_contexts.membership_refreshed_at IS 'When the repositories of a dynamic search context were last resolved.';
+COMMENT ON COLUMN search_contexts.membership_refresh_attempted_at IS 'When the repositories of a dynamic search context were last attempted to be resolved, whether or not that succeeded.';
+
+CREATE TABLE IF NOT EXISTS search_context_membership_events (
+    id BIGSERIAL PRIMARY KEY,
+    search_context_id bigint NOT NULL REFERENCES search_contexts(id) ON DELETE CASCADE DEFERRABLE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    kind text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+
+    CONSTRAINT search_context_membership_events_kind_valid CHECK (kind = ANY (ARRAY['added'::text, 'removed'::text]))
+);
+
+CREATE INDEX IF NOT EXISTS search_context_membership_events_search_context_id_idx ON search_context_membership_events USING btree (search_context_id, id);
+
+COMMENT ON TABLE search_context_membership_events IS 'Repositories added to or removed from dynamic search contexts when their membership is refreshed.';
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS notebook_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    notebook_id bigint NOT NULL REFERENCES notebooks(id) ON DELETE CASCADE DEFERRABLE,
+    title text NOT NULL,
+    blocks jsonb DEFAULT '[]'::jsonb NOT NULL,
+    author_user_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+
+    CONSTRAINT notebook_revisions_blocks_is_array CHECK (jsonb_typeof(blocks) = 'array'::text)
+);
+
+CREATE INDEX IF NOT EXISTS notebook_revisions_notebook_id_idx ON notebook_revisions USING btree (notebook_id, id);
+
+COMMENT ON TABLE notebook_revisions IS 'Snapshots of the title and blocks of a notebook, recorded every time the notebook is created, updated or restored.';
+COMMENT ON COLUMN notebook_revisions.author_user_id IS 'The user that made the change, or null if the user was removed.';
+
+-- Start the history of existing notebooks from their current state.
+INSERT INTO notebook_revisions (notebook_id, title, blocks, author_user_id, created_at)
+SELECT n.id, n.title, n.blocks, COALESCE(n.updater_user_id, n.creator_user_id), n.updated_at
+FROM notebooks n
+WHERE NOT EXISTS (SELECT FROM notebook_revisions r WHERE r.notebook_id = n.id);
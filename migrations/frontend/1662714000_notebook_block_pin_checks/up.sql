@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS notebook_block_pin_checks (
+    notebook_id bigint NOT NULL REFERENCES notebooks(id) ON DELETE CASCADE DEFERRABLE,
+    block_id text NOT NULL,
+    pinned_commit text NOT NULL,
+    head_commit text,
+    state text,
+    suggested_start_line integer,
+    suggested_end_line integer,
+    checked_at timestamp with time zone,
+    failure_message text,
+    failed_at timestamp with time zone,
+
+    PRIMARY KEY (notebook_id, block_id)
+);
+
+CREATE INDEX IF NOT EXISTS notebook_block_pin_checks_checked_at_idx ON notebook_block_pin_checks USING btree (checked_at);
+
+COMMENT ON TABLE notebook_block_pin_checks IS 'The latest comparison of the file and symbol notebook blocks pinned to a commit with the default branch of their repository, and the latest failed attempt to compare them.';
+COMMENT ON COLUMN notebook_block_pin_checks.pinned_commit IS 'The commit the block was pinned to when it was checked. A check is outdated once the block is pinned to a different commit.';
+COMMENT ON COLUMN notebook_block_pin_checks.head_commit IS 'The commit of the default branch the block was compared with, empty if the block is unavailable. Null until a check of the block succeeds.';
+COMMENT ON COLUMN notebook_block_pin_checks.state IS 'One of current, moved, changed or unavailable. Null until a check of the block succeeds.';
+COMMENT ON COLUMN notebook_block_pin_checks.failure_message IS 'The error of the latest check of the block if it failed, so that blocks whose check keeps failing are retried after the other blocks. Cleared once a check of the block succeeds.';
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS batch_change_merge_policies (
+    batch_change_id bigint NOT NULL PRIMARY KEY REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    merge_method text NOT NULL,
+    required_approvals integer DEFAULT 1 NOT NULL,
+    windows jsonb DEFAULT '[]'::jsonb NOT NULL,
+    max_merges_per_hour integer DEFAULT 0 NOT NULL,
+    creator_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+
+    CONSTRAINT batch_change_merge_policies_required_approvals_check CHECK (required_approvals >= 0),
+    CONSTRAINT batch_change_merge_policies_max_merges_per_hour_check CHECK (max_merges_per_hour >= 0)
+);
+
+COMMENT ON TABLE batch_change_merge_policies IS 'Policies that merge the changesets of a batch change automatically once their checks have passed and their reviews have been approved.';
+COMMENT ON COLUMN batch_change_merge_policies.merge_method IS 'One of MERGE or SQUASH.';
+COMMENT ON COLUMN batch_change_merge_policies.windows IS 'The windows in which changesets may be merged, in the format of the batchChanges.rolloutWindows site configuration. Changesets may be merged at any time if empty.';
+COMMENT ON COLUMN batch_change_merge_policies.max_merges_per_hour IS 'The maximum number of changesets that are merged within an hour, or 0 for no limit.';
+COMMENT ON COLUMN batch_change_merge_policies.creator_id IS 'The user who configured the policy, whose credentials are used to merge changesets.';
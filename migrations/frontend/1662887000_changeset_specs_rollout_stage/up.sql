@@ -0,0 +1,3 @@
+ALTER TABLE changeset_specs ADD COLUMN IF NOT EXISTS rollout_stage integer DEFAULT 0 NOT NULL;
+
+COMMENT ON COLUMN changeset_specs.rollout_stage IS 'The stage of the batch spec rollout the changeset is published in. 0 if the batch spec has no rollout stages.';
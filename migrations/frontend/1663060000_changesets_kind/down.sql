@@ -0,0 +1,96 @@
+DROP VIEW IF EXISTS branch_changeset_specs_and_changesets;
+CREATE VIEW branch_changeset_specs_and_changesets AS
+ SELECT changeset_specs.id AS changeset_spec_id,
+    COALESCE(changesets.id, (0)::bigint) AS changeset_id,
+    changeset_specs.repo_id,
+    changeset_specs.batch_spec_id,
+    changesets.owned_by_batch_change_id AS owner_batch_change_id,
+    repo.name AS repo_name,
+    changeset_specs.title AS changeset_name,
+    changesets.external_state,
+    changesets.publication_state,
+    changesets.reconciler_state,
+    changesets.computed_state
+   FROM ((changeset_specs
+     LEFT JOIN changesets ON (((changesets.repo_id = changeset_specs.repo_id) AND (changesets.current_spec_id IS NOT NULL) AND (EXISTS ( SELECT 1
+           FROM changeset_specs changeset_specs_1
+          WHERE ((changeset_specs_1.id = changesets.current_spec_id) AND (changeset_specs_1.head_ref = changeset_specs.head_ref)))))))
+     JOIN repo ON ((changeset_specs.repo_id = repo.id)))
+  WHERE ((changeset_specs.external_id IS NULL) AND (repo.deleted_at IS NULL));
+
+DROP VIEW IF EXISTS tracking_changeset_specs_and_changesets;
+CREATE VIEW tracking_changeset_specs_and_changesets AS
+ SELECT changeset_specs.id AS changeset_spec_id,
+    COALESCE(changesets.id, (0)::bigint) AS changeset_id,
+    changeset_specs.repo_id,
+    changeset_specs.batch_spec_id,
+    repo.name AS repo_name,
+    COALESCE((changesets.metadata ->> 'Title'::text), (changesets.metadata ->> 'title'::text)) AS changeset_name,
+    changesets.external_state,
+    changesets.publication_state,
+    changesets.reconciler_state,
+    changesets.computed_state
+   FROM ((changeset_specs
+     LEFT JOIN changesets ON (((changesets.repo_id = changeset_specs.repo_id) AND (changesets.external_id = changeset_specs.external_id))))
+     JOIN repo ON ((changeset_specs.repo_id = repo.id)))
+  WHERE ((changeset_specs.external_id IS NOT NULL) AND (repo.deleted_at IS NULL));
+
+DROP VIEW IF EXISTS reconciler_changesets;
+
+CREATE VIEW reconciler_changesets AS
+SELECT c.id,
+       c.batch_change_ids,
+       c.repo_id,
+       c.queued_at,
+       c.created_at,
+       c.updated_at,
+       c.metadata,
+       c.external_id,
+       c.external_service_type,
+       c.external_deleted_at,
+       c.external_branch,
+       c.external_updated_at,
+       c.external_state,
+       c.external_review_state,
+       c.external_check_state,
+       c.diff_stat_added,
+       c.diff_stat_changed,
+       c.diff_stat_deleted,
+       c.sync_state,
+       c.current_spec_id,
+       c.previous_spec_id,
+       c.publication_state,
+       c.owned_by_batch_change_id,
+       c.reconciler_state,
+       c.failure_message,
+       c.started_at,
+       c.finished_at,
+       c.process_after,
+       c.num_resets,
+       c.closing,
+       c.num_failures,
+       c.log_contents,
+       c.execution_logs,
+       c.syncer_error,
+       c.external_title,
+       c.worker_hostname,
+       c.ui_publication_state,
+       c.last_heartbeat_at,
+       c.external_fork_namespace,
+       c.detached_at,
+       c.refresh_base_rev
+FROM changesets c
+         JOIN repo r ON r.id = c.repo_id
+WHERE r.deleted_at IS NULL AND EXISTS (
+    SELECT 1
+    FROM batch_changes
+             LEFT JOIN users namespace_user ON batch_changes.namespace_user_id = namespace_user.id
+             LEFT JOIN orgs namespace_org ON batch_changes.namespace_org_id = namespace_org.id
+    WHERE c.batch_change_ids ? batch_changes.id::text AND namespace_user.deleted_at IS NULL AND namespace_org.deleted_at IS NULL
+    );
+
+ALTER TABLE changesets DROP CONSTRAINT IF EXISTS changesets_repo_external_id_unique;
+ALTER TABLE changesets ADD CONSTRAINT changesets_repo_external_id_unique UNIQUE (repo_id, external_id);
+
+ALTER TABLE changesets DROP COLUMN IF EXISTS kind;
+ALTER TABLE changeset_specs DROP COLUMN IF EXISTS kind;
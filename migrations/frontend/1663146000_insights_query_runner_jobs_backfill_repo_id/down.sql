@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS insights_query_runner_jobs_series_id_backfill_repo_id;
+
+ALTER TABLE insights_query_runner_jobs DROP COLUMN IF EXISTS backfill_repo_id;
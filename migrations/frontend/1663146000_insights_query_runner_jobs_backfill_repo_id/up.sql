@@ -0,0 +1,5 @@
+ALTER TABLE insights_query_runner_jobs ADD COLUMN IF NOT EXISTS backfill_repo_id integer;
+
+COMMENT ON COLUMN insights_query_runner_jobs.backfill_repo_id IS 'The repository that a backfill job searches. The frames of the job are recorded as completed in the backfill progress of the repository once the job succeeds.';
+
+CREATE INDEX IF NOT EXISTS insights_query_runner_jobs_series_id_backfill_repo_id ON insights_query_runner_jobs USING btree (series_id, backfill_repo_id) WHERE backfill_repo_id IS NOT NULL;
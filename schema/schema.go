@@ -185,6 +185,8 @@ type BatchChangeRolloutWindow struct {
 type BatchSpec struct {
 	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 	ChangesetTemplate *ChangesetTemplate `json:"changesetTemplate,omitempty"`
+	// CreateIssues description: Create issues on code hosts for changes that can't be made automatically. The issues are tracked like the other changesets of the batch change.
+	CreateIssues []*CreateIssues `json:"createIssues,omitempty"`
 	// Description description: The description of the batch change.
 	Description string `json:"description,omitempty"`
 	// ImportChangesets description: Import existing changesets on code hosts.
@@ -451,6 +453,16 @@ type CodeIntelRepositoryBadge struct {
 	// ForNerds description: Show entirely too much information.
 	ForNerds *bool `json:"forNerds,omitempty"`
 }
+type CreateIssues struct {
+	// Body description: The body of the issues. It can contain template variables, such as ${{ repository.name }}.
+	Body string `json:"body,omitempty"`
+	// Published description: Whether to publish the issues. If omitted, the issues can be published from the UI.
+	Published *bool `json:"published,omitempty"`
+	// Repositories description: The names of the repositories, as configured on your Sourcegraph instance, in which an issue is created. Only GitHub and GitLab repositories are supported.
+	Repositories []string `json:"repositories"`
+	// Title description: The title of the issues. It can contain template variables, such as ${{ repository.name }}.
+	Title string `json:"title"`
+}
 
 // CustomGitFetchMapping description: Mapping from Git clone URl domain/path to git fetch command. The `domainPath` field contains the Git clone URL domain/path part. The `fetch` field contains the custom git fetch command.
 type CustomGitFetchMapping struct {
@@ -623,6 +635,8 @@ type ExperimentalFeatures struct {
 	GoPackages string `json:"goPackages,omitempty"`
 	// JvmPackages description: Allow adding JVM package host connections
 	JvmPackages string `json:"jvmPackages,omitempty"`
+	// LuckySearch description: Configures the rules that lucky search applies to a query to generate alternative queries when the original query has few or no results.
+	LuckySearch *LuckySearch `json:"luckySearch,omitempty"`
 	// NpmPackages description: Allow adding npm package code host connections
 	NpmPackages string `json:"npmPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
@@ -1063,6 +1077,8 @@ func (v *IdentityProvider) UnmarshalJSON(data []byte) error {
 type ImportChangesets struct {
 	// ExternalIDs description: The changesets to import from the code host. For GitHub this is the PR number, for GitLab this is the MR number, for Bitbucket Server this is the PR number.
 	ExternalIDs []interface{} `json:"externalIDs"`
+	// Kind description: The kind of the changesets to import. Omit it to import pull requests (or merge requests), or set it to issue to import GitHub or GitLab issues.
+	Kind string `json:"kind,omitempty"`
 	// Repository description: The repository name as configured on your Sourcegraph instance.
 	Repository string `json:"repository"`
 }
@@ -1115,6 +1131,26 @@ type Log struct {
 	Sentry *Sentry `json:"sentry,omitempty"`
 }
 
+// LuckySearch description: Configures the rules that lucky search applies to a query to generate alternative queries when the original query has few or no results.
+type LuckySearch struct {
+	// NarrowRules description: The names of the rules that make a query more specific, in the order they are applied. Lucky search first applies all of them together, then fewer of them. Rules that are not listed are disabled. Defaults to the names of the rewrite rules followed by the built-in rules unquote-patterns, type-patterns, lang-patterns, symbol-patterns and code-host-filters.
+	NarrowRules []string `json:"narrowRules,omitempty"`
+	// RewriteRules description: Site-specific rules that rewrite each pattern of a query that matches a regular expression into query terms.
+	RewriteRules []*LuckySearchRewriteRule `json:"rewriteRules,omitempty"`
+	// WidenRules description: The names of the rules that make a query more general, in the order they are applied. Rules that are not listed are disabled. Defaults to the built-in rules regexp-patterns and and-patterns.
+	WidenRules []string `json:"widenRules,omitempty"`
+}
+type LuckySearchRewriteRule struct {
+	// Description description: The description of the rule shown to users next to the queries it generates. Defaults to the name of the rule.
+	Description string `json:"description,omitempty"`
+	// Name description: The name of the rule, used to order it and in the usage statistics of lucky search. It must be different from the names of the built-in rules.
+	Name string `json:"name"`
+	// Pattern description: Regular expression that must match a whole search pattern for the rule to apply.
+	Pattern string `json:"pattern"`
+	// Query description: The query terms that replace a matching pattern. $1 or ${name} refer to the groups of the regular expression.
+	Query string `json:"query"`
+}
+
 // Maven description: Configuration for resolving from Maven repositories.
 type Maven struct {
 	// Credentials description: Contents of a coursier.credentials file needed for accessing the Maven repositories.
@@ -1540,9 +1576,27 @@ type QuickLink struct {
 type Ranking struct {
 	// MaxReorderQueueSize description: The maximum number of search results that can be buffered to sort results. -1 is unbounded. The default is 24. Set this to small integers to limit latency increases from slow backends.
 	MaxReorderQueueSize *int `json:"maxReorderQueueSize,omitempty"`
+	// RelevanceWeights description: The weight of each signal used to rank file results by searches with sort:relevance. Signals are normalized to a value between 0 and 1 before they are weighted. A weight of 0 disables a signal.
+	RelevanceWeights *RelevanceWeights `json:"relevanceWeights,omitempty"`
+	// RelevanceWindow description: The number of file results that are buffered and reordered at a time by searches with sort:relevance. Larger windows rank more results together, but delay streaming the first results.
+	RelevanceWindow *int `json:"relevanceWindow,omitempty"`
 	// RepoScores description: a map of URI directories to numeric scores for specifying search result importance, like {"github.com": 500, "github.com/sourcegraph": 300, "github.com/sourcegraph/sourcegraph": 100}. Would rank "github.com/sourcegraph/sourcegraph" as 500+300+100=900, and "github.com/other/foo" as 500.
 	RepoScores map[string]float64 `json:"repoScores,omitempty"`
 }
+
+// RelevanceWeights description: The weight of each signal used to rank file results by searches with sort:relevance. Signals are normalized to a value between 0 and 1 before they are weighted. A weight of 0 disables a signal.
+type RelevanceWeights struct {
+	// Recency description: Weight of how recently the file was last modified.
+	Recency *float64 `json:"recency,omitempty"`
+	// RepoStars description: Weight of the star count of the repository.
+	RepoStars *float64 `json:"repoStars,omitempty"`
+	// SymbolDefinition description: Weight of a match overlapping the name of a symbol definition.
+	SymbolDefinition *float64 `json:"symbolDefinition,omitempty"`
+	// TestPath description: Penalty for files that look like tests.
+	TestPath *float64 `json:"testPath,omitempty"`
+	// VendorPath description: Penalty for files that look like vendored or generated code.
+	VendorPath *float64 `json:"vendorPath,omitempty"`
+}
 type Repos struct {
 	// Callsign description: The unique Phabricator identifier for the repository, like 'MUX'.
 	Callsign string `json:"callsign"`